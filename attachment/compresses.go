@@ -18,9 +18,9 @@ func CompressImage(reader io.Reader, contentType string, maxSize int) ([]byte, e
 	}
 
 	// Decode image
-	img, _, err := image.Decode(bytes.NewReader(data))
+	img, _, err := decodeImage(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode image: %w", err)
+		return nil, err
 	}
 
 	// Calculate new dimensions
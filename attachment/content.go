@@ -0,0 +1,139 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// FileContent the content of a file, seekable to serve the HTTP Range requests
+type FileContent struct {
+	io.ReadSeekCloser
+	Filename    string
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+	ETag        string
+}
+
+// Open opens the content of a file. The content is read from the storage on demand, a seek to another
+// offset reads a new range. The gzip files are decompressed in memory.
+func (manager Manager) Open(ctx context.Context, fileID string) (*FileContent, error) {
+	file, err := manager.getFileFromDatabase(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = available(file)
+	if err != nil {
+		return nil, err
+	}
+
+	size, modTime, etag, err := manager.storage.Stat(ctx, file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &FileContent{
+		Filename:    file.Filename,
+		ContentType: file.ContentType,
+		Size:        size,
+		ModTime:     modTime,
+		ETag:        etag,
+	}
+
+	// The ranges of a gzip file are ranges of the decompressed content
+	if strings.HasSuffix(file.Path, ".gz") {
+		reader, err := manager.storage.Reader(ctx, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		content.ReadSeekCloser = nopSeekCloser{bytes.NewReader(data)}
+		content.Size = int64(len(data))
+		return content, nil
+	}
+
+	content.ReadSeekCloser = &rangeReader{ctx: ctx, storage: manager.storage, path: file.Path, size: size}
+	return content, nil
+}
+
+// rangeReader reads a stored file from the current offset, a seek closes the range being read
+type rangeReader struct {
+	ctx     context.Context
+	storage Storage
+	path    string
+	size    int64
+	offset  int64
+	body    io.ReadCloser
+}
+
+// Read reads from the current offset
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		body, err := r.storage.RangeReader(r.ctx, r.path, r.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+// Seek sets the offset of the next read
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if offset != r.offset {
+		r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+// Close closes the range being read
+func (r *rangeReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
+
+// nopSeekCloser a seeker without resources to release
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+// Close does nothing
+func (nopSeekCloser) Close() error {
+	return nil
+}
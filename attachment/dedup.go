@@ -0,0 +1,476 @@
+package attachment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// blobPrefix the directory of the contents addressed by their hash
+const blobPrefix = ".blobs/"
+
+// ErrBlobNotFound is returned when the uploader stores no content with the hash
+var ErrBlobNotFound = errors.New("content not found")
+
+// blobModel the references of the blobs, the nodes sharing the database count them with conditional updates
+const blobModel = "__yao.attachment.blob"
+
+// The status of a blob, the content of a deleting blob is being removed and the blob is not linked anymore
+const (
+	blobStored   = "stored"
+	blobDeleting = "deleting"
+)
+
+// blobWait the time a link waits for the deletion of a blob, an older deletion was interrupted
+const blobWait = 30 * time.Second
+
+// UploadByHash creates a file from a content the caller already stores, the client sends the SHA-256 hash of the
+// content instead of the content. Only the files of the caller's openid or team (its client_id without an openid)
+// are looked up, ErrBlobNotFound is returned when the caller does not store the content, the client uploads it.
+func (manager Manager) UploadByHash(ctx context.Context, hash string, fileheader *FileHeader, option UploadOption) (*File, error) {
+	hash = strings.ToLower(hash)
+	if !manager.Dedup || !isHash(hash) {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, hash)
+	}
+
+	source, err := manager.findBlob(ctx, hash, option)
+	if err != nil {
+		return nil, err
+	}
+
+	// The content type and the size are the ones of the stored content
+	fileheader.Header.Set("Content-Type", source.ContentType)
+	fileheader.Size = int64(source.Bytes)
+	option.Gzip = strings.HasSuffix(source.Path, ".gz")
+	option.CompressImage = false
+
+	file, err := manager.makeFile(fileheader, option)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.checkQuota(ctx, option, fileheader.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	file.Hash = hash
+	file.Status = "uploaded"
+	file.Metadata = source.Metadata
+	err = manager.saveBlob(ctx, file, source.Path, option, func(stored bool) error {
+		if !stored || !manager.storage.Exists(ctx, source.Path) {
+			return fmt.Errorf("%w: %s", ErrBlobNotFound, hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.recheckQuota(ctx, file, option)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// saveFile saves an uploaded file to the database, the hashed content is moved to its blob or dropped when the
+// blob is already stored
+func (manager Manager) saveFile(ctx context.Context, file *File, option UploadOption) error {
+	if file.Hash == "" || file.Status != "uploaded" {
+		return manager.saveFileToDatabase(ctx, file, file.Path, option)
+	}
+
+	path := file.Path
+	blob := blobPath(file.Hash, path)
+	return manager.saveBlob(ctx, file, blob, option, func(stored bool) error {
+		if stored {
+			return manager.storage.Delete(ctx, path)
+		}
+		return manager.moveContent(ctx, path, blob, file.ContentType)
+	})
+}
+
+// saveBlob saves a file pointing to a blob. The file holds a reference of the blob before store is called, store
+// stores the content when the blob is not stored. The content of the file replaced by the upload is released
+// afterwards.
+func (manager Manager) saveBlob(ctx context.Context, file *File, blob string, option UploadOption, store func(stored bool) error) error {
+	previous, _ := manager.getFileFromDatabase(ctx, file.ID)
+	linked := previous != nil && previous.Path == blob
+
+	stored := true
+	if !linked {
+		var err error
+		stored, err = manager.linkBlob(ctx, blob)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := store(stored)
+	if err == nil {
+		file.Path = blob
+		err = manager.saveFileToDatabase(ctx, file, blob, option)
+	}
+
+	if err != nil {
+		if !linked {
+			if err := manager.unlinkBlob(ctx, &File{ID: file.ID, Path: blob, ContentType: file.ContentType}); err != nil {
+				log.Warn("[attachment] failed to release the blob of %s: %s", file.ID, err.Error())
+			}
+		}
+		return err
+	}
+
+	if previous != nil && !linked {
+		if err := manager.release(ctx, previous); err != nil {
+			log.Warn("[attachment] failed to release the previous content of %s: %s", file.ID, err.Error())
+		}
+	}
+	return nil
+}
+
+// release deletes a content and its variants once no file refers to it
+func (manager Manager) release(ctx context.Context, file *File) error {
+	if isBlob(file.Path) {
+		return manager.unlinkBlob(ctx, file)
+	}
+
+	refs, err := manager.blobRefs(ctx, file.Path)
+	if err != nil || refs > 0 {
+		return err
+	}
+
+	manager.deleteVariants(ctx, file)
+	if !manager.storage.Exists(ctx, file.Path) {
+		return nil
+	}
+	return manager.storage.Delete(ctx, file.Path)
+}
+
+// linkBlob adds a reference to a blob, returns false when the blob is not stored and the caller stores it.
+// The stored blobs are not deleted while they have references, a blob being deleted is linked once the deletion
+// is done. The blobs stored before their references were counted get the number of their files.
+func (manager Manager) linkBlob(ctx context.Context, path string) (bool, error) {
+	key := manager.blobKey(path)
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		record, err := getBlob(key)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case record == nil:
+			refs, err := manager.blobRefs(ctx, path)
+			if err != nil {
+				return false, err
+			}
+
+			// The blob is created by another node when the key already exists
+			_, err = model.Select(blobModel).Create(map[string]interface{}{
+				"blob_key": key,
+				"uploader": manager.Name,
+				"path":     path,
+				"refs":     refs + 1,
+				"status":   blobStored,
+			})
+			if err == nil {
+				return refs > 0 && manager.storage.Exists(ctx, path), nil
+			}
+			if record, _ := getBlob(key); record == nil {
+				return false, fmt.Errorf("failed to create the blob %s: %w", path, err)
+			}
+
+		case record["status"] == blobDeleting:
+			err = manager.waitBlob(ctx, key, record)
+			if err != nil {
+				return false, err
+			}
+
+		default:
+			refs := toInt64(record["refs"])
+			affected, err := model.Select(blobModel).UpdateWhere(model.QueryParam{
+				Wheres: []model.QueryWhere{
+					{Column: "blob_key", Value: key},
+					{Column: "status", Value: blobStored},
+					{Column: "refs", Value: refs},
+				},
+				Limit: 1,
+			}, map[string]interface{}{"refs": refs + 1})
+			if err != nil {
+				return false, fmt.Errorf("failed to link the blob %s: %w", path, err)
+			}
+			if affected > 0 {
+				return true, nil
+			}
+		}
+	}
+}
+
+// unlinkBlob removes a reference of a blob, the node removing the last reference deletes the content and the
+// variants. No file can link the blob during the deletion.
+func (manager Manager) unlinkBlob(ctx context.Context, file *File) error {
+	key := manager.blobKey(file.Path)
+	for {
+		record, err := getBlob(key)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case record == nil:
+			refs, err := manager.blobRefs(ctx, file.Path)
+			if err != nil || refs > 0 {
+				return err
+			}
+
+			// The blob stored before the references were counted is deleted by the node creating its record
+			_, err = model.Select(blobModel).Create(map[string]interface{}{
+				"blob_key":    key,
+				"uploader":    manager.Name,
+				"path":        file.Path,
+				"refs":        0,
+				"status":      blobDeleting,
+				"deleting_at": time.Now().Unix(),
+			})
+			if err == nil {
+				return manager.deleteBlob(ctx, file, key)
+			}
+			if record, _ := getBlob(key); record == nil {
+				return fmt.Errorf("failed to create the blob %s: %w", file.Path, err)
+			}
+
+		case record["status"] == blobDeleting:
+			return nil
+
+		default:
+			refs := toInt64(record["refs"])
+			data := map[string]interface{}{"refs": refs - 1}
+			if refs <= 1 {
+				data = map[string]interface{}{"refs": 0, "status": blobDeleting, "deleting_at": time.Now().Unix()}
+			}
+
+			affected, err := model.Select(blobModel).UpdateWhere(model.QueryParam{
+				Wheres: []model.QueryWhere{
+					{Column: "blob_key", Value: key},
+					{Column: "status", Value: blobStored},
+					{Column: "refs", Value: refs},
+				},
+				Limit: 1,
+			}, data)
+			if err != nil {
+				return fmt.Errorf("failed to release the blob %s: %w", file.Path, err)
+			}
+			if affected > 0 && refs <= 1 {
+				return manager.deleteBlob(ctx, file, key)
+			}
+			if affected > 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// deleteBlob deletes the content and the variants of a deleting blob, then its record
+func (manager Manager) deleteBlob(ctx context.Context, file *File, key string) error {
+	manager.deleteVariants(ctx, file)
+	if manager.storage.Exists(ctx, file.Path) {
+		err := manager.storage.Delete(ctx, file.Path)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := model.Select(blobModel).DeleteWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "blob_key", Value: key},
+			{Column: "status", Value: blobDeleting},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete the blob %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// waitBlob waits for the deletion of a blob, the record of a deletion older than blobWait is removed
+func (manager Manager) waitBlob(ctx context.Context, key string, record map[string]interface{}) error {
+	if time.Since(time.Unix(toInt64(record["deleting_at"]), 0)) < blobWait {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	}
+
+	log.Warn("[attachment] %s the deletion of the blob %v was interrupted", manager.Name, record["path"])
+	_, err := model.Select(blobModel).DeleteWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "blob_key", Value: key},
+			{Column: "status", Value: blobDeleting},
+			{Column: "deleting_at", Value: record["deleting_at"]},
+		},
+	})
+	return err
+}
+
+// getBlob returns the reference record of a blob, nil when the blob has no record
+func getBlob(key string) (map[string]interface{}, error) {
+	records, err := model.Select(blobModel).Get(model.QueryParam{
+		Select: []interface{}{"refs", "path", "status", "deleting_at"},
+		Wheres: []model.QueryWhere{{Column: "blob_key", Value: key}},
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the blob: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records[0], nil
+}
+
+// findBlob returns a clean file of the caller stored in the blob of the hash, the files of the other callers are
+// never linked so the hash does not tell whether they store the content
+func (manager Manager) findBlob(ctx context.Context, hash string, option UploadOption) (*File, error) {
+	owner := ownerWheres(option)
+	if owner == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, hash)
+	}
+
+	records, err := model.Select("__yao.attachment").Get(model.QueryParam{
+		Select: []interface{}{"file_id"},
+		Wheres: []model.QueryWhere{
+			{Column: "uploader", Value: manager.Name},
+			{Column: "hash", Value: hash},
+			{Column: "path", OP: "like", Value: blobPrefix + "%"},
+			{Column: "status", OP: "ne", Value: ScanQuarantined},
+			{Column: "status", OP: "ne", Value: ScanRejected},
+			{Wheres: owner},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the content %s: %w", hash, err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, hash)
+	}
+
+	fileID, _ := records[0]["file_id"].(string)
+	return manager.getFileFromDatabase(ctx, fileID)
+}
+
+// ownerWheres returns the conditions matching the files of the caller: its openid or its team, its client_id
+// when it has no openid. nil is returned for an anonymous caller.
+func ownerWheres(option UploadOption) []model.QueryWhere {
+	wheres := []model.QueryWhere{}
+	switch {
+	case option.OpenID != "":
+		wheres = append(wheres, model.QueryWhere{Column: "openid", Value: option.OpenID})
+	case option.ClientID != "":
+		wheres = append(wheres, model.QueryWhere{Column: "client_id", Value: option.ClientID})
+	}
+
+	if option.TeamID != "" {
+		wheres = append(wheres, model.QueryWhere{Column: "team_id", Value: option.TeamID, Method: "orwhere"})
+	}
+
+	if len(wheres) == 0 {
+		return nil
+	}
+	wheres[0].Method = ""
+	return wheres
+}
+
+// blobRefs returns the number of files of the uploader stored in a path
+func (manager Manager) blobRefs(ctx context.Context, path string) (int, error) {
+	records, err := model.Select("__yao.attachment").Get(model.QueryParam{
+		Select: []interface{}{"file_id"},
+		Wheres: []model.QueryWhere{
+			{Column: "uploader", Value: manager.Name},
+			{Column: "path", Value: path},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count the files of %s: %w", path, err)
+	}
+	return len(records), nil
+}
+
+// hashContent returns the SHA-256 of a stored content, the gzip files are hashed decompressed
+func (manager Manager) hashContent(ctx context.Context, path string) (string, error) {
+	reader, err := manager.storage.Reader(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// moveContent moves the stored bytes of a content to another path
+func (manager Manager) moveContent(ctx context.Context, from string, to string, contentType string) error {
+	reader, err := manager.storage.RangeReader(ctx, from, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = manager.storage.Upload(ctx, to, reader, contentType)
+	if err != nil {
+		return err
+	}
+	return manager.storage.Delete(ctx, from)
+}
+
+// blobKey returns the key of a blob of the uploader
+func (manager Manager) blobKey(path string) string {
+	sum := sha256.Sum256([]byte(manager.Name + ":" + path))
+	return hex.EncodeToString(sum[:])
+}
+
+// blobPath returns the storage path of a content: .blobs/<first two chars of the hash>/<hash><extension>,
+// the gzip contents keep their .gz suffix
+func blobPath(hash string, path string) string {
+	gzip := strings.HasSuffix(path, ".gz")
+	blob := blobPrefix + hash[:2] + "/" + hash + strings.ToLower(filepath.Ext(strings.TrimSuffix(path, ".gz")))
+	if gzip {
+		blob += ".gz"
+	}
+	return blob
+}
+
+// isBlob checks if a storage path is a blob, the blobs may be shared by several files
+func isBlob(path string) bool {
+	return strings.HasPrefix(path, blobPrefix)
+}
+
+// isHash checks if a string is a hex encoded SHA-256
+func isHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
@@ -0,0 +1,238 @@
+package attachment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestDedup(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	root := filepath.Join(config.Conf.DataRoot, "dedup")
+	manager, err := Register("dedup", "local", ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": root},
+		AllowedTypes: []string{"text/*"},
+		Dedup:        true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	ctx := context.Background()
+	content := "the same report"
+	sum := sha256.Sum256([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+
+	upload := func(name string, groups []string, gzip bool) *File {
+		header := newUploadHeader(name, "text/plain")
+		header.Size = int64(len(content))
+		option := UploadOption{Groups: groups, OpenID: groups[0], TeamID: "team-" + groups[0], Gzip: gzip}
+		file, err := manager.Upload(ctx, header, strings.NewReader(content), option)
+		if err != nil {
+			t.Fatalf("Failed to upload %s: %v", name, err)
+		}
+		return file
+	}
+
+	// The same content uploaded by two users is stored once
+	first := upload("report.txt", []string{"user1"}, false)
+	second := upload("copy.txt", []string{"user2"}, false)
+	if first.Hash != hash || second.Hash != hash {
+		t.Errorf("Expected the hash %s, got %s and %s", hash, first.Hash, second.Hash)
+	}
+	if first.Path != second.Path || !isBlob(first.Path) {
+		t.Errorf("Expected the files to share a blob, got %s and %s", first.Path, second.Path)
+	}
+	if count := countFiles(t, root); count != 1 {
+		t.Errorf("Expected 1 stored file, got %d", count)
+	}
+
+	info, err := manager.Info(ctx, second.ID)
+	if err != nil || info.Hash != hash {
+		t.Errorf("Expected the hash in the file info, got %+v %v", info, err)
+	}
+
+	// The gzip contents have their own blob
+	gzipped := upload("report.txt", []string{"user3"}, true)
+	if gzipped.Hash != hash || gzipped.Path != first.Path+".gz" {
+		t.Errorf("Expected the gzip blob of the content, got %s %s", gzipped.Hash, gzipped.Path)
+	}
+
+	// A known content of the caller is created by its hash
+	header := newUploadHeader("linked.txt", "")
+	linked, err := manager.UploadByHash(ctx, strings.ToUpper(hash), header, UploadOption{Groups: []string{"user1"}, OpenID: "user1"})
+	if err != nil {
+		t.Fatalf("Failed to upload by hash: %v", err)
+	}
+	if linked.Path != first.Path || linked.ContentType != "text/plain" || linked.Bytes != len(content) {
+		t.Errorf("Unexpected linked file %+v", linked)
+	}
+	data, err := manager.Read(ctx, linked.ID)
+	if err != nil || string(data) != content {
+		t.Errorf("Expected the content of the linked file, got %q %v", data, err)
+	}
+
+	// The content of a team member is linked, the contents of the other callers are not found
+	teammate, err := manager.UploadByHash(ctx, hash, newUploadHeader("team.txt", ""), UploadOption{OpenID: "user5", TeamID: "team-user2"})
+	if err != nil || teammate.Path != first.Path {
+		t.Errorf("Expected the content of the team, got %+v %v", teammate, err)
+	}
+
+	for _, option := range []UploadOption{{}, {OpenID: "user4"}, {ClientID: "other-client"}, {OpenID: "user4", TeamID: "team-other"}} {
+		_, err = manager.UploadByHash(ctx, hash, newUploadHeader("other.txt", ""), option)
+		if !errors.Is(err, ErrBlobNotFound) {
+			t.Errorf("Expected ErrBlobNotFound for %+v, got %v", option, err)
+		}
+	}
+
+	_, err = manager.UploadByHash(ctx, strings.Repeat("0", 64), newUploadHeader("unknown.txt", ""), UploadOption{OpenID: "user1"})
+	if !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("Expected ErrBlobNotFound, got %v", err)
+	}
+
+	// The blob counts its files
+	if refs := blobRecordRefs(t, manager, first.Path); refs != 4 {
+		t.Errorf("Expected 4 references of the blob, got %d", refs)
+	}
+
+	// The content is deleted with the last file
+	for _, file := range []*File{first, second} {
+		if err := manager.Delete(ctx, file.ID); err != nil {
+			t.Fatalf("Failed to delete %s: %v", file.ID, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, first.Path)); err != nil {
+			t.Errorf("Expected the shared content to be kept: %v", err)
+		}
+	}
+
+	for _, file := range []*File{linked, teammate} {
+		if err := manager.Delete(ctx, file.ID); err != nil {
+			t.Fatalf("Failed to delete %s: %v", file.ID, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, first.Path)); !os.IsNotExist(err) {
+		t.Error("Expected the content to be deleted with the last file")
+	}
+	if _, err := os.Stat(filepath.Join(root, gzipped.Path)); err != nil {
+		t.Errorf("Expected the gzip content to be kept: %v", err)
+	}
+	if refs := blobRecordRefs(t, manager, first.Path); refs != -1 {
+		t.Errorf("Expected the record of the deleted blob to be removed, got %d references", refs)
+	}
+
+	// The uploaders without deduplication do not hash the contents
+	plain, err := New(ManagerOption{Driver: "local", Options: map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, "plain")}, AllowedTypes: []string{"text/*"}})
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+	file, err := plain.Upload(ctx, newUploadHeader("plain.txt", "text/plain"), strings.NewReader(content), UploadOption{})
+	if err != nil {
+		t.Fatalf("Failed to upload: %v", err)
+	}
+	if file.Hash != "" || isBlob(file.Path) {
+		t.Errorf("Expected no blob, got %s %s", file.Hash, file.Path)
+	}
+}
+
+func TestDedupNodes(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	// Two nodes store the files of the uploader in the same storage and database
+	root := filepath.Join(config.Conf.DataRoot, "dedup-nodes")
+	nodes := []*Manager{}
+	for i := 0; i < 2; i++ {
+		manager, err := Register("dedup-nodes", "local", ManagerOption{
+			Driver:       "local",
+			Options:      map[string]interface{}{"path": root},
+			AllowedTypes: []string{"text/*"},
+			Dedup:        true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to register manager: %v", err)
+		}
+		nodes = append(nodes, manager)
+	}
+
+	ctx := context.Background()
+	content := "the shared report"
+	upload := func(manager *Manager) *File {
+		header := newUploadHeader("report.txt", "text/plain")
+		header.Size = int64(len(content))
+		file, err := manager.Upload(ctx, header, strings.NewReader(content), UploadOption{OpenID: "user1"})
+		if err != nil {
+			t.Errorf("Failed to upload: %v", err)
+		}
+		return file
+	}
+
+	// A node deletes the last file of the blob while the other one links it
+	file := upload(nodes[0])
+	for i := 0; i < 20 && file != nil; i++ {
+		var linked *File
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func(file *File) {
+			defer wg.Done()
+			if err := nodes[0].Delete(ctx, file.ID); err != nil {
+				t.Errorf("Failed to delete %s: %v", file.ID, err)
+			}
+		}(file)
+		go func() {
+			defer wg.Done()
+			linked = upload(nodes[1])
+		}()
+		wg.Wait()
+
+		if linked == nil {
+			break
+		}
+		data, err := nodes[0].Read(ctx, linked.ID)
+		if err != nil || string(data) != content {
+			t.Fatalf("Expected the content of the linked file, got %q %v", data, err)
+		}
+		if refs := blobRecordRefs(t, nodes[0], linked.Path); refs != 1 {
+			t.Fatalf("Expected 1 reference of the blob, got %d", refs)
+		}
+		file = linked
+	}
+}
+
+// blobRecordRefs returns the references of a blob, -1 when the blob has no record
+func blobRecordRefs(t *testing.T, manager *Manager, path string) int {
+	record, err := getBlob(manager.blobKey(path))
+	if err != nil {
+		t.Fatalf("Failed to get the blob %s: %v", path, err)
+	}
+	if record == nil {
+		return -1
+	}
+	return int(toInt64(record["refs"]))
+}
+
+// countFiles counts the files stored under a directory
+func countFiles(t *testing.T, root string) int {
+	count := 0
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			count++
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk %s: %v", root, err)
+	}
+	return count
+}
@@ -0,0 +1,343 @@
+package attachment
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// exifHeader the header of the APP1 segment holding the EXIF data
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifTags the EXIF tags extracted into the file metadata
+var exifTags = map[uint16]string{
+	0x010F: "make",
+	0x0110: "model",
+	0x0112: "orientation",
+	0x0131: "software",
+	0x0132: "datetime",
+	0x829A: "exposure_time",
+	0x829D: "f_number",
+	0x8827: "iso",
+	0x9003: "datetime_original",
+	0x920A: "focal_length",
+	0xA002: "width",
+	0xA003: "height",
+	0xA434: "lens_model",
+}
+
+// gpsTags the GPS tags extracted into the file metadata
+var gpsTags = map[uint16]string{
+	0x0001: "gps_latitude_ref",
+	0x0002: "gps_latitude",
+	0x0003: "gps_longitude_ref",
+	0x0004: "gps_longitude",
+	0x0006: "gps_altitude",
+}
+
+// tiffTypeSizes the sizes of the TIFF field types
+var tiffTypeSizes = map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 7: 1, 9: 4, 10: 8}
+
+// errNoExif the image has no EXIF data
+var errNoExif = errors.New("no exif data")
+
+// ReadExif reads the EXIF data of a JPEG image, the GPS coordinates are converted to decimal degrees
+func ReadExif(data []byte) (map[string]interface{}, error) {
+	segment, err := findExif(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tiff := segment[len(exifHeader):]
+	if len(tiff) < 8 {
+		return nil, fmt.Errorf("invalid exif header")
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid exif byte order")
+	}
+
+	exif := map[string]interface{}{}
+	ifd0 := order.Uint32(tiff[4:8])
+	pointers, err := readIFD(tiff, order, ifd0, exifTags, exif)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset, ok := pointers[0x8769]; ok {
+		if _, err := readIFD(tiff, order, offset, exifTags, exif); err != nil {
+			return nil, err
+		}
+	}
+
+	if offset, ok := pointers[0x8825]; ok {
+		gps := map[string]interface{}{}
+		if _, err := readIFD(tiff, order, offset, gpsTags, gps); err != nil {
+			return nil, err
+		}
+		setGPS(exif, gps)
+	}
+
+	return exif, nil
+}
+
+// StripExif removes the metadata of a JPEG image: the EXIF and XMP data (APP1) and the IPTC data (APP13), each may
+// hold the GPS position. The orientation is kept so the image is still displayed upright.
+// The image is returned unchanged when it has no metadata.
+func StripExif(data []byte) ([]byte, error) {
+	segments, err := jpegSegments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	orientation := 1
+	if exif, err := ReadExif(data); err == nil {
+		if value, ok := exif["orientation"].(int); ok {
+			orientation = value
+		}
+	}
+
+	stripped := bytes.NewBuffer(make([]byte, 0, len(data)))
+	pos, found := 0, false
+	for _, segment := range segments {
+		if segment.marker != 0xE1 && segment.marker != 0xED {
+			continue
+		}
+
+		stripped.Write(data[pos:segment.start])
+		if !found && orientation > 1 && orientation <= 8 {
+			stripped.Write(orientationSegment(orientation))
+		}
+		pos, found = segment.end, true
+	}
+
+	if !found {
+		return data, nil
+	}
+	stripped.Write(data[pos:])
+	return stripped.Bytes(), nil
+}
+
+// jpegSegment a segment of a JPEG image, the bounds include the marker and the length
+type jpegSegment struct {
+	marker  byte
+	start   int
+	end     int
+	payload []byte
+}
+
+// jpegSegments returns the segments of a JPEG image preceding the image data, none for the other formats
+func jpegSegments(data []byte) ([]jpegSegment, error) {
+	segments := []jpegSegment{}
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return segments, nil
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, fmt.Errorf("invalid jpeg segment at %d", pos)
+		}
+
+		marker := data[pos+1]
+
+		// Padding and the segments without a length
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			pos += 2
+			continue
+		}
+
+		// The image data follows the start of scan, there is no metadata after it
+		if marker == 0xDA || marker == 0xD9 {
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, fmt.Errorf("invalid jpeg segment length at %d", pos)
+		}
+
+		segments = append(segments, jpegSegment{marker: marker, start: pos, end: end, payload: data[pos+4 : end]})
+		pos = end
+	}
+	return segments, nil
+}
+
+// findExif returns the payload of the EXIF segment of a JPEG image
+func findExif(data []byte) ([]byte, error) {
+	segments, err := jpegSegments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, segment := range segments {
+		if segment.marker == 0xE1 && bytes.HasPrefix(segment.payload, exifHeader) {
+			return segment.payload, nil
+		}
+	}
+	return nil, errNoExif
+}
+
+// readIFD reads the tags of an image file directory, returns the offsets of the sub directories
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32, tags map[uint16]string, values map[string]interface{}) (map[uint16]uint32, error) {
+	pointers := map[uint16]uint32{}
+	if int(offset)+2 > len(tiff) {
+		return nil, fmt.Errorf("invalid exif directory offset %d", offset)
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := int(offset) + 2 + i*12
+		if entry+12 > len(tiff) {
+			return nil, fmt.Errorf("invalid exif directory entry %d", i)
+		}
+
+		tag := order.Uint16(tiff[entry:])
+		typ := order.Uint16(tiff[entry+2:])
+		n := int(order.Uint32(tiff[entry+4:]))
+
+		// The sub directories: Exif and GPS
+		if tag == 0x8769 || tag == 0x8825 {
+			pointers[tag] = order.Uint32(tiff[entry+8:])
+			continue
+		}
+
+		name, ok := tags[tag]
+		if !ok {
+			continue
+		}
+
+		size, ok := tiffTypeSizes[typ]
+		if !ok || n <= 0 || n > len(tiff) {
+			continue
+		}
+
+		// The values of up to 4 bytes are stored in the entry
+		raw := tiff[entry+8 : entry+12]
+		if size*n > 4 {
+			start := int(order.Uint32(tiff[entry+8:]))
+			if start+size*n > len(tiff) {
+				continue
+			}
+			raw = tiff[start : start+size*n]
+		}
+
+		if value := tiffValue(raw, order, typ, n); value != nil {
+			values[name] = value
+		}
+	}
+	return pointers, nil
+}
+
+// tiffValue decodes the value of a TIFF field, the single values are returned as scalars
+func tiffValue(raw []byte, order binary.ByteOrder, typ uint16, n int) interface{} {
+	switch typ {
+	case 2: // ASCII
+		return strings.TrimSpace(strings.TrimRight(string(raw[:n]), "\x00"))
+
+	case 1, 7: // BYTE, UNDEFINED
+		if n == 1 {
+			return int(raw[0])
+		}
+		return nil
+
+	case 3: // SHORT
+		values := make([]int, n)
+		for i := range values {
+			values[i] = int(order.Uint16(raw[i*2:]))
+		}
+		if n == 1 {
+			return values[0]
+		}
+		return values
+
+	case 4, 9: // LONG, SLONG
+		values := make([]int, n)
+		for i := range values {
+			if typ == 9 {
+				values[i] = int(int32(order.Uint32(raw[i*4:])))
+				continue
+			}
+			values[i] = int(order.Uint32(raw[i*4:]))
+		}
+		if n == 1 {
+			return values[0]
+		}
+		return values
+
+	case 5, 10: // RATIONAL, SRATIONAL
+		values := make([]float64, n)
+		for i := range values {
+			var num, den float64
+			if typ == 10 {
+				num, den = float64(int32(order.Uint32(raw[i*8:]))), float64(int32(order.Uint32(raw[i*8+4:])))
+			} else {
+				num, den = float64(order.Uint32(raw[i*8:])), float64(order.Uint32(raw[i*8+4:]))
+			}
+			if den != 0 {
+				values[i] = math.Round(num/den*1e6) / 1e6
+			}
+		}
+		if n == 1 {
+			return values[0]
+		}
+		return values
+	}
+	return nil
+}
+
+// setGPS converts the GPS coordinates to decimal degrees
+func setGPS(exif map[string]interface{}, gps map[string]interface{}) {
+	coordinate := func(value interface{}, ref interface{}, negative string) (float64, bool) {
+		dms, ok := value.([]float64)
+		if !ok || len(dms) != 3 {
+			return 0, false
+		}
+		degrees := dms[0] + dms[1]/60 + dms[2]/3600
+		if ref == negative {
+			degrees = -degrees
+		}
+		return math.Round(degrees*1e6) / 1e6, true
+	}
+
+	if latitude, ok := coordinate(gps["gps_latitude"], gps["gps_latitude_ref"], "S"); ok {
+		exif["gps_latitude"] = latitude
+	}
+	if longitude, ok := coordinate(gps["gps_longitude"], gps["gps_longitude_ref"], "W"); ok {
+		exif["gps_longitude"] = longitude
+	}
+	if altitude, ok := gps["gps_altitude"].(float64); ok {
+		exif["gps_altitude"] = altitude
+	}
+}
+
+// orientationSegment returns an APP1 segment holding only the orientation
+func orientationSegment(orientation int) []byte {
+	payload := append([]byte{}, exifHeader...)
+	payload = append(payload,
+		'M', 'M', 0x00, 0x2A, // Big endian TIFF
+		0x00, 0x00, 0x00, 0x08, // IFD0 offset
+		0x00, 0x01, // One entry
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, // Orientation, SHORT, 1 value
+		0x00, byte(orientation), 0x00, 0x00, // The value
+		0x00, 0x00, 0x00, 0x00, // No next IFD
+	)
+
+	segment := []byte{0xFF, 0xE1, 0x00, 0x00}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
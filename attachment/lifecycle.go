@@ -0,0 +1,426 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+)
+
+// sweepPageSize the number of the records read at once by the sweeper
+const sweepPageSize = 200
+
+// sweepers the cancel functions of the background sweepers by uploader
+var sweepers = sync.Map{}
+
+// ReferenceChecker checks the object referencing a file still exists, the id is the group following the group prefix of the rule
+type ReferenceChecker func(ctx context.Context, file *File, id string) (bool, error)
+
+// references the reference checkers, the other references are Yao processes
+var references = map[string]ReferenceChecker{
+	"chat": chatExists,
+	"kb":   documentExists,
+}
+
+// RegisterReference registers a reference checker, it must be called before the uploaders are loaded
+func RegisterReference(name string, checker ReferenceChecker) {
+	references[name] = checker
+}
+
+// lifecycle the lifecycle option with the parsed durations
+type lifecycle struct {
+	rules     []LifecycleRule
+	cold      Storage
+	reconcile bool
+	interval  time.Duration
+	grace     time.Duration
+}
+
+// newLifecycle parses the lifecycle option, creates the cold storage
+func newLifecycle(option *LifecycleOption) (lifecycle, error) {
+	lc := lifecycle{grace: 24 * time.Hour}
+	if option == nil {
+		return lc, nil
+	}
+
+	lc.rules = option.Rules
+	lc.reconcile = option.Reconcile
+	for i, rule := range option.Rules {
+		if rule.ExpireDays < 0 || rule.ArchiveDays < 0 {
+			return lc, fmt.Errorf("invalid days of the lifecycle rule %d", i)
+		}
+
+		if rule.ArchiveDays > 0 && option.Cold == nil {
+			return lc, fmt.Errorf("the lifecycle rule %d archives the files, the cold storage is required", i)
+		}
+
+		if _, ok := references[rule.Reference]; rule.Reference != "" && !ok && !strings.Contains(rule.Reference, ".") {
+			return lc, fmt.Errorf("reference %s of the lifecycle rule %d is not supported, use chat, kb or a process", rule.Reference, i)
+		}
+	}
+
+	for name, value := range map[string]string{"interval": option.Interval, "grace": option.Grace} {
+		if value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return lc, fmt.Errorf("invalid %s %s of the lifecycle", name, value)
+		}
+
+		if name == "interval" {
+			lc.interval = duration
+			continue
+		}
+		lc.grace = duration
+	}
+
+	if option.Cold != nil {
+		cold, err := newStorage(option.Cold.Driver, option.Cold.Options)
+		if err != nil {
+			return lc, fmt.Errorf("invalid cold storage: %w", err)
+		}
+		lc.cold = cold
+	}
+	return lc, nil
+}
+
+// startSweeper starts the background sweeper of an uploader, the sweeper of the previous registration is stopped
+func startSweeper(manager *Manager) {
+	if cancel, ok := sweepers.LoadAndDelete(manager.Name); ok {
+		cancel.(context.CancelFunc)()
+	}
+
+	if manager.lifecycle.interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sweepers.Store(manager.Name, cancel)
+
+	go func() {
+		ticker := time.NewTicker(manager.lifecycle.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-ticker.C:
+				report, err := manager.Sweep(ctx, false)
+				if err != nil {
+					log.Error("[attachment] %s sweep failed: %s", manager.Name, err.Error())
+					continue
+				}
+				report.log()
+			}
+		}
+	}()
+}
+
+// Sweep applies the lifecycle rules to the stored files: the expired files and the files whose referencing object
+// is gone are deleted, the old files are moved to the cold storage. With reconcile, the records without content and
+// the content without records are removed. Nothing is changed in a dry run.
+func (manager Manager) Sweep(ctx context.Context, dryRun bool) (*SweepReport, error) {
+	report := &SweepReport{
+		Uploader:     manager.Name,
+		DryRun:       dryRun,
+		StartedAt:    time.Now().Unix(),
+		Expired:      []string{},
+		Unreferenced: []string{},
+		Archived:     []string{},
+		Missing:      []string{},
+		Orphans:      []string{},
+		Errors:       []string{},
+	}
+
+	// The stored paths of the files kept, with their variants
+	paths := map[string]bool{}
+	err := manager.eachFile(ctx, func(file *File, groups []string, createdAt time.Time) error {
+		report.Scanned++
+
+		// The rules do not apply to a file of an unknown age, its content is kept
+		if createdAt.IsZero() {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to read the upload time of %s", file.ID))
+		} else if manager.sweepFile(ctx, file, groups, createdAt, report) {
+			return nil
+		}
+
+		paths[file.Path] = true
+		for name, option := range manager.Variants {
+			paths[variantPath(file.Path, name, variantFormat(file.ContentType, option))] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manager.lifecycle.reconcile {
+		err = manager.sweepOrphans(ctx, paths, report)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.EndedAt = time.Now().Unix()
+	return report, nil
+}
+
+// sweepFile applies the first rule matching the groups of a file, returns true when the file is deleted
+func (manager Manager) sweepFile(ctx context.Context, file *File, groups []string, createdAt time.Time, report *SweepReport) bool {
+	age := time.Since(createdAt)
+	remove := func(list *[]string) bool {
+		*list = append(*list, file.ID)
+		if report.DryRun {
+			return true
+		}
+
+		err := manager.Delete(ctx, file.ID)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to delete %s: %s", file.ID, err.Error()))
+		}
+		return true
+	}
+
+	rule, id, ok := manager.lifecycle.match(groups)
+	if ok {
+		if rule.ExpireDays > 0 && age >= days(rule.ExpireDays) {
+			return remove(&report.Expired)
+		}
+
+		if rule.Reference != "" && age >= manager.lifecycle.grace {
+			exists, err := referenceExists(ctx, rule.Reference, file, id)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to check the %s of %s: %s", rule.Reference, file.ID, err.Error()))
+			} else if !exists {
+				return remove(&report.Unreferenced)
+			}
+		}
+	}
+
+	// The rejected files and the files being uploaded have no content
+	if manager.lifecycle.reconcile && file.Status != ScanRejected && file.Status != "uploading" && !manager.storage.Exists(ctx, file.Path) {
+		return remove(&report.Missing)
+	}
+
+	tiered, isTiered := manager.storage.(*tieredStorage)
+	if ok && isTiered && rule.ArchiveDays > 0 && age >= days(rule.ArchiveDays) && tiered.hot.Exists(ctx, file.Path) {
+		report.Archived = append(report.Archived, file.ID)
+		if !report.DryRun {
+			err := tiered.archive(ctx, file.Path, file.ContentType)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to archive %s: %s", file.ID, err.Error()))
+			}
+		}
+	}
+	return false
+}
+
+// sweepOrphans removes the stored content without records, the content younger than the grace period may be
+// an upload in progress
+func (manager Manager) sweepOrphans(ctx context.Context, paths map[string]bool, report *SweepReport) error {
+	cutoff := time.Now().Add(-manager.lifecycle.grace)
+	orphans := []string{}
+	err := manager.storage.Walk(ctx, func(path string, size int64, modTime time.Time) error {
+		if !paths[path] && modTime.Before(cutoff) {
+			orphans = append(orphans, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the stored files: %w", err)
+	}
+
+	report.Orphans = append(report.Orphans, orphans...)
+	if report.DryRun {
+		return nil
+	}
+
+	for _, path := range orphans {
+		err := manager.storage.Delete(ctx, path)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to delete %s: %s", path, err.Error()))
+		}
+	}
+	return nil
+}
+
+// eachFile calls fn for each file of the uploader with its groups and upload time, the time is zero when the
+// record has no valid upload time
+func (manager Manager) eachFile(ctx context.Context, fn func(file *File, groups []string, createdAt time.Time) error) error {
+	m := model.Select("__yao.attachment")
+	lastID := int64(0)
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		records, err := m.Get(model.QueryParam{
+			Select: []interface{}{"id", "file_id", "name", "content_type", "path", "bytes", "status", "groups", "created_at"},
+			Wheres: []model.QueryWhere{
+				{Column: "uploader", Value: manager.Name},
+				{Column: "id", OP: "gt", Value: lastID},
+			},
+			Orders: []model.QueryOrder{{Column: "id", Option: "asc"}},
+			Limit:  sweepPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query the files: %w", err)
+		}
+
+		for _, record := range records {
+			lastID = toInt64(record["id"])
+			file := &File{Bytes: int(toInt64(record["bytes"]))}
+			file.ID, _ = record["file_id"].(string)
+			file.Filename, _ = record["name"].(string)
+			file.ContentType, _ = record["content_type"].(string)
+			file.Path, _ = record["path"].(string)
+			file.Status, _ = record["status"].(string)
+
+			var groups []string
+			decodeJSON(record["groups"], &groups)
+
+			// The upload time is zero when it can not be read
+			createdAt, ok := toTime(record["created_at"])
+			if ok {
+				file.CreatedAt = int(createdAt.Unix())
+			}
+
+			err := fn(file, groups, createdAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(records) < sweepPageSize {
+			return nil
+		}
+	}
+}
+
+// match returns the first rule matching the groups of a file, with the group following the group prefix
+func (lc lifecycle) match(groups []string) (LifecycleRule, string, bool) {
+	for _, rule := range lc.rules {
+		if len(groups) < len(rule.Groups) {
+			continue
+		}
+
+		matched := true
+		for i, group := range rule.Groups {
+			if group != "*" && group != groups[i] {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			id := ""
+			if len(groups) > len(rule.Groups) {
+				id = groups[len(rule.Groups)]
+			}
+			return rule, id, true
+		}
+	}
+	return LifecycleRule{}, "", false
+}
+
+// referenceExists checks the object referencing a file exists, an unknown object is considered to exist
+func referenceExists(ctx context.Context, reference string, file *File, id string) (bool, error) {
+	if checker, ok := references[reference]; ok {
+		return checker(ctx, file, id)
+	}
+
+	// The process returns true when the object exists
+	proc := process.NewWithContext(ctx, reference, id, map[string]interface{}{
+		"file_id":      file.ID,
+		"filename":     file.Filename,
+		"content_type": file.ContentType,
+		"path":         file.Path,
+	})
+	defer proc.Release()
+
+	err := proc.Execute()
+	if err != nil {
+		return true, err
+	}
+
+	exists, ok := proc.Value().(bool)
+	return exists || !ok, nil
+}
+
+// chatExists checks the chat of the group exists
+func chatExists(ctx context.Context, file *File, id string) (bool, error) {
+	if id == "" {
+		return true, nil
+	}
+
+	records, err := model.Select("__yao.agent.chat").Get(model.QueryParam{
+		Select: []interface{}{"id"},
+		Wheres: []model.QueryWhere{{Column: "chat_id", Value: id}},
+		Limit:  1,
+	})
+	if err != nil {
+		return true, err
+	}
+	return len(records) > 0, nil
+}
+
+// documentExists checks a knowledge base document holds the file
+func documentExists(ctx context.Context, file *File, id string) (bool, error) {
+	records, err := model.Select("__yao.kb.document").Get(model.QueryParam{
+		Select: []interface{}{"id"},
+		Wheres: []model.QueryWhere{{Column: "file_id", Value: file.ID}},
+		Limit:  1,
+	})
+	if err != nil {
+		return true, err
+	}
+	return len(records) > 0, nil
+}
+
+// log writes the summary of the sweep
+func (report *SweepReport) log() {
+	message := fmt.Sprintf("[attachment] %s sweep: %d files scanned, %d expired, %d unreferenced, %d archived, %d missing, %d orphans",
+		report.Uploader, report.Scanned, len(report.Expired), len(report.Unreferenced), len(report.Archived), len(report.Missing), len(report.Orphans))
+
+	if len(report.Errors) > 0 {
+		log.Error("%s, %d errors: %s", message, len(report.Errors), strings.Join(report.Errors, "; "))
+		return
+	}
+	log.Info("%s", message)
+}
+
+// days returns the duration of n days
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
+
+// toTime converts a timestamp column, the drivers return a time, a string or a unix timestamp
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+
+	case int64, int:
+		return time.Unix(toInt64(v), 0), true
+
+	case []byte:
+		return toTime(string(v))
+
+	case string:
+		for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05.000000"} {
+			if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,203 @@
+package attachment
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestLifecycle(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	// The chats still open
+	RegisterReference("tests.chat", func(ctx context.Context, file *File, id string) (bool, error) {
+		return id == "chat-1", nil
+	})
+	defer delete(references, "tests.chat")
+
+	hot := filepath.Join(config.Conf.DataRoot, "lifecycle")
+	cold := filepath.Join(config.Conf.DataRoot, "lifecycle-cold")
+	manager, err := Register("lifecycle", "local", ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": hot},
+		AllowedTypes: []string{"text/*"},
+		Lifecycle: &LifecycleOption{
+			Rules: []LifecycleRule{
+				{Groups: []string{"chats"}, Reference: "tests.chat"},
+				{Groups: []string{"tmp"}, ExpireDays: 1},
+				{ArchiveDays: 7},
+			},
+			Cold:      &ColdStorageOption{Driver: "local", Options: map[string]interface{}{"path": cold}},
+			Reconcile: true,
+			Grace:     "1h",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	ctx := context.Background()
+	upload := func(name string, groups []string, age time.Duration) *File {
+		header := newUploadHeader(name, "text/plain")
+		file, err := manager.Upload(ctx, header, strings.NewReader("content of "+name), UploadOption{Groups: groups})
+		if err != nil {
+			t.Fatalf("Failed to upload %s: %v", name, err)
+		}
+
+		_, err = model.Select("__yao.attachment").UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{{Column: "file_id", Value: file.ID}},
+		}, map[string]interface{}{"created_at": time.Now().Add(-age)})
+		if err != nil {
+			t.Fatalf("Failed to update the upload time of %s: %v", name, err)
+		}
+		return file
+	}
+
+	day := 24 * time.Hour
+	open := upload("open.txt", []string{"chats", "chat-1"}, 2*day)
+	closed := upload("closed.txt", []string{"chats", "chat-2"}, 2*day)
+	recent := upload("recent.txt", []string{"chats", "chat-3"}, time.Minute)
+	tmp := upload("tmp.txt", []string{"tmp"}, 2*day)
+	old := upload("old.txt", []string{"docs"}, 10*day)
+	kept := upload("kept.txt", []string{"docs"}, 2*day)
+	missing := upload("missing.txt", []string{"docs"}, 2*day)
+
+	// A record without content, the content without records
+	if err := os.Remove(filepath.Join(hot, missing.Path)); err != nil {
+		t.Fatalf("Failed to remove the content: %v", err)
+	}
+	for _, name := range []string{"orphan.txt", "new.txt"} {
+		if err := os.WriteFile(filepath.Join(hot, name), []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(hot, "orphan.txt"), past, past); err != nil {
+		t.Fatalf("Failed to change the time of orphan.txt: %v", err)
+	}
+
+	// Nothing is changed in a dry run
+	report, err := manager.Sweep(ctx, true)
+	if err != nil {
+		t.Fatalf("Failed to sweep: %v", err)
+	}
+	if report.Scanned != 7 || len(report.Expired) != 1 || len(report.Unreferenced) != 1 || len(report.Archived) != 1 ||
+		len(report.Missing) != 1 || len(report.Orphans) != 1 {
+		t.Errorf("Unexpected dry run report %+v", report)
+	}
+	if !manager.Exists(ctx, tmp.ID) || !manager.Exists(ctx, closed.ID) {
+		t.Error("Expected the files to be kept in a dry run")
+	}
+
+	report, err = manager.Sweep(ctx, false)
+	if err != nil {
+		t.Fatalf("Failed to sweep: %v", err)
+	}
+	if len(report.Errors) > 0 {
+		t.Errorf("Unexpected errors %v", report.Errors)
+	}
+
+	expected := map[string][]string{
+		"expired":      {tmp.ID},
+		"unreferenced": {closed.ID},
+		"archived":     {old.ID},
+		"missing":      {missing.ID},
+		"orphans":      {"orphan.txt"},
+	}
+	actual := map[string][]string{
+		"expired":      report.Expired,
+		"unreferenced": report.Unreferenced,
+		"archived":     report.Archived,
+		"missing":      report.Missing,
+		"orphans":      report.Orphans,
+	}
+	for name, ids := range expected {
+		sort.Strings(actual[name])
+		if strings.Join(actual[name], ",") != strings.Join(ids, ",") {
+			t.Errorf("Expected %s %v, got %v", name, ids, actual[name])
+		}
+	}
+
+	// The deleted files
+	for _, file := range []*File{tmp, closed, missing} {
+		if _, err := manager.Info(ctx, file.ID); err == nil {
+			t.Errorf("Expected %s to be deleted", file.Filename)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(hot, "orphan.txt")); !os.IsNotExist(err) {
+		t.Error("Expected the orphan content to be deleted")
+	}
+
+	// The kept files
+	for _, file := range []*File{open, recent, kept} {
+		if !manager.Exists(ctx, file.ID) {
+			t.Errorf("Expected %s to be kept", file.Filename)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(hot, "new.txt")); err != nil {
+		t.Error("Expected the content younger than the grace period to be kept")
+	}
+
+	// The archived file is moved to the cold storage and still readable
+	if _, err := os.Stat(filepath.Join(hot, old.Path)); !os.IsNotExist(err) {
+		t.Error("Expected the archived file to be removed from the hot storage")
+	}
+	if _, err := os.Stat(filepath.Join(cold, old.Path)); err != nil {
+		t.Errorf("Expected the archived file in the cold storage: %v", err)
+	}
+	data, err := manager.Read(ctx, old.ID)
+	if err != nil || string(data) != "content of old.txt" {
+		t.Errorf("Expected the archived file to be readable, got %q %v", data, err)
+	}
+
+	// A second sweep has nothing to do
+	report, err = manager.Sweep(ctx, false)
+	if err != nil {
+		t.Fatalf("Failed to sweep: %v", err)
+	}
+	if report.Scanned != 4 || len(report.Archived)+len(report.Missing)+len(report.Orphans)+len(report.Expired)+len(report.Unreferenced) != 0 {
+		t.Errorf("Expected nothing to do, got %+v", report)
+	}
+
+	// The archived files can be deleted
+	if err := manager.Delete(ctx, old.ID); err != nil {
+		t.Errorf("Failed to delete the archived file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cold, old.Path)); !os.IsNotExist(err) {
+		t.Error("Expected the archived file to be deleted from the cold storage")
+	}
+
+	// The content of a file without a valid upload time is kept
+	unknown := upload("unknown.txt", []string{"tmp"}, 2*day)
+	_, err = model.Select("__yao.attachment").UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "file_id", Value: unknown.ID}},
+	}, map[string]interface{}{"created_at": nil})
+	if err != nil {
+		t.Fatalf("Failed to clear the upload time: %v", err)
+	}
+	report, err = manager.Sweep(ctx, false)
+	if err != nil {
+		t.Fatalf("Failed to sweep: %v", err)
+	}
+	if len(report.Errors) != 1 || len(report.Expired)+len(report.Orphans) != 0 {
+		t.Errorf("Expected the file of an unknown age to be reported and kept, got %+v", report)
+	}
+	if !manager.Exists(ctx, unknown.ID) {
+		t.Error("Expected the content of the file of an unknown age to be kept")
+	}
+
+	// The archive rules require a cold storage
+	_, err = New(ManagerOption{Driver: "local", Options: map[string]interface{}{"path": hot}, Lifecycle: &LifecycleOption{Rules: []LifecycleRule{{ArchiveDays: 1}}}})
+	if err == nil {
+		t.Error("Expected an error for an archive rule without cold storage")
+	}
+}
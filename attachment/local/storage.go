@@ -142,6 +142,89 @@ func (storage *Storage) MergeChunks(ctx context.Context, path string, totalChunk
 	return nil
 }
 
+// DeleteChunks removes the chunks of an unfinished upload
+func (storage *Storage) DeleteChunks(ctx context.Context, path string, totalChunks int) error {
+	chunksDir := filepath.Join(storage.Path, ".chunks", path)
+	return os.RemoveAll(chunksDir)
+}
+
+// Stat returns the size, the modification time and the ETag of the stored file
+func (storage *Storage) Stat(ctx context.Context, path string) (int64, time.Time, string, error) {
+	fullpath := filepath.Join(storage.Path, path)
+	info, err := os.Stat(fullpath)
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+
+	etag := fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+	return info.Size(), info.ModTime(), etag, nil
+}
+
+// RangeReader reads length bytes of the stored file from the offset, a negative length reads to the end.
+// The bytes are read as stored, gzip files are not decompressed.
+func (storage *Storage) RangeReader(ctx context.Context, path string, offset int64, length int64) (io.ReadCloser, error) {
+	fullpath := filepath.Join(storage.Path, path)
+	file, err := os.Open(fullpath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if length < 0 {
+		return file, nil
+	}
+	return &rangeFile{Reader: io.LimitReader(file, length), file: file}, nil
+}
+
+// Walk calls fn for each stored file with its path, size and modification time, the chunks are skipped
+func (storage *Storage) Walk(ctx context.Context, fn func(path string, size int64, modTime time.Time) error) error {
+	return filepath.WalkDir(storage.Path, func(fullpath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && fullpath == storage.Path {
+				return nil
+			}
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".chunks" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		path, err := filepath.Rel(storage.Path, fullpath)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(path), info.Size(), info.ModTime())
+	})
+}
+
+// rangeFile a range of a file
+type rangeFile struct {
+	io.Reader
+	file *os.File
+}
+
+// Close closes the file
+func (r *rangeFile) Close() error {
+	return r.file.Close()
+}
+
 // Reader read file from local storage
 func (storage *Storage) Reader(ctx context.Context, path string) (io.ReadCloser, error) {
 	fullpath := filepath.Join(storage.Path, path)
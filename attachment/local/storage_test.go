@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -312,4 +313,26 @@ func TestLocalStorage(t *testing.T) {
 		// Content detection should identify this as HTML
 		assert.Equal(t, "text/html; charset=utf-8", contentType)
 	})
+
+	t.Run("Walk", func(t *testing.T) {
+		storage, err := New(map[string]interface{}{
+			"path": filepath.Join(tempDir, "walk_storage"),
+		})
+		assert.NoError(t, err)
+
+		ctx := context.Background()
+		_, err = storage.Upload(ctx, "docs/walk.txt", bytes.NewReader([]byte("walk")), "text/plain")
+		assert.NoError(t, err)
+		err = storage.UploadChunk(ctx, "docs/chunked.txt", 0, bytes.NewReader([]byte("chunk")), "text/plain")
+		assert.NoError(t, err)
+
+		// The chunks are skipped
+		paths := map[string]int64{}
+		err = storage.Walk(ctx, func(path string, size int64, modTime time.Time) error {
+			paths[path] = size
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"docs/walk.txt": 4}, paths)
+	})
 }
@@ -85,6 +85,7 @@ func Register(name string, driver string, option ManagerOption) (*Manager, error
 
 	// Register the manager
 	Managers[name] = manager
+	startSweeper(manager)
 	return manager, nil
 }
 
@@ -135,19 +136,40 @@ func RegisterDefault(name string) (*Manager, error) {
 
 // ReplaceEnv replaces the environment variables in the options
 func (option *ManagerOption) ReplaceEnv(root string) {
-	if option.Options != nil {
+	// Replace the environment variables of the signed URLs, the ffmpeg path and the scanner address
+	values := []*string{&option.SignKey, &option.PublicURL, &option.FFmpeg}
+	if option.Scanner != nil {
+		values = append(values, &option.Scanner.Address)
+	}
+	for _, value := range values {
+		if strings.HasPrefix(*value, "$ENV.") {
+			*value = os.ExpandEnv(fmt.Sprintf("${%s}", strings.TrimPrefix(*value, "$ENV.")))
+		}
+	}
+
+	replaceEnvOptions(option.Options, root)
+
+	// The options of the cold storage
+	if option.Lifecycle != nil && option.Lifecycle.Cold != nil {
+		replaceEnvOptions(option.Lifecycle.Cold.Options, root)
+	}
+}
+
+// replaceEnvOptions replaces the environment variables in the storage options, the local paths are relative to root
+func replaceEnvOptions(options map[string]interface{}, root string) {
+	if options != nil {
 		// Replace the environment variables in the options
-		for k, v := range option.Options {
+		for k, v := range options {
 			if iv, ok := v.(string); ok {
 				if strings.HasPrefix(iv, "$ENV.") {
 					iv = os.ExpandEnv(fmt.Sprintf("${%s}", strings.TrimPrefix(iv, "$ENV.")))
-					option.Options[k] = iv
+					options[k] = iv
 				}
 
 				// Path
 				if k == "path" {
 					iv = strings.TrimPrefix(iv, "/")
-					option.Options[k] = filepath.Join(root, iv)
+					options[k] = filepath.Join(root, iv)
 				}
 
 			}
@@ -163,24 +185,11 @@ func New(option ManagerOption) (*Manager, error) {
 			wildcards: []string{},
 		}}
 
-	switch strings.ToLower(option.Driver) {
-	case "local":
-		storage, err := local.New(option.Options)
-		if err != nil {
-			return nil, err
-		}
-		manager.storage = storage
-
-	case "s3":
-		storage, err := s3.New(option.Options)
-		if err != nil {
-			return nil, err
-		}
-		manager.storage = storage
-
-	default:
-		return nil, fmt.Errorf("driver %s does not support", option.Driver)
+	storage, err := newStorage(option.Driver, option.Options)
+	if err != nil {
+		return nil, err
 	}
+	manager.storage = storage
 
 	// Max size
 	if option.MaxSize != "" {
@@ -200,6 +209,36 @@ func New(option ManagerOption) (*Manager, error) {
 		manager.chunsize = chunsize
 	}
 
+	// Storage quotas
+	quotas, err := newQuotas(option.Quotas)
+	if err != nil {
+		return nil, err
+	}
+	manager.quotas = quotas
+
+	// Variants
+	err = validateVariants(option.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	// Content scanner
+	scanner, err := newScanner(option.Scanner)
+	if err != nil {
+		return nil, err
+	}
+	manager.scanner = scanner
+
+	// Lifecycle rules, the files are moved to the cold storage
+	lifecycle, err := newLifecycle(option.Lifecycle)
+	if err != nil {
+		return nil, err
+	}
+	manager.lifecycle = lifecycle
+	if lifecycle.cold != nil {
+		manager.storage = &tieredStorage{hot: manager.storage, cold: lifecycle.cold}
+	}
+
 	// init allowedTypes
 	if len(option.AllowedTypes) > 0 {
 		for _, t := range option.AllowedTypes {
@@ -215,16 +254,41 @@ func New(option ManagerOption) (*Manager, error) {
 	return manager, nil
 }
 
+// newStorage creates the storage of a driver
+func newStorage(driver string, options map[string]interface{}) (Storage, error) {
+	switch strings.ToLower(driver) {
+	case "local":
+		storage, err := local.New(options)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+
+	case "s3":
+		storage, err := s3.New(options)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	}
+	return nil, fmt.Errorf("driver %s does not support", driver)
+}
+
 // LocalPath gets the local path of the file
 func (manager Manager) LocalPath(ctx context.Context, fileID string) (string, string, error) {
 	// Get the real storage path from database
-	storagePath, err := manager.getStoragePathFromDatabase(ctx, fileID)
+	file, err := manager.getFileFromDatabase(ctx, fileID)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = available(file)
 	if err != nil {
 		return "", "", err
 	}
 
 	// Call the storage implementation
-	return manager.storage.LocalPath(ctx, storagePath)
+	return manager.storage.LocalPath(ctx, file.Path)
 }
 
 // Upload uploads a file, Content-Sync must be true for chunked upload
@@ -245,6 +309,12 @@ func (manager Manager) Upload(ctx context.Context, fileheader *FileHeader, reade
 		// Store the chunk info
 		chunkIndex := 0
 		if start == 0 {
+			// The whole file must fit in the quotas
+			err = manager.checkQuota(ctx, option, total)
+			if err != nil {
+				return nil, err
+			}
+
 			chunksize := end - start + 1
 			totalChunks := (total + chunksize - 1) / chunksize
 			uploadChunks.LoadOrStore(file.ID, &UploadChunk{
@@ -312,17 +382,34 @@ func (manager Manager) Upload(ctx context.Context, fileheader *FileHeader, reade
 			file.Bytes = int(chunkdata.Total)
 			file.Status = "uploaded"
 
+			// Hash and scan the content, extract the image size and EXIF data
+			err = manager.processUploaded(ctx, file, option)
+			if err != nil {
+				return nil, err
+			}
+
 			// Save file information to database when chunked upload is complete
-			err = manager.saveFileToDatabase(ctx, file, file.Path, option)
+			err = manager.saveFile(ctx, file, option)
 			if err != nil {
 				return nil, fmt.Errorf("failed to save chunked file to database: %w", err)
 			}
+
+			err = manager.recheckQuota(ctx, file, option)
+			if err != nil {
+				return nil, err
+			}
+			manager.generateUploadVariants(file)
 		}
 
 		return file, nil
 	}
 
 	// Handle single file upload
+	err = manager.checkQuota(ctx, option, fileheader.Size)
+	if err != nil {
+		return nil, err
+	}
+
 	var finalReader io.Reader = reader
 
 	// Apply gzip compression if requested
@@ -386,15 +473,49 @@ func (manager Manager) Upload(ctx context.Context, fileheader *FileHeader, reade
 	// Update the file status
 	file.Status = "uploaded"
 
+	// Hash and scan the content, extract the image size and EXIF data
+	err = manager.processUploaded(ctx, file, option)
+	if err != nil {
+		return nil, err
+	}
+
 	// Save file information to database
-	err = manager.saveFileToDatabase(ctx, file, file.Path, option)
+	err = manager.saveFile(ctx, file, option)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save file to database: %w", err)
 	}
 
+	err = manager.recheckQuota(ctx, file, option)
+	if err != nil {
+		return nil, err
+	}
+	manager.generateUploadVariants(file)
+
 	return file, nil
 }
 
+// processUploaded hashes and scans the content of an uploaded file, the image metadata is extracted once the file
+// is clean. The received content is hashed before the EXIF data is stripped, the compressed images are not hashed.
+func (manager Manager) processUploaded(ctx context.Context, file *File, option UploadOption) error {
+	if manager.Dedup && !(option.CompressImage && strings.HasPrefix(file.ContentType, "image/")) {
+		hash, err := manager.hashContent(ctx, file.Path)
+		if err != nil {
+			return err
+		}
+		file.Hash = hash
+	}
+
+	err := manager.scan(ctx, file)
+	if err != nil {
+		return err
+	}
+
+	if file.Status != "uploaded" {
+		return nil
+	}
+	return manager.extractImageMetadata(ctx, file)
+}
+
 // compressStoredImage compresses an already stored image
 func (manager Manager) compressStoredImage(ctx context.Context, file *File, option UploadOption) error {
 	// Download the stored file using storage path
@@ -423,11 +544,17 @@ func (manager Manager) compressStoredImage(ctx context.Context, file *File, opti
 // Download downloads a file
 func (manager Manager) Download(ctx context.Context, fileID string) (*FileResponse, error) {
 	// Get real storage path from database
-	storagePath, err := manager.getStoragePathFromDatabase(ctx, fileID)
+	file, err := manager.getFileFromDatabase(ctx, fileID)
 	if err != nil {
 		return nil, err
 	}
 
+	err = available(file)
+	if err != nil {
+		return nil, err
+	}
+	storagePath := file.Path
+
 	reader, contentType, err := manager.storage.Download(ctx, storagePath)
 	if err != nil {
 		return nil, err
@@ -457,6 +584,11 @@ func (manager Manager) Read(ctx context.Context, fileID string) ([]byte, error)
 		return nil, err
 	}
 
+	err = available(file)
+	if err != nil {
+		return nil, err
+	}
+
 	reader, err := manager.storage.Reader(ctx, file.Path)
 	if err != nil {
 		return nil, err
@@ -641,6 +773,9 @@ func (manager Manager) List(ctx context.Context, option ListOption) (*ListResult
 		if path, ok := record["path"].(string); ok {
 			file.Path = path
 		}
+		if hash, ok := record["hash"].(string); ok {
+			file.Hash = hash
+		}
 		if bytes, ok := record["bytes"].(int64); ok {
 			file.Bytes = int(bytes)
 		} else if bytesInt, ok := record["bytes"].(int); ok {
@@ -654,6 +789,9 @@ func (manager Manager) List(ctx context.Context, option ListOption) (*ListResult
 			// Fallback to current time if not available
 			file.CreatedAt = int(time.Now().Unix())
 		}
+		if metadata, ok := record["metadata"]; ok {
+			decodeJSON(metadata, &file.Metadata)
+		}
 
 		files = append(files, file)
 	}
@@ -911,15 +1049,43 @@ func (manager Manager) Delete(ctx context.Context, fileID string) error {
 		return err
 	}
 
-	// Delete from storage
-	err = manager.storage.Delete(ctx, storagePath)
-	if err != nil {
-		return err
+	// The blobs are shared by the files with the same content, the content is deleted with the last file
+	if isBlob(storagePath) {
+		file, err := manager.getFileFromDatabase(ctx, fileID)
+		if err != nil {
+			return err
+		}
+
+		err = manager.deleteFileFromDatabase(ctx, fileID)
+		if err != nil {
+			return err
+		}
+		return manager.release(ctx, file)
+	}
+
+	// Delete the variants
+	if len(manager.Variants) > 0 {
+		if file, err := manager.getFileFromDatabase(ctx, fileID); err == nil {
+			manager.deleteVariants(ctx, file)
+		}
+	}
+
+	// Delete from storage, the content of the rejected files is already removed
+	if manager.storage.Exists(ctx, storagePath) {
+		err = manager.storage.Delete(ctx, storagePath)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Delete from database
+	return manager.deleteFileFromDatabase(ctx, fileID)
+}
+
+// deleteFileFromDatabase deletes the file information from the database
+func (manager Manager) deleteFileFromDatabase(ctx context.Context, fileID string) error {
 	m := model.Select("__yao.attachment")
-	_, err = m.DeleteWhere(model.QueryParam{
+	_, err := m.DeleteWhere(model.QueryParam{
 		Wheres: []model.QueryWhere{
 			{Column: "file_id", Value: fileID},
 		},
@@ -945,12 +1111,15 @@ func (manager Manager) saveFileToDatabase(ctx context.Context, file *File, stora
 		"name":         file.Filename,
 		"user_path":    option.OriginalFilename,
 		"path":         storagePath,
+		"hash":         file.Hash,
 		"bytes":        int64(file.Bytes),
 		"status":       file.Status,
 		"gzip":         option.Gzip,
 		"groups":       option.Groups,
 		"client_id":    option.ClientID,
 		"openid":       option.OpenID,
+		"team_id":      option.TeamID,
+		"metadata":     file.Metadata,
 	}
 
 	// Check if record exists first
@@ -1018,10 +1187,18 @@ func (manager Manager) getFileFromDatabase(ctx context.Context, fileID string) (
 		file.Path = path
 	}
 
+	if hash, ok := record["hash"].(string); ok {
+		file.Hash = hash
+	}
+
 	if bytes, ok := record["bytes"].(int64); ok {
 		file.Bytes = int(bytes)
 	}
 
+	if err := decodeJSON(record["metadata"], &file.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode the metadata of %s: %w", fileID, err)
+	}
+
 	return file, nil
 }
 
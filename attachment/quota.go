@@ -0,0 +1,281 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// ErrQuotaExceeded the file would exceed a storage quota
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+// QuotaLimits returns the limits of a client, user, team or group, e.g. the storage plan of a team.
+// The limits of the quota option apply when ok is false.
+var QuotaLimits func(ctx context.Context, uploader string, scope string, id string) (maxBytes int64, maxFiles int64, ok bool)
+
+// quotaScopes the scopes of the storage usage, in the order they are reported
+var quotaScopes = []string{"client_id", "openid", "team", "group"}
+
+// quota a storage quota with the parsed limits
+type quota struct {
+	scope    string
+	depth    int
+	maxBytes int64
+	maxFiles int64
+}
+
+// newQuotas parses the quota options
+func newQuotas(options []QuotaOption) ([]quota, error) {
+	quotas := []quota{}
+	for _, option := range options {
+		q := quota{scope: option.Scope, depth: option.Depth, maxFiles: option.MaxFiles}
+		if !isQuotaScope(q.scope) {
+			return nil, fmt.Errorf("quota scope %s is not supported, use %s", q.scope, strings.Join(quotaScopes, ", "))
+		}
+
+		if q.depth <= 0 {
+			q.depth = 1
+		}
+
+		if option.MaxBytes != "" {
+			maxBytes, err := getSize(option.MaxBytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_bytes of the %s quota: %w", q.scope, err)
+			}
+			q.maxBytes = maxBytes
+		}
+		quotas = append(quotas, q)
+	}
+	return quotas, nil
+}
+
+// Usage returns the storage consumption of the client, user, team and group of the upload options,
+// with the quotas that apply to them. The scopes without a value in the options are omitted.
+func (manager Manager) Usage(ctx context.Context, option UploadOption) ([]QuotaUsage, error) {
+	usages := []QuotaUsage{}
+	for _, scope := range quotaScopes {
+		q := manager.quota(scope)
+		id := q.id(option)
+		if id == "" {
+			continue
+		}
+
+		usage, err := manager.usage(ctx, q, id)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, *usage)
+	}
+	return usages, nil
+}
+
+// checkQuota checks a file of the given size fits in the quotas of the upload options
+func (manager Manager) checkQuota(ctx context.Context, option UploadOption, size int64) error {
+	return manager.fitQuota(ctx, option, size, 1)
+}
+
+// recheckQuota checks the quotas again once the file is saved: the uploads running at the same time passed
+// checkQuota against the same usage. The file is deleted when the usage with it exceeds a quota,
+// the concurrent uploads crossing the quota together are all rejected.
+func (manager Manager) recheckQuota(ctx context.Context, file *File, option UploadOption) error {
+	err := manager.fitQuota(ctx, option, 0, 0)
+	if err == nil {
+		return nil
+	}
+
+	if delErr := manager.Delete(ctx, file.ID); delErr != nil {
+		log.Error("[attachment] %s failed to delete %s over the quota: %s", manager.Name, file.ID, delErr.Error())
+	}
+	return err
+}
+
+// fitQuota checks the usage grown by the given bytes and files fits in the quotas of the upload options
+func (manager Manager) fitQuota(ctx context.Context, option UploadOption, size int64, files int64) error {
+	for _, q := range manager.quotas {
+		id := q.id(option)
+		if id == "" {
+			continue
+		}
+
+		maxBytes, maxFiles := manager.limits(ctx, q, id)
+		if maxBytes <= 0 && maxFiles <= 0 {
+			continue
+		}
+
+		usage, err := manager.usage(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		if maxBytes > 0 && usage.Bytes+size > maxBytes {
+			return fmt.Errorf("%w: %s %s uses %d of %d bytes, the file is %d bytes", ErrQuotaExceeded, q.scope, id, usage.Bytes, maxBytes, size)
+		}
+
+		if maxFiles > 0 && usage.Files+files > maxFiles {
+			return fmt.Errorf("%w: %s %s has %d of %d files", ErrQuotaExceeded, q.scope, id, usage.Files, maxFiles)
+		}
+	}
+	return nil
+}
+
+// usage sums up the files of a client, user, team or group
+func (manager Manager) usage(ctx context.Context, q quota, id string) (*QuotaUsage, error) {
+	maxBytes, maxFiles := manager.limits(ctx, q, id)
+	usage := &QuotaUsage{Scope: q.scope, ID: id, MaxBytes: maxBytes, MaxFiles: maxFiles}
+
+	// The rejected files are removed from the storage
+	wheres := []model.QueryWhere{{Column: "uploader", Value: manager.Name}, {Column: "status", OP: "ne", Value: ScanRejected}}
+	switch q.scope {
+	case "client_id":
+		wheres = append(wheres, model.QueryWhere{Column: "client_id", Value: id})
+	case "openid":
+		wheres = append(wheres, model.QueryWhere{Column: "openid", Value: id})
+	case "team":
+		wheres = append(wheres, model.QueryWhere{Column: "team_id", Value: id})
+	case "group":
+		// The storage path starts with the groups, the paths matched by the pattern of a group with a wildcard
+		// are checked one by one
+		pattern, exact := groupPattern(id)
+		wheres = append(wheres, model.QueryWhere{Column: "path", OP: "like", Value: pattern})
+		if !exact {
+			return manager.groupUsage(usage, wheres)
+		}
+	}
+
+	m := model.Select("__yao.attachment")
+	records, err := m.Get(model.QueryParam{
+		Select: []interface{}{dbal.Raw("COUNT(*) as files"), dbal.Raw("SUM(bytes) as bytes")},
+		Wheres: wheres,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the storage usage: %w", err)
+	}
+
+	if len(records) > 0 {
+		usage.Files = toInt64(records[0]["files"])
+		usage.Bytes = toInt64(records[0]["bytes"])
+	}
+	return usage, nil
+}
+
+// groupUsage sums up the files of a group matched by the wheres whose path starts with the group
+func (manager Manager) groupUsage(usage *QuotaUsage, wheres []model.QueryWhere) (*QuotaUsage, error) {
+	records, err := model.Select("__yao.attachment").Get(model.QueryParam{
+		Select: []interface{}{"path", "bytes"},
+		Wheres: wheres,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the storage usage: %w", err)
+	}
+
+	for _, record := range records {
+		if path, _ := record["path"].(string); strings.HasPrefix(path, usage.ID+"/") {
+			usage.Files++
+			usage.Bytes += toInt64(record["bytes"])
+		}
+	}
+	return usage, nil
+}
+
+// OwnsGroup checks the caller of the upload options stores a file in the group the group quota counts for the
+// options: a file of its openid, of its client_id without an openid, or of its team. The groups are paths given
+// by the clients, the usage of a group is only reported to the callers storing files in it.
+func (manager Manager) OwnsGroup(ctx context.Context, option UploadOption) (bool, error) {
+	id := manager.quota("group").id(option)
+	if id == "" {
+		return true, nil
+	}
+
+	owner := ownerWheres(option)
+	if owner == nil {
+		return false, nil
+	}
+
+	pattern, exact := groupPattern(id)
+	param := model.QueryParam{
+		Select: []interface{}{"path"},
+		Wheres: []model.QueryWhere{
+			{Column: "uploader", Value: manager.Name},
+			{Column: "path", OP: "like", Value: pattern},
+			{Wheres: owner},
+		},
+	}
+	if exact {
+		param.Limit = 1
+	}
+
+	records, err := model.Select("__yao.attachment").Get(param)
+	if err != nil {
+		return false, fmt.Errorf("failed to query the files of the group %s: %w", id, err)
+	}
+	for _, record := range records {
+		if path, _ := record["path"].(string); strings.HasPrefix(path, id+"/") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// groupPattern returns the LIKE pattern of the storage paths of a group, exact when the group has no wildcard.
+// LIKE has no escape character on every database: the pattern of a group with a wildcard (%, _ or the MySQL
+// escape \) stops before it, the matched paths must be checked.
+func groupPattern(id string) (string, bool) {
+	prefix := id + "/"
+	if i := strings.IndexAny(prefix, `%_\`); i >= 0 {
+		return prefix[:i] + "%", false
+	}
+	return prefix + "%", true
+}
+
+// limits returns the limits of a client, user, team or group
+func (manager Manager) limits(ctx context.Context, q quota, id string) (int64, int64) {
+	if QuotaLimits != nil {
+		if maxBytes, maxFiles, ok := QuotaLimits(ctx, manager.Name, q.scope, id); ok {
+			return maxBytes, maxFiles
+		}
+	}
+	return q.maxBytes, q.maxFiles
+}
+
+// quota returns the quota of a scope, an unlimited one when the scope has no quota
+func (manager Manager) quota(scope string) quota {
+	for _, q := range manager.quotas {
+		if q.scope == scope {
+			return q
+		}
+	}
+	return quota{scope: scope, depth: 1}
+}
+
+// id returns the client, user, team or group of the upload options the quota applies to
+func (q quota) id(option UploadOption) string {
+	switch q.scope {
+	case "client_id":
+		return option.ClientID
+	case "openid":
+		return option.OpenID
+	case "team":
+		return option.TeamID
+	case "group":
+		if len(option.Groups) < q.depth {
+			return ""
+		}
+		return strings.Join(option.Groups[:q.depth], "/")
+	}
+	return ""
+}
+
+// isQuotaScope checks the scope of a quota is supported
+func isQuotaScope(scope string) bool {
+	for _, s := range quotaScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestQuota(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	manager, err := Register("quota", "local", ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, "quota")},
+		AllowedTypes: []string{"text/*"},
+		Quotas: []QuotaOption{
+			{Scope: "openid", MaxBytes: "100"},
+			{Scope: "team", MaxFiles: 2},
+			{Scope: "group", Depth: 2, MaxBytes: "1K"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	ctx := context.Background()
+	upload := func(size int, option UploadOption) (*File, error) {
+		header := newUploadHeader("quota.txt", "text/plain")
+		header.Size = int64(size)
+		return manager.Upload(ctx, header, strings.NewReader(strings.Repeat("a", size)), option)
+	}
+
+	// The bytes of a user
+	user := UploadOption{OpenID: "user-1", Groups: []string{"users", "user-1"}}
+	if _, err := upload(60, user); err != nil {
+		t.Fatalf("Failed to upload file: %v", err)
+	}
+	if _, err := upload(60, user); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected the quota of the user to be exceeded, got %v", err)
+	}
+	if _, err := upload(40, user); err != nil {
+		t.Errorf("Expected the file to fit in the quota, got %v", err)
+	}
+
+	// The other users have their own quota
+	if _, err := upload(60, UploadOption{OpenID: "user-2"}); err != nil {
+		t.Errorf("Expected the quota of another user to be free, got %v", err)
+	}
+
+	// The files of a team
+	team := UploadOption{TeamID: "team-1"}
+	for i := 0; i < 2; i++ {
+		if _, err := upload(10, team); err != nil {
+			t.Fatalf("Failed to upload file: %v", err)
+		}
+	}
+	if _, err := upload(10, team); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected the quota of the team to be exceeded, got %v", err)
+	}
+
+	// The usage of a user and its group
+	usages, err := manager.Usage(ctx, user)
+	if err != nil {
+		t.Fatalf("Failed to get usage: %v", err)
+	}
+	if len(usages) != 2 {
+		t.Fatalf("Expected the usage of the user and the group, got %+v", usages)
+	}
+	if usages[0] != (QuotaUsage{Scope: "openid", ID: "user-1", Bytes: 100, Files: 2, MaxBytes: 100}) {
+		t.Errorf("Unexpected usage of the user %+v", usages[0])
+	}
+	if usages[1] != (QuotaUsage{Scope: "group", ID: "users/user-1", Bytes: 100, Files: 2, MaxBytes: 1024}) {
+		t.Errorf("Unexpected usage of the group %+v", usages[1])
+	}
+
+	// The wildcards of a group are not matched by the other groups
+	for _, groups := range [][]string{{"team_a", "x"}, {"teamxa", "x"}} {
+		if _, err := upload(10, UploadOption{OpenID: "user-4", Groups: groups}); err != nil {
+			t.Fatalf("Failed to upload file: %v", err)
+		}
+	}
+	usages, err = manager.Usage(ctx, UploadOption{Groups: []string{"team_a", "x"}})
+	if err != nil {
+		t.Fatalf("Failed to get usage: %v", err)
+	}
+	if len(usages) != 1 || usages[0].ID != "team_a/x" || usages[0].Files != 1 || usages[0].Bytes != 10 {
+		t.Errorf("Expected the usage of the group only, got %+v", usages)
+	}
+
+	// The usage of a group is reported to the callers storing files in it
+	for _, c := range []struct {
+		option UploadOption
+		owns   bool
+	}{
+		{UploadOption{OpenID: "user-4", Groups: []string{"team_a", "x"}}, true},
+		{UploadOption{OpenID: "user-4", Groups: []string{"team_a", "y"}}, false},
+		{UploadOption{OpenID: "user-5", Groups: []string{"team_a", "x"}}, false},
+		{UploadOption{Groups: []string{"team_a", "x"}}, false},
+		{UploadOption{OpenID: "user-5"}, true},
+	} {
+		owns, err := manager.OwnsGroup(ctx, c.option)
+		if err != nil || owns != c.owns {
+			t.Errorf("Expected %+v to own the group: %v, got %v %v", c.option, c.owns, owns, err)
+		}
+	}
+
+	// A deleted file frees its space
+	file, err := upload(30, UploadOption{OpenID: "user-3"})
+	if err != nil {
+		t.Fatalf("Failed to upload file: %v", err)
+	}
+	if err := manager.Delete(ctx, file.ID); err != nil {
+		t.Fatalf("Failed to delete file: %v", err)
+	}
+	usages, err = manager.Usage(ctx, UploadOption{OpenID: "user-3"})
+	if err != nil {
+		t.Fatalf("Failed to get usage: %v", err)
+	}
+	if usages[0].Files != 0 || usages[0].Bytes != 0 {
+		t.Errorf("Expected no usage after the delete, got %+v", usages[0])
+	}
+
+	// The limits of a storage plan
+	QuotaLimits = func(ctx context.Context, uploader string, scope string, id string) (int64, int64, bool) {
+		if scope == "team" && id == "team-1" {
+			return 0, 10, true
+		}
+		return 0, 0, false
+	}
+	defer func() { QuotaLimits = nil }()
+
+	if _, err := upload(10, team); err != nil {
+		t.Errorf("Expected the plan of the team to allow more files, got %v", err)
+	}
+
+	// The resumable uploads are checked when the session is created
+	_, err = manager.CreateUpload(ctx, "user-2", newUploadHeader("large.txt", "text/plain"), 200, nil, UploadOption{OpenID: "user-2"})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected the upload session to exceed the quota, got %v", err)
+	}
+
+	// The unsupported scopes are rejected
+	_, err = New(ManagerOption{Driver: "local", Options: map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, "quota")}, Quotas: []QuotaOption{{Scope: "tenant"}}})
+	if err == nil {
+		t.Error("Expected an error for an unsupported scope")
+	}
+}
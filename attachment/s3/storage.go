@@ -231,6 +231,26 @@ func (storage *Storage) MergeChunks(ctx context.Context, path string, totalChunk
 	return nil
 }
 
+// DeleteChunks removes the chunks of an unfinished upload from S3
+func (storage *Storage) DeleteChunks(ctx context.Context, path string, totalChunks int) error {
+	if storage.client == nil {
+		return fmt.Errorf("s3 client not initialized")
+	}
+
+	for i := 0; i < totalChunks; i++ {
+		chunkKey := filepath.Join(storage.prefix, ".chunks", path, fmt.Sprintf("chunk_%d", i))
+		_, err := storage.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(storage.Bucket),
+			Key:    aws.String(chunkKey),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete chunk %s %d: %w", path, i, err)
+		}
+	}
+
+	return nil
+}
+
 // Reader read file from S3
 func (storage *Storage) Reader(ctx context.Context, path string) (io.ReadCloser, error) {
 	if storage.client == nil {
@@ -259,6 +279,91 @@ func (storage *Storage) Reader(ctx context.Context, path string) (io.ReadCloser,
 	return result.Body, nil
 }
 
+// Stat returns the size, the modification time and the ETag of the stored object
+func (storage *Storage) Stat(ctx context.Context, path string) (int64, time.Time, string, error) {
+	if storage.client == nil {
+		return 0, time.Time{}, "", fmt.Errorf("s3 client not initialized")
+	}
+
+	key := filepath.Join(storage.prefix, path)
+	result, err := storage.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return 0, time.Time{}, "", fmt.Errorf("failed to stat file %s: %w", path, err)
+	}
+
+	size := aws.ToInt64(result.ContentLength)
+	modTime := aws.ToTime(result.LastModified)
+	etag := aws.ToString(result.ETag)
+	if etag == "" {
+		etag = fmt.Sprintf(`"%x-%x"`, modTime.UnixNano(), size)
+	}
+	return size, modTime, etag, nil
+}
+
+// RangeReader reads length bytes of the stored object from the offset, a negative length reads to the end.
+// The bytes are read as stored, gzip files are not decompressed.
+func (storage *Storage) RangeReader(ctx context.Context, path string, offset int64, length int64) (io.ReadCloser, error) {
+	if storage.client == nil {
+		return nil, fmt.Errorf("s3 client not initialized")
+	}
+
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length >= 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	key := filepath.Join(storage.prefix, path)
+	result, err := storage.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(byteRange),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s %s: %w", path, byteRange, err)
+	}
+	return result.Body, nil
+}
+
+// Walk calls fn for each stored file with its path, size and modification time, the chunks are skipped
+func (storage *Storage) Walk(ctx context.Context, fn func(path string, size int64, modTime time.Time) error) error {
+	if storage.client == nil {
+		return fmt.Errorf("s3 client not initialized")
+	}
+
+	prefix := ""
+	if storage.prefix != "" {
+		prefix = strings.TrimSuffix(storage.prefix, "/") + "/"
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(storage.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(storage.Bucket),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			path := strings.TrimPrefix(aws.ToString(object.Key), prefix)
+			if path == "" || strings.HasPrefix(path, ".chunks/") {
+				continue
+			}
+
+			err := fn(path, aws.ToInt64(object.Size), aws.ToTime(object.LastModified))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Download download file from S3
 func (storage *Storage) Download(ctx context.Context, path string) (io.ReadCloser, string, error) {
 	if storage.client == nil {
@@ -0,0 +1,361 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// ErrFileUnavailable the file is quarantined or rejected, its content can not be read
+var ErrFileUnavailable = errors.New("file is not available")
+
+// ErrNotQuarantined the reviewed file is not quarantined
+var ErrNotQuarantined = errors.New("file is not quarantined")
+
+// The statuses of a scan
+const (
+	ScanClean       = "clean"
+	ScanQuarantined = "quarantined"
+	ScanRejected    = "rejected"
+)
+
+// Scanner scans the content of the uploaded files before they become available, e.g. an antivirus
+type Scanner interface {
+	Scan(ctx context.Context, file *File, reader io.Reader) (*ScanResult, error)
+}
+
+// ScanResult the result of a scan
+type ScanResult struct {
+	Status  string `json:"status"`           // clean, quarantined or rejected
+	Reason  string `json:"reason,omitempty"` // e.g. the signature found
+	Scanner string `json:"scanner,omitempty"`
+}
+
+// ScannerFactory creates a scanner from the scanner option
+type ScannerFactory func(option ScannerOption) (Scanner, error)
+
+// scanners the scanner drivers
+var scanners = map[string]ScannerFactory{
+	"clamd":   newClamdScanner,
+	"process": newProcessScanner,
+}
+
+// RegisterScanner registers a scanner driver, it must be called before the uploaders are loaded
+func RegisterScanner(driver string, factory ScannerFactory) {
+	scanners[driver] = factory
+}
+
+// newScanner creates the scanner of an uploader
+func newScanner(option *ScannerOption) (Scanner, error) {
+	if option == nil {
+		return nil, nil
+	}
+
+	factory, ok := scanners[option.Driver]
+	if !ok {
+		return nil, fmt.Errorf("scanner driver %s does not support", option.Driver)
+	}
+
+	for name, status := range map[string]string{"infected": option.Infected, "on_error": option.OnError} {
+		switch status {
+		case "", ScanQuarantined, ScanRejected:
+		default:
+			return nil, fmt.Errorf("%s status %s of the scanner is not supported, use quarantined or rejected", name, status)
+		}
+	}
+
+	return factory(*option)
+}
+
+// scan checks a stored file before it becomes available. The real type of the content must match the declared
+// content type, then the scanner of the uploader checks the content. The status of the file is set to quarantined
+// or rejected when the check fails, the content of the rejected files is removed.
+func (manager Manager) scan(ctx context.Context, file *File) error {
+	result, err := manager.checkContentType(ctx, file)
+	if err != nil {
+		return err
+	}
+
+	if result == nil && manager.scanner != nil {
+		result, err = manager.runScanner(ctx, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	if result == nil || result.Status == ScanClean {
+		return nil
+	}
+
+	log.Warn("[attachment] %s file %s (%s) is %s: %s", manager.Name, file.ID, file.Filename, result.Status, result.Reason)
+	file.Status = result.Status
+	if file.Metadata == nil {
+		file.Metadata = map[string]interface{}{}
+	}
+	file.Metadata["scan"] = map[string]interface{}{"status": result.Status, "reason": result.Reason, "scanner": result.Scanner}
+
+	if result.Status == ScanRejected {
+		err = manager.storage.Delete(ctx, file.Path)
+		if err != nil {
+			return fmt.Errorf("failed to remove the rejected file %s: %w", file.ID, err)
+		}
+	}
+	return nil
+}
+
+// runScanner scans the content of a file, a failed scan sets the on_error status
+func (manager Manager) runScanner(ctx context.Context, file *File) (*ScanResult, error) {
+	option := manager.Scanner
+	timeout := time.Duration(option.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	reader, err := manager.storage.Reader(ctx, file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	result, err := manager.scanner.Scan(ctx, file, reader)
+	if err != nil {
+		status := option.OnError
+		if status == "" {
+			status = ScanQuarantined
+		}
+		return &ScanResult{Status: status, Reason: "scan failed: " + err.Error(), Scanner: option.Driver}, nil
+	}
+
+	switch result.Status {
+	case ScanClean, ScanQuarantined, ScanRejected:
+	default:
+		return nil, fmt.Errorf("scanner %s returned the unsupported status %q", option.Driver, result.Status)
+	}
+
+	if result.Scanner == "" {
+		result.Scanner = option.Driver
+	}
+	return result, nil
+}
+
+// checkContentType detects the type of the content from its first bytes, the file is rejected when the content
+// does not match the declared content type, e.g. a html page uploaded as an image. The content without a known
+// signature, e.g. a plain text, matches any type.
+func (manager Manager) checkContentType(ctx context.Context, file *File) (*ScanResult, error) {
+	if manager.TrustContentType {
+		return nil, nil
+	}
+
+	reader, err := manager.storage.Reader(ctx, file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	declared := mediaType(file.ContentType)
+	detected := detectContentType(head[:n])
+	if n == 0 || isGenericType(detected) || contentTypeMatches(declared, detected) {
+		return nil, nil
+	}
+
+	// The files of an unknown type must be of an allowed type
+	if (declared == "" || declared == "application/octet-stream") && manager.allowed(detected, filepath.Ext(file.Filename)) {
+		return nil, nil
+	}
+
+	return &ScanResult{
+		Status:  ScanRejected,
+		Reason:  fmt.Sprintf("the content is %s, the declared type is %s", detected, declared),
+		Scanner: "content_type",
+	}, nil
+}
+
+// detectContentType detects the type of the content, the executables are detected besides the http sniffing types
+func detectContentType(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("\x7fELF")):
+		return "application/x-executable"
+
+	case len(head) >= 4 && isMachO(binary.BigEndian.Uint32(head)):
+		return "application/x-mach-binary"
+
+	case bytes.HasPrefix(head, []byte("MZ")) && len(head) >= 0x40:
+		// The PE header offset is at 0x3C
+		offset := int(binary.LittleEndian.Uint32(head[0x3C:]))
+		if offset > 0 && offset+4 <= len(head) && bytes.Equal(head[offset:offset+4], []byte("PE\x00\x00")) {
+			return "application/x-msdownload"
+		}
+	}
+	return mediaType(http.DetectContentType(head))
+}
+
+// isMachO checks the magic number of the Mach-O executables
+func isMachO(magic uint32) bool {
+	switch magic {
+	case 0xFEEDFACE, 0xFEEDFACF, 0xCEFAEDFE, 0xCFFAEDFE:
+		return true
+	}
+	return false
+}
+
+// isGenericType checks the detected type is a fallback, the content has no known signature
+func isGenericType(contentType string) bool {
+	return contentType == "application/octet-stream" || contentType == "text/plain"
+}
+
+// contentTypeMatches checks the detected type of the content matches the declared type
+func contentTypeMatches(declared string, detected string) bool {
+	if declared == detected {
+		return true
+	}
+
+	family := func(contentType string) string {
+		family := strings.SplitN(contentType, "/", 2)[0]
+		if family == "audio" || family == "video" || contentType == "application/ogg" {
+			return "media"
+		}
+		return family
+	}
+
+	switch detected {
+	case "text/html", "text/xml":
+		// The markup documents: html, xml, svg or the other text documents
+		return strings.HasPrefix(declared, "text/") || declared == "application/xml" || strings.HasSuffix(declared, "+xml")
+
+	case "application/zip":
+		// The zip containers: office documents, epub, jar
+		return strings.Contains(declared, "zip") || strings.HasPrefix(declared, "application/vnd.") || declared == "application/java-archive"
+
+	case "application/x-gzip":
+		return strings.Contains(declared, "gzip") || strings.Contains(declared, "tar")
+	}
+
+	// The images, the audios and videos, the fonts may have several names, e.g. audio/mp3 and audio/mpeg
+	switch family(detected) {
+	case "image", "media", "font":
+		return family(declared) == family(detected)
+	}
+	return false
+}
+
+// mediaType returns the media type without the parameters, e.g. text/html for text/html; charset=utf-8
+func mediaType(contentType string) string {
+	mediatype, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	}
+	return mediatype
+}
+
+// available checks the content of a file can be read, the quarantined and rejected files are not available
+func available(file *File) error {
+	if file.Status == ScanQuarantined || file.Status == ScanRejected {
+		return fmt.Errorf("%w: %s is %s", ErrFileUnavailable, file.ID, file.Status)
+	}
+	return nil
+}
+
+// Release makes a quarantined file available once a reviewer found it clean, e.g. a false positive of the scanner.
+// The review is added to Metadata["scan"], the image metadata and the upload variants are generated as for a
+// clean upload. ErrNotQuarantined is returned when the file is not quarantined, e.g. reviewed already.
+func (manager Manager) Release(ctx context.Context, fileID string, reviewer string) (*File, error) {
+	file, err := manager.review(ctx, fileID, "uploaded", "released", reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	scan := file.Metadata["scan"]
+	err = manager.extractImageMetadata(ctx, file)
+	if err != nil {
+		log.Warn("[attachment] %s failed to extract the metadata of the released file %s: %s", manager.Name, file.ID, err.Error())
+	}
+	file.Metadata["scan"] = scan
+
+	_, err = model.Select("__yao.attachment").UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "file_id", Value: file.ID}},
+		Limit:  1,
+	}, map[string]interface{}{"metadata": file.Metadata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save the metadata of %s: %w", file.ID, err)
+	}
+
+	manager.generateUploadVariants(file)
+	return file, nil
+}
+
+// Reject rejects a quarantined file once a reviewer confirmed the scan, its content is removed as the content of
+// the files rejected on upload. ErrNotQuarantined is returned when the file is not quarantined.
+func (manager Manager) Reject(ctx context.Context, fileID string, reviewer string) (*File, error) {
+	file, err := manager.review(ctx, fileID, ScanRejected, "rejected", reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if manager.storage.Exists(ctx, file.Path) {
+		err = manager.storage.Delete(ctx, file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove the rejected file %s: %w", file.ID, err)
+		}
+	}
+	return file, nil
+}
+
+// review sets the status of a quarantined file and records the review in its scan result. The status is only
+// changed while the file is quarantined, of two reviews at the same time one fails with ErrNotQuarantined.
+func (manager Manager) review(ctx context.Context, fileID string, status string, decision string, reviewer string) (*File, error) {
+	file, err := manager.getFileFromDatabase(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if file.Status != ScanQuarantined {
+		return nil, fmt.Errorf("%w: %s is %s", ErrNotQuarantined, file.ID, file.Status)
+	}
+
+	if file.Metadata == nil {
+		file.Metadata = map[string]interface{}{}
+	}
+	scan, _ := file.Metadata["scan"].(map[string]interface{})
+	if scan == nil {
+		scan = map[string]interface{}{}
+	}
+	scan["review"] = map[string]interface{}{"status": decision, "reviewer": reviewer, "reviewed_at": time.Now().Format(time.RFC3339)}
+	file.Metadata["scan"] = scan
+
+	affected, err := model.Select("__yao.attachment").UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "file_id", Value: file.ID},
+			{Column: "status", Value: ScanQuarantined},
+		},
+		Limit: 1,
+	}, map[string]interface{}{"status": status, "metadata": file.Metadata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save the review of %s: %w", file.ID, err)
+	}
+	if affected == 0 {
+		return nil, fmt.Errorf("%w: %s was reviewed already", ErrNotQuarantined, file.ID)
+	}
+
+	log.Info("[attachment] %s file %s (%s) is %s by %s", manager.Name, file.ID, file.Filename, decision, reviewer)
+	file.Status = status
+	return file, nil
+}
@@ -0,0 +1,97 @@
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// clamdChunkSize the size of the chunks streamed to clamd
+const clamdChunkSize = 32 * 1024
+
+// clamdScanner scans the content with the INSTREAM command of clamd, the ClamAV daemon
+type clamdScanner struct {
+	network  string
+	address  string
+	infected string
+}
+
+// newClamdScanner creates a clamd scanner, the address is a TCP address or a unix socket, e.g. unix:/run/clamd.sock
+func newClamdScanner(option ScannerOption) (Scanner, error) {
+	scanner := clamdScanner{network: "tcp", address: option.Address, infected: option.Infected}
+	if scanner.address == "" {
+		scanner.address = "127.0.0.1:3310"
+	}
+
+	if strings.HasPrefix(scanner.address, "unix:") {
+		scanner.network = "unix"
+		scanner.address = strings.TrimPrefix(scanner.address, "unix:")
+	}
+
+	if scanner.infected == "" {
+		scanner.infected = ScanRejected
+	}
+	return scanner, nil
+}
+
+// Scan streams the content to clamd, the reply is "stream: OK" or "stream: <signature> FOUND"
+func (scanner clamdScanner) Scan(ctx context.Context, file *File, reader io.Reader) (*ScanResult, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, scanner.network, scanner.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	_, err = conn.Write([]byte("zINSTREAM\x00"))
+	if err != nil {
+		return nil, err
+	}
+
+	// The chunks are prefixed by their size, a zero size ends the stream
+	buffer := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := reader.Read(buffer[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buffer, uint32(n))
+			if _, err := conn.Write(buffer[:4+n]); err != nil {
+				return nil, fmt.Errorf("failed to stream the content to clamd: %w", err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = conn.Write([]byte{0, 0, 0, 0})
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read the reply of clamd: %w", err)
+	}
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+
+	switch {
+	case strings.HasSuffix(reply, " FOUND"):
+		signature := strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND")
+		return &ScanResult{Status: scanner.infected, Reason: signature, Scanner: "clamd"}, nil
+
+	case reply == "stream: OK":
+		return &ScanResult{Status: ScanClean, Scanner: "clamd"}, nil
+	}
+	return nil, fmt.Errorf("clamd: %s", reply)
+}
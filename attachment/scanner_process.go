@@ -0,0 +1,90 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/yaoapp/gou/process"
+)
+
+// processScanner scans the content with a Yao process
+type processScanner struct {
+	process  string
+	infected string
+}
+
+// newProcessScanner creates a scanner calling a Yao process
+func newProcessScanner(option ScannerOption) (Scanner, error) {
+	if option.Process == "" {
+		return nil, fmt.Errorf("the process of the process scanner is required")
+	}
+
+	scanner := processScanner{process: option.Process, infected: option.Infected}
+	if scanner.infected == "" {
+		scanner.infected = ScanRejected
+	}
+	return scanner, nil
+}
+
+// Scan writes the content to a temporary file and calls the process with the file information and the temporary path.
+// The process returns true or "clean" for a clean file, false for an infected file, "quarantined" or "rejected",
+// or a map with the status and the reason.
+func (scanner processScanner) Scan(ctx context.Context, file *File, reader io.Reader) (*ScanResult, error) {
+	tmp, err := os.CreateTemp("", "yao-scan-*"+filepath.Ext(file.Filename))
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, reader)
+	tmp.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	info := map[string]interface{}{
+		"file_id":      file.ID,
+		"filename":     file.Filename,
+		"content_type": file.ContentType,
+		"bytes":        file.Bytes,
+		"path":         file.Path,
+	}
+
+	proc := process.NewWithContext(ctx, scanner.process, info, tmp.Name())
+	defer proc.Release()
+
+	err = proc.Execute()
+	if err != nil {
+		return nil, err
+	}
+	return scanner.result(proc.Value())
+}
+
+// result converts the value returned by the process
+func (scanner processScanner) result(value interface{}) (*ScanResult, error) {
+	result := &ScanResult{Scanner: scanner.process}
+	switch v := value.(type) {
+	case nil:
+		result.Status = ScanClean
+
+	case bool:
+		result.Status = ScanClean
+		if !v {
+			result.Status = scanner.infected
+		}
+
+	case string:
+		result.Status = v
+
+	case map[string]interface{}:
+		result.Status, _ = v["status"].(string)
+		result.Reason, _ = v["reason"].(string)
+
+	default:
+		return nil, fmt.Errorf("process %s returned an unsupported value %v", scanner.process, value)
+	}
+	return result, nil
+}
@@ -0,0 +1,299 @@
+package attachment
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+// testClamd starts a clamd server replying FOUND for the streams containing the EICAR string
+func testClamd(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				if command, err := reader.ReadString(0); err != nil || command != "zINSTREAM\x00" {
+					conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+
+				var content bytes.Buffer
+				size := make([]byte, 4)
+				for {
+					if _, err := io.ReadFull(reader, size); err != nil {
+						return
+					}
+					n := binary.BigEndian.Uint32(size)
+					if n == 0 {
+						break
+					}
+					if _, err := io.CopyN(&content, reader, int64(n)); err != nil {
+						return
+					}
+				}
+
+				if strings.Contains(content.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+					conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+					return
+				}
+				conn.Write([]byte("stream: OK\x00"))
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestScanner(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	register := func(name string, scanner *ScannerOption) *Manager {
+		manager, err := Register(name, "local", ManagerOption{
+			Driver:       "local",
+			Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, name)},
+			AllowedTypes: []string{"text/*", "image/*", "application/octet-stream"},
+			Scanner:      scanner,
+		})
+		if err != nil {
+			t.Fatalf("Failed to register manager: %v", err)
+		}
+		return manager
+	}
+
+	ctx := context.Background()
+	upload := func(manager *Manager, filename string, contentType string, content string) *File {
+		header := newUploadHeader(filename, contentType)
+		header.Size = int64(len(content))
+		file, err := manager.Upload(ctx, header, strings.NewReader(content), UploadOption{OpenID: "user-1"})
+		if err != nil {
+			t.Fatalf("Failed to upload %s: %v", filename, err)
+		}
+		return file
+	}
+
+	address := testClamd(t)
+	eicar := `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+	// The infected files are rejected, the content is removed
+	manager := register("clamd", &ScannerOption{Driver: "clamd", Address: address})
+	clean := upload(manager, "clean.txt", "text/plain", "Hello World")
+	if clean.Status != "uploaded" {
+		t.Errorf("Expected the clean file to be uploaded, got %s", clean.Status)
+	}
+
+	infected := upload(manager, "eicar.txt", "text/plain", eicar)
+	if infected.Status != ScanRejected {
+		t.Fatalf("Expected the infected file to be rejected, got %s", infected.Status)
+	}
+	scan, _ := infected.Metadata["scan"].(map[string]interface{})
+	if scan["reason"] != "Eicar-Test-Signature" || scan["scanner"] != "clamd" {
+		t.Errorf("Unexpected scan result %v", infected.Metadata)
+	}
+	if manager.storage.Exists(ctx, infected.Path) {
+		t.Error("Expected the content of the rejected file to be removed")
+	}
+	if _, err := manager.Read(ctx, infected.ID); !errors.Is(err, ErrFileUnavailable) {
+		t.Errorf("Expected the rejected file not to be available, got %v", err)
+	}
+
+	// The rejected files do not use the quota
+	usages, err := manager.Usage(ctx, UploadOption{OpenID: "user-1"})
+	if err != nil {
+		t.Fatalf("Failed to get usage: %v", err)
+	}
+	if usages[0].Files != 1 || usages[0].Bytes != int64(len("Hello World")) {
+		t.Errorf("Expected only the clean file in the usage, got %+v", usages[0])
+	}
+
+	// The rejected files can be deleted
+	if err := manager.Delete(ctx, infected.ID); err != nil {
+		t.Errorf("Failed to delete the rejected file: %v", err)
+	}
+
+	// The quarantined files are kept but not available
+	manager = register("quarantine", &ScannerOption{Driver: "clamd", Address: address, Infected: ScanQuarantined})
+	quarantined := upload(manager, "eicar.txt", "text/plain", eicar)
+	if quarantined.Status != ScanQuarantined || !manager.storage.Exists(ctx, quarantined.Path) {
+		t.Errorf("Expected the infected file to be quarantined, got %s", quarantined.Status)
+	}
+	info, err := manager.Info(ctx, quarantined.ID)
+	if err != nil || info.Status != ScanQuarantined {
+		t.Errorf("Expected the quarantined status to be saved, got %v %v", info, err)
+	}
+	if _, err := manager.Open(ctx, quarantined.ID); !errors.Is(err, ErrFileUnavailable) {
+		t.Errorf("Expected the quarantined file not to be available, got %v", err)
+	}
+
+	// A reviewer releases a quarantined file, the file becomes available
+	released, err := manager.Release(ctx, quarantined.ID, "admin")
+	if err != nil || released.Status != "uploaded" {
+		t.Fatalf("Failed to release the quarantined file: %+v %v", released, err)
+	}
+	data, err := manager.Read(ctx, quarantined.ID)
+	if err != nil || string(data) != eicar {
+		t.Errorf("Expected the released file to be available, got %q %v", data, err)
+	}
+	info, err = manager.Info(ctx, quarantined.ID)
+	scan, _ = info.Metadata["scan"].(map[string]interface{})
+	review, _ := scan["review"].(map[string]interface{})
+	if err != nil || info.Status != "uploaded" || review["status"] != "released" || review["reviewer"] != "admin" {
+		t.Errorf("Expected the review to be saved, got %+v %v", info, err)
+	}
+	if _, err := manager.Release(ctx, quarantined.ID, "admin"); !errors.Is(err, ErrNotQuarantined) {
+		t.Errorf("Expected ErrNotQuarantined for a released file, got %v", err)
+	}
+	if _, err := manager.Reject(ctx, clean.ID, "admin"); !errors.Is(err, ErrNotQuarantined) {
+		t.Errorf("Expected ErrNotQuarantined for a clean file, got %v", err)
+	}
+
+	// A reviewer rejects a quarantined file, the content is removed
+	quarantined = upload(manager, "eicar.txt", "text/plain", eicar)
+	rejected, err := manager.Reject(ctx, quarantined.ID, "admin")
+	if err != nil || rejected.Status != ScanRejected {
+		t.Fatalf("Failed to reject the quarantined file: %+v %v", rejected, err)
+	}
+	if manager.storage.Exists(ctx, quarantined.Path) {
+		t.Error("Expected the content of the rejected file to be removed")
+	}
+	if _, err := manager.Read(ctx, quarantined.ID); !errors.Is(err, ErrFileUnavailable) {
+		t.Errorf("Expected the rejected file not to be available, got %v", err)
+	}
+	if _, err := manager.Release(ctx, quarantined.ID, "admin"); !errors.Is(err, ErrNotQuarantined) {
+		t.Errorf("Expected ErrNotQuarantined for a rejected file, got %v", err)
+	}
+
+	// The files are quarantined when clamd is not reachable
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	unreachable := listener.Addr().String()
+	listener.Close()
+
+	manager = register("unreachable", &ScannerOption{Driver: "clamd", Address: unreachable})
+	file := upload(manager, "clean.txt", "text/plain", "Hello World")
+	if file.Status != ScanQuarantined {
+		t.Errorf("Expected the file to be quarantined when the scan fails, got %s", file.Status)
+	}
+
+	// The unsupported statuses are rejected
+	_, err = New(ManagerOption{Driver: "local", Options: map[string]interface{}{"path": config.Conf.DataRoot}, Scanner: &ScannerOption{Driver: "clamd", Infected: "deleted"}})
+	if err == nil {
+		t.Error("Expected an error for an unsupported status")
+	}
+}
+
+func TestScannerContentType(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	manager, err := Register("content-type", "local", ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, "content-type")},
+		AllowedTypes: []string{"text/*", "image/*", "application/octet-stream"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+
+	// An executable with a PE header at 0x40
+	exe := make([]byte, 128)
+	copy(exe, "MZ")
+	binary.LittleEndian.PutUint32(exe[0x3C:], 0x40)
+	copy(exe[0x40:], "PE\x00\x00")
+
+	tests := []struct {
+		filename    string
+		contentType string
+		content     string
+		status      string
+	}{
+		{"image.png", "image/png", buf.String(), "uploaded"},
+		{"image.jpg", "image/jpeg", buf.String(), "uploaded"},   // Another image type
+		{"notes.png", "image/png", "Image content", "uploaded"}, // No signature
+		{"page.png", "image/png", "<html><script>alert(1)</script></html>", ScanRejected},
+		{"page.txt", "text/plain", "<html><body>Hello</body></html>", "uploaded"},
+		{"setup.png", "image/png", string(exe), ScanRejected},
+		{"image.bin", "application/octet-stream", buf.String(), "uploaded"},
+		{"setup.bin", "application/octet-stream", string(exe), ScanRejected},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		header := newUploadHeader(tt.filename, tt.contentType)
+		header.Size = int64(len(tt.content))
+		file, err := manager.Upload(ctx, header, strings.NewReader(tt.content), UploadOption{})
+		if err != nil {
+			t.Fatalf("Failed to upload %s: %v", tt.filename, err)
+		}
+		if file.Status != tt.status {
+			t.Errorf("Expected %s to be %s, got %s %v", tt.filename, tt.status, file.Status, file.Metadata)
+		}
+	}
+
+	// The content type check can be disabled
+	manager.TrustContentType = true
+	header := newUploadHeader("page.png", "image/png")
+	file, err := manager.Upload(ctx, header, strings.NewReader("<html></html>"), UploadOption{})
+	if err != nil || file.Status != "uploaded" {
+		t.Errorf("Expected the declared type to be trusted, got %v %v", file, err)
+	}
+}
+
+func TestProcessScannerResult(t *testing.T) {
+	scanner := processScanner{process: "scripts.scan", infected: ScanQuarantined}
+	tests := []struct {
+		value  interface{}
+		status string
+	}{
+		{nil, ScanClean},
+		{true, ScanClean},
+		{false, ScanQuarantined},
+		{"rejected", ScanRejected},
+		{map[string]interface{}{"status": "rejected", "reason": "macro"}, ScanRejected},
+	}
+
+	for _, tt := range tests {
+		result, err := scanner.result(tt.value)
+		if err != nil {
+			t.Fatalf("Failed to convert %v: %v", tt.value, err)
+		}
+		if result.Status != tt.status || result.Scanner != "scripts.scan" {
+			t.Errorf("Expected %v to be %s, got %+v", tt.value, tt.status, result)
+		}
+	}
+
+	if _, err := scanner.result(42); err == nil {
+		t.Error("Expected an error for an unsupported value")
+	}
+}
@@ -0,0 +1,101 @@
+package attachment
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/config"
+)
+
+// SignedURLBase the base URL of the signed URLs when the manager has no public URL, set when the file API is attached
+var SignedURLBase = ""
+
+// Signed URL errors
+var (
+	ErrSignatureInvalid = errors.New("invalid signature")
+	ErrSignatureExpired = errors.New("signature expired")
+)
+
+// processKey the signing key used when neither the manager nor the application has one
+var processKey []byte
+var processKeyOnce sync.Once
+
+// SignedURL returns an HMAC-signed URL of the file content, valid for ttl. The URL is served
+// without authentication, so emails and embedded tags can reference the private files.
+// disposition is "inline" (default) or "attachment".
+func (manager Manager) SignedURL(fileID string, ttl time.Duration, disposition string) (string, error) {
+	if ttl <= 0 {
+		return "", fmt.Errorf("ttl must be positive")
+	}
+
+	if disposition == "" {
+		disposition = "inline"
+	}
+	if disposition != "inline" && disposition != "attachment" {
+		return "", fmt.Errorf("disposition %s is not supported, use inline or attachment", disposition)
+	}
+
+	base := manager.PublicURL
+	if base == "" {
+		base = SignedURLBase
+	}
+
+	expires := time.Now().Add(ttl).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("disposition", disposition)
+	query.Set("signature", manager.sign(fileID, expires, disposition))
+
+	return fmt.Sprintf("%s/%s/%s/signed?%s", strings.TrimRight(base, "/"), url.PathEscape(manager.Name), url.PathEscape(fileID), query.Encode()), nil
+}
+
+// VerifySignature verifies the signature of a signed URL
+func (manager Manager) VerifySignature(fileID string, expires int64, disposition string, signature string) error {
+	expected := manager.sign(fileID, expires, disposition)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrSignatureInvalid
+	}
+
+	if time.Now().Unix() > expires {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+// sign signs the uploader, the file, the expiration and the disposition of a signed URL
+func (manager Manager) sign(fileID string, expires int64, disposition string) string {
+	mac := hmac.New(sha256.New, manager.signKey())
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s", manager.Name, fileID, expires, disposition)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signKey returns the key of the signed URLs: the manager key, the JWT secret, or a key generated for
+// the process. The URLs signed with a generated key are invalid after a restart and on the other nodes.
+func (manager Manager) signKey() []byte {
+	if manager.SignKey != "" {
+		return []byte(manager.SignKey)
+	}
+
+	if config.Conf.JWTSecret != "" {
+		return []byte(config.Conf.JWTSecret)
+	}
+
+	processKeyOnce.Do(func() {
+		processKey = make([]byte, 32)
+		if _, err := rand.Read(processKey); err != nil {
+			log.Error("[attachment] failed to generate the signing key: %s", err.Error())
+		}
+		log.Warn("[attachment] no sign_key or JWT secret, the signed URLs are valid until the process restarts")
+	})
+	return processKey
+}
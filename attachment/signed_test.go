@@ -0,0 +1,126 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestSignedURL(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	manager, err := RegisterDefault("test")
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	signedURL, err := manager.SignedURL("file-id", time.Minute, "")
+	if err != nil {
+		t.Fatalf("Failed to sign URL: %v", err)
+	}
+
+	parsed, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %v", err)
+	}
+	if !strings.HasSuffix(parsed.Path, "/test/file-id/signed") {
+		t.Errorf("Unexpected path %s", parsed.Path)
+	}
+
+	query := parsed.Query()
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if query.Get("disposition") != "inline" {
+		t.Errorf("Expected the inline disposition, got %s", query.Get("disposition"))
+	}
+
+	err = manager.VerifySignature("file-id", expires, "inline", query.Get("signature"))
+	if err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+
+	// The signature covers the file, the expiration and the disposition
+	if err := manager.VerifySignature("other-id", expires, "inline", query.Get("signature")); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected an invalid signature for another file, got %v", err)
+	}
+	if err := manager.VerifySignature("file-id", expires+3600, "inline", query.Get("signature")); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected an invalid signature for another expiration, got %v", err)
+	}
+	if err := manager.VerifySignature("file-id", expires, "attachment", query.Get("signature")); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected an invalid signature for another disposition, got %v", err)
+	}
+
+	// The expired signatures
+	past := time.Now().Add(-time.Minute).Unix()
+	if err := manager.VerifySignature("file-id", past, "inline", manager.sign("file-id", past, "inline")); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("Expected an expired signature, got %v", err)
+	}
+
+	if _, err := manager.SignedURL("file-id", 0, ""); err == nil {
+		t.Error("Expected an error for a zero ttl")
+	}
+	if _, err := manager.SignedURL("file-id", time.Minute, "download"); err == nil {
+		t.Error("Expected an error for an unsupported disposition")
+	}
+}
+
+func TestOpenRange(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	manager, err := RegisterDefault("test")
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	ctx := context.Background()
+	content := "0123456789abcdefghijklmnopqrstuvwxyz"
+	for _, gzip := range []bool{false, true} {
+		file, err := manager.Upload(ctx, newUploadHeader("range.txt", "text/plain"), strings.NewReader(content), UploadOption{Gzip: gzip})
+		if err != nil {
+			t.Fatalf("Failed to upload file: %v", err)
+		}
+
+		reader, err := manager.Open(ctx, file.ID)
+		if err != nil {
+			t.Fatalf("Failed to open file: %v", err)
+		}
+
+		if reader.Size != int64(len(content)) || reader.ETag == "" || reader.ContentType != "text/plain" {
+			t.Errorf("Unexpected content info %+v (gzip %v)", reader, gzip)
+		}
+
+		// Seek to a range and read it
+		if _, err := reader.Seek(10, io.SeekStart); err != nil {
+			t.Fatalf("Failed to seek: %v", err)
+		}
+		data := make([]byte, 6)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			t.Fatalf("Failed to read the range: %v", err)
+		}
+		if string(data) != "abcdef" {
+			t.Errorf("Expected abcdef, got %s (gzip %v)", data, gzip)
+		}
+
+		// The tail of the file
+		if _, err := reader.Seek(-3, io.SeekEnd); err != nil {
+			t.Fatalf("Failed to seek: %v", err)
+		}
+		tail, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Failed to read the tail: %v", err)
+		}
+		if string(tail) != "xyz" {
+			t.Errorf("Expected xyz, got %s (gzip %v)", tail, gzip)
+		}
+		reader.Close()
+	}
+}
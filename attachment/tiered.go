@@ -0,0 +1,109 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// tieredStorage a hot storage with a cold storage of the archived files. The files are written to the hot
+// storage and read from the storage holding them.
+type tieredStorage struct {
+	hot  Storage
+	cold Storage
+}
+
+// of returns the storage holding a file, the hot storage when the file is in none of them
+func (storage *tieredStorage) of(ctx context.Context, path string) Storage {
+	if !storage.hot.Exists(ctx, path) && storage.cold.Exists(ctx, path) {
+		return storage.cold
+	}
+	return storage.hot
+}
+
+// archive moves a file from the hot storage to the cold storage, the stored bytes are copied as is
+func (storage *tieredStorage) archive(ctx context.Context, path string, contentType string) error {
+	reader, err := storage.hot.RangeReader(ctx, path, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = storage.cold.Upload(ctx, path, reader, contentType)
+	if err != nil {
+		return err
+	}
+	return storage.hot.Delete(ctx, path)
+}
+
+func (storage *tieredStorage) Upload(ctx context.Context, path string, reader io.Reader, contentType string) (string, error) {
+	return storage.hot.Upload(ctx, path, reader, contentType)
+}
+
+func (storage *tieredStorage) UploadChunk(ctx context.Context, path string, chunkIndex int, reader io.Reader, contentType string) error {
+	return storage.hot.UploadChunk(ctx, path, chunkIndex, reader, contentType)
+}
+
+func (storage *tieredStorage) MergeChunks(ctx context.Context, path string, totalChunks int) error {
+	return storage.hot.MergeChunks(ctx, path, totalChunks)
+}
+
+func (storage *tieredStorage) DeleteChunks(ctx context.Context, path string, totalChunks int) error {
+	return storage.hot.DeleteChunks(ctx, path, totalChunks)
+}
+
+func (storage *tieredStorage) Download(ctx context.Context, path string) (io.ReadCloser, string, error) {
+	return storage.of(ctx, path).Download(ctx, path)
+}
+
+func (storage *tieredStorage) Reader(ctx context.Context, path string) (io.ReadCloser, error) {
+	return storage.of(ctx, path).Reader(ctx, path)
+}
+
+func (storage *tieredStorage) GetContent(ctx context.Context, path string) ([]byte, error) {
+	return storage.of(ctx, path).GetContent(ctx, path)
+}
+
+func (storage *tieredStorage) URL(ctx context.Context, path string) string {
+	return storage.of(ctx, path).URL(ctx, path)
+}
+
+func (storage *tieredStorage) Exists(ctx context.Context, path string) bool {
+	return storage.hot.Exists(ctx, path) || storage.cold.Exists(ctx, path)
+}
+
+// Delete deletes a file from the storages holding it
+func (storage *tieredStorage) Delete(ctx context.Context, path string) error {
+	if storage.cold.Exists(ctx, path) {
+		err := storage.cold.Delete(ctx, path)
+		if err != nil {
+			return err
+		}
+
+		if !storage.hot.Exists(ctx, path) {
+			return nil
+		}
+	}
+	return storage.hot.Delete(ctx, path)
+}
+
+func (storage *tieredStorage) LocalPath(ctx context.Context, path string) (string, string, error) {
+	return storage.of(ctx, path).LocalPath(ctx, path)
+}
+
+func (storage *tieredStorage) Stat(ctx context.Context, path string) (int64, time.Time, string, error) {
+	return storage.of(ctx, path).Stat(ctx, path)
+}
+
+func (storage *tieredStorage) RangeReader(ctx context.Context, path string, offset int64, length int64) (io.ReadCloser, error) {
+	return storage.of(ctx, path).RangeReader(ctx, path, offset, length)
+}
+
+// Walk lists the files of the hot storage then the files of the cold storage
+func (storage *tieredStorage) Walk(ctx context.Context, fn func(path string, size int64, modTime time.Time) error) error {
+	err := storage.hot.Walk(ctx, fn)
+	if err != nil {
+		return err
+	}
+	return storage.cold.Walk(ctx, fn)
+}
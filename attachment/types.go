@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"mime/multipart"
+	"time"
 
 	"github.com/yaoapp/gou/types"
 )
@@ -46,18 +47,153 @@ type FileManager interface {
 
 	// LocalPath gets the local path of the file
 	LocalPath(ctx context.Context, fileID string) (string, string, error)
+
+	// CreateUpload creates a resumable upload session for a file of the given length, owned by the subject
+	CreateUpload(ctx context.Context, subject string, fileheader *FileHeader, length int64, metadata map[string]string, option UploadOption) (*UploadSession, error)
+
+	// GetUpload retrieves an upload session of the subject with the byte ranges received so far
+	GetUpload(ctx context.Context, subject string, uploadID string) (*UploadSession, error)
+
+	// WriteUpload appends the data at the offset of an upload session of the subject, the file is saved once complete
+	WriteUpload(ctx context.Context, subject string, uploadID string, offset int64, reader io.Reader) (*UploadSession, error)
+
+	// AbortUpload aborts an upload session of the subject and removes its chunks
+	AbortUpload(ctx context.Context, subject string, uploadID string) error
+
+	// Open opens the content of a file, seekable to serve the HTTP Range requests
+	Open(ctx context.Context, fileID string) (*FileContent, error)
+
+	// SignedURL returns an HMAC-signed URL of the file content, valid for ttl
+	SignedURL(fileID string, ttl time.Duration, disposition string) (string, error)
+
+	// OpenVariant opens a variant of an image or a video, the variant is generated on the first request
+	OpenVariant(ctx context.Context, fileID string, name string) (*FileContent, error)
+
+	// Usage returns the storage consumption and the quotas of the client, user, team and group of the upload options
+	Usage(ctx context.Context, option UploadOption) ([]QuotaUsage, error)
+
+	// UploadByHash creates a file from a stored content with the SHA-256 hash, the client skips the upload of the content
+	UploadByHash(ctx context.Context, hash string, fileheader *FileHeader, option UploadOption) (*File, error)
+
+	// Sweep applies the lifecycle rules and reconciles the database with the storage, nothing is changed in a dry run
+	Sweep(ctx context.Context, dryRun bool) (*SweepReport, error)
 }
 
 // File the file
 type File struct {
-	ID          string `json:"file_id"`
-	UserPath    string `json:"user_path"` // User-specified complete file path
-	Path        string `json:"path"`      // Actual storage path
-	Bytes       int    `json:"bytes"`
-	CreatedAt   int    `json:"created_at"`
-	Filename    string `json:"filename"`
-	ContentType string `json:"content_type"`
-	Status      string `json:"status"` // uploading, uploaded, indexing, indexed, upload_failed, index_failed
+	ID          string                 `json:"file_id"`
+	UserPath    string                 `json:"user_path"`      // User-specified complete file path
+	Path        string                 `json:"path"`           // Actual storage path
+	Hash        string                 `json:"hash,omitempty"` // SHA-256 of the content, set when the uploader deduplicates the contents
+	Bytes       int                    `json:"bytes"`
+	CreatedAt   int                    `json:"created_at"`
+	Filename    string                 `json:"filename"`
+	ContentType string                 `json:"content_type"`
+	Status      string                 `json:"status"`             // uploading, uploaded, quarantined, rejected, indexing, indexed, upload_failed, index_failed
+	Metadata    map[string]interface{} `json:"metadata,omitempty"` // Image metadata: width, height and the EXIF data, the scan result of the quarantined and rejected files
+}
+
+// UploadSession a resumable upload session, the file is stored as chunks until all the bytes are received
+type UploadSession struct {
+	ID          string            `json:"upload_id"`
+	FileID      string            `json:"file_id"`
+	Filename    string            `json:"filename"`
+	ContentType string            `json:"content_type"`
+	Path        string            `json:"path"`     // Storage path of the file
+	Length      int64             `json:"length"`   // Total size in bytes
+	Offset      int64             `json:"offset"`   // Number of bytes received
+	Chunks      []int64           `json:"chunks"`   // Sizes of the received chunks in order
+	Ranges      []ByteRange       `json:"ranges"`   // Byte ranges received, one per chunk
+	Metadata    map[string]string `json:"metadata"` // Metadata sent by the client
+	Option      UploadOption      `json:"options"`  // Upload options applied when the file is complete
+	Status      string            `json:"status"`   // uploading, completed
+	ExpiresAt   int64             `json:"expires_at"`
+	File        *File             `json:"file,omitempty"` // The uploaded file, set once the upload is complete
+	Subject     string            `json:"-"`              // The authorized subject owning the session
+}
+
+// ByteRange a range of received bytes, both ends are inclusive
+type ByteRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// VariantOption a derivative of the images and videos, e.g. a thumbnail, the video variants are rendered from a frame
+type VariantOption struct {
+	Width    int     `json:"width,omitempty" yaml:"width,omitempty"`         // Max width, Optional, default is the width of the original
+	Height   int     `json:"height,omitempty" yaml:"height,omitempty"`       // Max height, Optional, default is the height of the original
+	Fit      string  `json:"fit,omitempty" yaml:"fit,omitempty"`             // contain or cover, Optional, default is contain, cover crops the image to the size
+	Format   string  `json:"format,omitempty" yaml:"format,omitempty"`       // jpeg, png or webp, Optional, default is the format of the original, jpeg for the other formats
+	Quality  int     `json:"quality,omitempty" yaml:"quality,omitempty"`     // Quality of the jpeg and webp variants, Optional, default is 85
+	Time     float64 `json:"time,omitempty" yaml:"time,omitempty"`           // Time of the video frame in seconds, Optional, default is the first frame
+	OnUpload bool    `json:"on_upload,omitempty" yaml:"on_upload,omitempty"` // Generate the variant when the file is uploaded, Optional, default is on the first request
+}
+
+// ScannerOption the content scanner of the uploaded files, the files are available once the scanner finds them clean
+type ScannerOption struct {
+	Driver   string                 `json:"driver" yaml:"driver"`                         // clamd, process or a registered driver
+	Address  string                 `json:"address,omitempty" yaml:"address,omitempty"`   // Address of clamd, Optional, default is 127.0.0.1:3310
+	Process  string                 `json:"process,omitempty" yaml:"process,omitempty"`   // Name of the Yao process of the process driver
+	Timeout  int                    `json:"timeout,omitempty" yaml:"timeout,omitempty"`   // Timeout of a scan in seconds, Optional, default is 60
+	Infected string                 `json:"infected,omitempty" yaml:"infected,omitempty"` // Status of the infected files: quarantined or rejected, Optional, default is rejected
+	OnError  string                 `json:"on_error,omitempty" yaml:"on_error,omitempty"` // Status of the files when the scan fails: quarantined or rejected, Optional, default is quarantined
+	Options  map[string]interface{} `json:"options,omitempty" yaml:"options,omitempty"`   // Options of the registered drivers, Optional
+}
+
+// LifecycleOption the lifecycle of the stored files
+type LifecycleOption struct {
+	Rules     []LifecycleRule    `json:"rules,omitempty" yaml:"rules,omitempty"`         // Lifecycle rules, the first rule matching the groups of a file applies
+	Cold      *ColdStorageOption `json:"cold,omitempty" yaml:"cold,omitempty"`           // Cold storage of the archived files, Optional
+	Reconcile bool               `json:"reconcile,omitempty" yaml:"reconcile,omitempty"` // Remove the records without content and the content without records, the storage must be dedicated to the uploader
+	Interval  string             `json:"interval,omitempty" yaml:"interval,omitempty"`   // Interval of the background sweeper, e.g. 24h, Optional, default is no background sweeper
+	Grace     string             `json:"grace,omitempty" yaml:"grace,omitempty"`         // Min age of the unreferenced files and the content without records, Optional, default is 24h
+}
+
+// LifecycleRule a lifecycle rule of the files of some groups
+type LifecycleRule struct {
+	Groups      []string `json:"groups,omitempty" yaml:"groups,omitempty"`             // Group prefix of the files, * matches any group, e.g. ["user", "*", "chat"], Optional, default is all the files
+	ExpireDays  int      `json:"expire_days,omitempty" yaml:"expire_days,omitempty"`   // Delete the files N days after the upload, Optional
+	ArchiveDays int      `json:"archive_days,omitempty" yaml:"archive_days,omitempty"` // Move the files to the cold storage N days after the upload, Optional
+	Reference   string   `json:"reference,omitempty" yaml:"reference,omitempty"`       // Delete the files once the referencing object is gone: chat, kb or a Yao process, Optional
+}
+
+// ColdStorageOption the storage of the archived files
+type ColdStorageOption struct {
+	Driver  string                 `json:"driver" yaml:"driver"`                       // local or s3
+	Options map[string]interface{} `json:"options,omitempty" yaml:"options,omitempty"` // Options of the driver
+}
+
+// SweepReport the report of a lifecycle sweep, the files are listed by ID and the content without records by path
+type SweepReport struct {
+	Uploader     string   `json:"uploader"`
+	DryRun       bool     `json:"dry_run"`
+	StartedAt    int64    `json:"started_at"`
+	EndedAt      int64    `json:"ended_at"`
+	Scanned      int      `json:"scanned"`      // Number of the records checked
+	Expired      []string `json:"expired"`      // Deleted by the expire rules
+	Unreferenced []string `json:"unreferenced"` // Deleted because the referencing object is gone
+	Archived     []string `json:"archived"`     // Moved to the cold storage
+	Missing      []string `json:"missing"`      // Records without content, removed
+	Orphans      []string `json:"orphans"`      // Content without records, removed
+	Errors       []string `json:"errors"`
+}
+
+// QuotaOption the storage quota of each client, user, team or group
+type QuotaOption struct {
+	Scope    string `json:"scope" yaml:"scope"`                             // Scope of the quota: client_id, openid, team or group
+	Depth    int    `json:"depth,omitempty" yaml:"depth,omitempty"`         // Group levels of the group scope, Optional, default is 1, e.g. 2 for ["user", "user123"]
+	MaxBytes string `json:"max_bytes,omitempty" yaml:"max_bytes,omitempty"` // Max total size of the files, Optional, e.g. 1G
+	MaxFiles int64  `json:"max_files,omitempty" yaml:"max_files,omitempty"` // Max number of files, Optional
+}
+
+// QuotaUsage the storage consumption of a client, user, team or group, zero limits are unlimited
+type QuotaUsage struct {
+	Scope    string `json:"scope"`
+	ID       string `json:"id"`
+	Bytes    int64  `json:"bytes"`
+	Files    int64  `json:"files"`
+	MaxBytes int64  `json:"max_bytes"`
+	MaxFiles int64  `json:"max_files"`
 }
 
 // FileResponse represents a file download response
@@ -93,6 +229,9 @@ type Manager struct {
 	maxsize      int64
 	chunsize     int64
 	allowedTypes allowedType
+	quotas       []quota
+	scanner      Scanner
+	lifecycle    lifecycle
 }
 
 // Storage the storage interface
@@ -100,24 +239,38 @@ type Storage interface {
 	Upload(ctx context.Context, path string, reader io.Reader, contentType string) (string, error)
 	UploadChunk(ctx context.Context, path string, chunkIndex int, reader io.Reader, contentType string) error
 	MergeChunks(ctx context.Context, path string, totalChunks int) error
+	DeleteChunks(ctx context.Context, path string, totalChunks int) error
 	Download(ctx context.Context, path string) (io.ReadCloser, string, error)
 	Reader(ctx context.Context, path string) (io.ReadCloser, error)
 	GetContent(ctx context.Context, path string) ([]byte, error)
 	URL(ctx context.Context, path string) string
 	Exists(ctx context.Context, path string) bool
 	Delete(ctx context.Context, path string) error
-	LocalPath(ctx context.Context, path string) (string, string, error) // Returns absolute path and content type
+	LocalPath(ctx context.Context, path string) (string, string, error)                              // Returns absolute path and content type
+	Stat(ctx context.Context, path string) (int64, time.Time, string, error)                         // Returns size, modification time and ETag
+	RangeReader(ctx context.Context, path string, offset int64, length int64) (io.ReadCloser, error) // Reads the stored bytes, a negative length reads to the end
+	Walk(ctx context.Context, fn func(path string, size int64, modTime time.Time) error) error       // Lists the stored files, the chunks are skipped
 }
 
 // ManagerOption the manager option
 type ManagerOption struct {
 	types.MetaInfo
-	MaxSize      string                 `json:"max_size,omitempty" yaml:"max_size,omitempty"`           // Max size of the file, Optional, default is 20M
-	ChunkSize    string                 `json:"chunk_size,omitempty" yaml:"chunk_size,omitempty"`       // Chunk size of the file, Optional, default is 2M
-	AllowedTypes []string               `json:"allowed_types,omitempty" yaml:"allowed_types,omitempty"` // Allowed types of the file, Optional, default is all
-	Gzip         bool                   `json:"gzip,omitempty" yaml:"gzip,omitempty"`                   // Gzip the file, Optional, default is false
-	Driver       string                 `json:"driver,omitempty" yaml:"driver,omitempty"`               // Driver, Optional, default is local
-	Options      map[string]interface{} `json:"options,omitempty" yaml:"options,omitempty"`             // Options, Optional
+	MaxSize          string                   `json:"max_size,omitempty" yaml:"max_size,omitempty"`                     // Max size of the file, Optional, default is 20M
+	ChunkSize        string                   `json:"chunk_size,omitempty" yaml:"chunk_size,omitempty"`                 // Chunk size of the file, Optional, default is 2M
+	AllowedTypes     []string                 `json:"allowed_types,omitempty" yaml:"allowed_types,omitempty"`           // Allowed types of the file, Optional, default is all
+	Gzip             bool                     `json:"gzip,omitempty" yaml:"gzip,omitempty"`                             // Gzip the file, Optional, default is false
+	Driver           string                   `json:"driver,omitempty" yaml:"driver,omitempty"`                         // Driver, Optional, default is local
+	Options          map[string]interface{}   `json:"options,omitempty" yaml:"options,omitempty"`                       // Options, Optional
+	SignKey          string                   `json:"sign_key,omitempty" yaml:"sign_key,omitempty"`                     // Key of the signed URLs, Optional, default is the JWT secret
+	PublicURL        string                   `json:"public_url,omitempty" yaml:"public_url,omitempty"`                 // Base URL of the signed URLs, Optional, e.g. https://example.com/v1/file
+	Quotas           []QuotaOption            `json:"quotas,omitempty" yaml:"quotas,omitempty"`                         // Storage quotas of the clients, users, teams or groups, Optional, default is unlimited
+	Variants         map[string]VariantOption `json:"variants,omitempty" yaml:"variants,omitempty"`                     // Derivatives of the images and videos by name, e.g. thumb, Optional
+	FFmpeg           string                   `json:"ffmpeg,omitempty" yaml:"ffmpeg,omitempty"`                         // Path of ffmpeg, renders the video frames and the webp variants, Optional, default is ffmpeg
+	Scanner          *ScannerOption           `json:"scanner,omitempty" yaml:"scanner,omitempty"`                       // Content scanner of the uploaded files, Optional, e.g. clamd
+	Lifecycle        *LifecycleOption         `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`                   // Lifecycle rules of the stored files: expiry, cold storage and garbage collection, Optional
+	TrustContentType bool                     `json:"trust_content_type,omitempty" yaml:"trust_content_type,omitempty"` // Skip the check of the content against the declared content type, Optional, default is false
+	Dedup            bool                     `json:"dedup,omitempty" yaml:"dedup,omitempty"`                           // Store the identical contents once, addressed by their SHA-256 hash, Optional, default is false
+	StripMetadata    bool                     `json:"strip_metadata,omitempty" yaml:"strip_metadata,omitempty"`         // Strip the EXIF, XMP and IPTC metadata from the stored jpeg images except the orientation, Optional, default is false
 }
 
 type allowedType struct {
@@ -134,6 +287,7 @@ type UploadOption struct {
 	Groups           []string `json:"groups,omitempty" form:"groups"`                       // Groups, Optional, default is empty, Multi-level groups like ["user", "user123", "chat", "chat456"]
 	ClientID         string   `json:"client_id,omitempty" form:"client_id"`                 // Client identifier
 	OpenID           string   `json:"openid,omitempty" form:"openid"`                       // OpenID identifier
+	TeamID           string   `json:"team_id,omitempty" form:"team_id"`                     // Team identifier
 }
 
 // ListOption defines options for listing files
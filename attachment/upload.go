@@ -0,0 +1,514 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// UploadExpiration the time an unfinished upload session is kept after its last write
+var UploadExpiration = 24 * time.Hour
+
+// uploadExpireInterval the minimum interval between two cleanups of the expired sessions of an uploader
+const uploadExpireInterval = time.Hour
+
+// Upload session errors
+var (
+	ErrUploadNotFound    = errors.New("upload not found")
+	ErrUploadOffset      = errors.New("upload offset mismatch")
+	ErrUploadLocked      = errors.New("upload is being written by another request")
+	ErrUploadTooLarge    = errors.New("upload is too large")
+	ErrUploadInterrupted = errors.New("upload interrupted")
+)
+
+// uploadLocks the locks of the upload sessions being written, upload_id -> *sync.Mutex
+var uploadLocks = sync.Map{}
+
+// uploadsExpiredAt the last cleanup of the expired sessions, uploader -> time.Time
+var uploadsExpiredAt = sync.Map{}
+
+// countReader counts the bytes read. A read error of the client ends the data at the bytes received so far,
+// the error is kept to be returned once they are stored.
+type countReader struct {
+	reader io.Reader
+	n      int64
+	err    error
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	if err != nil && err != io.EOF {
+		r.err = err
+		return n, io.EOF
+	}
+	return n, err
+}
+
+// CreateUpload creates a resumable upload session for a file of the given length, owned by the subject.
+// The file name and content type are read from the file header, the file is validated as a regular upload.
+func (manager Manager) CreateUpload(ctx context.Context, subject string, fileheader *FileHeader, length int64, metadata map[string]string, option UploadOption) (*UploadSession, error) {
+	if length < 0 {
+		return nil, fmt.Errorf("invalid upload length %d", length)
+	}
+
+	if manager.maxsize > 0 && length > manager.maxsize {
+		return nil, fmt.Errorf("%w: file size %d exceeds the maximum size of %d", ErrUploadTooLarge, length, manager.maxsize)
+	}
+
+	err := manager.checkQuota(ctx, option, length)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.expireUploadsOnce(ctx)
+
+	fileheader.Size = length
+	file, err := manager.makeFile(fileheader, option)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	session := &UploadSession{
+		ID:          generateID(fmt.Sprintf("%s-%d", file.Path, time.Now().UnixNano())),
+		FileID:      file.ID,
+		Filename:    file.Filename,
+		ContentType: file.ContentType,
+		Path:        file.Path,
+		Length:      length,
+		Chunks:      []int64{},
+		Metadata:    metadata,
+		Option:      option,
+		Status:      "uploading",
+		ExpiresAt:   time.Now().Add(UploadExpiration).Unix(),
+		Subject:     subject,
+	}
+
+	m := model.Select("__yao.attachment.upload")
+	data := manager.uploadRecord(session)
+	data["upload_id"] = session.ID
+	data["uploader"] = manager.Name
+	data["file_id"] = session.FileID
+	data["name"] = session.Filename
+	data["content_type"] = session.ContentType
+	data["path"] = session.Path
+	data["length"] = session.Length
+	data["metadata"] = session.Metadata
+	data["options"] = session.Option
+	data["subject"] = session.Subject
+	_, err = m.Create(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save upload session: %w", err)
+	}
+
+	// An empty file is complete once created
+	if length == 0 {
+		err = manager.completeUpload(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	session.Ranges = session.ranges()
+	return session, nil
+}
+
+// MaxSize returns the maximum size of a file in bytes, 0 means unlimited
+func (manager Manager) MaxSize() int64 {
+	return manager.maxsize
+}
+
+// GetUpload retrieves an upload session of the subject with the byte ranges received so far.
+// The sessions of the other subjects are not found.
+func (manager Manager) GetUpload(ctx context.Context, subject string, uploadID string) (*UploadSession, error) {
+	session, err := manager.getUpload(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Subject != subject {
+		return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+	}
+	return session, nil
+}
+
+// getUpload retrieves an upload session of any subject
+func (manager Manager) getUpload(ctx context.Context, uploadID string) (*UploadSession, error) {
+	m := model.Select("__yao.attachment.upload")
+	records, err := m.Get(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "upload_id", Value: uploadID},
+			{Column: "uploader", Value: manager.Name},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query upload session: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+	}
+
+	record := records[0]
+	session := &UploadSession{
+		ID:        uploadID,
+		Chunks:    []int64{},
+		Metadata:  map[string]string{},
+		Length:    toInt64(record["length"]),
+		Offset:    toInt64(record["offset"]),
+		ExpiresAt: toInt64(record["expires_at"]),
+	}
+	session.FileID, _ = record["file_id"].(string)
+	session.Filename, _ = record["name"].(string)
+	session.ContentType, _ = record["content_type"].(string)
+	session.Path, _ = record["path"].(string)
+	session.Status, _ = record["status"].(string)
+	session.Subject, _ = record["subject"].(string)
+
+	for column, v := range map[string]interface{}{"chunks": &session.Chunks, "metadata": &session.Metadata, "options": &session.Option} {
+		if err := decodeJSON(record[column], v); err != nil {
+			return nil, fmt.Errorf("invalid upload session %s %s: %w", uploadID, column, err)
+		}
+	}
+
+	// The file may have been deleted since the upload completed
+	if session.Status == "completed" {
+		session.File, _ = manager.getFileFromDatabase(ctx, session.FileID)
+	}
+
+	session.Ranges = session.ranges()
+	return session, nil
+}
+
+// WriteUpload appends the data at the offset of an upload session of the subject, the offset must be the number
+// of bytes received so far. Each write is stored as a chunk, the chunks are merged and the file is saved once all
+// the bytes are received. When the reader fails, the bytes read before are kept and ErrUploadInterrupted is returned
+// with the session, the client resumes from its offset. A failed storage write is discarded.
+func (manager Manager) WriteUpload(ctx context.Context, subject string, uploadID string, offset int64, reader io.Reader) (*UploadSession, error) {
+	unlock, err := lockUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	session, err := manager.GetUpload(ctx, subject, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != session.Offset {
+		return session, fmt.Errorf("%w: expected %d, got %d", ErrUploadOffset, session.Offset, offset)
+	}
+
+	if session.Status == "completed" {
+		return session, nil
+	}
+
+	remaining := session.Length - session.Offset
+	counter := &countReader{reader: io.LimitReader(reader, remaining)}
+
+	var chunk io.Reader = counter
+	if session.Option.Gzip {
+		compressed, err := GzipFromReader(counter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to gzip chunk: %w", err)
+		}
+		chunk = bytes.NewReader(compressed)
+	}
+
+	// The request context is cancelled with a broken connection, the bytes received are stored anyway
+	err = manager.storage.UploadChunk(context.WithoutCancel(ctx), session.Path, len(session.Chunks), chunk, session.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The data beyond the length of the upload is rejected with the chunk
+	if counter.n == remaining && counter.err == nil {
+		if n, _ := reader.Read(make([]byte, 1)); n > 0 {
+			return nil, fmt.Errorf("%w: the length is %d bytes", ErrUploadTooLarge, session.Length)
+		}
+	}
+
+	if counter.n == 0 {
+		return session, manager.interruptedUpload(counter.err)
+	}
+
+	session.Chunks = append(session.Chunks, counter.n)
+	session.Offset += counter.n
+	session.ExpiresAt = time.Now().Add(UploadExpiration).Unix()
+	err = manager.saveUpload(session)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Offset == session.Length {
+		err = manager.completeUpload(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	session.Ranges = session.ranges()
+	return session, manager.interruptedUpload(counter.err)
+}
+
+// interruptedUpload returns the read error of a write, nil if the data was read to the end
+func (manager Manager) interruptedUpload(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUploadInterrupted, err)
+}
+
+// AbortUpload aborts an upload session of the subject and removes its chunks.
+// The file of a completed session is kept, use Delete to remove it.
+func (manager Manager) AbortUpload(ctx context.Context, subject string, uploadID string) error {
+	unlock, err := lockUpload(uploadID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	session, err := manager.GetUpload(ctx, subject, uploadID)
+	if err != nil {
+		return err
+	}
+	return manager.removeUpload(ctx, session)
+}
+
+// ExpireUploads removes the expired upload sessions of the uploader and the chunks of the unfinished ones,
+// returns the number of sessions removed
+func (manager Manager) ExpireUploads(ctx context.Context) (int, error) {
+	m := model.Select("__yao.attachment.upload")
+	records, err := m.Get(model.QueryParam{
+		Select: []interface{}{"upload_id"},
+		Wheres: []model.QueryWhere{
+			{Column: "uploader", Value: manager.Name},
+			{Column: "expires_at", OP: "lt", Value: time.Now().Unix()},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query expired upload sessions: %w", err)
+	}
+
+	removed := 0
+	for _, record := range records {
+		uploadID, _ := record["upload_id"].(string)
+		err := manager.expireUpload(ctx, uploadID)
+		if err != nil {
+			if errors.Is(err, ErrUploadLocked) || errors.Is(err, ErrUploadNotFound) {
+				continue
+			}
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// expireUpload removes an expired upload session of any subject
+func (manager Manager) expireUpload(ctx context.Context, uploadID string) error {
+	unlock, err := lockUpload(uploadID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	session, err := manager.getUpload(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+	return manager.removeUpload(ctx, session)
+}
+
+// expireUploadsOnce removes the expired upload sessions, at most once per interval
+func (manager Manager) expireUploadsOnce(ctx context.Context) {
+	if last, ok := uploadsExpiredAt.Load(manager.Name); ok && time.Since(last.(time.Time)) < uploadExpireInterval {
+		return
+	}
+	uploadsExpiredAt.Store(manager.Name, time.Now())
+
+	if _, err := manager.ExpireUploads(ctx); err != nil {
+		log.Error("[attachment] %s failed to remove the expired uploads: %s", manager.Name, err.Error())
+	}
+}
+
+// completeUpload merges the chunks of a complete session and saves the file
+func (manager Manager) completeUpload(ctx context.Context, session *UploadSession) error {
+	var err error
+	if len(session.Chunks) > 0 {
+		err = manager.storage.MergeChunks(ctx, session.Path, len(session.Chunks))
+	} else {
+		_, err = manager.storage.Upload(ctx, session.Path, bytes.NewReader(nil), session.ContentType)
+	}
+	if err != nil {
+		return err
+	}
+
+	file := &File{
+		ID:          session.FileID,
+		UserPath:    session.Option.OriginalFilename,
+		Path:        session.Path,
+		Filename:    session.Filename,
+		ContentType: session.ContentType,
+		Bytes:       int(session.Length),
+		CreatedAt:   int(time.Now().Unix()),
+		Status:      "uploaded",
+	}
+
+	// Apply image compression if requested
+	if session.Option.CompressImage && strings.HasPrefix(file.ContentType, "image/") {
+		err = manager.compressStoredImage(ctx, file, session.Option)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Hash and scan the content, extract the image size and EXIF data
+	err = manager.processUploaded(ctx, file, session.Option)
+	if err != nil {
+		return err
+	}
+
+	err = manager.saveFile(ctx, file, session.Option)
+	if err != nil {
+		return fmt.Errorf("failed to save uploaded file to database: %w", err)
+	}
+
+	err = manager.recheckQuota(ctx, file, session.Option)
+	if err != nil {
+		return err
+	}
+	manager.generateUploadVariants(file)
+
+	session.Status = "completed"
+	session.File = file
+	return manager.saveUpload(session)
+}
+
+// removeUpload removes an upload session, the chunks of an unfinished session are removed with it
+func (manager Manager) removeUpload(ctx context.Context, session *UploadSession) error {
+	if session.Status != "completed" {
+		// A failed write may have left the next chunk behind
+		err := manager.storage.DeleteChunks(ctx, session.Path, len(session.Chunks)+1)
+		if err != nil {
+			return fmt.Errorf("failed to delete the chunks of %s: %w", session.ID, err)
+		}
+	}
+
+	m := model.Select("__yao.attachment.upload")
+	_, err := m.DeleteWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "upload_id", Value: session.ID},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete upload session: %w", err)
+	}
+
+	uploadLocks.Delete(session.ID)
+	return nil
+}
+
+// saveUpload saves the progress of an upload session
+func (manager Manager) saveUpload(session *UploadSession) error {
+	m := model.Select("__yao.attachment.upload")
+	_, err := m.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "upload_id", Value: session.ID},
+		},
+	}, manager.uploadRecord(session))
+	if err != nil {
+		return fmt.Errorf("failed to save upload session: %w", err)
+	}
+	return nil
+}
+
+// uploadRecord returns the progress columns of an upload session
+func (manager Manager) uploadRecord(session *UploadSession) map[string]interface{} {
+	return map[string]interface{}{
+		"offset":     session.Offset,
+		"chunks":     session.Chunks,
+		"status":     session.Status,
+		"expires_at": session.ExpiresAt,
+	}
+}
+
+// ranges returns the byte ranges of the received chunks
+func (session *UploadSession) ranges() []ByteRange {
+	ranges := make([]ByteRange, 0, len(session.Chunks))
+	start := int64(0)
+	for _, size := range session.Chunks {
+		ranges = append(ranges, ByteRange{Start: start, End: start + size - 1})
+		start += size
+	}
+	return ranges
+}
+
+// lockUpload locks an upload session, a session is written by one request at a time
+func lockUpload(uploadID string) (func(), error) {
+	v, _ := uploadLocks.LoadOrStore(uploadID, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	if !mu.TryLock() {
+		return nil, fmt.Errorf("%w: %s", ErrUploadLocked, uploadID)
+	}
+	return mu.Unlock, nil
+}
+
+// decodeJSON decodes a json column, the drivers return either the decoded value or the raw string
+func decodeJSON(value interface{}, v interface{}) error {
+	switch data := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if data == "" {
+			return nil
+		}
+		return jsoniter.UnmarshalFromString(data, v)
+	case []byte:
+		return jsoniter.Unmarshal(data, v)
+	default:
+		raw, err := jsoniter.Marshal(data)
+		if err != nil {
+			return err
+		}
+		return jsoniter.Unmarshal(raw, v)
+	}
+}
+
+// toInt64 converts a numeric column to int64
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	case []byte:
+		n, _ := strconv.ParseInt(string(v), 10, 64)
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,194 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func newUploadHeader(filename string, contentType string) *FileHeader {
+	fileHeader := &FileHeader{
+		FileHeader: &multipart.FileHeader{
+			Filename: filename,
+			Header:   make(map[string][]string),
+		},
+	}
+	fileHeader.Header.Set("Content-Type", contentType)
+	return fileHeader
+}
+
+func TestUploadSession(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	manager, err := RegisterDefault("test")
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	ctx := context.Background()
+	content := strings.Repeat("Lorem ipsum dolor sit amet. ", 40)
+	length := int64(len(content))
+
+	session, err := manager.CreateUpload(ctx, "user-1", newUploadHeader("resumable.txt", "text/plain"), length,
+		map[string]string{"filename": "resumable.txt"}, UploadOption{Groups: []string{"uploads"}, OriginalFilename: "docs/resumable.txt"})
+	if err != nil {
+		t.Fatalf("Failed to create upload: %v", err)
+	}
+	if session.Status != "uploading" || session.Offset != 0 || len(session.Ranges) != 0 {
+		t.Fatalf("Expected an empty session, got %+v", session)
+	}
+
+	// The first chunk, then the connection drops
+	session, err = manager.WriteUpload(ctx, "user-1", session.ID, 0, strings.NewReader(content[:300]))
+	if err != nil {
+		t.Fatalf("Failed to write the first chunk: %v", err)
+	}
+
+	// The client asks the server which bytes it already has
+	resumed, err := manager.GetUpload(ctx, "user-1", session.ID)
+	if err != nil {
+		t.Fatalf("Failed to get upload: %v", err)
+	}
+	if resumed.Offset != 300 || len(resumed.Ranges) != 1 || resumed.Ranges[0] != (ByteRange{Start: 0, End: 299}) {
+		t.Errorf("Expected the first 300 bytes, got %d %v", resumed.Offset, resumed.Ranges)
+	}
+	if resumed.Metadata["filename"] != "resumable.txt" || resumed.Option.OriginalFilename != "docs/resumable.txt" {
+		t.Errorf("Expected the metadata and the options of the session, got %v %+v", resumed.Metadata, resumed.Option)
+	}
+
+	// A write at another offset is rejected
+	_, err = manager.WriteUpload(ctx, "user-1", session.ID, 100, strings.NewReader("duplicate"))
+	if !errors.Is(err, ErrUploadOffset) {
+		t.Errorf("Expected an offset mismatch, got %v", err)
+	}
+
+	// The data beyond the length is rejected
+	_, err = manager.WriteUpload(ctx, "user-1", session.ID, 300, strings.NewReader(content[300:]+"overflow"))
+	if !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected the upload to be too large, got %v", err)
+	}
+
+	// The sessions of the other subjects are not found
+	if _, err := manager.GetUpload(ctx, "user-2", session.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected the session of another subject to be not found, got %v", err)
+	}
+	if _, err := manager.WriteUpload(ctx, "user-2", session.ID, 300, strings.NewReader(content[300:])); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected the write of another subject to be rejected, got %v", err)
+	}
+	if err := manager.AbortUpload(ctx, "user-2", session.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected the abort of another subject to be rejected, got %v", err)
+	}
+
+	// The connection drops in the middle of a write, the bytes received are kept
+	broken := io.MultiReader(strings.NewReader(content[300:500]), iotest.ErrReader(errors.New("connection reset")))
+	resumed, err = manager.WriteUpload(ctx, "user-1", session.ID, 300, broken)
+	if !errors.Is(err, ErrUploadInterrupted) {
+		t.Errorf("Expected the write to be interrupted, got %v", err)
+	}
+	if resumed == nil || resumed.Offset != 500 || len(resumed.Ranges) != 2 {
+		t.Fatalf("Expected the 200 bytes received to be kept, got %+v", resumed)
+	}
+
+	// Resume from the offset
+	session, err = manager.WriteUpload(ctx, "user-1", session.ID, resumed.Offset, strings.NewReader(content[500:]))
+	if err != nil {
+		t.Fatalf("Failed to resume upload: %v", err)
+	}
+	if session.Status != "completed" || session.Offset != length || session.File == nil {
+		t.Fatalf("Expected the upload to be complete, got %+v", session)
+	}
+
+	data, err := manager.Read(ctx, session.FileID)
+	if err != nil {
+		t.Fatalf("Failed to read uploaded file: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("Uploaded content mismatch. Expected length %d, got %d", len(content), len(data))
+	}
+
+	info, err := manager.Info(ctx, session.FileID)
+	if err != nil {
+		t.Fatalf("Failed to get file info: %v", err)
+	}
+	if info.Status != "uploaded" || info.UserPath != "docs/resumable.txt" {
+		t.Errorf("Expected the uploaded file, got %+v", info)
+	}
+
+	// The files larger than the max size are rejected when the session is created
+	_, err = manager.CreateUpload(ctx, "user-1", newUploadHeader("large.txt", "text/plain"), 100<<20, nil, UploadOption{})
+	if !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected the upload to be too large, got %v", err)
+	}
+}
+
+func TestUploadSessionAbort(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	manager, err := RegisterDefault("test")
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	ctx := context.Background()
+	session, err := manager.CreateUpload(ctx, "user-1", newUploadHeader("aborted.txt", "text/plain"), 100, nil, UploadOption{})
+	if err != nil {
+		t.Fatalf("Failed to create upload: %v", err)
+	}
+	_, err = manager.WriteUpload(ctx, "user-1", session.ID, 0, strings.NewReader("partial content"))
+	if err != nil {
+		t.Fatalf("Failed to write chunk: %v", err)
+	}
+
+	chunksDir := filepath.Join(config.Conf.DataRoot, "test", ".chunks", session.Path)
+	if _, err := os.Stat(chunksDir); err != nil {
+		t.Fatalf("Expected the chunks to be stored: %v", err)
+	}
+
+	err = manager.AbortUpload(ctx, "user-1", session.ID)
+	if err != nil {
+		t.Fatalf("Failed to abort upload: %v", err)
+	}
+	if _, err := os.Stat(chunksDir); !os.IsNotExist(err) {
+		t.Errorf("Expected the chunks to be removed, got %v", err)
+	}
+	if _, err := manager.GetUpload(ctx, "user-1", session.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected the session to be removed, got %v", err)
+	}
+
+	// The expired sessions are removed with their chunks
+	expiration := UploadExpiration
+	UploadExpiration = -time.Hour
+	defer func() { UploadExpiration = expiration }()
+
+	expired, err := manager.CreateUpload(ctx, "user-1", newUploadHeader("expired.txt", "text/plain"), 100, nil, UploadOption{})
+	if err != nil {
+		t.Fatalf("Failed to create upload: %v", err)
+	}
+	_, err = manager.WriteUpload(ctx, "user-1", expired.ID, 0, strings.NewReader("abandoned"))
+	if err != nil {
+		t.Fatalf("Failed to write chunk: %v", err)
+	}
+
+	removed, err := manager.ExpireUploads(ctx)
+	if err != nil {
+		t.Fatalf("Failed to expire uploads: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 expired session, got %d", removed)
+	}
+	if _, err := os.Stat(filepath.Join(config.Conf.DataRoot, "test", ".chunks", expired.Path)); !os.IsNotExist(err) {
+		t.Errorf("Expected the chunks of the expired session to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,485 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Decode the gif images
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/yaoapp/kun/log"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Decode the webp images
+)
+
+// Variant errors
+var (
+	ErrVariantNotFound     = errors.New("variant not found")
+	ErrVariantNotSupported = errors.New("variants are only available for images and videos")
+	ErrVariantFailed       = errors.New("failed to generate the variant")
+)
+
+// maxImagePixels the max pixels of the decoded images, about 160 MB decoded: a small compressed image may
+// declare a huge size
+const maxImagePixels = 40_000_000
+
+// variantLocks serializes the generation of each variant
+var variantLocks sync.Map
+
+// variantContentTypes the content types of the variant formats
+var variantContentTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"webp": "image/webp",
+}
+
+// validateVariants checks the variant options
+func validateVariants(variants map[string]VariantOption) error {
+	for name, option := range variants {
+		if name == "" || strings.ContainsAny(name, "/\\.") {
+			return fmt.Errorf("invalid variant name %q", name)
+		}
+
+		if option.Width < 0 || option.Height < 0 {
+			return fmt.Errorf("invalid size of the %s variant", name)
+		}
+
+		switch option.Fit {
+		case "", "contain", "cover":
+		default:
+			return fmt.Errorf("fit %s of the %s variant is not supported, use contain or cover", option.Fit, name)
+		}
+
+		switch strings.ToLower(option.Format) {
+		case "", "jpeg", "jpg", "png", "webp":
+		default:
+			return fmt.Errorf("format %s of the %s variant is not supported, use jpeg, png or webp", option.Format, name)
+		}
+	}
+	return nil
+}
+
+// OpenVariant opens a variant of an image or a video. The variant is generated on the first request
+// and stored next to the original.
+func (manager Manager) OpenVariant(ctx context.Context, fileID string, name string) (*FileContent, error) {
+	option, ok := manager.Variants[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVariantNotFound, name)
+	}
+
+	file, err := manager.getFileFromDatabase(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = available(file)
+	if err != nil {
+		return nil, err
+	}
+
+	path, format, err := manager.variant(ctx, file, name, option)
+	if err != nil {
+		return nil, err
+	}
+
+	size, modTime, etag, err := manager.storage.Stat(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename))
+	return &FileContent{
+		ReadSeekCloser: &rangeReader{ctx: ctx, storage: manager.storage, path: path, size: size},
+		Filename:       fmt.Sprintf("%s.%s.%s", filename, name, variantExtension(format)),
+		ContentType:    variantContentTypes[format],
+		Size:           size,
+		ModTime:        modTime,
+		ETag:           etag,
+	}, nil
+}
+
+// variant returns the storage path and the format of a variant, the variant is generated when it is missing
+func (manager Manager) variant(ctx context.Context, file *File, name string, option VariantOption) (string, string, error) {
+	if !strings.HasPrefix(file.ContentType, "image/") && !strings.HasPrefix(file.ContentType, "video/") {
+		return "", "", ErrVariantNotSupported
+	}
+
+	format := variantFormat(file.ContentType, option)
+	path := variantPath(file.Path, name, format)
+	if manager.storage.Exists(ctx, path) {
+		return path, format, nil
+	}
+
+	// The concurrent requests wait for the first one to generate the variant
+	lock, _ := variantLocks.LoadOrStore(manager.Name+":"+path, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if manager.storage.Exists(ctx, path) {
+		return path, format, nil
+	}
+
+	err := manager.generateVariant(ctx, file, path, format, option)
+	if err != nil {
+		return "", "", fmt.Errorf("%w %s of %s: %s", ErrVariantFailed, name, file.ID, err.Error())
+	}
+	return path, format, nil
+}
+
+// generateVariant renders a variant and stores it
+func (manager Manager) generateVariant(ctx context.Context, file *File, path string, format string, option VariantOption) error {
+	var img image.Image
+	var orientation int
+	var err error
+
+	if strings.HasPrefix(file.ContentType, "video/") {
+		img, err = manager.videoFrame(ctx, file, option.Time)
+	} else {
+		img, orientation, err = manager.decodeStoredImage(ctx, file)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The rotated images are resized before they are turned upright
+	width, height := option.Width, option.Height
+	if orientation >= 5 && orientation <= 8 {
+		width, height = height, width
+	}
+	img = orientImage(resizeImage(img, width, height, option.Fit), orientation)
+
+	quality := option.Quality
+	if quality <= 0 || quality > 100 {
+		quality = 85
+	}
+
+	data, err := manager.encodeVariant(ctx, img, format, quality)
+	if err != nil {
+		return err
+	}
+
+	_, err = manager.storage.Upload(ctx, path, bytes.NewReader(data), variantContentTypes[format])
+	return err
+}
+
+// generateUploadVariants generates the variants of a file that are rendered on upload
+func (manager Manager) generateUploadVariants(file *File) {
+	if !strings.HasPrefix(file.ContentType, "image/") && !strings.HasPrefix(file.ContentType, "video/") {
+		return
+	}
+
+	for name, option := range manager.Variants {
+		if !option.OnUpload {
+			continue
+		}
+
+		// The video frames may take a while, the upload does not wait for them
+		go func(name string, option VariantOption) {
+			if _, _, err := manager.variant(context.Background(), file, name, option); err != nil {
+				log.Error("[attachment] %s", err.Error())
+			}
+		}(name, option)
+	}
+}
+
+// deleteVariants deletes the variants of a file
+func (manager Manager) deleteVariants(ctx context.Context, file *File) {
+	for name, option := range manager.Variants {
+		path := variantPath(file.Path, name, variantFormat(file.ContentType, option))
+		if !manager.storage.Exists(ctx, path) {
+			continue
+		}
+
+		if err := manager.storage.Delete(ctx, path); err != nil {
+			log.Warn("[attachment] failed to delete the %s variant of %s: %s", name, file.ID, err.Error())
+		}
+	}
+}
+
+// extractImageMetadata sets the size and the EXIF data of a stored image to the file metadata.
+// The uploaders with StripMetadata strip the metadata from the stored jpeg images, except the orientation.
+func (manager Manager) extractImageMetadata(ctx context.Context, file *File) error {
+	if !strings.HasPrefix(file.ContentType, "image/") {
+		return nil
+	}
+
+	reader, err := manager.storage.Reader(ctx, file.Path)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return err
+	}
+
+	metadata := map[string]interface{}{}
+	if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		metadata["width"] = config.Width
+		metadata["height"] = config.Height
+	}
+
+	if exif, err := ReadExif(data); err == nil && len(exif) > 0 {
+		metadata["exif"] = exif
+	}
+
+	if manager.StripMetadata {
+		stripped, err := StripExif(data)
+		if err != nil {
+			return err
+		}
+
+		if len(stripped) != len(data) {
+			if strings.HasSuffix(file.Path, ".gz") {
+				stripped, err = Gzip(stripped)
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = manager.storage.Upload(ctx, file.Path, bytes.NewReader(stripped), file.ContentType)
+			if err != nil {
+				return fmt.Errorf("failed to store the image without the exif data: %w", err)
+			}
+		}
+	}
+
+	if len(metadata) > 0 {
+		file.Metadata = metadata
+	}
+	return nil
+}
+
+// decodeStoredImage decodes a stored image, returns the EXIF orientation of the jpeg images
+func (manager Manager) decodeStoredImage(ctx context.Context, file *File) (image.Image, int, error) {
+	reader, err := manager.storage.Reader(ctx, file.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	img, _, err := decodeImage(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orientation := 1
+	if exif, err := ReadExif(data); err == nil {
+		if value, ok := exif["orientation"].(int); ok {
+			orientation = value
+		}
+	}
+	return img, orientation, nil
+}
+
+// decodeImage decodes an image, the size declared by its header is checked against maxImagePixels first
+func decodeImage(data []byte) (image.Image, string, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return nil, "", fmt.Errorf("image of %dx%d pixels exceeds the limit of %d pixels", config.Width, config.Height, maxImagePixels)
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, format, nil
+}
+
+// videoFrame renders a frame of a stored video with ffmpeg
+func (manager Manager) videoFrame(ctx context.Context, file *File, seconds float64) (image.Image, error) {
+	path, _, err := manager.storage.LocalPath(ctx, file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := manager.runFFmpeg(ctx, nil,
+		"-ss", strconv.FormatFloat(seconds, 'f', -1, 64), "-i", path,
+		"-frames:v", "1", "-f", "image2pipe", "-vcodec", "png", "-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the video frame: %w", err)
+	}
+
+	return png.Decode(bytes.NewReader(data))
+}
+
+// encodeVariant encodes a variant, the webp images are encoded with ffmpeg
+func (manager Manager) encodeVariant(ctx context.Context, img image.Image, format string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "png":
+		err := png.Encode(&buf, img)
+		return buf.Bytes(), err
+
+	case "webp":
+		err := png.Encode(&buf, img)
+		if err != nil {
+			return nil, err
+		}
+		data, err := manager.runFFmpeg(ctx, &buf,
+			"-f", "image2pipe", "-i", "-",
+			"-c:v", "libwebp", "-quality", strconv.Itoa(quality), "-f", "webp", "-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode webp: %w", err)
+		}
+		return data, nil
+	}
+
+	// JPEG has no transparency, the transparent pixels are white
+	flattened := image.NewRGBA(img.Bounds())
+	draw.Draw(flattened, flattened.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	err := jpeg.Encode(&buf, flattened, &jpeg.Options{Quality: quality})
+	return buf.Bytes(), err
+}
+
+// runFFmpeg runs ffmpeg and returns its output
+func (manager Manager) runFFmpeg(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	ffmpeg := manager.FFmpeg
+	if ffmpeg == "" {
+		ffmpeg = "ffmpeg"
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ffmpeg, append([]string{"-hide_banner", "-loglevel", "error"}, args...)...)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// resizeImage resizes an image to fit in the size (contain), or to fill it and crop the overflow (cover).
+// The images are never enlarged.
+func resizeImage(img image.Image, width int, height int, fit string) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 || (width <= 0 && height <= 0) {
+		return img
+	}
+
+	// Crop the image to the aspect ratio of the size
+	src := bounds
+	if fit == "cover" && width > 0 && height > 0 {
+		if w*height > h*width {
+			cropped := h * width / height
+			src.Min.X += (w - cropped) / 2
+			src.Max.X = src.Min.X + cropped
+		} else {
+			cropped := w * height / width
+			src.Min.Y += (h - cropped) / 2
+			src.Max.Y = src.Min.Y + cropped
+		}
+		w, h = src.Dx(), src.Dy()
+	}
+
+	scale := 1.0
+	if width > 0 && float64(width)/float64(w) < scale {
+		scale = float64(width) / float64(w)
+	}
+	if height > 0 && float64(height)/float64(h) < scale {
+		scale = float64(height) / float64(h)
+	}
+
+	dw, dh := int(float64(w)*scale+0.5), int(float64(h)*scale+0.5)
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Src, nil)
+	return dst
+}
+
+// orientImage turns an image upright according to its EXIF orientation
+func orientImage(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // Mirrored
+				sx, sy = w-1-x, y
+			case 3: // Rotated 180
+				sx, sy = w-1-x, h-1-y
+			case 4: // Mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // Mirrored and rotated 270
+				sx, sy = y, x
+			case 6: // Rotated 90
+				sx, sy = y, h-1-x
+			case 7: // Mirrored and rotated 90
+				sx, sy = w-1-y, h-1-x
+			case 8: // Rotated 270
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return dst
+}
+
+// variantFormat returns the format of a variant, the format of the original by default
+func variantFormat(contentType string, option VariantOption) string {
+	switch strings.ToLower(option.Format) {
+	case "jpeg", "jpg":
+		return "jpeg"
+	case "png", "webp":
+		return strings.ToLower(option.Format)
+	}
+
+	if contentType == "image/png" {
+		return "png"
+	}
+	return "jpeg"
+}
+
+// variantPath returns the storage path of a variant, next to the original
+func variantPath(path string, name string, format string) string {
+	return fmt.Sprintf("%s.%s.%s", strings.TrimSuffix(path, ".gz"), name, variantExtension(format))
+}
+
+// variantExtension returns the file extension of a variant format
+func variantExtension(format string) string {
+	if format == "jpeg" {
+		return "jpg"
+	}
+	return format
+}
@@ -0,0 +1,254 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+// testExifJPEG returns a jpeg image with the EXIF data of a phone photo: the make, the orientation and the GPS position
+func testExifJPEG(t *testing.T, width int, height int, orientation int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+
+	// TIFF little endian: IFD0 at 8 with Make, Orientation and the GPS pointer, GPS IFD at 50
+	tiff := make([]byte, 120)
+	le := binary.LittleEndian
+	copy(tiff, "II")
+	le.PutUint16(tiff[2:], 42)
+	le.PutUint32(tiff[4:], 8)
+
+	le.PutUint16(tiff[8:], 3)
+	entry := func(offset int, tag uint16, typ uint16, count uint32, value uint32) {
+		le.PutUint16(tiff[offset:], tag)
+		le.PutUint16(tiff[offset+2:], typ)
+		le.PutUint32(tiff[offset+4:], count)
+		le.PutUint32(tiff[offset+8:], value)
+	}
+	entry(10, 0x010F, 2, 6, 100)                 // Make at 100
+	entry(22, 0x0112, 3, 1, uint32(orientation)) // Orientation
+	entry(34, 0x8825, 4, 1, 50)                  // GPS IFD
+	copy(tiff[100:], "Phone\x00")
+
+	le.PutUint16(tiff[50:], 2)
+	entry(52, 0x0001, 2, 2, uint32('S'))
+	entry(64, 0x0002, 5, 3, 76) // Latitude at 76: 33/1 51/1 36/1
+	for i, value := range []uint32{33, 51, 36} {
+		le.PutUint32(tiff[76+i*8:], value)
+		le.PutUint32(tiff[80+i*8:], 1)
+	}
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), append(segment, payload...)...), data[2:]...)
+}
+
+// testSegment returns a jpeg segment with the payload
+func testSegment(marker byte, payload string) []byte {
+	segment := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// testBombPNG returns the header of a png image declaring a huge size
+func testBombPNG(width uint32, height uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], width)
+	binary.BigEndian.PutUint32(ihdr[8:], height)
+	ihdr[12], ihdr[13] = 8, 2 // 8 bits RGB
+
+	data := append([]byte("\x89PNG\r\n\x1a\n"), 0, 0, 0, 13)
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestExif(t *testing.T) {
+	data := testExifJPEG(t, 40, 20, 6)
+
+	// The XMP and IPTC segments may hold the GPS position too
+	xmp := testSegment(0xE1, "http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta><exif:GPSLatitude>33,51S</exif:GPSLatitude></x:xmpmeta>")
+	iptc := testSegment(0xED, "Photoshop 3.0\x008BIM")
+	data = append(append(append([]byte{}, data[:2]...), append(xmp, iptc...)...), data[2:]...)
+
+	exif, err := ReadExif(data)
+	if err != nil {
+		t.Fatalf("Failed to read exif: %v", err)
+	}
+	if exif["make"] != "Phone" || exif["orientation"] != 6 || exif["gps_latitude"] != -33.86 {
+		t.Errorf("Unexpected exif data %v", exif)
+	}
+
+	stripped, err := StripExif(data)
+	if err != nil {
+		t.Fatalf("Failed to strip exif: %v", err)
+	}
+
+	// Only the orientation is left
+	exif, err = ReadExif(stripped)
+	if err != nil {
+		t.Fatalf("Failed to read the stripped exif: %v", err)
+	}
+	if len(exif) != 1 || exif["orientation"] != 6 {
+		t.Errorf("Expected only the orientation, got %v", exif)
+	}
+	if bytes.Contains(stripped, []byte("GPSLatitude")) || bytes.Contains(stripped, []byte("Photoshop")) {
+		t.Error("Expected the XMP and IPTC data to be stripped")
+	}
+
+	if _, err := jpeg.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("The stripped image is not valid: %v", err)
+	}
+}
+
+func TestVariants(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	manager, err := Register("variants", "local", ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, "variants")},
+		AllowedTypes: []string{"image/*", "text/*"},
+		Variants: map[string]VariantOption{
+			"thumb": {Width: 20, Height: 20, Fit: "cover"},
+			"small": {Width: 30, Format: "png"},
+		},
+		StripMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to register manager: %v", err)
+	}
+
+	// A 100x50 photo taken rotated, it is displayed as 50x100
+	ctx := context.Background()
+	data := testExifJPEG(t, 100, 50, 6)
+	header := newUploadHeader("photo.jpg", "image/jpeg")
+	header.Size = int64(len(data))
+
+	file, err := manager.Upload(ctx, header, bytes.NewReader(data), UploadOption{})
+	if err != nil {
+		t.Fatalf("Failed to upload image: %v", err)
+	}
+
+	info, err := manager.Info(ctx, file.ID)
+	if err != nil {
+		t.Fatalf("Failed to get file info: %v", err)
+	}
+	exif, _ := info.Metadata["exif"].(map[string]interface{})
+	if toInt64(info.Metadata["width"]) != 100 || toInt64(info.Metadata["height"]) != 50 || exif["make"] != "Phone" {
+		t.Errorf("Unexpected metadata %v", info.Metadata)
+	}
+
+	// The stored image has no EXIF data but the orientation
+	stored, err := manager.Read(ctx, file.ID)
+	if err != nil {
+		t.Fatalf("Failed to read image: %v", err)
+	}
+	if exif, err := ReadExif(stored); err != nil || len(exif) != 1 {
+		t.Errorf("Expected only the orientation in the stored image, got %v %v", exif, err)
+	}
+
+	for name, size := range map[string]image.Point{"thumb": {20, 20}, "small": {30, 60}} {
+		content, err := manager.OpenVariant(ctx, file.ID, name)
+		if err != nil {
+			t.Fatalf("Failed to open the %s variant: %v", name, err)
+		}
+
+		variant, err := io.ReadAll(content)
+		content.Close()
+		if err != nil {
+			t.Fatalf("Failed to read the %s variant: %v", name, err)
+		}
+
+		imageConfig, format, err := image.DecodeConfig(bytes.NewReader(variant))
+		if err != nil {
+			t.Fatalf("Failed to decode the %s variant: %v", name, err)
+		}
+		if imageConfig.Width != size.X || imageConfig.Height != size.Y {
+			t.Errorf("Expected the %s variant to be %v, got %dx%d", name, size, imageConfig.Width, imageConfig.Height)
+		}
+		if name == "small" && (format != "png" || content.ContentType != "image/png" || content.Filename != "photo.small.png") {
+			t.Errorf("Unexpected small variant %s %s %s", format, content.ContentType, content.Filename)
+		}
+	}
+
+	// The variant is stored next to the original
+	if !manager.storage.Exists(ctx, file.Path+".thumb.jpg") {
+		t.Errorf("Expected the variant to be stored at %s.thumb.jpg", file.Path)
+	}
+
+	// The unknown variants and the other files
+	if _, err := manager.OpenVariant(ctx, file.ID, "large"); !errors.Is(err, ErrVariantNotFound) {
+		t.Errorf("Expected the variant not to be found, got %v", err)
+	}
+
+	text, err := manager.Upload(ctx, newUploadHeader("notes.txt", "text/plain"), bytes.NewReader([]byte("notes")), UploadOption{})
+	if err != nil {
+		t.Fatalf("Failed to upload file: %v", err)
+	}
+	if _, err := manager.OpenVariant(ctx, text.ID, "thumb"); !errors.Is(err, ErrVariantNotSupported) {
+		t.Errorf("Expected the text files to have no variants, got %v", err)
+	}
+
+	// The images declaring more pixels than the limit are not decoded
+	bomb := testBombPNG(100000, 100000)
+	header = newUploadHeader("bomb.png", "image/png")
+	header.Size = int64(len(bomb))
+	bombFile, err := manager.Upload(ctx, header, bytes.NewReader(bomb), UploadOption{})
+	if err != nil {
+		t.Fatalf("Failed to upload image: %v", err)
+	}
+	if _, err := manager.OpenVariant(ctx, bombFile.ID, "thumb"); !errors.Is(err, ErrVariantFailed) {
+		t.Errorf("Expected the variant of the huge image to fail, got %v", err)
+	}
+
+	// The metadata is kept by the uploaders without StripMetadata
+	kept, err := New(ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, "variants-kept")},
+		AllowedTypes: []string{"image/*"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+	header = newUploadHeader("photo.jpg", "image/jpeg")
+	header.Size = int64(len(data))
+	keptFile, err := kept.Upload(ctx, header, bytes.NewReader(data), UploadOption{})
+	if err != nil {
+		t.Fatalf("Failed to upload image: %v", err)
+	}
+	if stored, err := kept.Read(ctx, keptFile.ID); err != nil || !bytes.Equal(stored, data) {
+		t.Errorf("Expected the image to be stored unchanged, got %d bytes %v", len(stored), err)
+	}
+
+	// The variants are deleted with the file
+	if err := manager.Delete(ctx, file.ID); err != nil {
+		t.Fatalf("Failed to delete file: %v", err)
+	}
+	if manager.storage.Exists(ctx, file.Path+".thumb.jpg") {
+		t.Error("Expected the variant to be deleted with the file")
+	}
+}
@@ -98,10 +98,16 @@
 // .tmp/data/yao/data/kb/providers/embedding/openai/zh-cn.json
 // .tmp/data/yao/data/kb/providers/extraction/openai/en.json
 // .tmp/data/yao/data/kb/providers/extraction/openai/zh-cn.json
+// .tmp/data/yao/data/kb/providers/fetcher/crawler/en.json
+// .tmp/data/yao/data/kb/providers/fetcher/crawler/zh-cn.json
 // .tmp/data/yao/data/kb/providers/fetcher/http/en.json
 // .tmp/data/yao/data/kb/providers/fetcher/http/zh-cn.json
 // .tmp/data/yao/data/kb/providers/fetcher/mcp/en.json
 // .tmp/data/yao/data/kb/providers/fetcher/mcp/zh-cn.json
+// .tmp/data/yao/data/kb/providers/reranker/llm/en.json
+// .tmp/data/yao/data/kb/providers/reranker/llm/zh-cn.json
+// .tmp/data/yao/data/kb/providers/reranker/openai/en.json
+// .tmp/data/yao/data/kb/providers/reranker/openai/zh-cn.json
 // .tmp/data/yao/fields/model.trans.json
 // .tmp/data/yao/langs/en-US.json
 // .tmp/data/yao/langs/zh-cn/global.yml
@@ -113,6 +119,8 @@
 // .tmp/data/yao/models/agent/assistant.mod.yao
 // .tmp/data/yao/models/agent/chat.mod.yao
 // .tmp/data/yao/models/agent/history.mod.yao
+// .tmp/data/yao/models/attachment/blob.mod.yao
+// .tmp/data/yao/models/attachment/upload.mod.yao
 // .tmp/data/yao/models/attachment.mod.yao
 // .tmp/data/yao/models/audit.mod.yao
 // .tmp/data/yao/models/config.mod.yao
@@ -124,6 +132,8 @@
 // .tmp/data/yao/models/kb/collection.mod.yao
 // .tmp/data/yao/models/kb/document.mod.yao
 // .tmp/data/yao/models/member.mod.yao
+// .tmp/data/yao/models/messenger/delivery.mod.yao
+// .tmp/data/yao/models/messenger/outbox.mod.yao
 // .tmp/data/yao/models/role.mod.yao
 // .tmp/data/yao/models/team.mod.yao
 // .tmp/data/yao/models/user/oauth_account.mod.yao
@@ -2263,6 +2273,46 @@ func yaoDataKbProvidersExtractionOpenaiZhCnJson() (*asset, error) {
 	return a, nil
 }
 
+var _yaoDataKbProvidersFetcherCrawlerEnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x97\xdd\x6f\xdb\x36\x10\xc0\xdf\xfd\x57\x10\x7e\xda\x0c\x47\xb6\x93\xb5\x6b\x3b\xf4\x21\x4d\xb7\xac\x40\x16\x04\x89\x83\x3d\x6c\x83\x41\x49\x67\x8b\x0b\x45\xaa\x24\x15\xdb\x2d\xf2\xbf\xef\xf8\x61\x5b\x72\xa9\xd4\xe9\xd2\x97\xc4\xba\x3b\x1e\xc5\xdf\x7d\x51\x9f\x7b\x84\xf4\x59\xde\x7f\x43\xfa\xb3\xd9\x9a\xca\x24\x53\x74\xc9\x41\xf5\x87\x56\x61\x98\xe1\x60\x75\x7f\x42\x4a\xce\x9a\x9a\x1c\x74\xa6\x58\x65\x98\x14\x56\xef\x74\x9a\x50\xb2\x84\x54\x33\x03\x64\xae\x64\x89\x8f\x1a\x20\x27\xb7\xd7\x17\x44\x2a\xfb\x84\x9a\x92\x56\xc9\xaa\xe4\x84\x8a\x9c\xd0\x3c\xd7\x04\x68\x56\x90\x8a\x2e\x80\x50\xeb\x20\x97\x59\x5d\x82\x30\x09\xf9\x4d\x72\x2e\x97\x9a\x98\x02\x08\x67\xe2\x4e\x93\x25\x33\x05\x13\x4e\xc0\x44\xc6\xeb\x1c\x9c\x1b\x58\xf9\xdf\x15\x35\x06\x94\xd0\xa4\xae\x88\x91\xd6\x17\x54\xa6\xf0\x3b\xf9\x1d\x38\x2b\x99\x19\x12\x05\xba\x82\xcc\x68\xa2\x64\x2a\x8d\x4e\xcc\xca\x38\x2b\x86\x22\x77\x7e\x5c\xc9\xe9\x7a\xe8\x84\xfa\x8e\x55\xfe\x25\xf2\xba\xe2\x2c\xa3\x78\xba\x8c\x0a\x29\xf0\x27\xb7\x67\xd3\x89\x47\xa2\xe0\x63\xcd\x14\x58\x96\x7f\xfd\xe3\x24\x95\x92\x15\x28\xc3\x40\xa3\xec\x33\x4a\x50\x56\xd2\xd5\xcc\xbd\xd7\x56\x64\x39\xaf\x2b\x87\x99\x09\x03\x8b\x80\xd8\x2b\x36\x01\xf8\x83\xae\xc8\x7b\xb7\x6c\xab\xdb\x0b\xc1\x05\xdc\x03\x86\x40\xce\x03\xac\xb9\xc3\x87\xf8\x5d\x28\xec\xfb\x6f\x82\x91\x90\xb1\x3f\xa6\x6e\x89\x87\x36\x48\x56\x60\xcf\x64\xfd\x38\xa5\x0f\x19\xea\x04\x5f\x27\xcd\xcd\xe7\xb4\xe6\x06\x37\x3e\xde\xca\x4a\x26\x58\x59\x97\x28\x1b\xef\x64\x74\x15\x64\x93\x9d\x30\x93\x65\x25\x05\x06\xd9\xbe\xf7\x07\x51\xd5\xe6\xb2\x2e\xd3\xe6\xb9\x97\x2c\x77\x84\xfa\x05\xe5\xf3\x9d\x58\xaa\x1c\xad\xd0\x97\x13\x3c\x0c\x77\x48\x6d\x78\xf5\xd3\x91\x5e\xb9\x65\x5d\x48\x9d\xd6\xa3\x42\x44\xd4\x90\x52\x6a\x13\x85\x30\x19\x8f\x23\x18\x26\x51\x0c\xe3\xf1\x33\x92\x38\x6e\x91\x08\x55\x11\xe3\xa0\x8d\x62\x62\x11\xc1\xf0\x21\x54\xd2\x55\xa8\x9e\x4e\x1a\xe7\x5c\xa6\xbb\x1a\x0b\xf9\xe1\x72\x05\x8b\xcd\x41\xb2\x59\x82\x65\x08\xca\xa6\x20\x24\x64\x40\x4a\x6a\xb2\x02\xb4\xab\x3f\x2c\x45\x0d\x0b\x5b\xdb\x43\x32\x68\xa8\xc4\xda\x29\x93\xed\x1b\xb8\x3a\x97\xb5\xb1\x1d\x03\x4d\x4a\xf0\xb6\x6e\x3f\x6f\x79\x01\xf4\x1e\x08\x94\x95\x59\x6f\x37\x77\xea\x02\x03\xb4\x4d\xdd\x4d\xba\xc7\x02\xd6\xef\xc7\x63\x30\x85\x95\x39\x55\x40\x77\xea\x8a\xd3\x0c\x0a\xc9\x3d\xef\xfe\x08\x1b\x94\x1e\x0d\x06\x7f\x8b\xc2\x98\x4a\xbf\x19\x8d\x52\x2e\x17\x09\xac\x68\x59\x71\x48\xd0\x19\x2a\x23\xe1\x9b\xd7\x9c\x7f\x19\xbe\x93\x56\xf8\x42\x23\x7b\x52\xf8\x7e\x5d\x3d\x47\xf8\x6c\x93\xdb\x8b\xde\x77\xa0\x46\x55\x56\xb0\x7b\x70\xf4\xf0\xcf\x68\x90\x54\xf9\xfc\x60\x56\x3f\xb5\x58\x85\x1e\x3e\xf3\x2d\x3c\x86\x2c\x95\x92\x03\x15\x11\x66\xd7\x7e\x6d\xa3\xfd\x77\x52\xbb\x41\x30\x3b\x52\x39\xd3\x34\x74\xd5\x74\xbd\x3f\x3d\x96\x94\x19\xec\xba\x6a\x7f\x8c\x44\x49\x1a\x55\x43\x9c\xe5\x0d\x66\x7f\x56\x1c\xdc\x00\x5e\xb4\xa8\xb8\x0d\x63\x30\xc4\x5e\x5b\xd9\xb2\x78\x6f\x57\x90\x1f\x34\x64\x52\xe4\xfa\xc7\x4e\x10\xde\x2e\x05\xb3\x04\xc0\x11\xbc\x94\xc4\xce\x3b\xd0\x46\xfb\x59\x6b\x2b\x2f\x21\x53\x24\xd5\x38\xba\x4d\xb3\x06\x25\x86\xa3\x59\x23\xbb\x65\x81\x2e\x98\x7b\xe6\x52\x60\x77\x8e\x22\x1a\x1f\x38\x58\x5e\x3e\x63\x3b\x7d\xd9\xa2\x89\x2f\xab\x66\x38\x03\x9c\xbf\xc3\x4b\xf2\x16\x97\x91\x53\xb7\xac\x8b\xa6\x33\x71\x9e\x37\x95\xb8\xa1\x89\x97\x0e\xae\xa5\xe7\x64\x0b\x13\xb8\xcd\x54\x67\x51\x73\xd0\x6d\xa2\xf1\x22\x3d\x57\xb4\x2a\xae\x4f\xcf\x8f\xc2\x8d\x6d\x34\x49\xc6\xfd\x47\x18\x75\x56\xed\xe3\x8e\xbe\x52\xae\x3f\xb7\x50\x16\x40\x51\x1c\xad\x53\x99\xfe\x8b\x47\x8c\x70\x3c\xab\xb5\xc1\xab\xcb\xef\x61\x69\x17\xcb\x8d\xd9\x74\x7a\x45\xc2\x36\x96\xdc\xe6\x86\x68\x87\xc9\x96\x6e\x03\x58\xe3\xba\x36\x47\xe4\x1d\xd5\x78\x89\xab\xd0\xe6\xd0\x43\xbf\xda\xa1\xfc\xf2\xee\xe7\xe4\xa7\x35\xce\x36\xc5\x3e\xd1\xf0\xfa\x3b\x55\x77\x6a\xb5\xb1\xb4\x3d\xb4\x6c\xf6\xc8\xb4\x2c\x03\x1b\xd7\xa1\x28\x2a\xf0\x80\xee\x32\x9b\xbb\x3b\x5e\x83\x4c\x67\xcf\xff\x5a\xfa\xc4\x52\xe8\x1d\x50\x85\x9b\x1a\x79\x87\x25\x8f\x3b\xbf\xa3\x9a\x65\x6e\xff\xf6\xb2\x38\xd7\xc8\xa5\xaf\x91\x54\x9e\x67\x96\xe1\xb5\xf8\xe8\x82\x8a\x45\x8d\x05\xf5\x6d\x44\x9d\x0f\xb2\xf5\xf1\x18\xd3\x2b\x05\x73\x50\x98\x37\x84\x07\xf3\x4d\x05\xbb\x2b\x68\x37\x46\x10\x47\xb7\x37\x43\x10\xbf\x7c\x7c\x3b\x4e\x5e\xff\x2f\xa8\x6d\x57\xc3\x4f\xc5\xd1\xd9\xa5\xfb\xfd\xaa\x03\x6a\xab\xd9\x45\xee\x8f\xfb\x50\xcf\xa4\xbc\x63\xdf\xc6\x32\x2c\x7d\x0c\xa1\x37\x69\xe6\xa3\x06\xad\x6d\x8a\x96\x54\x20\x44\xf7\xf9\xf7\x7d\xd2\x31\x6c\xf4\x96\xa6\xd9\xe4\xf8\xe4\xc9\xb4\x4e\x76\xb4\x7a\xcd\xff\x9b\x2e\x67\x58\x09\x78\x71\x7d\xda\x77\xc8\xd4\x2f\x3a\x60\x06\x5f\xfb\x2e\x46\xc2\x36\x36\xf1\xc2\x87\x2d\x7e\x15\x87\xd5\xd1\x91\x70\x12\x9b\xa5\x2f\xa2\xb3\xf4\x19\x87\xe9\xeb\xde\x86\xd0\x43\xef\xa1\xf7\x1f\x12\xd2\x6e\xdb\x6a\x10\x00\x00")
+
+func yaoDataKbProvidersFetcherCrawlerEnJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoDataKbProvidersFetcherCrawlerEnJson,
+		"yao/data/kb/providers/fetcher/crawler/en.json",
+	)
+}
+
+func yaoDataKbProvidersFetcherCrawlerEnJson() (*asset, error) {
+	bytes, err := yaoDataKbProvidersFetcherCrawlerEnJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/data/kb/providers/fetcher/crawler/en.json", size: 4202, mode: os.FileMode(420), modTime: time.Unix(1792287496, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoDataKbProvidersFetcherCrawlerZhCnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x97\x5b\x4f\xdb\x48\x14\x80\xdf\xf9\x15\x56\x1e\x11\x84\x50\xb6\xdd\xb6\xab\x3e\x50\x1e\xba\x2b\x55\xd5\xaa\xa5\x4f\xbb\x2b\xe4\x38\x03\xf1\xd6\xb1\x5d\xdb\xd9\x86\x56\x48\x81\x42\x09\x34\x21\x2c\x2d\x20\x52\xaa\x2e\x2d\xb7\x72\x4b\xab\x05\x72\xe3\xf2\x63\xea\xf1\xe5\xa9\x7f\x61\x8f\x3d\xb9\xd8\x60\x07\xe8\x52\x29\x4a\xa2\x73\xe6\x1c\xcf\x7c\x73\x6e\x7e\xd6\x42\x51\x01\x36\x12\xb8\x49\x05\xfa\xfa\x06\x69\x21\xc8\x48\xf4\x13\x0e\x49\x81\x36\x4b\xa1\xb0\x0a\x87\x2c\x9d\x7e\xf8\xb7\xbe\xb9\xa0\xa7\xb6\x8c\x85\x4d\xa2\x8a\x20\x99\x91\x58\x51\x61\x05\xde\x5a\xa0\x56\xa6\xf4\xb5\x0c\xde\x9e\xa6\x1e\xde\xbf\x4b\x69\xa9\x39\x4a\x66\x15\x14\xa3\xc5\x60\x22\xc6\x51\x60\x88\xb3\x73\xc4\xc9\xd7\x83\x34\x2e\xed\xe3\x4f\x2f\xb4\x7c\x56\x2d\x6e\x98\x4b\x7b\xe6\xdb\xf7\x5a\xa1\x82\x27\xff\x51\x8b\x65\xb5\x98\x04\xa1\x36\x37\xae\x2d\x7d\xf8\x92\x1c\xc1\x8b\xeb\x38\x3d\x86\xa7\x37\xf1\x4c\x5a\x9b\x9a\x31\x17\x96\x8d\xb5\x51\x9c\x5a\xc0\x2f\xc6\xb4\xf4\x84\x56\xf8\x8c\xcb\xab\xa0\xaa\x3a\x99\xfd\x64\x8e\x67\xcd\x85\x69\x9c\xda\x37\x0a\xef\xcc\x5c\xd6\x7c\x75\xa4\x4d\xad\xc0\x13\xcd\xe1\x3d\xbc\x93\xa2\x24\x21\x2c\x28\x72\x50\x49\x28\x14\xce\x4e\xe2\xb1\x7d\x6d\xf2\x15\x6c\xcc\x9c\xdf\x35\x73\xaf\xc9\xc6\x8c\xc2\xbf\xc6\xf1\xb8\x39\x9e\xc1\xcb\x19\x3d\x37\x0a\x8f\x33\xd2\xcf\xad\x43\xc1\x6e\xc8\xc9\x25\xf4\x38\xce\x4a\xc8\x62\xf6\xdb\x1f\xb6\x44\x94\x04\x11\x49\x0a\x8b\x64\x90\x3d\x03\x09\xc8\x62\x74\xa2\x2f\x82\x44\x25\x5a\x17\x59\x3c\x07\x45\x1b\x27\xcb\x2b\x68\xa0\x0a\x99\x28\x6a\xa0\xb5\xc5\x24\x5e\x5e\x23\x07\x6b\xa8\x9b\xc2\x76\x1e\x15\x76\x8c\x3f\x8f\x00\x08\xd8\x6d\x88\x32\x96\xd6\xf5\xe5\x32\xce\x6e\x54\xf9\xbb\x2e\xc8\xba\xcf\x91\x12\x5e\xfc\x84\xdf\x1c\xa9\xc5\x6d\xb0\x74\x9e\xb2\xfa\xd8\x7e\x3a\xce\x29\xf0\xc8\x2b\x75\x59\x8c\xe5\xd9\x58\x3c\x06\xb2\x50\x43\x46\x27\xaa\xb2\xce\x86\x90\x11\x62\xa2\xc0\x23\xde\x32\x0f\xfc\xc2\x8b\x71\xe5\x5e\x3c\x16\x76\x1e\xfa\x09\x1b\xb1\xf1\x04\xa2\x34\xd7\xdf\x10\x0b\x52\x04\x56\x81\x2f\x5b\x30\xd4\xd6\xe0\x29\xd2\x03\x0e\xc4\x17\xe0\x59\x8f\x0f\x5f\xa4\xf6\xba\x5c\x95\x53\x6e\xd4\x19\x50\x3e\x48\x3a\x43\x21\x0f\x28\x9d\x9e\x50\x42\xa1\x4b\xe4\x72\xc5\xc5\x85\xe5\x19\x2e\x1e\x41\x5e\x54\x64\x45\x62\xf9\x01\x0f\x28\x24\xa5\x48\x26\xf9\x12\x31\x56\x87\xeb\x38\xec\x98\xb1\xb0\x24\x73\xe6\x58\x46\xdf\x5a\x25\xb6\x90\x31\x90\xc4\xc6\x52\x9a\x64\x2d\x80\x6a\xa5\x70\xba\x04\x4b\x40\xa0\xed\xec\x19\x85\x3c\x3e\x1a\x85\x55\xad\x75\x79\xa5\xa2\x8d\x66\x89\x1c\x96\xab\xc5\x0c\x2e\xae\xe2\x4c\xd6\xd8\xd9\x21\xc9\x66\xe5\xb6\xbd\xb2\xbe\x46\x9f\x5d\xd0\x3f\x96\x41\x7e\x3a\x88\x27\x92\x50\x1f\xc0\x4e\x2d\x56\xb4\xc5\xb2\xcf\x3d\x05\x02\xde\xe8\x7b\x51\x42\xe9\x96\x10\xdd\x50\x8b\x1c\xcd\xa0\xa8\xc0\x11\xcc\x81\x8e\x88\xc0\xc8\x1d\xad\xad\xbf\xf3\x51\x45\x11\xe5\x9b\x1d\x1d\x61\x4e\x18\x08\xa2\x04\x1d\x13\x39\x14\x04\x67\xa0\xf4\xb8\xb5\xfe\x38\xc7\x9d\xbe\xb5\x2e\xd7\xad\xa1\xc4\xc5\x6f\xcd\x59\xff\x9a\xdd\x1a\xa9\x60\x17\xb9\xb5\xef\x80\x8d\x96\x98\x28\xfb\x17\xb2\xf1\xc1\x57\x47\x6b\x50\x8c\xf4\x9f\x1b\xd6\x0f\x2e\x58\x12\x92\x45\xc4\x28\x7d\xa4\x7a\x7b\x31\x0b\x0b\x02\x87\x68\xde\x03\xda\xa9\xc2\xef\x4f\xce\xc6\xe6\x6c\x11\xfa\xea\xb0\xb6\xfd\xbe\x0a\x92\x74\x07\x7d\x7b\x02\x1f\x8d\x9d\x68\x1c\x3e\x04\x15\x29\x8e\xbc\x19\x3e\x78\xc2\x2a\x4c\xf4\xdc\x09\x7f\xd5\x45\x23\x82\x38\x7a\xd0\x0b\x02\x7f\xa2\x8c\xd4\x19\x18\xf9\x82\xf6\x79\xa4\xd6\xe4\x52\xfa\xda\xcc\xd7\x83\x09\x5f\x0e\x38\x5f\xc2\xd3\x24\x36\xac\xac\x52\x8b\xcb\xda\xd6\x12\x71\xa1\x96\x5e\x82\x17\x2b\xa2\x6a\xe7\x76\xd2\xca\x8d\x3a\xa1\x68\x6f\x76\xcd\xd9\x63\x6d\x7e\x5f\x3d\x3c\xd6\x5f\xaf\x1b\xf9\x95\xa6\xb0\x42\xe7\x6c\x30\xd7\x2e\xb1\x90\x5e\x73\x71\x8d\xcb\x48\xea\x83\x06\x63\xfb\x3b\x7f\x56\xc2\xd9\xb4\x54\x41\xad\x7c\xd0\xa7\x5f\xf8\xc7\x96\x8d\x0f\x08\x39\x57\x43\x44\xa9\xa5\x77\x20\x51\xcb\x53\x66\x72\x42\x7b\xf9\x91\x72\xe3\x24\xe9\xea\x97\x9f\x77\x24\x5a\x8c\xde\xef\xbe\xd3\xde\x43\x26\xb6\x8e\xce\x60\x28\xd0\x04\x8e\x6f\xc2\x36\x77\x74\x46\xa6\xfe\xe8\x62\x18\x45\x34\x88\x3d\x53\x54\x08\xff\x09\x39\xec\x15\x9d\xe3\x1b\x78\x27\xa7\x96\x26\xf0\xf2\xae\xf9\x7c\xfd\x0c\x86\x30\xa9\x90\xee\x65\xf1\xa9\x59\x52\x3f\xf7\xf6\xfe\x4a\x11\x7b\x17\x2e\xc7\xa8\xd6\x4f\x73\xb2\x4f\x3a\xde\x43\xb2\x02\x6b\xce\x7b\xe4\xeb\x0d\x90\xa7\xe7\x3e\x5b\xde\x1d\x57\xa2\x82\xc4\x3e\xa5\xab\x7b\x6f\xa8\xfc\x23\xea\x64\xad\x4f\x69\x6f\x9f\xbb\x95\x27\x78\x54\x03\x67\x31\x69\x95\xfc\xf2\xa6\x5a\x39\x34\x37\xd2\x46\x7e\xd8\x8a\x32\x32\xad\x43\x42\xda\x6e\x4e\x83\xf1\xad\xf5\x67\xc5\x8e\x57\xfc\xdc\x46\xb4\x84\x24\x4a\x11\x1e\x21\xde\x9e\xfc\x6f\xd3\x32\xcb\x50\x34\x40\x70\xdb\x79\x73\xf5\x98\xf9\x1c\x21\x45\x78\x32\x0c\x4c\xd2\xed\x77\x69\x7e\x20\x0e\x19\xfa\x8d\x44\x57\x70\x76\xde\xc8\x6f\x1b\xeb\xc9\xa6\x5c\xc9\xe8\x67\x15\xb9\xd5\x39\x48\x4b\x62\xd1\x0c\x1e\xe2\xdb\x1f\x3e\x68\x43\xfc\x4f\x8f\x6f\x85\x82\x37\xfe\x17\x4a\xb7\xab\xb6\xa7\xd1\xf6\x9e\x7b\xf6\xff\xeb\x3e\x24\x5d\x85\xcd\x63\x4a\x3c\x49\xb2\x47\x10\x1e\xb1\xdf\x06\xb0\x6a\x7a\x76\x48\xaa\x07\x39\x23\xff\x56\xdf\x59\x82\x1a\x67\x35\x4f\x62\x48\x7d\xe7\x28\x94\x91\x2c\xc3\x36\x6e\xd1\x61\xa6\xf3\x4a\xd7\x85\x71\x75\x35\x70\xb5\x38\x7f\x6b\x95\x4d\x61\x63\x48\x88\x2b\x17\x7b\xf9\x30\xf6\xc7\xa0\x01\x9e\xa3\xe5\x66\x66\xeb\x6f\xc2\x56\x5d\xb3\x2b\x1d\x31\x86\x0f\xf4\xcd\xba\x0b\x9f\x66\xd0\xe5\xd5\x3e\xaf\x7a\xb6\xcf\x4b\xec\x9f\x37\x5a\x6a\x9c\x86\x5a\x86\x5a\xfe\x03\x2b\x44\x4d\xa2\x4a\x10\x00\x00")
+
+func yaoDataKbProvidersFetcherCrawlerZhCnJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoDataKbProvidersFetcherCrawlerZhCnJson,
+		"yao/data/kb/providers/fetcher/crawler/zh-cn.json",
+	)
+}
+
+func yaoDataKbProvidersFetcherCrawlerZhCnJson() (*asset, error) {
+	bytes, err := yaoDataKbProvidersFetcherCrawlerZhCnJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/data/kb/providers/fetcher/crawler/zh-cn.json", size: 4170, mode: os.FileMode(420), modTime: time.Unix(1792287496, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _yaoDataKbProvidersFetcherHttpEnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\x5f\x53\xdb\xba\x13\x7d\xe7\x53\xec\xe4\xa5\xd0\x09\x89\x43\x1a\xf8\x95\x4c\x1f\x28\xfd\x41\x7b\x4b\x68\x87\x84\xd2\x3b\x77\x3a\x19\xc5\x5e\xc7\x2a\xb2\xe4\x4a\x32\x49\x7a\xa7\xdf\xfd\x8e\x24\xc7\xb1\x83\x63\x02\xb4\xc3\xf0\x4f\xda\x5d\x9d\x73\xbc\xde\xdd\xe8\xdf\x1d\x80\x06\x0d\x1a\xc7\xd0\x18\x8f\x17\x44\xb4\x22\xad\x93\x46\xd3\xac\x6a\xaa\x19\x9a\x8d\xf7\xa3\xd1\x67\xb8\xc1\x09\x9c\xa1\xf6\x23\x94\x6e\x3b\x40\xe5\x4b\x9a\x68\x2a\xb8\x31\x72\x7b\x0a\x7c\xc1\x35\x72\x0d\xa1\x14\x31\xcc\x70\x02\xd7\x57\x17\x0a\x52\x45\xf9\x14\x4c\xa0\xb6\xf9\x31\x84\x44\x0a\x2d\x7c\xc1\x54\x0b\x86\x69\x92\x08\xa9\x15\xf8\xa9\xd2\x22\x86\x08\x49\x80\x52\x35\x21\x55\x28\x81\x4c\x4d\x34\x5f\xf0\x90\x4e\x53\x49\xcc\x71\x4d\x20\x3c\x00\x4d\x63\x14\xa9\x06\x85\x5a\x53\x3e\x55\x10\x0a\x09\x12\x19\x25\x13\x86\xf6\xe4\x25\x14\x89\x5a\x52\xbc\x23\xac\xe5\x90\x4b\xfc\x91\x52\x89\x86\xf4\x3f\xdf\xec\x4a\x22\x45\x82\x52\x53\x54\x8d\x63\x30\x92\x00\x34\x32\x18\xf9\x82\x51\x64\x91\x58\x41\xc4\xe4\x3b\xfa\xda\x06\x73\xeb\x4b\xa5\x4e\x1d\x83\xf7\x99\x6b\x6e\xb0\xa6\xd5\xd2\xcc\xe8\x9a\x1d\x03\x5a\x00\xe5\x3e\x4b\x03\x84\x19\xd5\x11\x18\x90\xa8\xb4\x6a\xad\xa2\x14\x70\x87\x84\x29\xcc\x37\x7c\x11\x27\x82\x23\xd7\x26\xf8\x25\x2a\x8d\xc1\xca\x6b\x46\x03\x1d\x99\x8d\x30\x65\x6c\xb5\x2c\x64\x80\xb2\x71\x0c\x9d\x7c\xa5\x42\x04\xbb\x7e\x92\xea\x48\x48\xfa\x93\x64\xf0\x57\x5b\x05\x49\x94\x96\x94\x4f\xf3\xf0\x65\x59\xca\x11\x4a\x36\x6b\xca\x94\x2c\x33\x6d\xec\x83\x25\xa9\x8e\x90\x6b\xea\x13\x8d\x41\x85\x38\x59\xb0\x90\xa4\xcc\xaa\x50\xde\x28\x09\xf4\x81\x27\xa9\x2e\xef\x27\x8c\xf8\x18\x09\xe6\x24\x69\xbc\x45\x22\x51\x82\x16\xb7\xc8\x41\x48\x78\x4b\x14\xf5\x2d\x84\xb2\x5b\xb5\xb4\x65\x79\xf3\xc5\x5f\xcd\x82\xa4\xbe\x8f\x89\x7e\x9a\x96\xce\xb5\x56\x44\x6b\xb2\x54\x4f\x0b\x50\x09\xfa\x34\x5c\x40\x22\x31\x44\x29\x31\xc8\x5f\x0d\x73\x64\x8d\x8a\x2f\xdb\x2f\x6b\x84\x1c\x22\x2b\xbe\x06\xd6\x00\x79\x1a\x9b\xf7\xaa\xb0\x06\x25\x96\xd6\x8a\x91\x09\x32\x0b\x95\x31\x18\x19\x0c\xa5\x28\xd6\xe6\x8e\xb0\x14\xab\x40\xd4\x50\x26\x7c\x51\xa2\x56\xe5\xb7\x24\xa7\x65\x8a\xa5\xdd\x5f\xcd\x2d\x41\xbf\x1f\x0d\x2e\x6a\xf0\x6a\x9c\xeb\x76\xa4\x63\xf6\x20\x6a\x13\x08\x02\xe1\xa7\x31\x72\xad\x40\x70\xb6\x68\x3c\x0d\xd2\x5f\xc3\x4f\x97\x35\x90\x48\x92\x30\xf3\xea\x50\xc1\xdb\xdf\xd5\xda\x3b\x58\x85\xcc\xc4\x83\x80\x68\xf2\x1c\x50\x5f\x6b\x65\x2a\x62\x9a\xc7\xac\x69\x65\x9b\x6f\xa1\xda\xd7\xa2\x68\x4f\x84\xf6\x99\x11\xca\x61\x84\x73\xfd\xd0\x83\x4c\x8c\xe5\x83\x98\x5c\x3c\xe3\xb0\xcc\xbf\x35\x60\x85\xff\xbe\x55\xd7\x90\x88\xb0\xb0\xba\x86\x1c\xd4\xd4\x90\xfd\x0b\xc2\xa7\x29\x99\xe2\x33\x8a\x09\xe4\x31\xea\xaa\xca\xe7\xbc\x78\xb0\xcc\xdc\x16\xe6\x8c\xee\xe6\x1a\x82\x7c\xff\x7a\xd8\x44\xde\xff\xf1\xc6\x6b\xbd\x7e\x56\x5d\x2e\x87\x6a\xfe\x8c\xf6\x4f\x2f\xed\xdf\xff\xdb\x50\x97\x37\x6b\xda\xad\xd4\xf4\xca\x50\xb4\xfb\x8f\xd7\x72\xe9\x5b\xa7\x61\x66\x53\x6c\x6c\x3a\xc2\x65\x3b\xfb\x43\xdd\xcc\x4c\x74\xea\xb8\xdd\xc6\x39\x89\x13\x86\x2d\x5f\xc4\x8f\x6e\x63\xaf\x2a\xe5\x3a\x15\xe2\x96\x3e\x2d\xf3\x32\xd7\x3a\xb1\x9c\x49\x51\x2b\x85\x4a\x99\xb9\x20\x26\x9c\x4c\x31\xae\x4d\xbb\x67\x49\x96\x1d\xf4\x86\x4c\xfc\xce\x41\xb7\x9f\x35\x4e\xe4\x3e\xbe\x61\x74\x1a\xe9\x47\xeb\xd7\x5b\xe9\xb7\x53\xfc\x9d\xa9\xd9\x30\xa3\xee\xd8\x8e\xba\x55\xf3\xe6\x9a\x92\x2b\x15\xaf\xcd\x84\x7c\x62\xdd\x36\xcd\x9a\xd7\xab\x21\xda\x85\xb1\x93\x66\x60\x66\xa9\x70\x61\xb3\x2f\x74\x53\x7d\xab\x18\x22\x97\xf1\x5c\x92\x24\xba\x3a\x39\xdf\xcf\x66\xff\x76\xa7\xe5\x35\xaa\x07\xcf\xb5\x71\xe0\xfe\x28\x50\xca\x92\xbc\x14\xbf\x73\x87\xc1\xf2\xa4\xb2\x80\x79\x31\xae\x05\x52\x45\x7c\x3d\xee\x92\x67\xe1\x63\xc5\xa6\xe4\x29\x8d\x06\x85\x84\xaf\x26\x70\x1a\x49\x11\x23\xbc\x43\x75\xab\x45\xb2\x01\xfe\x40\xfc\xa4\x8c\x91\x76\xaf\xe5\xc1\xee\x0d\xe5\x81\x98\x29\xb8\x1c\x41\xc7\x6b\x79\x7d\xb8\xa1\xfc\xf0\x55\x1f\xe6\x87\xaf\xf6\xe0\x24\x49\x18\xde\xe0\xe4\x23\xd5\xed\x5e\xf7\xa8\xd5\x3d\x84\xdd\x8f\x66\x52\x68\x02\xa3\xb7\x08\xe7\xe8\xdf\x8a\x3d\x70\xa7\xb6\x3b\x07\x5e\xcb\x7e\xc1\x90\x84\x44\xd2\xcc\xa5\xfe\xc5\x72\x80\x27\x52\xcc\x8c\x14\x82\x43\x06\xa8\xb1\x3d\xe9\x33\x2a\x31\x14\xf3\xdf\xc4\xba\x0f\xf2\xee\xd8\x52\xd9\x73\xfc\xda\x07\x5e\xc7\xf3\x3a\x5e\x07\xb2\x83\x1c\xd1\x5a\x5a\x4b\x48\xcf\xe2\xe5\x54\x84\x98\xf8\x9f\x86\xdb\x90\x1a\x10\x9f\x72\x2d\x54\xd4\x87\x0f\x5c\x23\x83\x01\xf1\xe1\xd3\x10\xbe\x42\xc7\x1b\x77\x7a\xe3\xa3\xad\x1f\xe8\x17\x94\xa6\xe6\xb4\x3b\x47\x8f\x7a\x98\x19\xe0\x02\x69\x87\x7d\x7b\xca\x03\x31\xa1\x0c\xb3\x84\xda\x86\xf3\x05\xe5\xe9\xbc\x0f\x27\x3c\x90\x82\x06\xd0\xf1\xfa\x30\x1c\xec\x9f\xbf\x3e\xea\x9e\x3d\x3d\x7d\x33\x14\xcf\xc9\xe2\x0c\xd0\x23\xa8\xbb\x0f\xe2\x1b\x38\xd7\x9e\xfe\x7f\xae\x51\x2e\x6f\x2c\x0a\x17\x15\x13\x64\x62\xd6\xb8\x57\xed\xbf\x6d\xfb\x71\xfc\xa0\xd4\x16\x5c\xfc\xf1\x13\xbb\x43\x76\xcd\xb0\x45\x93\x38\xbd\xc7\x23\xeb\x15\xbb\xa9\xc2\x00\x66\x11\x72\x78\xe1\x8c\x5e\x00\x55\xa0\x6c\xa9\xc7\x00\xc8\x44\xdc\xe1\x5e\x75\xeb\xd8\xd0\x26\xca\xdd\x77\xbd\xf3\xfe\x2d\xd2\x2a\x59\xd7\x39\x3e\xa0\x62\xb7\xa4\x62\x76\x53\x54\xa5\x1d\xe5\x1a\xa7\x85\xa1\x6d\x25\xde\x28\xbb\x5e\xda\x55\xe8\x0b\x1e\xa8\xbd\x8d\xe2\x5d\xb9\xe9\x2d\xbf\x90\xa2\x1c\x32\x9f\x4a\x5d\xba\x9e\x97\xaf\xc6\x94\xd3\xd8\x76\xc9\xde\x6a\x8d\xcc\xb3\xb5\xee\x61\xc1\xf4\xbe\x86\x97\x69\x3c\x29\x42\xaf\x9e\x7a\xd7\x46\xb8\xa5\x26\xa1\x60\x4c\xcc\xc6\x12\x03\x2a\xd1\xd7\x95\xd7\x5c\x13\x21\x18\x12\x5e\x21\xce\x99\xf5\x86\xab\xdc\x7b\x93\x36\x27\xa9\x16\x31\xd1\xd4\x27\x8c\x2d\xc0\x1d\xea\xee\xbd\xf2\x93\x2b\x45\x32\x1d\x78\xc3\x94\x31\xa3\xda\x8f\xb6\x66\xdd\x2b\xb1\x8e\xc9\xbc\x9e\xf2\xe6\x7c\x18\x90\xf9\x16\x7c\x07\xee\xe1\x01\xb7\xcf\x06\x44\xb8\xe2\x69\xe6\x2e\x27\x40\x25\xe3\x4e\x55\x56\x78\x15\x59\xd1\xfb\x8d\x39\x71\x58\x52\xe7\x0e\x25\x0d\x17\x63\xa5\xd8\xe3\xb2\xe1\x8b\xf5\x83\xe1\xf0\x62\xa3\x2e\x2b\x13\xf0\x51\x6a\x1a\xda\x8b\x3c\x77\x67\xeb\x2e\x84\x2b\xee\x3b\xff\x40\x3a\x1c\x39\xc2\x3b\xe6\xfb\xd7\xce\x7f\x01\x00\x00\xff\xff\xe6\x1a\x63\xf0\xfd\x16\x00\x00")
 
 func yaoDataKbProvidersFetcherHttpEnJsonBytes() ([]byte, error) {
@@ -2343,6 +2393,86 @@ func yaoDataKbProvidersFetcherMcpZhCnJson() (*asset, error) {
 	return a, nil
 }
 
+var _yaoDataKbProvidersRerankerLlmEnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa5\x95\xdf\x6f\xda\x30\x10\xc7\xdf\xf9\x2b\x4e\x79\xa6\x08\xd6\x56\x9a\xf6\xb6\x6e\xda\xd4\x09\xda\x6a\x54\x7b\x19\x15\x3a\x92\x83\x78\x75\xec\xd4\x76\x80\xac\xe2\x7f\xdf\xd9\x09\x21\x6d\xa1\x02\xed\x2d\xb9\x5f\xb9\xfb\xe4\x7b\xf6\x73\x07\x20\x12\x49\xf4\x09\xa2\xe9\xb4\x44\xdd\x93\x32\x8b\xba\xde\xe8\x84\x93\xe4\xed\xc3\xe1\x08\x7e\x92\x41\xf5\x48\xa6\x72\x25\x64\x63\x23\x72\x27\xb4\xf2\x01\x95\xd3\x82\x4b\x09\x2c\xa1\x89\x53\x30\x64\x0b\xe9\x2c\xcc\x4a\x40\xfb\x28\xd4\x02\x10\xe2\x14\x1d\x64\x3a\x21\x09\x4e\x83\x8d\xb5\x21\x20\xe4\xe0\x1c\xad\xc5\x05\x01\x2e\x50\x28\xeb\x42\x9d\xa7\x82\x4c\xd9\x83\xb1\xd4\x2b\x32\x6c\x41\xe5\x2b\x18\x6d\xed\x19\xa9\x98\x8b\x98\x2e\x08\x07\x2b\x6d\xf8\xc3\x2b\xe1\x52\x40\x55\xc2\x6d\x4e\xea\xf3\x35\xc4\x3a\xcb\xd1\x89\x99\xa4\xd6\x47\x7b\x55\xef\x86\x9e\x0a\x61\xc8\x4f\xfc\x3b\x8a\xb5\x52\x14\x3b\x6d\xa2\x87\xe0\xcc\x8d\xce\xc9\x38\x41\x96\xdd\xcf\x6c\x61\xdb\x2e\x66\x6b\xf2\x70\xca\x3c\xb0\xb1\xce\xf0\x70\xa1\x72\x65\xdf\x42\xfb\xd2\x64\x35\xbe\x57\xd4\x9a\x5e\xeb\x40\xd0\xf3\x30\x79\x1b\x93\x7f\x0c\xa0\x2a\xb8\x35\x28\xdb\x6b\x17\x9d\x23\x93\xf6\x05\x77\x46\x3f\xbf\x56\xa4\x82\x79\x4c\x92\xeb\xef\x9c\xa4\x8a\xcc\x0f\x5f\xbf\x43\x33\x54\xf0\x4a\x9c\x91\xf4\x69\xdf\xef\xee\xcf\x2e\x34\x8c\x84\x12\x4d\x6e\x88\x58\xa2\x2c\xc2\x88\x8c\x4a\xa1\xe8\x2d\x72\xc7\x81\x67\xd9\x9b\xc0\x57\xf3\x7e\x43\xfe\xb5\xa8\x12\x20\x1e\x59\x67\x22\x46\xd9\x85\x85\xd6\x09\xcc\x79\x78\x3f\x65\x9b\xe4\xab\xe1\x9c\x29\xa8\xf1\x6c\xba\x47\xf5\x7e\x4c\xdb\xef\x76\x7c\x45\xce\xb1\xf8\xfe\x14\xc9\x82\x32\xa6\x09\x5a\x05\x69\x49\x5a\x07\x7d\x7a\x99\x1c\xdf\xd4\x57\xa2\x7c\x4c\xf4\x08\xbf\xce\x0f\x74\x96\x70\x84\xe5\x88\xde\xf2\xfc\xdd\xbe\x5a\x95\x2a\xa1\xb4\xba\xa8\x9f\x1e\x9a\xdf\xbd\x12\x89\x4b\x7d\xd6\xbc\x90\x72\xa7\x02\x6d\x78\x85\xd8\x3c\xe8\xb4\x9a\x8f\xaa\x6a\xa7\xe8\x7c\x14\x32\x0e\x6a\x7c\x49\xc6\x88\xa4\x96\x6f\x25\xea\xad\xcc\xb7\xba\x3f\x49\xcc\xd7\x2a\x2f\x5a\x5a\xce\x25\xc6\x94\x6a\x59\xcd\x12\xed\x93\xe2\x0e\x40\x8a\x72\xfe\x16\xc0\x87\x17\x00\x66\xe8\xe2\x74\x6a\xc5\x5f\xda\x47\x41\x28\x47\x0b\x32\x7b\x30\x5c\xf9\x3c\x18\xfb\xbc\x43\x2c\x6e\x8a\x6c\x46\x61\xcb\xb7\x6b\x5c\xad\x76\x12\x0e\x49\xf0\xe7\x12\x59\xb7\x97\xc6\xa0\xdf\x18\xfd\x6c\x59\x58\xe0\xc1\xce\x86\xeb\xda\x76\xd9\x7f\x07\x5b\xd5\xc0\xd1\x60\xce\x5f\x2a\x03\xd7\x53\x49\x6a\x11\x32\x4e\x00\x33\xc2\x35\xdc\xd5\xe7\xfb\xb0\xca\x3f\x04\xe8\xbe\x75\xc2\x01\xf2\xe5\x10\x17\xce\xdf\x14\x2e\x15\x16\x54\x43\x8f\xcf\x47\x83\x31\x2f\xa6\x3d\xc0\xaa\xbf\x97\x56\xdb\xda\xf0\xfa\xd8\x0e\xfe\x6f\x62\x17\x2f\x88\xf1\x5d\x92\xe5\xee\xb4\x4b\xa3\xb0\x4e\x67\x70\x57\x65\x1e\xe2\x34\x2e\xad\xa3\x0c\xaa\xfa\xdb\x75\xaa\xcf\xcf\x1e\x78\x8a\x86\x72\x59\x42\xc6\xd5\x60\xc6\xd7\x2a\xfc\x18\xdf\xde\x30\x51\x83\xa5\x0f\x7f\x9e\xf0\xff\x4a\x68\x3d\x89\xba\x30\x89\x82\x06\x27\xd1\xa6\xba\x44\xeb\xcb\x66\xce\xb5\xa1\xef\xe1\x0f\xfa\x27\xed\xe7\x3d\xad\xdd\x67\x43\x78\x70\x45\x87\x84\x4b\xbe\xf6\xb9\xf3\xd2\x97\x2f\x2c\x85\xf6\xeb\xca\xdb\x31\xea\xe1\xa2\x63\x4f\xb1\xcb\xce\xf6\xf0\xdb\x74\x36\x9d\x7f\x91\x69\x1f\x72\xd4\x08\x00\x00")
+
+func yaoDataKbProvidersRerankerLlmEnJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoDataKbProvidersRerankerLlmEnJson,
+		"yao/data/kb/providers/reranker/llm/en.json",
+	)
+}
+
+func yaoDataKbProvidersRerankerLlmEnJson() (*asset, error) {
+	bytes, err := yaoDataKbProvidersRerankerLlmEnJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/data/kb/providers/reranker/llm/en.json", size: 2260, mode: os.FileMode(420), modTime: time.Unix(1792286672, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoDataKbProvidersRerankerLlmZhCnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa5\x55\xdf\x4f\xda\x50\x14\x7e\xe7\xaf\x68\xfa\xac\x06\xa7\x26\xcb\xde\x4c\x96\x2c\x2e\x99\x2e\xd1\xec\x65\x1a\x53\xe1\x2a\xcd\x4a\xdb\x95\xd6\xe9\x8c\x89\x23\x8a\x98\x0d\x30\x53\x99\xbf\x15\x83\x4e\x9d\x02\xcb\x34\x13\xaa\xe1\x8f\x19\xf7\xb6\x3c\xed\x5f\xd8\xb9\x2d\x94\x8a\xa0\x98\xbd\xd1\x73\xbe\x73\xf8\xbe\xef\x9e\x73\xef\x8c\x87\x61\x58\xde\xcf\x3e\x63\xd8\xd1\xd1\x69\x4e\xea\x10\x84\x20\xdb\x46\x83\x2a\xaf\x0a\x88\xc6\x71\xfa\x3b\x39\x4e\xe1\xdd\xcf\xe5\xc5\x18\x89\x7f\xc5\x85\x84\x0d\xf0\xa3\x90\x4f\xe1\x65\x95\x97\x44\x0a\x33\x33\x27\x38\x9b\x37\xb3\x3b\x36\x98\xec\xe7\x49\x2c\x43\xf6\x0e\xcd\xec\x41\xe9\xaa\x40\xb2\x89\xd2\xd5\x29\xc9\x5c\x9a\xcb\x37\x64\x69\x05\x47\x23\x7f\xaf\xbf\x00\x9e\x2c\x6f\x1b\x17\x07\x86\xbe\x42\x76\xb7\x69\xff\x64\xce\xfe\x8b\x3f\x73\xe1\xf2\xdc\x1e\x2e\x1c\x91\x85\x83\x52\x21\x5e\x2a\xa4\x71\x62\xc9\xb8\x4e\x1a\xfb\x9f\xf0\xc6\x31\xd4\x96\xe7\xc2\xc6\xea\x31\x4d\xe9\x7a\xe9\x66\x8d\x19\x90\x91\xd8\xdb\xc7\xe0\x85\x6b\x9c\xc9\x1b\x9b\xf3\x6e\x2e\xd0\xcc\xa6\xac\xa0\xf7\x1a\xaf\x20\x2a\xf7\x2d\xeb\x93\x44\x11\xf9\x54\x49\x61\x47\xac\xa4\xac\x48\x32\x52\x54\x1e\x85\x20\x3d\x03\x11\x88\xd5\x30\xd5\x10\x75\x66\x5a\xb6\x8c\x09\xa9\x0a\x2f\x4e\x58\x9d\xed\x78\xd5\x31\xb3\xb8\x4b\xe2\x87\xc0\xb3\x96\xab\x33\x8b\x3a\xe2\xf2\xa2\x8e\x2f\x7c\x56\xf5\x38\xad\xaa\x1a\x2a\xdd\xc6\x39\x4d\x50\x69\xa7\x5a\xd0\x27\x05\x65\x49\x44\xa2\x15\x1e\x44\x02\xf0\xae\x25\x91\xa8\x05\xa9\xea\xca\x37\xe3\xa8\xb1\xb2\x02\x37\x86\x04\x5a\xf6\xe2\xf5\x50\x7b\xb7\xc4\xbc\xe2\x45\xde\xa9\xb5\x10\x93\x9c\xa0\x59\xda\xc0\x23\x91\xe3\x3b\x26\x64\x15\x80\xed\xc1\x3b\xc0\x3a\xa1\xb8\xf8\x03\xce\xb1\x74\xb5\x6a\xe8\x09\x72\x19\xb7\x0f\x0e\x2f\x47\x6d\xdd\xf5\xa5\x55\x55\xaa\xa2\x21\x27\x33\xdb\xd6\x12\xe9\x56\xf8\xde\x4f\x35\x9b\xc7\xe9\x18\xd9\x09\xdb\x43\x4b\xcf\x24\x9a\x26\xc9\x73\xb2\x75\x81\x17\x23\x46\x2a\xc3\xb6\x4e\xe9\x39\x42\xf2\x20\x42\xef\x98\x37\x5d\x4d\x78\xf9\x01\x11\x02\x44\xc7\x64\xd7\xbd\xac\x5c\x9d\x18\x7b\x3a\x5c\x34\x2a\xbf\x46\x9c\x63\xfe\xc0\xfb\xd5\x00\x2d\x1b\xd7\x04\xa1\x76\xfa\x92\xe2\x47\x74\x84\x3b\x3d\x2e\xf6\x6c\x50\xf2\x5b\x6c\x5b\x1f\xec\x0a\x81\x66\x53\x6d\x1e\x45\x8c\xad\xa4\x33\xb2\xe0\xe0\xed\xfd\x6b\x71\x76\xfb\x44\x59\x73\x8d\xae\x2c\x70\x3e\x14\x90\x04\x5b\x02\xdb\x68\xf2\x6a\xba\x03\x9c\x30\x7e\x57\xf7\x93\x5b\xba\xc7\x38\xd5\x17\x18\x0d\xf1\x1f\x51\x23\xf1\xbc\xa8\xa2\x09\xa8\x6a\xa0\x7e\x29\x4f\xce\x52\x70\x1d\xe2\x5c\xa2\xa9\x07\x70\xd1\x01\xc8\xcc\xfe\x26\x3f\xc3\xce\x72\x57\x76\x7d\x2d\x57\x5e\x4c\x34\x31\xa3\xd3\xeb\x04\xa9\xb4\xa0\xb5\xae\x9d\xb5\x18\x37\x55\x89\xf5\x78\xef\x71\xad\x5f\x0b\x8e\xb9\xb9\x3f\xe0\x4b\xd7\xed\x79\xe0\xa6\x46\x05\x24\x4e\x58\x15\x8f\xf1\xc5\x56\xb7\x3d\x07\xd6\x94\xd7\x8a\x70\x69\x37\x77\xc7\x86\x46\x4f\x61\xaf\x70\x34\x47\xce\xd3\xf8\xfc\x9b\x71\x76\x04\xd6\x34\xf5\xc5\xdb\xd0\x19\x77\xd4\xf1\xe6\xa9\x1b\xfc\xdf\xee\x74\xdf\x72\x07\x9e\x87\xa0\xac\x3e\xee\x1d\x58\x3c\xc5\x99\xcd\x52\x7e\x89\x24\x96\x8d\x74\xc1\xcc\xc6\x9a\x1b\x53\x1d\x15\xe3\x97\x6e\xe8\x7b\x4e\x01\xb8\x82\xb7\x76\xe1\x5e\xc2\xc5\x85\x72\x4a\x27\xeb\x59\x66\x66\x18\xce\xc2\x8f\xa6\x86\xd9\x36\x66\x98\x0d\xf9\x24\x05\x0d\xb3\xb3\x0c\x7d\x34\x5e\x0e\x0e\xf4\x33\xe0\xa5\xa1\xcf\xd3\xe7\x35\x1a\x81\xdf\xf0\xd4\x30\x5e\x06\xcc\x06\xcf\x1e\xb9\x88\x43\x68\x4a\xed\x55\x10\xd7\x74\x17\x8d\xb5\x0d\xe3\xa4\x50\xba\x29\xc2\x53\x5c\xd6\xd7\xcd\x4c\xda\xd6\xd1\x40\xef\x03\x37\x53\x8f\xa7\x7a\xa1\xcd\x7a\x66\x3d\xff\x00\x2a\x53\xe2\x3a\x96\x08\x00\x00")
+
+func yaoDataKbProvidersRerankerLlmZhCnJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoDataKbProvidersRerankerLlmZhCnJson,
+		"yao/data/kb/providers/reranker/llm/zh-cn.json",
+	)
+}
+
+func yaoDataKbProvidersRerankerLlmZhCnJson() (*asset, error) {
+	bytes, err := yaoDataKbProvidersRerankerLlmZhCnJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/data/kb/providers/reranker/llm/zh-cn.json", size: 2198, mode: os.FileMode(420), modTime: time.Unix(1792286672, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoDataKbProvidersRerankerOpenaiEnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x95\xdf\x6f\xda\x30\x10\xc7\xdf\xf9\x2b\x4e\xd1\x1e\x36\x29\x40\x7f\x6c\x7d\xe8\x5b\x5b\xb1\xad\x6b\xe9\x26\xba\x4d\x9b\xa6\x0a\x39\xc9\x41\xbc\x3a\x76\x6a\x3b\x30\x54\xf1\xbf\xef\xec\x84\x04\x28\xb4\x20\xf5\xad\xfd\xde\x9d\x7d\xf7\xc9\xd7\xc7\x63\x0b\x20\xe0\x49\x70\x0a\xc1\x70\x38\x63\xaa\xa3\x72\x94\x8c\x07\xa1\xd3\x2d\xb7\x02\x5d\xe8\x2b\x89\x67\x97\x70\xa1\xb2\x9c\x59\x1e\x09\x84\x01\x6a\x26\xef\x51\x97\x89\x09\x9a\x58\xf3\xdc\x72\x25\x5d\x7a\x19\x34\x60\x53\x04\x83\x4c\xc7\x29\x68\x34\x85\xb0\x06\xa6\xdc\xa6\xc0\x20\xd6\xca\x98\x36\xca\x58\x25\xa8\x29\x47\x4f\x30\x81\x68\x06\x4c\x42\x75\x57\xdc\xdc\xd5\xd5\xfe\x3c\x38\xfb\x76\x19\x82\x29\xe8\x34\x66\xe0\x0b\x97\x2c\xa4\x8e\x52\xd4\xb8\x9c\x3c\x66\x16\xa7\x6c\x66\x42\x98\x5c\x5f\xf7\x43\xf8\xc5\xe5\x88\x52\x64\x8c\xa0\x34\xdc\x72\xc1\x63\x25\x3f\x0a\x35\xed\x94\xad\x6b\x7c\x28\xb8\x46\x47\xe0\xcf\x9d\x57\x72\x4d\x0c\xb4\xe5\x68\x48\x7b\x24\x85\x34\xaa\x91\x18\x5b\xa5\x6b\xc9\xe1\x99\xe5\x9e\x8e\xb1\x9a\xcb\xb1\x3f\xae\xd4\x17\xd8\x2e\xea\xaa\x3a\xb6\x46\xaa\x1e\xb6\x4a\x04\x35\xf2\xd4\x96\x27\xe6\x84\x2d\x55\xc6\x86\x70\x8f\x8e\x50\x02\x19\x51\x13\xc0\x68\xf0\xc2\x10\xb7\x69\x8a\xd2\x55\xcd\xbc\x24\x95\x25\xa0\x16\x22\xac\x87\xac\x6e\x1e\x31\xfa\x04\xee\xd6\x46\x74\xe0\x94\x44\xe9\xe5\x4b\x99\x17\xb6\x89\xe5\x82\xc5\x98\x2a\x41\x5f\xc8\x45\x4b\x63\x74\xca\xce\x9a\xac\x29\x4f\x6c\xea\xe2\xa3\x42\x88\x46\x56\xba\x2c\x3b\xf4\xc2\xbc\xd4\x03\x37\xc6\x5e\x04\x09\x00\x7c\x76\x45\xdb\x00\x9e\x33\x83\xf0\x63\x70\xbd\x09\x5c\x77\x72\x08\xdc\x00\x4b\x12\x82\x64\x15\xd9\xce\xdd\xef\x2d\xa8\x0a\x0b\xe4\x98\xf4\x15\xf9\xa4\xd6\xe6\xe6\xb4\xdb\x65\x39\xef\xfc\x25\x73\x76\x18\xa7\x06\x76\x06\x75\xb4\x02\x8a\xbe\xf4\xde\x9c\xae\xa8\x66\x1b\x26\x17\x77\xee\xd9\x40\xc9\xd0\x74\xee\x41\x31\x72\x0c\xf9\x47\x13\xa9\x7b\x94\xaf\x08\xe6\x4d\xef\xe6\x67\x67\xd0\x1b\x9c\xdd\x5c\x0d\xe9\xc6\xe1\x55\xef\xf7\xce\x54\x8e\x57\xa8\x78\xdf\xef\xc5\xa5\xef\x2b\xb6\x51\x29\xf7\x14\x55\x96\x2f\x6a\xaf\x99\x6f\x51\xd0\x8b\x6d\x82\x28\x8b\xcc\xed\x90\xea\x7f\xa8\x9b\xf4\x51\xc1\x22\xdf\x79\x70\xfe\xa9\x57\xef\x4e\x98\x1c\x41\xff\xb8\x3e\xc2\x27\x4e\x98\x28\x7c\xe7\xd1\x18\xdb\xba\x4a\x6c\x4f\x8e\xda\xd9\x5a\xe2\xda\x28\x7d\xea\x96\x76\x9b\x1c\x17\x4c\xac\x6e\x57\xf7\x85\xc5\xc8\x5b\xdf\xed\x0a\xb7\x7f\xdd\x66\x74\xdb\xb0\xd9\x8d\xeb\x67\x2f\xa6\xb7\xba\xc0\x3a\x32\x0f\x5f\x18\xce\xed\xe4\xe5\xe9\xb6\x8c\xe6\x5e\xc7\xca\x6c\x11\x3d\xe2\x76\xb6\x34\xc1\xee\xa3\x2e\x8e\x59\x38\xdb\xb7\x40\x2e\x0b\x76\xef\xfa\xa2\x30\x56\x65\x5b\x7a\x7d\xbe\x13\xbf\x87\xab\x9b\x9b\x9f\x88\xe6\xea\xea\xaf\xbb\xa7\x76\x4f\x99\x18\x3d\xb5\xfb\xfb\x15\xbb\x5b\x9e\x21\x2d\xaa\x4d\x86\xe7\xd2\xe2\x18\xf5\x06\xc7\x7f\x2f\x8b\xe0\xad\x41\xea\x28\x31\xef\x9e\x71\xff\x43\x81\xb4\x0f\xab\x6b\x80\x4b\xa8\x6a\x36\x3e\x84\x93\x83\x5a\xcc\xb8\xe4\x99\xb7\xfb\x61\xa3\xb1\x7f\x95\x76\x72\x70\xf0\xcc\x9a\xb8\x29\xb2\x68\xb9\xf1\x17\x88\x7c\x68\x2d\x40\xce\x5b\xf3\xd6\x7f\x02\x77\x4a\xd0\xae\x08\x00\x00")
+
+func yaoDataKbProvidersRerankerOpenaiEnJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoDataKbProvidersRerankerOpenaiEnJson,
+		"yao/data/kb/providers/reranker/openai/en.json",
+	)
+}
+
+func yaoDataKbProvidersRerankerOpenaiEnJson() (*asset, error) {
+	bytes, err := yaoDataKbProvidersRerankerOpenaiEnJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/data/kb/providers/reranker/openai/en.json", size: 2222, mode: os.FileMode(420), modTime: time.Unix(1792286676, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoDataKbProvidersRerankerOpenaiZhCnJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x55\x5d\x4f\x1a\x41\x14\x7d\xe7\x57\x4c\x48\x1f\x05\xbf\xda\x3e\xf8\xa6\x8d\x6d\xac\x1f\x6d\x34\x6d\xda\x34\xc6\x2c\x30\xc2\xd4\xfd\xea\xb2\x8b\x35\x86\x04\xad\x56\x51\x2a\x4a\x1b\x8d\x96\x5a\x6d\xb5\x25\x36\x15\xdb\x1a\xad\x02\xfa\x63\x64\x76\x97\x27\xff\x42\x67\x76\x81\x15\x10\x85\xc6\x84\x04\x38\x77\xee\xde\x7b\xce\xdc\x7b\x76\xdc\x06\x80\x1d\x79\xec\x6d\xc0\x3e\x34\x34\xc6\x08\x4e\x41\x84\x3c\x83\xec\x0d\x14\x97\x91\xcc\x42\x1a\x7a\x44\xc0\xf6\x2e\x80\xa7\xd3\x78\xf7\x28\x37\xf3\x4e\x5d\x88\xe1\xe3\xa8\x79\xc8\x03\xfd\x6e\x09\x89\x32\x12\x78\x7a\x34\x9b\x39\xd5\x3e\x24\x40\x49\x86\xb6\x36\x05\x1a\x25\x28\x31\xfc\x08\x50\x17\xb6\x71\xf4\xab\x1a\x5d\xcc\x9e\x7c\x24\x78\xf6\x78\x0b\x47\xc3\x5a\x7a\x59\xdb\x98\xc0\xab\x09\x9c\x3c\x52\x17\xe3\xda\xfe\x17\x2d\xf5\x5e\x5d\x8f\xd3\x52\xcb\x7b\x66\xb5\xf3\x74\x24\x7b\x32\x8f\xbf\x4d\x82\x87\x88\x67\xce\x42\x13\xf7\x04\x1f\x94\x60\xa1\x44\x66\x09\x4f\xff\x21\x68\xa0\xa7\xa7\x97\x7c\x3d\x43\xfc\x30\x89\xf2\x6e\x08\xd4\xd9\x65\x30\x80\x58\xe4\x16\xf8\xfb\xac\x30\x7a\x16\x9a\x34\x1b\x97\xe0\x2b\x05\x49\x90\x72\x7f\x31\x68\x20\xa2\x44\xd8\x4b\x32\x82\x7e\x82\x8d\x13\x84\x60\x24\x8b\x87\x6e\x59\x90\x8a\x10\x15\x66\x4c\x34\x74\xf1\xcb\x12\xe2\xbd\xc6\xe3\x4c\xbc\x20\x98\x7e\xba\x4e\x89\xae\x26\xac\x58\x99\x4e\x45\x15\x4d\x41\xa8\x44\x79\xcd\x8a\xb9\x84\xb1\x1a\xdf\xc1\xf1\x44\xf6\xef\xbc\xba\x7c\xa4\xef\x9e\x68\x99\x5d\x75\xe5\xc0\x94\xb8\x78\x8c\xa4\xe2\xf8\x1e\xfe\x14\x22\xb4\x71\xf2\x6d\x2e\xb6\x8d\x63\x11\x35\xb1\x89\xd7\xe7\x0b\x5c\xf3\x0d\x0c\x33\x0a\x2b\xd3\xe2\x16\xe8\x16\x38\x51\xe0\x21\x6f\xc0\x5d\xbc\xa8\xc8\x56\x4c\x64\x19\x37\xf4\x09\xac\x07\x52\xf2\x76\x73\x32\x9c\xe6\x3d\x5a\xa7\x46\x91\x47\xf6\xd1\xf8\xb0\xc2\xb2\x16\x2c\x48\x66\x5a\xb3\x01\x04\x4d\xdc\xee\x13\xfc\x72\x5d\x42\x9a\xea\x98\xfc\xea\xd0\x12\x7f\x3e\xd6\x36\x43\xe0\x49\x7f\x4f\x5e\xc4\xc8\x34\x5e\xfc\xa1\x1f\x26\xf1\xc9\x14\x51\x50\x9f\xd9\xc1\x73\x09\xf5\x30\x85\xe7\x36\x40\x63\xa0\xf9\x46\x75\xf2\xc9\xb2\xe8\x6f\x6b\x6c\x64\x44\xe4\x7c\x49\x46\xd5\xc9\x20\x52\xa2\x66\xc1\x5a\x4a\x04\x1b\x81\x63\x75\xe9\xd5\xfe\x98\x2c\x9d\x31\x04\xf5\x4c\x9e\x95\x45\xb7\x2c\xb5\x0d\x3a\x20\x43\xee\x19\x64\x53\x5b\x5a\x38\x82\xa3\x4b\xb9\xd0\xc4\x8d\x6a\x74\xab\xb3\xef\xa9\xb3\xbf\xb3\xbf\xbd\xaf\x7b\x88\x14\x1f\xea\xee\x7c\x5e\xb3\x40\xad\x25\x02\x71\x82\x07\xb2\xf5\x8d\x94\xb1\x1a\x35\xc8\xf3\x3f\x2b\x34\x00\x59\xe2\x16\x56\x10\xf2\x0a\x47\x0d\x26\xff\x1f\x14\xfb\x34\xa2\x2c\xe3\x32\x9a\xb7\x77\x3c\xe8\x04\xfd\xc6\x62\x11\xd5\x03\x2d\xa0\xb7\xb5\xf8\x08\xe3\x60\x80\x61\x15\xa3\x79\x97\x17\x3a\xa4\xfc\x41\x47\xa0\xc5\xc1\x95\x1d\x2c\x23\x83\xb7\xd6\xf4\xe4\x4f\x3d\x11\x2a\xf3\x59\x72\xcd\x38\x9a\xcc\x85\xd6\xf4\xd3\x19\x40\x2d\xd3\x70\xc9\x0b\xa6\x49\x36\x44\xdf\x8c\xe4\xde\x24\xb4\xcc\xef\xf2\x0a\x05\x0d\x64\x49\x81\xc5\x48\xb0\xe1\x1a\x8a\xd4\xb5\x2f\x72\xac\x42\x90\x6e\x4c\x09\x43\x17\xe3\x87\x0e\x8e\x54\x24\x16\xce\x7b\x15\x86\xbd\x92\xb0\x51\xc5\xb2\x81\x02\xfd\xb2\x4b\xb5\xd7\xde\x36\xb5\x8a\xdd\xb5\xec\x51\xb8\x4a\xbf\x57\x76\x53\xe9\xd4\x15\xf5\xf3\xbf\x06\x2b\xa7\xdf\xc7\xb0\xc3\x95\xd3\x7f\xbb\x64\xfa\x65\xc4\x41\x41\xb9\xd4\x52\x11\x2f\x43\x2f\x49\xb9\xe4\xe5\x74\x30\x4d\x3c\x90\x7c\x72\x2b\xfb\xe7\xe9\x59\xed\x7b\xec\x3c\x1d\xae\xba\x0f\x7a\xf2\x50\xfd\x35\x79\x69\x4e\x95\xdd\xb8\xdb\x54\x04\x39\xc4\x23\xce\xd8\x80\x66\x0b\x63\x5e\xe7\xb1\xbb\x4d\x4d\x57\x98\x47\x9f\xc2\xb9\x2e\xf6\x7f\x8d\x30\x77\x6c\x05\x3d\x83\xb6\xa0\xed\x1f\x1e\x12\x96\x9c\xd8\x08\x00\x00")
+
+func yaoDataKbProvidersRerankerOpenaiZhCnJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoDataKbProvidersRerankerOpenaiZhCnJson,
+		"yao/data/kb/providers/reranker/openai/zh-cn.json",
+	)
+}
+
+func yaoDataKbProvidersRerankerOpenaiZhCnJson() (*asset, error) {
+	bytes, err := yaoDataKbProvidersRerankerOpenaiZhCnJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/data/kb/providers/reranker/openai/zh-cn.json", size: 2264, mode: os.FileMode(420), modTime: time.Unix(1792286676, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _yaoFieldsModelTransJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5b\xcd\x72\xdb\x36\x10\xbe\xeb\x29\x30\xa8\x0f\xed\x8c\xa2\x71\x7b\xe8\x41\xb7\x24\x72\x1a\x77\x9a\xa4\x13\x69\x3a\xe3\xe9\xf4\x00\x11\x6b\x09\x35\x7e\x58\x02\xb4\xe2\x28\x7c\xf7\x0e\x40\x52\x24\x68\xd2\x96\x6b\x59\xa2\x54\xe4\x90\x10\xbb\x8b\xbf\x0f\xfb\x2d\x00\x61\xb3\x1e\x20\x84\x6f\x49\xc2\xc8\x9c\x83\xc6\x63\x64\x05\x08\xe1\x48\x71\x95\xd8\x22\xc2\x71\xc2\x04\x49\xee\xf0\x18\xe1\xef\xde\xbd\xbb\xb8\x38\x3f\xc7\x28\x1b\x20\x94\x0d\x07\xb6\x32\xe1\x8c\xe8\x7a\x55\x6d\x12\x26\x17\xd6\x9c\xc2\x35\x49\xb9\xc1\xc3\xa2\xcd\x25\x49\x5a\xc4\x02\x28\x4b\xc5\x0c\xbe\x18\xab\x34\xf6\xdf\x42\xc3\x95\x5c\xb4\xc9\xe7\x4c\x16\x03\xaa\x4b\x29\x31\x60\x98\x80\xd9\x57\xd7\x49\x51\x2a\xb5\x95\xa6\x29\xd5\x86\x88\xb8\x52\xb9\x62\xa5\x97\x77\x97\xd2\xc0\x02\xdc\xc8\x59\xf1\xe9\x69\xa3\x04\x04\x48\xa3\x5b\x0c\x52\xa9\xd9\x42\x02\x9d\x3d\xd8\x8c\x16\x84\xf3\x47\xd5\x0f\x74\x33\x67\x8b\xee\xea\x4e\xb9\xc5\x18\xdf\x78\x8d\x94\xd2\xcb\x76\xe3\x69\x63\xc8\x1d\xe6\x97\x13\xd7\x21\x2d\xcb\xcc\x1f\xc8\x46\x4e\x21\x62\x82\x70\x2b\xbc\xe6\x8a\x54\x4b\xaa\xd2\x39\x87\x7b\xe2\xb2\xb7\x49\x55\xad\x14\xbd\x6b\xb5\xdb\x34\xd3\x6a\xf6\xeb\xf4\xd3\x47\xab\xfc\x5b\x2b\x59\xca\xec\xf7\xbc\x29\xb4\x86\x6f\x9a\xc2\x34\x65\xb4\xc5\xab\x59\xfc\x9a\xd2\x04\xb4\x6e\xf3\x78\x12\xb5\x28\x2b\x4a\x5d\x33\xe0\xb4\xc6\xa8\xd2\xa2\x14\x38\x13\x6e\x1c\xf4\xa5\x04\x21\x7c\x03\x8e\x13\x67\x6b\x4e\xe6\xc0\xd1\xb7\x6f\x28\x52\xc2\xa2\x6d\x3f\x25\x11\x90\x15\x03\x28\x49\xe4\xc6\xbd\x5a\x42\x02\xa3\xb3\xb5\x33\x18\x09\x62\xa2\x65\xdd\x0c\x28\x33\x5e\x37\xd6\xef\xef\x62\x87\xe6\xa5\x8c\x53\x53\x33\x46\x36\x58\xa8\x58\x17\x71\x83\x93\x08\x96\x8a\xd3\xdc\x45\xce\x7e\xfb\x3e\xe6\x40\x34\x20\x66\xab\xfd\x80\xee\x8f\x33\xcb\x03\x4b\xfe\xa7\xfc\xca\x86\x9b\x39\xab\x44\xec\x62\xc6\xc5\x5c\xeb\xaa\x5b\x06\x2b\xdb\x74\xd6\xcf\x99\x1b\x92\xfb\xef\x4b\x4e\xbd\x9a\x9c\x0b\xb9\xc3\xda\x8c\x32\xd4\x2f\x5c\x06\x05\x3a\x39\x3b\x40\xa6\x62\x1f\xd4\x80\x7f\xb6\xe5\xc5\x14\x38\x44\x5d\x30\xd4\x84\xe8\x01\x48\xb4\x6b\xa3\x1d\x93\xa1\xdf\x86\x8a\x0d\x53\xd2\xc5\x92\xb3\x51\xde\xf7\x27\x27\x5a\xe7\x9a\x0c\xd7\xec\x0f\x44\xb0\x53\x84\x6b\x1f\xac\x6c\xc3\x6b\x46\x16\xbd\x07\xeb\x34\x97\xde\x0f\x3c\x26\x3f\x9a\xae\x0f\x4c\x1f\x1b\xae\x5f\x27\x40\x9e\x87\xe2\x03\x21\xb8\x01\x22\x49\x8d\x9a\xb2\xaf\x90\xc7\x74\xc1\xe4\x67\xb5\xb2\x3d\xfd\x34\xb4\x27\x9b\x2f\x45\xe9\xe7\x1a\x7e\x3d\xa5\x91\x52\xdc\xb0\xb8\x73\xb7\x32\xcc\xe4\x87\xc6\xc7\xb6\xa5\xd3\x5e\xa7\x86\xcf\xdb\xcb\x53\xaf\x36\xdb\x09\x31\xf0\x3b\x8b\x6e\x36\xb7\x8e\xff\x08\xec\x13\xc2\x48\x9c\x77\x57\xde\x25\xfb\xbd\xbb\x1e\x0d\x3e\x47\x73\xc8\xed\x3b\xa2\x83\x1a\xae\xf8\x0e\x48\x12\x08\xbb\x81\xcf\xc1\x11\x08\xbb\x13\x7c\x02\x61\x77\x84\xa8\x47\x58\x4a\x4c\xd8\x61\x2b\xf8\x1c\x1c\x81\xb0\x3b\xc1\xe7\xc0\x84\xcd\x17\x88\xb8\x9f\xf3\xaf\xae\xae\xae\x5e\x7d\xf8\xf0\x6a\x32\xc1\xe8\x48\x88\xbc\x05\xd2\xf7\x88\x1c\x8e\xcb\x08\x61\xbd\x54\xab\x59\x01\x44\xdd\x07\xde\xbf\x1f\x0b\x31\xd6\x1a\x6f\x7d\x55\xfc\xff\x10\x7c\x77\x98\x1d\xec\x7a\x5d\x3d\xcd\x35\xe1\x6a\x0f\x04\xa8\x6d\x6e\x3d\x8e\x0c\xcf\x5e\x22\x2f\x5a\x54\x4f\x8f\x21\x5c\x84\x70\x11\xc2\x45\x08\x17\xcd\x25\x2a\xc3\x45\x1e\x2f\xd8\xe6\xf1\xbd\x87\x6f\xc2\x1f\x53\x31\xef\x84\xf6\xb8\x5e\x86\x7b\x37\xd7\x1e\x12\xf4\xe9\xfc\xdb\x27\x72\xde\x36\xdb\x4c\x54\x09\xf4\x09\xf4\x09\xf4\xd9\x3e\xd9\xc2\xe5\x3a\x05\xca\xf4\x97\x32\x5b\x1e\x5c\xb6\x7f\x46\xa4\x4c\x5b\xca\xd8\xd1\x9a\x24\x85\xe3\x3d\x46\xbe\x04\xcd\x0e\x8b\xb6\x4f\xcd\x3c\x45\x32\xb0\xf3\xb4\xd9\x19\xf8\xb7\x4f\x3c\x7d\x86\xf9\xd9\xc3\x81\x69\x81\x69\x81\x69\x2f\xc3\xb4\xb9\x52\x1c\x88\xec\x1d\xc7\x3e\x13\xca\xd4\x2f\x89\x4a\xbb\xd2\xc6\x7c\x98\xaa\x7c\xc3\x3f\x3d\x05\xb2\x5c\x74\x63\xb6\x8d\x8e\xc7\x17\xd2\x39\xd1\x10\xe1\x5b\xc2\x53\xdb\xd3\x8f\xf5\xa5\x6b\xab\x32\xc9\x0f\x0a\xf5\x3a\xe7\xde\x2f\x48\x08\xfd\xb5\xa5\x07\x1f\x88\xc3\x27\x89\xe5\xa1\xa2\xc1\x74\xc5\x7c\x37\xee\x06\x32\x5a\x42\x74\x03\xf4\x8f\x12\x9d\xc6\x09\x34\x95\x6f\x7d\x83\xf3\x61\x6b\xfd\xb7\x4b\xc6\x69\x02\xd2\x47\xbd\xa3\x2d\xdf\xb8\xc4\xfb\x29\x11\xc1\xfd\x97\x9b\xfd\x24\xdb\x56\x4f\xd9\x91\x12\x71\xea\xd2\x31\x30\xc8\x48\x51\x26\x17\x23\x3b\x90\xd1\x85\x2d\x01\x7e\x66\xee\x67\x67\xf3\x13\x70\xcd\xef\xe9\xce\x71\xd2\x09\xbd\x8f\x2d\x61\xd7\x49\x69\x3f\xd9\xbf\xa7\xe5\x01\x83\xf2\xef\x6c\x90\x0d\xfe\x0d\x00\x00\xff\xff\x0e\xa4\xe8\xa9\x5a\x3a\x00\x00")
 
 func yaoFieldsModelTransJsonBytes() ([]byte, error) {
@@ -2563,7 +2693,47 @@ func yaoModelsAgentHistoryModYao() (*asset, error) {
 	return a, nil
 }
 
-var _yaoModelsAttachmentModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\x5f\x6f\xd3\x3e\x14\x7d\xef\xa7\xb8\xca\xf3\x7e\xd2\x7e\x48\x43\x6a\xdf\xc6\x06\x68\x12\x82\x09\x98\x78\x98\xa6\xca\x4d\x6e\x52\x23\xc7\x0e\xf6\x8d\xa0\x9b\xfa\xdd\x91\x9d\xa4\xb5\x53\xa7\x5d\x02\xe3\xa9\xea\xfd\x73\x7c\xce\xf5\xb5\x7d\xf3\x34\x03\x48\x24\x2b\x31\x59\x40\xc2\x88\x58\xba\x2e\x51\x52\x72\x66\xed\x82\xad\x50\x58\xc7\x65\xcf\x91\xa1\x49\x35\xaf\x88\x2b\x19\xba\x81\xd8\x4a\x20\xe4\x4a\x83\x21\xa5\xb9\x2c\x20\xe7\x02\x61\x8f\x6c\xe0\x27\xa7\x35\x94\x48\x2c\x63\xc4\x80\xc9\x0c\x58\x9a\xa2\x31\x90\x2a\x49\x5a\x89\x66\x09\x62\x85\x49\x16\x70\x9f\x98\x8d\x21\x2c\x93\x07\x67\x5d\xd5\x5c\x10\xb7\x8b\x92\xae\xd1\x99\x34\xb2\x4c\x49\xb1\xf1\x6d\x46\x69\x4a\x16\x30\x9f\xcf\xe7\x2d\xd8\x4a\x58\x85\x56\xed\xb0\x5e\x80\x24\x55\xa5\xfb\x1b\x11\x95\xcc\x00\xb6\x0e\x2d\x55\xa2\x2e\xa5\x63\xe7\xb2\x1a\x54\x0f\x97\x67\x2d\x9e\x5d\x7a\x53\x39\xdb\xcd\xf5\xde\x16\xa9\x2b\xf8\x7e\x8f\xc5\x9d\xe4\x3f\x6a\xbf\x7e\xc0\x33\x94\xc4\x73\x8e\x3a\x71\xf1\xdb\xb3\x38\x09\x5b\xf7\x65\x8c\x89\x21\xbb\x2f\x11\x36\xef\xec\x4e\x0d\xf0\x70\x3e\x6f\xe9\x7d\x36\xca\x82\xd6\xc9\x02\x5e\x5d\x5c\xec\x8c\xb2\x16\xa2\x2d\x79\xce\x84\xc1\x9d\xa3\x76\x72\xbc\xad\x72\x56\x2e\x33\xfc\xd5\x1a\x8f\x6a\xaa\x2b\xa1\x58\xe6\x2f\x7f\x52\xd4\xdd\x41\x4a\x5f\x55\x07\x0a\x0e\x2b\x22\xec\xfc\xfc\xb4\xb0\x67\x4b\xb0\x4d\x8e\x92\x96\xe1\x62\x27\x65\x5c\x35\x69\xf0\x35\x48\xeb\x4b\x69\xc1\xff\x8d\x12\xf7\xfb\x7c\x05\x1f\x83\xf0\x3e\xf3\x10\x6c\xc7\xf8\xe2\xaf\x32\xae\xb5\x18\xd3\x39\x9f\x3f\x0c\xf3\x0d\x9c\x3b\xba\xff\x9f\xc7\xf9\x46\xbb\xdd\x89\x38\xca\xd7\xbf\x66\x9f\xcf\xfb\x3a\x96\xd5\xe7\x1f\x85\x7e\x29\x1d\x23\x7b\xfd\x78\x8f\x8f\xeb\xed\x89\xf7\x8c\x41\xbd\xac\x18\xad\xc7\xb4\x8b\x41\x0d\xb7\x41\x8e\x7f\x8f\x1b\xd4\xff\x99\x0a\x53\x7b\x7d\x66\x90\xaa\xb2\x12\x48\xd8\xbc\x8e\xe1\x4a\xd3\x76\xe1\xa4\xa6\x91\x72\xbe\x90\xd2\xac\xc0\x61\x45\x97\x29\xd5\x4c\xb8\x67\xde\xc6\x59\x78\xf7\xee\xd3\xba\x51\x35\x42\xd0\xc4\xe3\x5c\x68\x55\x57\xe6\x50\xd3\x77\x13\x34\x75\xa7\xe8\x7d\x2f\xbc\xdf\x58\x7d\xb8\x5e\xc5\x8f\x53\x79\xe4\xd5\x21\x91\x95\x52\x02\x59\x94\x4b\x10\xef\x31\xf9\xb6\x46\x5a\xa3\x6e\xfa\x82\x1b\xb0\xc0\x15\x7a\xaf\x78\x86\x39\xab\x05\x4d\xaf\xda\x6a\x43\x18\x29\xda\x8a\x17\x37\x92\xb0\x08\xde\xf6\x8e\xee\x9b\x30\xa7\x5f\x39\xc3\x1f\x11\xb8\x84\x1e\xf4\x9f\xef\xb0\x21\x46\x75\x84\x2c\xca\xba\x8c\xf6\x6c\x18\xde\xe7\x59\x69\x65\x07\x4d\x3b\x95\xf6\x91\x55\x37\xcc\xde\xb7\x16\xe8\xa6\x0d\xff\x78\xec\x8c\xde\x8e\x74\x7a\x7a\x71\xce\x16\x86\x35\xa9\xcb\x9c\x71\x11\xc9\xef\xec\xad\xf9\x21\xb2\xe3\x11\x46\x23\xce\xbf\x56\x85\x46\x13\xa9\xe6\xe0\x1d\x70\x7b\x90\xe2\x55\xf4\x76\x5f\xcc\x0e\x1a\xb8\xcc\x95\x2e\xd9\xc0\xab\x72\xe4\x8a\x3e\xca\x1c\xb5\x56\x63\x46\xbe\xb7\x61\xbc\xc7\xd9\x79\x4e\xb0\x7c\x3d\x91\x65\x2a\xb8\x9d\xeb\x46\x4d\xdc\x57\x2e\x67\x68\xe6\x6e\xbd\x53\xa6\xee\x69\x4f\x84\xaa\x50\x8e\xe2\xff\xa9\x42\x39\x40\xbe\x71\xbd\x04\xf9\x59\x7b\x38\x12\x8d\xc2\x6d\xa2\xfd\x12\x7b\x6a\x3e\xcd\x9a\x53\xe7\x3e\xcd\x9a\x98\xdd\xb9\x7e\x82\x84\x78\x89\x86\x58\x59\x99\x6e\x11\xfb\xa5\x98\xd3\x32\x43\xfb\x16\x9b\xee\x9e\x82\xed\x6c\x3b\xfb\x1d\x00\x00\xff\xff\x6d\x48\xa5\x86\x19\x0f\x00\x00")
+var _yaoModelsAttachmentBlobModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9d\x55\xc9\x6e\xdb\x30\x10\xbd\xe7\x2b\x06\x3a\xab\x80\x1b\xb4\x01\xda\x9b\x8b\x1c\x1a\x14\x28\x8a\xa6\x39\x15\x81\x41\x89\x23\x99\x08\x45\xaa\xe4\xa8\x8d\x60\xf8\xdf\x3b\xa4\x16\xd3\xf1\xd2\xb8\x3e\x18\xe0\x9b\xed\xbd\x19\x6a\xb8\xb9\x02\xc8\x8c\x68\x30\xfb\x08\x59\xa1\x6d\x91\xe5\x01\xd1\xa2\x40\x1d\xa0\x4f\x33\x24\xd1\x97\x4e\xb5\xa4\xac\x09\x86\xef\x58\xa1\x43\x53\xa2\x07\x5b\x01\xad\x11\x24\xca\xae\xd5\xaa\x14\x84\x12\x4a\x6b\x08\x0d\xf9\x1c\x50\x94\x6b\xa8\x94\x46\xf0\x64\x1d\x9b\x94\x01\x01\xa1\x14\xac\xad\x96\x1c\x6e\x10\xdc\x94\x6d\xa8\x45\xa2\xf6\x5c\xe4\x67\xe6\x7b\x4f\xd8\x64\x8f\x11\x2d\x3a\xa5\x49\x85\xea\xe4\x3a\x8c\x90\x43\x21\xad\xd1\x7d\x8a\x79\xeb\x88\xcf\x1f\xf8\x37\x26\x2b\x74\x90\xb7\xe1\x43\x22\x56\x10\x31\xb3\x86\x49\xae\x66\xdd\x6c\x2e\x6d\x13\xb0\x49\xfb\x8e\x19\x0c\x79\xd8\x6b\x1b\xd3\x96\x56\x77\x8d\x89\x34\x63\xe4\x90\x3e\x29\xa0\xe4\x98\x33\x70\xe8\xdb\x88\xdd\xdd\xee\xb0\xbd\x1e\x43\x6a\x49\x38\x3c\x18\xf5\xab\xc3\xa1\x5d\x4a\x32\xa8\x2a\x85\x2e\x8b\x9e\xdb\xfc\x78\xe1\xe0\xbc\x7a\xc2\xfe\xb0\xbc\x27\xa7\x4c\x7d\x8a\xc2\x97\x34\x24\xe1\x70\xff\x79\xf9\xe6\xfa\xfd\xcd\x34\x67\x1e\xb2\x15\x12\x1d\x08\x23\x23\x10\xe6\x2a\x6a\x84\x56\xd0\x7a\x72\x4a\x5a\x1a\xea\xa0\xa9\x69\xcd\xb9\x6e\xde\xcd\x98\xe9\xb4\x1e\x27\x53\x09\xed\x71\x36\x74\x51\x72\x32\xd1\x88\x2a\x23\xf1\x79\x04\xcf\xaa\x9f\xe8\x5d\xa0\xfe\xe1\x20\x24\x51\xbf\x9c\xef\x09\x34\xc2\xb0\x4e\x17\x05\x73\xa6\x73\x42\xaf\x17\x8b\x7f\x2b\x7d\xb5\xa6\xd0\xd9\x0b\xf4\xdc\x8f\x03\xf9\xb6\x17\x96\x4e\xf4\xf5\x13\x7b\xbb\x38\xa3\xe4\x2c\x69\xfe\x72\xfc\x21\x69\xc5\x7b\xa1\x4e\x5b\x3d\xb3\xde\x6d\x94\xa3\x9c\xbf\x76\x4d\xc1\xbd\x67\xb6\x61\x99\xf8\x64\x9b\x1c\xb2\x97\x58\x89\x4e\x87\xb0\xff\xe4\xee\x49\x50\x77\x84\x3d\x9a\xae\x39\xda\xf0\x7d\xf7\x84\xf6\x0f\x26\x37\x2e\xc3\xc0\x5d\xf0\x9a\xd4\x48\xe1\xf6\x0c\xdf\xb4\x87\x02\xc3\xc9\x61\x63\x7f\xb3\x9e\xa2\x67\x1f\x63\x25\xe6\xa0\x28\x98\x8d\x25\xd0\xca\x3c\xb1\x4d\x98\xbe\x61\xd1\xbb\x3a\x76\x5a\xc7\xbc\x29\x63\x3b\xb2\x3c\x88\x1f\x0a\x0c\x5b\xf3\x45\x3f\x66\xbf\x8b\xef\xe0\x94\x76\x25\xe8\xb0\x2f\x85\xaa\xef\x4e\x0e\xf6\x76\x52\xbc\xa4\x53\x3b\xee\x19\x48\x35\xc8\x5d\x6f\xda\xdd\x73\x12\xa2\xac\xc9\x59\x36\xf0\x43\x31\x03\xf0\x47\x78\x08\xd7\xc8\xb9\xae\xa5\x54\x4b\x32\xe2\x9d\x1c\xfe\x7f\x1c\xdf\x0a\x2d\x42\x7c\x58\xd9\x9b\x61\x87\x47\xf1\x18\x77\xf8\xe0\x33\x37\x74\xc3\xda\x26\x46\x7e\xda\x45\xe1\x6d\xa9\x68\x15\x89\xc4\xa8\x78\x91\xb8\xc4\xf6\xea\x2f\xeb\x16\x9c\xb8\x48\x07\x00\x00")
+
+func yaoModelsAttachmentBlobModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsAttachmentBlobModYao,
+		"yao/models/attachment/blob.mod.yao",
+	)
+}
+
+func yaoModelsAttachmentBlobModYao() (*asset, error) {
+	bytes, err := yaoModelsAttachmentBlobModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/attachment/blob.mod.yao", size: 1864, mode: os.FileMode(420), modTime: time.Unix(1792292227, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoModelsAttachmentUploadModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x57\x4b\x4f\xdc\x30\x10\xbe\xf3\x2b\x46\x7b\xde\x03\xad\x4a\xa5\xf6\x86\xfa\x90\x90\x5a\xa8\x0a\x3d\x21\xb4\x72\x92\xc9\xc6\xd4\xb1\xd3\xd8\x66\x77\x8b\xf8\xef\x1d\xdb\x49\x70\xb2\x1b\x48\x56\xe5\xb0\x28\x93\x99\xf1\xf7\x79\x9e\x79\x3c\x01\x58\x48\x56\xe2\xe2\x23\x2c\x6c\x25\x14\xcb\x16\x4b\x27\x13\x2c\x41\xe1\x84\xbf\xbc\x10\xae\x51\x6b\xae\x64\x78\x99\xa1\x4e\x6b\x5e\x19\x27\x20\x95\x9f\xa8\x6d\xc9\x12\x81\x10\x3c\x80\x0e\xca\x7a\x09\xa6\x40\xa8\x31\x45\xfe\x80\x19\xa4\x85\x95\xbf\x35\xa8\xdc\x8b\x73\x2e\x50\x43\x82\x5c\xae\x1b\x3b\x6c\xce\x36\x6c\xad\xc9\xef\xed\x42\xef\xb4\xc1\x72\x71\xe7\xa5\x89\xe5\xc2\x70\x77\xa0\xa9\x2d\x7a\x51\x8d\x2c\x53\x52\xec\x62\x99\x56\xb5\xa1\xe7\x0f\xf4\xd7\x38\x23\x60\x24\x78\xa4\x87\x88\x2b\x33\x86\xa5\x45\x89\xd2\xac\x22\xda\xa4\x90\xaa\xd2\x49\x23\xea\x0d\x1b\x08\x9e\x48\xeb\xc9\x3b\x4e\x95\xb0\xa5\xf4\x40\xbd\x65\x38\x20\x3a\x82\xb7\x3e\x1d\x8a\x5d\xe5\x65\x17\x9f\x9f\x65\x23\x57\x0c\xb1\x4e\x8c\x46\xf2\x3f\x76\x78\xc5\xc0\x33\x7a\xcd\x73\x8e\xf5\xc2\xdb\x3c\x2d\x0f\x83\x09\x66\xab\x43\x98\xb4\xa9\x29\x06\xe3\xb8\xc6\xf0\x8c\x01\x01\xab\x29\xd8\xc9\xce\x47\x39\x15\x9c\xe4\x3a\xf2\x8e\x72\x6d\x0a\x72\xf0\xfe\x5d\x27\x93\x56\x88\x26\x4c\x39\x13\x1a\xbb\x17\xd6\x73\x8e\xc2\xeb\xa5\x5c\x66\xb8\x6d\x84\x13\x48\xd3\xd5\xcc\xe5\x1c\x9b\x44\x94\xcf\xbb\xa4\x81\x92\x49\xb6\x26\xae\x21\xb9\x5d\x0e\xb7\x39\x7d\x80\xea\xdb\xd3\xd3\xd7\xb9\x4e\x66\xe5\x0e\x99\x17\xc8\xaf\x64\x31\x16\x46\xff\x2e\x0a\x5e\x53\x9d\x6d\x45\x8e\x52\x3a\x3b\xfb\x8f\x94\xfc\xff\xe9\x7c\x2e\x7b\xea\x43\x32\x7d\x67\x1d\xe2\xb3\x17\x82\xf0\x22\xb8\x54\x49\xe3\xfa\x84\x87\x35\x1d\xe4\xa7\x60\x06\x37\x3d\xb3\x21\xd8\xc6\x39\xf4\x9d\x4f\xca\x9c\x17\x41\x57\x8c\xec\xa7\x83\xbd\x36\xaa\xa6\x7c\x86\x1f\x3d\xb3\x08\x6c\xab\xe0\xfc\xc6\x1d\x3c\xb4\xf8\xa6\xb3\xb3\x1a\x41\x93\x22\xa5\x8d\xc4\x2d\x91\x52\xc0\xcd\x01\x56\x6f\x4e\x8f\xa5\xd5\xb8\xd8\x23\x96\xf0\xf5\x05\x5d\xe4\x3a\x2e\xdc\x8e\xdc\xb7\x81\x51\x44\xeb\x46\x19\x26\x40\xf3\xbf\x18\x93\x02\x2e\xa9\x7f\x19\x8c\xda\xd6\x2c\x94\x2a\xcf\x35\x9a\x99\x28\xaf\x06\x46\x11\xca\x4b\x5b\x26\xa1\x34\x3d\xaa\x6e\xa2\x3e\x2b\x67\x98\x33\x2b\x9c\xf2\xb1\x49\xee\x23\xb8\x0f\xf9\x5e\x37\x43\x7f\x90\xdc\x03\xf5\x38\x53\xe8\x32\xbb\x21\x3f\x9c\xfd\x74\xb1\xaa\xee\xb5\xd7\x08\xe5\xab\x6d\xa2\x44\xc3\x32\x66\xd8\x64\x98\xdf\xf7\x0c\x22\xa0\xed\x4b\x1a\x61\x54\x80\xbd\x79\x05\x9b\x02\xa5\x7f\x6e\xc7\xdb\x86\x69\x48\x69\xdf\x30\xf1\xad\xcf\xc1\xae\xfc\xba\x34\xfd\x86\xaf\x86\xfa\xfb\xa3\xb7\x71\x09\xac\xaa\x08\x75\xf6\x0c\x3a\xe4\x30\x01\x56\x65\x25\xd0\xe0\x71\x88\xb5\x61\xc6\x1e\x00\x8c\xd2\x96\x07\x5b\x48\x5f\x7d\x7c\x55\x18\x3a\x56\xed\x26\x79\xdb\x4c\x6c\xdf\xa3\xbc\x07\x0f\x3f\x0b\x1b\xe0\x20\xd1\x63\xdd\xd9\x03\x07\xb7\x15\xaf\x51\xaf\xd8\xdc\x2a\xfd\x12\x0c\xe1\xdc\x8c\x2d\x69\x5b\x30\xbc\x44\xe2\x58\x56\xc0\x72\x43\x75\xbb\x29\x78\x5a\x84\xa9\x2a\x73\x2e\xb9\x2e\x30\xda\x9b\x5c\x39\x97\xea\x61\x24\xaf\x8e\x1c\xa8\xda\x26\xf7\x98\x9a\x39\x13\x60\x68\x11\xef\x3d\xd6\x14\xaa\xa6\xba\x26\xd8\x41\x0d\xd4\x46\xb6\x4b\x4f\xc3\x64\x09\x6e\x15\xf7\x12\x7a\xe9\x57\x23\x96\xd1\x27\xc0\xa6\xe6\xae\x67\x31\x99\x01\x4b\x68\x39\xd7\x87\x67\xc2\xc8\x42\xf1\xca\xe2\x47\xbf\x77\xcd\xa7\x80\x60\xa1\x60\x68\xd5\x0f\x0b\xba\xd7\x46\xbf\xa0\x07\x9d\x2e\xcf\x1e\xe9\x3e\xda\x18\xe9\xf6\x10\xf7\xe9\x90\x9b\x55\x86\x2e\xe5\x74\x7b\xf5\x74\xc4\xd3\xc9\x3f\xa0\x0f\x0a\xa2\x26\x0d\x00\x00")
+
+func yaoModelsAttachmentUploadModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsAttachmentUploadModYao,
+		"yao/models/attachment/upload.mod.yao",
+	)
+}
+
+func yaoModelsAttachmentUploadModYao() (*asset, error) {
+	bytes, err := yaoModelsAttachmentUploadModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/attachment/upload.mod.yao", size: 3366, mode: os.FileMode(420), modTime: time.Unix(1792289825, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoModelsAttachmentModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbd\x58\xdb\x4e\xdc\x30\x10\x7d\xe7\x2b\xac\x7d\x5e\x24\x8a\x0a\x52\x79\xa3\x5c\x0a\x52\x2f\xa8\x80\x5a\x09\xa1\x95\x37\x99\x4d\x8c\x1c\x3b\xd8\x8e\x28\x20\xfe\xbd\x63\xe7\xb2\x76\xd6\xbb\x4b\xb6\x14\x1e\x80\xcc\xcd\xe7\x8c\xc7\xe3\x49\x9e\xb7\x08\x19\x09\x5a\xc0\xe8\x80\x8c\xa8\x31\x34\xc9\x0b\x10\x66\x34\xb6\x72\x4e\xa7\xc0\xad\xe2\xb0\xa7\x48\x41\x27\x8a\x95\x86\x49\x11\xaa\x89\xa1\x53\x0e\x64\x26\x15\xd1\x46\x2a\x26\x32\x32\x63\x28\x98\x47\xd6\xe4\x81\x99\x9c\x14\x60\x68\x4a\x0d\x25\x54\xa4\x84\x26\x09\x68\x4d\x12\x29\x8c\x92\xbc\x5e\xc2\xd0\x4c\x63\xec\x9b\x91\x7e\xd4\x06\x8a\xd1\xad\x93\x4e\x2b\xc6\x0d\xb3\x8b\x1a\x55\x81\x13\x29\xa0\xa9\x14\xfc\xd1\x97\x69\xa9\x0c\x3e\x7f\xc2\x9f\x26\x18\xa2\x42\xc1\x33\x3e\x2c\xe7\x8b\x9a\x44\x16\xee\x31\x42\x6a\x84\x16\x2f\x2e\x5a\x22\x79\x55\x08\x87\xce\x79\xd5\x51\xbd\xb8\x2c\x6d\xe2\xd9\xa5\x1f\x4b\x27\x3b\x3f\x9e\xcb\x22\x79\x25\xbe\xde\x43\x71\x2d\xd8\x7d\xe5\xe7\x8f\xb0\x14\x7f\xb3\x19\x03\x35\x72\xf6\x2f\xe3\x38\x08\x9b\xf7\x49\x0c\x89\x36\x76\x5f\x22\x68\x4e\xed\x4e\x2d\xc1\xe1\x74\xde\xd2\x73\x6f\x10\x99\xc9\xd1\x64\x77\x6f\xaf\x13\x8a\x8a\xf3\x26\xe5\x33\xca\x35\x74\x8a\xca\xd1\xf1\xb6\xca\x49\x99\x48\xe1\x4f\x23\x5c\xc9\xa9\x2a\xb9\xa4\xa9\xbf\xfc\x5a\x52\xd7\x0b\x2e\x7d\x56\x6d\x50\xe2\x62\x45\x88\xed\xec\xac\x27\xf6\x6a\x0a\xb6\xc8\x71\xf5\x49\xb8\xd8\x5a\x1a\x47\xb5\x1b\xb9\x0a\xdc\xfa\x54\x9a\xe0\xef\xc3\xc4\xfd\x7d\x3d\x83\xef\x81\x79\x1f\x79\x18\xac\x43\xbc\xf7\xa6\x88\x2b\xc5\x87\x54\xce\xcf\xaf\xcb\xf1\x06\xca\x0e\xee\x87\x9d\x38\xde\x68\xb5\x3b\x12\x2b\xf1\xfa\x6d\xf6\xf5\xb8\x8f\x63\x5e\x7d\xfc\xd1\xd0\xff\x8b\xc7\xc0\x5a\x5f\x5d\xe3\xc3\x6a\x7b\xc3\x3e\xa3\x41\x4d\x4a\x8a\x81\x07\x94\x0b\xfa\x90\x8b\xc0\xc7\xef\xe3\xa8\xdd\xd6\x25\x24\xb6\x7d\xa6\x78\x50\x8b\x92\x83\x81\xfa\x76\x0c\x57\xda\x6c\x17\xd6\x72\x1a\x48\xe7\x12\xaf\x6f\x9a\xc1\x72\x46\x87\x89\xa9\x28\x77\xd7\xbc\xb5\xb3\xe1\xdd\xbd\x6f\xf2\x9a\xd5\x00\x42\x1b\x1e\xe7\x9c\xea\x7c\x83\x16\x7a\x16\xb8\x79\x8c\x2e\xcf\x0e\xb7\x77\xf7\xf6\x89\x9c\x75\x24\xda\x8e\x3a\x26\x1a\x0c\x79\xc8\x41\x38\x55\x77\x65\xa4\x90\xe2\xff\x2c\xa1\x06\xb4\xd3\x34\xf6\x3a\xc2\x7e\xff\xe3\xdb\x6d\x66\xa6\x64\x55\xea\x45\xf2\x77\x3a\x38\xcf\x2d\xf5\x2f\x3d\xf3\xfe\x99\xea\x87\xeb\xe1\x5b\x0d\xe5\x89\x95\x8b\x40\xa6\x52\x72\xa0\x51\x2c\x81\xbd\x87\xe4\x57\x0e\x98\x41\x55\xe7\x9d\x69\x62\x03\x97\xe0\x0d\x30\x29\xcc\x68\xc5\xcd\xe6\x05\x33\x7d\xc4\x6d\x8a\x60\x65\xd9\x39\xee\x5a\x16\x8c\x35\x2d\xdc\xcf\xa1\x4f\x3f\x73\x9a\x3d\x21\x58\x41\x7a\xa1\xff\xbd\xb8\xb5\xa1\xa6\x8a\x80\x05\x51\x15\xd1\xe3\x1a\x9a\xf7\x71\x96\x4a\xda\x19\xdb\x0e\xe4\xfd\xc8\xb2\x9d\xe3\x6f\x1a\x09\x69\x07\x2d\xff\x1c\x75\x42\x6f\x47\x50\x76\x5f\x51\x45\x71\x28\x14\xa1\x58\xc1\x1d\x24\x26\x94\x39\xea\xbd\x90\x4e\x16\x9a\xd5\xab\x4c\x66\x14\x61\x2f\xfa\xb7\xf2\x46\x7c\x1b\x29\x8e\x08\xf8\x01\x5d\x52\xc9\x4c\x61\xa2\x06\xf4\x95\x8b\x05\x17\x2f\xf9\x17\xf3\xbc\xb7\xa1\xb1\x5c\xb0\x4f\x16\x74\xc9\xdd\xbb\xe2\x22\x5b\x89\x1c\x94\x92\x43\x06\xe3\x93\xd0\xde\xc3\xec\x34\x6b\x50\xee\x6f\x88\x32\xe1\xcc\x4e\xbf\x83\xde\x4b\x8e\x9c\xcf\xb2\x37\x93\x46\xbb\xc9\xbb\xc9\x66\xbd\x57\x96\x20\x06\xe1\xff\x81\x0e\x4b\xc0\xd7\xaa\x77\x04\x6f\x80\x16\xc3\xb2\x7f\x85\x1e\xcb\x72\xef\x74\xef\x07\xbe\xfd\x68\xf0\xea\x7b\xef\xdb\x82\x83\x07\xfe\xbc\xb0\x73\x4b\x1b\xf3\x80\x3c\xb0\xd4\xe4\x63\x92\x03\xcb\x72\xe3\xbe\x4b\xd8\x0b\xfd\xe4\xf7\xf9\x29\x09\x63\x44\xeb\x7d\xab\x69\x46\xd8\xfa\xb8\x3b\x34\xf6\xfb\xc0\x73\xfd\xc1\xa0\xee\x72\xee\x83\x41\x6d\xd3\xb5\xdc\x67\xa4\xc0\x0a\xc0\x96\x5c\x94\xba\xcd\x8b\xfd\x7e\x31\x33\x93\x14\xec\x84\xa8\xdb\x2b\x04\x97\x78\xd9\xfa\x0b\xc4\x16\xf1\x69\xaf\x11\x00\x00")
 
 func yaoModelsAttachmentModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -2578,7 +2748,7 @@ func yaoModelsAttachmentModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/attachment.mod.yao", size: 3865, mode: os.FileMode(420), modTime: time.Unix(1758619331, 0)}
+	info := bindataFileInfo{name: "yao/models/attachment.mod.yao", size: 4527, mode: os.FileMode(420), modTime: time.Unix(1792285313, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -2643,7 +2813,7 @@ func yaoModelsDslModYao() (*asset, error) {
 	return a, nil
 }
 
-var _yaoModelsJobCategoryModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x55\x4d\x6f\xd4\x30\x10\xbd\xef\xaf\x18\xf9\xdc\x4a\x05\x51\xa4\xdd\x1b\x62\x39\x94\x03\x48\x20\xc4\x01\xa1\xca\x49\x26\xd9\x29\x8e\x1d\xec\x89\xd4\x80\xf6\xbf\x23\x3b\xbb\x89\xb3\xf1\x6e\x1b\xd4\x53\xa4\xf9\x78\xf3\xde\xcc\xc4\xf3\x77\x05\x20\xb4\xac\x51\x6c\x40\xe4\x92\xb1\x32\xb6\x13\x57\xde\xaa\x64\x86\xca\x9b\x3f\x9a\x0c\xde\x4f\x5c\x05\xba\xdc\x52\xc3\x64\xf4\x31\xe0\x98\x0b\x2c\x33\x85\x50\x1a\x0b\xc6\x56\x52\xd3\x1f\xd2\x15\x14\x54\x96\x68\x51\x33\x70\xd7\xa0\x03\x53\xc2\x83\xc9\x5c\x8f\xc6\xb2\x72\x62\x03\x3f\x84\xeb\x1c\x63\x2d\x7e\x06\x6b\xd6\x92\x62\xf2\xf8\x6c\x5b\x0c\x26\x8b\xb2\x30\x5a\x75\x62\x03\xa5\x54\xae\x37\x3a\x63\x59\x6c\x60\xbd\x5e\xaf\x0f\x68\x99\xf2\x6a\xbc\xb2\x48\xdb\x83\xc9\xee\x27\xfa\x00\x44\x6e\xea\x1a\x35\x9f\x6a\xec\x25\x88\x15\xc0\x3e\x20\xe6\x46\xb5\xb5\x0e\x14\x43\x5e\x8f\x1c\x61\x53\x71\x40\xf4\xe5\xbb\x26\xd8\xee\xb6\xa3\x6d\xe8\x64\x6c\x8c\x8a\xbf\x6b\xd9\x5c\x93\xce\x2d\x7a\x0b\x34\x96\x6a\x69\x3b\xf8\x85\x9d\x08\xd1\xfb\xab\x74\xdd\xa3\x9e\xfb\x14\x01\xc7\x96\x74\x95\x20\x31\xc8\x3c\xc3\xe6\x9b\xa6\xdf\x2d\x42\x9f\x0f\x54\xa0\x66\x2a\x09\x6d\x98\x29\xef\x10\x4e\xda\xe8\xb1\x51\x57\xbc\x13\x1b\x78\xfb\x66\xb0\xe9\x56\xa9\xc3\x28\x86\x61\x05\x47\x1b\xf0\x0f\x73\xbd\xa8\x2f\x7c\xff\x43\xd8\xa7\x49\x5e\x24\x6d\x4b\xae\x51\xb2\x03\x0f\xec\x77\xf0\x09\x35\xaf\x6f\x6f\x5f\x52\x0e\xe5\x46\x2f\x90\x73\x37\x09\x8f\x54\x78\xc7\xc2\xb9\xbc\xba\xb9\x49\x29\x79\x92\x72\xfc\xa3\xcf\x98\x33\x3e\x72\x82\xf7\x36\x95\x13\xd1\x1f\xc6\x94\x04\x5f\xc2\x2e\xfc\xfa\x33\x5a\xa4\x19\x2b\xb4\x09\x66\x5f\x8d\x65\xf8\x6c\x8b\xd8\x19\x11\x0b\x6e\xe3\xdd\xa1\xa5\x45\xbf\x2c\x63\x68\x81\xa5\x6c\x95\x0f\x4d\x36\x33\xac\xc5\x65\xbe\xfd\xfb\x36\x63\x9c\x19\xa3\x50\xea\x14\xe3\x90\x31\x7d\x7d\x4f\x69\x7f\xdf\x21\xef\xd0\xaf\x00\x39\x20\x07\x12\xfa\x3a\x89\x7d\x18\x15\x4c\x77\x78\x91\x0a\xd4\x3e\x34\xf1\xe2\x9c\x97\xf1\xe1\x34\xe5\x1c\xfd\xe1\x8a\x90\x83\x59\x9d\x91\xfd\xf1\x22\x5c\xfe\x33\x49\x17\xf8\xf8\x9c\x3d\x1a\xee\xca\x02\x49\x5f\x66\x39\xcf\xd1\xe4\x2b\x5d\x4f\xd3\x96\xcd\x64\x05\xd0\x9f\x47\x73\xbc\xbe\x87\x3b\xd7\xa0\xad\xc9\xb9\xde\x36\xf6\x47\x30\xd5\xe8\x58\xd6\x8d\x1b\x3b\xb1\x5f\xed\x57\xff\x02\x00\x00\xff\xff\x33\xdf\x71\xfe\xf9\x07\x00\x00")
+var _yaoModelsJobCategoryModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x56\xdb\x8e\xd3\x30\x10\x7d\xef\x57\x58\x79\xee\x4a\x05\xb1\x48\xf0\x86\xb6\x3c\x2c\x12\x20\x81\x10\x0f\x08\xad\x9c\x78\xd2\x0e\x38\x76\xf0\x45\x6a\x58\xf5\xdf\x19\x3b\x69\xe2\xb4\x69\xb7\x41\x5b\x55\xbd\x9c\xb9\x9d\xe3\x19\xdb\x79\x5c\x30\x96\x29\x5e\x41\xf6\x96\x65\x05\x77\xb0\xd1\xa6\xc9\x96\x01\x95\x3c\x07\x19\xe0\x0f\x3a\x67\x77\x23\x93\x00\x5b\x18\xac\x1d\x6a\x75\x70\x38\xc4\x32\xc7\x73\x09\xac\xd4\x86\x69\xb3\xe1\x0a\xff\xa2\xda\x30\x81\x65\x09\x06\x94\x63\xae\xa9\xc1\x32\x5d\xb2\x5f\x3a\xb7\x6d\x36\xc7\x37\x96\xd2\xfc\xc8\x6c\x63\x1d\x54\xd9\xcf\x88\xe6\x1e\xa5\xc3\x90\xdf\x19\x0f\x11\x32\xc0\x85\x56\xb2\x21\xac\xe4\xd2\xb6\xa0\xd5\xc6\x11\xf0\x86\x5e\x5d\x36\xaa\x4f\xc0\x23\xfd\x49\xb4\x51\xb9\x87\x91\x3e\xb2\x15\xba\xaa\x88\xd3\xb1\xc6\x56\x42\x46\x3e\xfb\x98\xb1\xd0\xd2\x57\x2a\x52\x8c\x71\x6d\xe6\x24\x37\x8a\x2e\x63\x28\x4f\xfa\x02\x76\xbf\x1e\xb0\x7e\x25\x53\x30\x29\xfe\xce\x3b\x7d\x83\xaa\x30\x10\x10\x56\x1b\xac\x38\xd1\xf8\x0d\x4d\x16\xbd\xf7\xcb\xe9\xba\x07\x3d\x0f\x53\x04\xac\x33\xb4\xf2\x13\x24\x7a\x99\x67\xd8\x7c\x53\xf8\xc7\x03\x6b\xe3\x19\x0a\x82\xb1\x44\x30\xb1\xa7\x6e\x0b\xec\x68\x19\x43\x6e\x50\x1b\xb7\xa5\xe0\xd7\xaf\x7a\x4c\x79\x29\xbb\x56\xf4\xcd\x8a\x06\x1f\xf3\x77\x7d\xbd\xa8\x2f\x7e\xff\x87\xb0\x4f\xa3\xb8\x44\xda\x1a\x6d\x2d\x79\xc3\x42\xe2\x30\x83\x4f\xa8\x79\x79\x7b\xfb\x9c\x72\xb0\xa0\xed\x72\xbd\x9c\xfb\x91\x7b\xa2\x22\x18\x66\xf6\xe5\xc5\x6a\x35\xa5\xe4\x49\xca\xe9\x46\x3f\x61\xee\x60\xe7\x26\x78\xaf\xa7\x62\x12\xfa\x7d\x9b\x26\x93\xcf\x61\x17\xb7\xfe\x09\x2d\x54\x94\x1f\xcc\x04\xb3\xaf\xe4\xcf\x3e\x1b\x91\x1a\x13\x62\xd1\xac\x83\x39\x2e\xa9\x68\x87\x65\x70\x15\x50\x72\x2f\x83\xeb\xea\xec\x58\x5c\xe4\x5b\xf1\xdd\x03\xf5\xae\xf0\x86\x8e\xc2\xa2\x99\x43\xfd\x23\xdf\xb1\xbb\xa9\xd0\x84\x7f\xf0\x31\x5e\xa9\xb0\x6d\x61\x07\x85\x0f\x2b\x6b\x8f\xe7\x3c\x1e\xbc\xac\x26\x91\x4a\x0b\x58\xb2\x55\x14\xeb\x95\xc4\x0a\x1d\x88\x67\x94\xdb\x1d\xe7\x27\x2a\x73\xad\x25\x70\x35\xd5\xa0\x18\x31\xbe\x6c\x8e\x55\x7e\xdf\x02\xc9\x09\x13\x8f\x96\xd1\x9b\xb3\xb6\xce\xc4\xf8\x0f\x0a\xc6\x5b\x76\x96\x0a\x50\xc1\x55\xcc\x91\xf1\xfe\x38\xe4\x1c\xfd\xbe\x27\xf4\xfb\xa4\xce\xc0\xfe\x70\x01\x5e\x3e\x88\x50\x09\xd8\x5d\xb3\x6d\xfa\x6b\x74\x86\xa4\x2f\x27\x31\xd7\x68\x0a\x95\x6e\xc6\x61\xf3\x7a\x42\x9f\xed\xd3\x80\x3e\x3c\x6c\x74\xd7\x3a\xcd\x6f\x85\xd6\xb6\xd8\xb0\x3e\x99\xc3\x0a\xac\xe3\x55\x6d\x87\x95\xd8\x2f\xf6\x8b\x7f\x82\x1d\xe8\x8b\xe8\x08\x00\x00")
 
 func yaoModelsJobCategoryModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -2658,12 +2828,12 @@ func yaoModelsJobCategoryModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/job/category.mod.yao", size: 2041, mode: os.FileMode(420), modTime: time.Unix(1758619331, 0)}
+	info := bindataFileInfo{name: "yao/models/job/category.mod.yao", size: 2280, mode: os.FileMode(420), modTime: time.Unix(1792279690, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _yaoModelsJobExecutionModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x59\x5b\x4f\x1c\xb9\x12\x7e\xe7\x57\x94\xfa\x89\x48\x70\x42\x8e\x8e\x8e\x0e\x48\xe7\x01\x91\x48\x9b\x95\x56\x8b\x02\x51\x1e\x22\x34\xf2\xb8\xab\xbb\x0b\xdc\x76\xc7\x17\x60\x76\xc5\x7f\x5f\xd9\xee\x7b\xf7\x0c\x33\x1d\xf2\x06\xb6\xbf\xaa\xfa\x5c\xd5\x75\xf1\xfc\x7d\x04\x90\x48\x56\x62\x72\x01\x09\x3e\x23\x77\x96\x94\x4c\x4e\xfc\xb2\x60\x6b\x14\x7e\xfd\x77\xb5\x86\x4f\xc3\xbd\x14\x0d\xd7\x54\x85\x85\xfa\x44\x8b\x06\xcb\xd6\x02\x21\x53\x1a\xac\x66\xfc\x81\x64\x0e\x24\x53\x7a\xa4\xd4\x31\xd1\x3b\x47\xd2\x58\x26\x39\x9a\x28\xd3\xb2\xdc\x24\x17\xf0\x3d\x31\x1b\x63\xb1\x4c\xee\xc2\xea\xda\x91\xb0\xe4\xb5\x58\xed\x30\x2c\x69\x64\xa9\x92\x62\x93\x5c\x40\xc6\x84\x89\x8b\x46\x69\x9b\x5c\xc0\xf9\xf9\xf9\x79\x2d\x6d\x2d\x3c\x2b\xcf\xb0\xc7\xf1\x5e\xad\x57\x43\x9e\x00\x09\x57\x65\x89\xd2\x4e\xb8\x46\x26\xc9\x11\xc0\x4b\x90\xc9\x95\x70\xa5\x0c\x46\x06\x60\x94\xdd\x93\x4e\x69\x2d\xd2\x1b\xb0\xa9\xc2\xda\xe7\x8f\xdd\x5a\x7b\xa5\xfd\xc5\x9e\xf6\x4b\x67\xd5\x29\x49\xae\xd1\xaf\x40\xa5\xa9\x64\x7a\x03\x0f\xb8\x49\xc2\xe9\x97\x93\x79\xbd\x2d\xa3\xd5\x9c\x05\xc6\x6a\x92\xf9\x8c\x15\x1d\xd1\x2d\xf6\x7c\x95\xf4\xc3\x21\x44\x01\x40\x29\x4a\x4b\x19\xa1\x8e\xce\x2d\x70\xc6\x99\x3d\x35\x28\x73\x5b\x24\x17\xf0\xdf\xff\xb4\x6b\xd2\x09\x51\x3b\xa6\x75\x5d\xd8\x70\x41\x53\xed\xe5\x9d\x5c\xbd\x07\x0f\x62\xe9\x5d\xba\x85\xdf\x17\xcc\x50\xa3\xe4\x08\x56\x05\x3e\xf7\x6a\x0d\xb6\x20\xd3\x23\xb6\x46\xa1\x64\x6e\xc0\xaa\x85\xd4\x48\xa6\xf8\xbc\x0f\x33\x63\x99\x75\x66\xca\x0c\xa5\x2b\x67\x78\xdd\x8c\x8e\xf7\x78\x7d\x9a\xb8\x05\xc6\xc2\x55\xf3\xf1\x7e\xaf\x57\x00\x92\x1f\x0e\x1d\xa6\xc9\x09\xbc\x7f\xdf\xfb\x08\xc8\x40\xdc\x00\x26\x53\x78\x62\x64\x7d\x30\x58\xe5\x45\x6a\xdb\xa1\x49\x92\x25\x26\xe8\xaf\xe0\x86\x89\x8c\x35\xc6\x44\x50\x1f\xc2\xb4\x43\x6a\x27\xe5\x3c\x88\x3b\xad\x51\x5a\xb1\x01\x92\x50\x69\x95\x6b\x34\xa6\x03\x72\x55\x56\x02\xed\xd4\xe6\x8c\x24\x99\x02\x53\x30\x8e\x73\x34\x26\x73\x42\x6c\x3a\x5c\xc6\x48\xcc\x80\xc2\x2a\x3c\x91\x2d\x00\xb5\x56\xba\xaf\xc9\x5f\xa2\x98\x01\x3d\x31\x03\xed\x26\xac\x37\xe0\x0c\xea\xf7\x31\x85\x75\x70\x4b\x25\x2a\x67\xc7\x60\xbf\x9c\x82\x72\xbd\x5b\x7c\xa0\xa0\x65\xaa\x24\x53\x9a\x63\xe0\x01\x16\x75\x49\x92\xd9\xf6\x0e\xef\x5a\xb7\xa6\x98\x31\x27\x42\x10\x34\xde\x7c\xbb\x00\xb5\x9a\xf2\x1c\xf5\x8a\x33\x8b\xb9\xd2\x9b\xbd\x43\xf5\x36\x02\xe1\x6a\x02\xec\x05\xed\x6f\x94\x17\xa7\x02\x1f\x51\x40\xad\x08\xa6\x8a\xe6\xc2\xb6\x64\xd2\x31\x11\xef\xf6\xab\x41\x0d\x71\xc1\x5f\x54\x94\xd3\x8f\x35\xc3\x0b\x4c\x5d\xeb\xc8\x5b\x2a\xf1\x74\xcd\x8c\x0f\x95\xb8\xe3\xa3\xf4\x98\x6b\x25\x4f\x80\xa4\x45\xfd\xc8\xc4\xbb\x0e\x8e\x8f\xde\xda\xe8\x46\xff\xe7\x69\xaa\xe9\x11\x65\xa3\xa9\x17\x31\xac\xa2\xc6\xdd\x16\xb5\x64\x02\x2e\xaf\x3f\x03\x67\x42\xf4\x0e\xd5\xa5\x2e\x9c\xbb\x09\x7f\x9f\xc6\x2f\xc4\x62\x3a\x23\x33\xc5\x0a\x65\x8a\x92\x6f\x42\x80\x7c\x6c\xff\xad\x29\x8c\x10\x77\xbf\xc0\xf7\x46\x39\xdd\xcf\xf2\xaf\xa6\xdf\xc6\xf7\x37\x23\x60\xcf\xf3\x37\x15\x72\xca\x88\xb7\x7e\x8f\x4a\xfa\x05\xe7\x18\x9f\x2d\x4a\x43\x6b\x81\xef\x66\xf2\xf0\x87\x7f\xff\x6f\x8e\x6b\xd3\x32\x2c\x0c\x73\x25\x2d\x3e\xdb\x29\xd7\x7b\xd3\xf6\x0e\xb3\x51\x3e\xc6\xf5\x2b\x7c\x9a\x92\x8f\x60\xd6\x0b\xf2\x78\x3c\x64\xd7\x12\x2d\x4b\x99\x65\xb3\x5f\xed\xeb\x05\xa4\x89\xed\x15\x9b\xb1\xda\x27\x1b\x63\x59\x59\xcd\xd5\x92\x06\x09\x97\xf3\x76\x7f\x2b\x7c\x90\x0f\x2b\xa3\xcf\x4a\xad\x4a\x5f\x10\xb4\x93\x70\xec\x5b\x83\x6e\xf5\x5e\xad\xcd\xbb\xad\x6c\x0e\xf7\xcd\x93\xd2\x0f\xa8\x0f\x6b\x00\xbe\x05\xcc\xb6\x1e\xa0\xde\x6d\x0b\x65\xc1\x64\x1a\x92\xc0\x90\xed\xaf\x0e\xba\x4a\x2b\x5f\xa8\x0e\x63\x76\x1d\x41\xdb\xa8\x75\xdb\xa1\x61\x2b\x90\x3d\x6e\x9e\x90\xf2\xc2\x76\xbc\xcc\xde\x5d\xcd\x32\x5e\x1a\xad\xde\xac\x98\xb5\x58\x56\x33\x41\xe9\x53\x6c\x8e\x7a\x86\xdb\x17\x0f\x84\xcb\x31\x70\x10\x93\xc4\x0b\x08\x0a\xa0\x56\x10\x9d\x46\x06\x8e\xcf\x02\xe5\x8c\xb4\xb1\xcd\x66\x2f\x0e\xbb\x32\x79\xb6\x35\x47\xee\x76\x17\xf3\x7d\xc9\x6a\x61\xe3\x7d\x1d\xd0\xf0\x6a\xff\x3d\xe8\x4f\xa3\xca\x7e\xcb\x9d\xb5\x74\x59\xbc\x86\x5f\xec\xcb\xd0\xf0\x2d\xc9\x2e\x11\xb7\x2d\xb7\xdc\x36\x50\x78\xf2\x59\xa6\x23\x58\xeb\x7b\xc3\xf4\xe1\xcb\xe5\xe1\xf6\x7f\xf2\xa8\x83\xad\x0f\xba\xde\xd0\xf6\xba\x87\x5c\x19\xe4\x4a\xa6\x33\x73\xc2\xf6\x6f\xe9\x36\x42\xe1\x66\x0c\xed\x57\x26\x6e\xfd\x78\x5e\x6b\xf1\x6d\x6c\x5a\x8f\x79\x83\xa4\x7f\x4c\xb2\x40\x4d\xde\x9d\x99\x56\x65\x98\x98\x94\x06\xf5\x88\x5a\x53\x9a\xa2\xdc\x9e\xed\x77\xb2\x4b\x9d\x66\xc3\x3c\xfb\x3a\xad\x8f\x13\xcc\xec\x0c\xd4\x88\xf6\x33\x44\x49\x42\xd0\xe4\x06\x0f\x31\xb4\xd2\xa4\x34\xd9\x99\xe6\x77\xbb\xa1\xd7\x13\xcc\xac\xa1\x8d\x68\x38\x2e\x28\x2f\x50\x83\x74\xe5\x1a\x35\xfc\x1f\xea\xff\x9b\x03\x07\x66\xb2\x45\xd5\x28\x8c\x5a\x87\x91\x1c\x63\xb6\x90\x8c\xc7\xa0\x42\xcd\x51\x5a\x96\x23\x1c\x9f\x9d\x7e\x38\x3b\x7b\xcb\xfc\xdc\x25\xe6\x38\x31\xcc\x30\xd9\xd2\xc5\x75\x96\xfe\x39\x46\xce\xf2\xa9\xe5\x03\x49\x2e\x5c\xea\x1b\x87\xd6\x8d\xbe\xa3\x33\x05\xd3\x98\xc2\xf2\xa6\x8e\x2b\x99\x51\xbe\x32\x92\x55\xa6\x50\xfb\x77\xa3\x57\x01\x07\x37\x13\x5c\xbf\xf1\xae\x37\x41\x65\xe1\x43\x8e\xba\x9a\xcf\x85\xf5\x8b\x8d\xcf\x0b\xcb\x08\x68\x34\xde\x95\xfb\xda\xfd\x65\x74\x7c\xf6\xd2\xa3\xcc\x9f\xb8\xd5\x30\xdf\xaf\x48\x66\x6a\xff\xc0\xf0\x10\xf8\x3c\x80\xf4\xef\xd2\x6a\xc7\xad\xf3\xce\x0e\xc2\xc1\x0b\xd7\x25\x6b\x4a\x35\x8e\xde\x19\x16\xb6\xf8\x96\xf1\x87\x95\xd5\x6c\x6e\x06\x1b\x4e\x1d\xfd\xf2\xcb\x1f\xe0\x76\x88\x19\x58\xee\xf7\x83\xcc\x81\xd1\xa3\x7a\xe6\xcd\x5e\x98\x33\x4b\xb4\x9a\xf8\xfe\xdf\xe0\x1f\xe3\xf3\xb3\x41\x50\x4b\x05\x41\x0f\x08\x25\x96\x4a\x6f\xc0\x19\x96\xe3\x09\x5c\x5d\x7f\x0d\x11\x7b\x02\x68\xf9\xbf\x16\x7f\x78\x07\x8d\x7f\x7b\x8e\x7d\xdd\x85\x2e\x18\xfc\x8e\xea\xa1\x3e\x66\x72\xdc\xf9\x14\xfd\xdc\xeb\x4e\xef\xd5\x7a\x35\xe9\xa6\x7a\xcf\xd9\xed\xab\xea\xa0\xc9\xbb\x9b\xa3\x72\xa5\xca\x4a\x19\xb2\x3e\x56\x52\x7c\x0e\x3d\xc2\xfd\xe0\xfd\xbf\x20\x63\xbd\x2f\x7e\x38\xd4\x84\x66\xf7\xd3\xf5\xd0\xce\xf8\x4a\xb9\xdb\xd4\xe6\x25\x73\x99\xa9\x11\x5d\xbf\x97\xd4\x16\xc6\x27\x3f\x1f\x2f\xa0\x74\x8a\xa1\x69\x3f\xc0\xe8\x7a\x26\xdd\x69\x74\x6f\x6e\x5d\x66\x77\x14\x30\xbd\xe5\x43\x0c\x6d\xdf\x70\x76\x59\x3a\x7d\xe4\x5b\x66\xf0\xf8\x0d\x0f\x98\x64\x62\x63\xe8\xa0\x78\xd8\xf6\xea\x34\x67\x70\x73\xe6\xe7\xcc\xad\x9f\x9e\x96\x18\x5b\xcf\x84\xa3\x49\xac\x6f\xea\xec\xd4\x38\x9e\x91\xf7\x36\x39\x4e\xbe\xbc\x60\x24\xdb\x5f\xda\x92\x51\x9e\x68\xdf\x4b\xeb\xdf\xc1\x2a\xd4\x25\x19\x13\xd7\xba\x39\xa4\x1b\x7f\x4c\x97\x6f\x5e\x8e\x5e\x8e\xfe\x09\x00\x00\xff\xff\x77\x3a\x68\x76\x21\x1c\x00\x00")
+var _yaoModelsJobExecutionModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x1a\x5d\x6f\xdb\x36\xf0\x3d\xbf\x82\xf0\x53\x02\x38\x6b\x3a\x0c\xc3\x36\x60\x0f\x41\x5a\xa0\xdd\xba\x2d\x68\x52\xf4\xa1\x08\x0c\x5a\xa2\x2d\xd6\x12\xa9\x91\x54\x1c\xaf\xc8\x7f\xdf\x1d\x49\x49\x94\x44\x3b\x96\x9a\x04\x01\x1c\x93\xbc\xe3\x7d\x7f\x31\xdf\x4e\x08\x99\x09\x5a\xb0\xd9\x6f\x64\xc6\x1e\x58\x52\x19\x2e\xc5\x6c\x8e\xcb\x39\x5d\xb2\x1c\xd7\xff\x90\x4b\xf2\xb6\xbb\x97\x32\x9d\x28\x5e\xda\x05\x7f\xa2\x81\x26\x86\x2e\x73\x46\x56\x52\x11\xa3\x68\xb2\xe1\x62\x4d\xb8\x48\xf9\x3d\x4f\x2b\x9a\x07\xe7\xb8\xd0\x86\x8a\x84\x69\x87\xd3\xd0\xb5\x06\x64\x5f\x66\x7a\xa7\x0d\x2b\x66\x77\x76\x75\x59\xf1\xdc\x70\xbc\xc5\xa8\x8a\xd9\x25\xc5\x68\x2a\x45\xbe\x83\xb5\x15\xcd\xb5\x5b\xd4\x52\x19\x58\xf8\x15\x7e\x3c\x36\x20\x02\x16\xbe\xc1\x97\x80\xc7\xaf\x72\xb9\xe8\xf2\x09\x9b\x89\x2c\x0a\x26\xcc\x80\x57\xc7\xc9\x0c\x0e\x3d\x5a\x9c\x89\xcc\xab\x42\x58\x22\x2d\xa0\xc3\x1d\x60\xe7\xa9\x47\x89\x04\xec\x4a\xbb\xf6\xfe\x4d\xbb\xd6\x88\x34\x5c\x0c\x6e\xbf\xac\x8c\x3c\xe7\x22\x51\x0c\x57\x48\xa9\x78\x41\xd5\x8e\x6c\xd8\x6e\x66\x4f\x3f\xce\xe3\xf7\x36\x1c\x2d\x62\x14\x68\xa3\x40\x07\x11\x2a\x5a\x46\xf7\xd0\xf3\x49\xf0\x7f\x2b\x46\x1c\x02\xc2\x53\x58\xe6\x2b\xce\x94\x53\x6e\xc6\x22\xca\x0c\xae\x61\x62\x6d\x32\x40\xf3\xf3\x4f\xcd\x9a\xa8\xf2\xdc\x2b\xa6\x51\x9d\xdd\xa8\xec\x4d\x5e\xcb\x07\x79\x45\x0d\x8e\xe2\x12\x55\xba\x87\xbf\x8f\x6c\xc5\x14\x03\xaa\x89\x91\x96\x1f\x40\x0e\x9f\x5c\x07\x8c\x01\x16\x29\xd6\x1a\x4e\x4c\x64\x0d\x6c\x9f\x3d\x1c\xc3\x19\x08\xd0\x54\x7a\xc8\x19\x13\x55\x11\xe1\xeb\xa6\x77\x3c\xe0\xeb\xed\x40\x2d\xa4\x8f\x5c\xd6\xce\xfb\xc5\xaf\xc0\x1a\x68\xa0\x62\x20\x5a\xf2\xea\x55\xe0\x04\x20\x0d\xb7\x41\xa8\x48\xc9\x96\x72\x83\xc6\x00\x02\x03\x94\xca\xb4\xd0\x7e\xa7\x0f\x8e\xcb\xda\x1a\x0c\x7e\x56\x25\xe8\x89\xd1\xa2\x15\x30\x4a\x96\xac\xb8\xe0\x3a\x6b\x71\xc1\x57\xc3\x69\xce\xff\x8b\x20\x04\x7a\x96\xcc\x05\x15\x7f\x88\xa5\x2d\xa4\xaa\x84\x88\x03\x25\x95\x02\x5d\x9b\x7c\x07\x80\xe0\x5a\x72\xad\x98\xd6\x2d\x20\x88\xaf\xcc\x99\x19\xf2\xef\x68\x03\xf6\x75\x95\x40\xb0\xd2\x2b\x50\xf4\xae\x85\x5b\x51\x9e\x47\x80\xec\x2a\xd9\x72\x93\x11\xa6\x94\x54\xe1\x4d\xa8\x90\x3c\x02\xb4\xa5\x40\x65\xbd\x49\x96\x3b\x52\x69\xa6\x5e\xb9\x70\xd8\x82\x1b\x5e\x30\x59\x99\x3e\x30\x2e\xa7\x04\x36\xda\x93\x1b\xbe\xef\x16\x50\x47\xc2\x2c\x23\xc4\x30\x55\x70\x41\x4d\x28\xc4\x14\x22\xec\x1e\x8e\xd0\x06\xd0\x53\x14\x03\x7f\x63\x9a\x50\x85\x51\x20\xa3\x95\xee\x60\xd0\x1b\x5e\x96\x70\xf7\xf0\x6a\xbf\x33\xb7\x58\x52\x56\x32\x70\x0f\x91\xec\x48\x22\x21\x47\x38\x83\xd8\x82\x53\x12\x21\x0d\x29\x58\xcd\xcd\x5d\x63\xb8\x29\x5b\xd1\x2a\xb7\x66\x5e\xdb\xeb\xf3\xb9\x20\xf0\xb4\x5e\x33\xb5\x48\x40\x1e\x6b\xa9\x76\x47\x3b\xe3\xad\x03\x24\x57\x03\xc0\xc0\x2d\xdf\xf1\x75\x76\x9e\xb3\x7b\x96\x13\x7f\x11\x19\x5e\x14\x73\xcc\x82\x0a\x48\x9d\x4e\x23\x9f\xc0\x26\x88\x5b\x40\xed\x39\x3c\x1d\xd1\x27\x60\xac\x55\xa3\xf8\x5b\xb0\x8b\xf3\x25\xd5\x68\xc0\x6e\x07\x7d\xe7\x34\x51\x52\xcc\xc1\x13\x40\xfd\xf7\x34\x3f\x6b\xc1\x81\x3c\x51\x1b\x17\xfe\x79\x9e\x2a\x0e\x9f\xf5\x4d\x81\x1d\xd3\x92\xd7\x46\x02\x58\x04\xe4\xf6\xcb\xeb\xf7\xc0\x51\x9e\x07\x87\x7c\x32\xb7\xe7\x6e\xec\xdf\xe7\xce\x6f\xc1\x5c\x22\x38\x5b\x83\xb0\xa6\xf3\xa6\xf9\xea\x59\xe8\x41\xdc\xbd\x80\xee\xb5\xac\x54\x98\xc7\x9e\x4c\x30\xb5\xee\x6f\x7a\x80\x81\xe6\x6f\x4a\x96\x40\xe6\x4c\x1a\xbd\xbb\x4b\xc2\x94\x7a\xca\x40\x8a\x42\x73\xe0\xe1\x2c\x92\x69\x5e\xff\xf8\x4b\x8c\xd7\xba\x28\x9a\x68\xe6\x12\xf4\xff\x60\x86\xbc\x7e\xd5\x4d\x75\x14\xb5\xf2\x3e\x5c\x58\xc3\xa4\xce\x8d\x69\x60\xe4\xee\xb8\x8d\x1d\xe0\xd2\x34\xa5\x86\xce\xf6\x71\x73\x38\x45\xd6\xb6\xbd\xa0\x11\xaa\x31\x04\x42\x52\x2a\xca\x58\xb6\xac\x21\xc9\x65\x9c\xee\xcf\x19\x1a\x79\x37\xf7\xdb\x78\xd5\x00\x42\x9e\x82\xec\x42\x4e\x31\x97\xb5\xab\x50\x32\xe8\xb3\xd9\xf3\xe9\x66\x2b\xd5\x06\x54\x33\xaa\xc4\xf9\x6c\x61\xf6\x55\x39\x7e\xb7\x29\x05\x32\xd0\x83\x0d\x02\x5d\x6e\x5f\xda\xe8\x84\x4c\xd9\x38\xb6\xfe\x06\x88\x7d\x4c\x5d\xe5\x98\x71\x14\x41\xac\x24\x93\x79\xea\x18\x0a\x4b\xd2\x9c\x41\xc8\x78\x69\xae\xec\x25\xd0\x52\x94\x1c\xca\x89\xd1\x56\xf9\x01\xa1\x21\x7e\x5a\xe8\x27\x2c\x73\xc0\x1a\xf1\xb7\xce\x89\x84\x5d\x27\x0a\xa8\xa6\xe8\x86\x11\x79\xcf\xea\x02\x1d\x8f\xa4\xc4\x97\x45\x41\xd9\xf5\x8c\x26\x9b\x31\x28\x04\x97\x8c\x9a\xd1\xfc\xbf\xab\x21\xf7\xf1\xfe\x81\x6a\xe3\xb9\x85\xe2\x8d\x6d\x21\xaa\x40\x5d\x84\x9c\x39\x3f\x99\x16\x46\x20\x20\xb9\x6a\x30\xd9\x2d\xb0\xb9\x3a\xde\x24\xaf\x5a\x48\xf2\x27\x8b\xa7\xf9\xf0\x0c\x60\x27\x72\x65\x09\xa6\x69\xc1\x0d\x66\xbd\x46\x07\xa0\x38\x11\x5b\x27\x25\x68\x0f\x21\x29\x64\x69\xad\x2d\xb4\x55\xee\x4b\x1b\x73\x5d\x8d\x8c\x73\xd3\xba\xe6\xd9\xeb\xaa\xf5\xbe\x17\x04\x36\x59\x07\x64\x52\xf7\x96\x35\x31\xd0\x7d\x48\x03\x81\xf8\x08\x61\xc4\xdb\xb1\x69\xb2\xa8\x49\x1b\x6d\xd3\x97\x35\x4f\x4f\xb9\xf3\x20\x74\x39\x3b\x6f\x64\xd2\x75\x79\x6c\x25\xa0\x71\xc1\x26\x2a\xe9\x19\x18\xe6\xd5\xae\xb4\x9e\xd1\xb9\xa1\x53\xc2\xae\x67\x9c\x49\x5c\x3b\xa0\x7d\x16\xd1\x6e\x5b\x6d\x43\xfc\xb8\xdf\x6d\x19\x54\xc7\x26\x1a\xa0\x5e\x44\xbf\xd8\xc0\xec\x40\xb9\x50\x91\x96\x11\x0d\x63\x65\xbc\x0e\xe3\x4b\xc3\xdb\x47\x04\x04\xe5\xf6\x00\x3b\x1a\xe6\x49\x66\x3b\x24\xd0\x8d\x3b\xe7\x72\x2d\xfc\x9e\x5e\x58\x96\x57\x5c\x41\x60\xf3\x9b\x41\xf9\xd0\x76\x37\x17\x7b\x4b\xdb\xc3\xea\xa2\xd8\xe4\x2e\x26\x4e\x84\xae\x2d\x34\x79\x72\x30\xd4\x19\x9c\xb8\x2b\xc3\x59\xd0\xaa\x61\x97\x3a\x31\xbc\xb0\x2e\xed\x24\x62\x4a\x51\xe8\xe0\xf6\x79\xea\x6d\x0d\x4a\xb6\xe8\xb3\x2d\x83\xfe\xbe\x67\xf4\x32\xec\x72\xc6\xd3\xff\x16\xa1\x46\x53\x6f\xef\x7a\x46\xda\xfd\x40\x62\xa1\x19\xf6\xf0\x7a\x8c\x2f\xdd\x3a\x50\x72\xd3\x07\x0d\x1b\x8a\xc4\xe0\xdc\xd8\xdf\x82\x33\x91\xd4\xe7\x88\x4e\xad\x7e\xca\x05\x14\x41\x1c\xd5\xb9\x52\xb2\x70\x59\x46\xd9\x4a\x48\xf1\x14\xfa\xac\xb3\x69\xb5\x42\x5a\x29\xda\x2d\x8f\x9f\x66\xeb\xcd\x00\x26\x3a\x9c\xab\x51\xe3\x40\xaa\xe0\x79\xce\x07\x12\x1c\x43\x68\xa9\xb8\x04\xfe\x77\x63\x08\xbd\x1e\xc0\x44\x09\xad\x51\x93\xd3\x0c\x82\x34\x96\x9a\x55\xb1\x84\x8f\xdf\x89\xff\x5e\x1f\x18\x19\xc9\x26\x65\x23\x3b\xb7\x1b\xc7\x64\x1f\x66\x0f\x93\xee\x18\x56\x5f\x09\xec\xd2\x35\x83\x68\x7d\xfe\xfa\xe2\xe2\x39\xe3\x73\x1b\x98\xdd\xa0\x47\x1f\xdd\x7c\xb7\x94\xfe\xd3\x87\x8c\xf2\xe3\xf1\x83\x71\x25\x79\x65\x6b\x8c\x46\x8d\x58\x30\xe8\x8c\x62\x63\x30\xbd\x17\x07\x53\x5d\xf1\xf5\x42\x0b\x5a\xea\x4c\x1e\x3f\x44\xb8\xb2\x70\xe4\x66\x00\x17\xce\x4b\xfc\x26\xd6\x89\xe8\xc8\xee\xae\xda\x5d\x68\x98\x6c\x30\x2e\x4c\x63\x00\x94\x8d\xaa\x3c\x96\xee\x8f\xbd\xe3\x51\xa1\x3b\x9c\xdf\x21\x55\x3b\x2c\x5e\x70\xb1\x92\xc7\x1b\x06\x82\x90\xf7\x1d\x90\x50\x96\x70\x27\x84\x50\x54\xb6\x45\x4e\x10\xb9\x2a\x68\x9d\xaa\x59\x6f\xc4\x3b\x71\x32\x63\x68\xb2\x59\xe0\x83\x5f\x64\x74\xd6\x1d\x16\x85\xe9\x37\xd9\x90\xdb\x2e\x4c\x87\x72\xdc\xb7\x38\x3b\x44\xf7\xf2\x19\x92\x3d\x31\x66\x16\x38\xc5\x4e\x8e\xf7\xc1\xbf\xfa\xe7\xa3\x46\xe0\xb1\x92\x9c\x43\x2b\x5e\xb0\x02\xcb\xf2\x4a\x43\x3c\x99\x93\xab\xeb\x4f\xd6\x62\xe7\x84\x99\xe4\x87\xc9\x8e\x37\x6a\x6a\x77\xe4\xb4\xae\x15\xe8\x84\x79\xdd\x89\x9f\xc5\xba\x48\xce\x0e\xbe\x91\x3e\x04\xd5\x29\xbe\xe6\x0d\xaa\xa9\xe0\x9d\xb5\x79\xee\xeb\x14\x79\x77\xf1\xb6\xbb\x28\xa5\x86\x02\x80\x58\x1a\x6c\x8d\xf0\xb5\xf3\x30\x0d\x05\x83\x71\x2d\x12\xc3\xb7\x8b\xc3\x6f\xaa\x5d\x3a\xdd\xf3\xd9\x61\x52\xeb\x27\xb6\x69\xa4\x3a\x68\x3f\xe6\xf6\x14\xba\xf7\x23\xb4\x17\x28\x65\x52\x66\x8b\xf6\x11\x44\xfb\x51\xe2\x41\xa2\x83\x71\xe3\x34\xba\x1d\x82\xa1\x94\x27\x48\xb7\x37\xb0\x8b\xcb\x76\x30\x70\x3b\x9a\xd2\x7a\x16\xe6\xda\x6c\x9c\x93\x61\x71\x38\x86\xce\xe6\x89\xe0\x90\x44\x87\x6f\x48\xd3\x04\xdb\x7f\x22\x02\x77\xa4\xf9\x4e\x73\x3d\x89\xe2\xc1\xdb\xc4\x90\xe0\xfa\xcc\xf7\x91\xeb\x5f\x36\xa6\x10\xeb\x7b\xd7\x5e\xc7\x18\x92\x1a\xed\x6e\xfb\xbd\xfc\xd1\x24\xbb\x0e\x3d\xc9\x28\x17\xcd\xbf\xaa\xcc\x7a\xf1\xac\x79\x8e\xf3\xff\x48\x52\xe2\x73\xa9\xd6\x6e\xad\xed\x97\xda\x36\x4d\xb7\x71\xf1\xf1\xe4\xf1\xe4\x7f\x8e\x6c\xde\xbb\x62\x23\x00\x00")
 
 func yaoModelsJobExecutionModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -2678,12 +2848,12 @@ func yaoModelsJobExecutionModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/job/execution.mod.yao", size: 7201, mode: os.FileMode(420), modTime: time.Unix(1758619331, 0)}
+	info := bindataFileInfo{name: "yao/models/job/execution.mod.yao", size: 9058, mode: os.FileMode(420), modTime: time.Unix(1792291273, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _yaoModelsJobJobModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\x5f\x4f\xdc\x38\x10\x7f\xe7\x53\x8c\xf2\x52\x90\xa8\x68\xab\xeb\xe9\x40\xba\x87\x1e\xa0\xaa\xd5\x15\x2a\xa0\xea\x43\x55\x45\x4e\x32\xd9\x35\x75\xec\xd4\x7f\xca\x46\x27\xbe\xfb\x69\x9c\x7f\xce\xe2\x5d\x36\x88\xbe\x80\xd6\xf1\x6f\x66\x7e\x33\xe3\x99\xb1\xff\xdb\x03\x48\x24\xab\x30\x39\x81\xe4\x56\x65\xc9\x21\x2d\x08\x96\xa1\xa0\x95\x8f\xfd\x4a\x81\x26\xd7\xbc\xb6\x5c\xc9\x6e\x1d\x2c\xcb\x04\x42\xa9\x34\x54\x4c\xb2\x05\x97\x0b\xb0\xcc\xfc\x00\x5c\x61\xee\x68\x23\x30\x59\x80\xc9\x97\x58\x38\xc1\xe5\xa2\x15\x64\xd9\xc2\x24\x27\xf0\x2d\x31\x8d\xb1\x58\x25\xdf\xfd\x6a\xe6\xb8\xb0\x9c\x44\x5b\xed\xd0\x2f\x69\x64\x85\x92\xa2\x49\x4e\xa0\x64\xc2\xb4\x8b\x46\x69\x9b\x9c\xc0\xf1\xf1\xf1\x71\x27\x2d\x13\x64\x3a\xd1\x88\x10\x01\x48\x72\x55\x55\x28\x6d\x6f\x74\xc5\xb8\x6c\x2d\x4f\xf6\x00\xee\xbd\x90\x5c\x09\x57\x49\x6f\x95\xc7\xb4\xc2\x02\x71\xbc\xe8\xa4\x91\xc6\xa6\xf6\x6b\x1f\xce\xc6\xb5\xc1\x5d\xe1\x62\xa0\xf8\x9d\xb3\xea\x25\x97\xb9\x46\x5a\x81\x5a\xf3\x8a\xe9\x06\x7e\x60\x93\xf8\xdd\xf7\x87\x71\xbd\xb7\x2a\x4b\x63\xba\x8d\xd5\xbd\x3f\xa7\xfa\x89\xe1\x06\x1b\xbe\x48\xfe\xd3\x21\xb4\x50\xe0\x05\x4a\xcb\x4b\x8e\xda\x07\xd0\x2e\x11\x46\x9f\x91\x44\x94\x0b\xbb\x4c\x4e\xe0\xcf\x3f\x86\x35\xe9\x84\xe8\xdc\x3d\x04\xc4\x7f\x70\x5e\x74\x17\xbb\xad\x84\xfc\xff\x79\x74\x2e\x26\x90\x80\xd0\x19\x37\xb5\x60\x0d\x90\x4c\x50\xe5\x16\x0e\x6f\xde\xbe\x7d\x9c\x04\x97\x05\xae\x76\xe1\xc0\x73\x25\x67\x70\xf8\x30\xd9\x1e\xd8\x4f\x1f\x76\x8f\xc3\xeb\x57\xaf\x62\x1c\x1e\xb5\x36\x3c\xb7\x0f\x8c\xb6\xb8\xb2\x11\x93\xcf\x62\x98\xb5\x73\x14\x95\x3b\xc7\xb0\x9c\x59\x5c\x28\xdd\xcc\x4b\xf0\xd3\x0e\xb5\x29\xcb\xaf\xb0\x44\x8d\x32\x47\xb0\xca\x7b\xb3\x57\x03\x76\xc9\x0d\xf9\x16\x32\x14\x4a\x2e\x0c\x58\xf5\xc4\x74\xdf\x39\x53\x2a\xb6\x4a\xef\x94\xfe\x81\x3a\x95\xae\x32\x0f\x69\x72\x69\x71\x81\x3a\xc2\xf3\x13\x5b\xc1\x57\x0f\x85\x0b\x57\x65\xa8\x4d\x94\xee\x27\xb6\xe2\x95\xab\x40\xfa\x3d\x74\x0a\x72\x25\x73\xa7\x35\x95\x99\x56\xb5\xe9\x12\xab\x65\x3f\x4a\x29\xb0\x64\x4e\x90\x94\xd7\x1b\x29\x6f\x65\x67\x2c\xb3\x2e\x42\x0a\xa5\xab\x22\x8c\xae\xd7\xb6\xaf\xe5\x93\xfa\x85\x9a\x09\x01\xeb\x52\x55\xdf\x72\xbe\x75\x2b\x64\xba\x66\xa5\x4d\x0e\xe1\xe8\x08\x08\xca\x0d\x64\x48\x45\x2d\x57\xb2\xe4\x0b\xa7\xb1\x38\x04\xa9\x2c\x50\x07\x69\x28\x11\xb4\x93\x23\xda\xaf\x4e\xd0\xc3\xbe\x0c\xbb\xe6\x85\x45\xb0\xdf\x49\xe9\x93\xb1\x47\x2c\x99\x01\x96\x5b\xfe\x0b\xc7\x56\xb7\x6f\x0e\x46\x44\xcd\x9c\xc1\x62\x04\x8c\x0d\x91\x1b\xb0\x58\xd5\x4a\x33\xcd\x45\x03\xed\xc6\x11\x98\xab\xaa\x16\x68\x43\x6c\xc9\x25\x37\x4b\x2c\xc0\xb8\x3c\x47\x63\x4a\x27\x44\x03\xfb\x14\xd3\x17\x4a\xe6\xf8\x82\xa2\x1a\x2a\x2f\x19\x17\x13\x01\xfe\xb7\x6f\xc5\x77\x4a\xbe\x20\xaf\x58\xdd\x00\x93\x4d\xa5\x34\x06\x4e\xe5\x86\x42\x5f\x24\x81\x63\xfa\x35\xc8\x1a\x70\x06\xf5\x51\xdb\xb3\x3b\xd0\xf7\x48\x2e\xf5\xb1\x79\xc6\x43\xa4\x8a\x48\xcb\xd8\x90\x64\x9f\x26\x9b\xd7\x52\x6c\x0c\xc3\x54\x66\x2c\xc5\xde\x5f\x5e\x5d\x7e\xb9\xf9\x70\x71\xde\x3a\xf2\xbc\xcd\x0a\x70\x86\xf2\xec\xbd\x82\x85\xd2\xca\x59\x2e\x11\xf6\x05\x5f\x2c\xed\x1d\xd2\xdf\x43\x28\x99\xb1\x41\x30\x3e\x5f\x5d\x9e\x9e\x5f\x5f\x27\xa1\x0c\x66\x80\xc8\xd7\x28\x8b\x76\x18\x50\x14\x57\xd8\xe7\x46\x09\x66\x29\x77\x97\xc8\x7e\x35\xad\xc4\x83\x6d\xbe\x0e\x8c\x7c\x3e\x7f\x77\xf3\x1a\xa6\xde\xd5\x3b\x9f\xee\x0e\x05\x37\x13\xd4\x5a\x04\xc6\x59\x10\x6a\x66\x2d\x6a\xb9\x3d\x0a\x94\xe0\xd3\x00\xd0\x8a\xcf\xe5\xf6\x5c\x04\x47\x47\x53\x23\x0a\xb7\x66\xcc\x60\x01\x4a\x02\x7d\xea\x55\x87\x09\xcf\xb0\x52\xd2\x87\xe6\xca\x49\x2a\x1e\x96\x4b\xa7\x9c\x11\x0d\xc5\xa8\xfd\xdc\x87\x67\x5b\x18\x5a\x2b\x7f\x43\x04\x70\x55\x6b\x34\x26\xda\xba\x37\x76\xc8\x21\x12\xe7\x11\x74\x10\x8f\x53\xf2\xca\xa8\xc1\x77\x89\x5e\x73\xe1\x8b\xca\x33\x4e\x21\xd4\x09\x7d\xdd\x49\x73\xe5\xa4\x9d\xdb\x09\xaf\x7c\xc9\x3a\x9d\x42\xb7\xb6\xc1\xae\xc8\x59\xaa\xb6\xd6\x50\x16\x50\x1d\x74\x1a\x63\x0d\x30\xca\xe9\xf1\x06\xd8\x09\x48\x2d\xaf\x50\xb9\x59\xa4\xce\x5a\x28\xdc\xac\x43\xc3\xf9\xb6\xdb\x33\x96\xad\x4e\x11\x70\x09\x06\x73\x25\x0b\x03\xfb\x39\x93\xd4\xb6\xa8\x7d\x6a\x5e\x14\x28\xa1\x46\x3d\x62\x0e\x9e\x36\x9c\xd5\x9a\x2b\xcd\x6d\x33\x87\xd3\xe7\x07\x98\x8d\xf5\xb7\x17\x0f\xfb\x4b\xbe\x58\xa2\xee\x03\xf7\x37\x74\xbf\xfb\x0d\x07\xf3\xc2\x35\xff\xac\xe5\x1a\xa9\xe6\xa6\x59\x84\xea\xe6\x21\xb4\x05\xc1\x3f\x71\xae\x5f\x0c\x6a\xb8\x5b\x2a\xe8\x84\x47\x86\xaf\xf1\x40\xbd\xf9\xeb\x19\xd9\x48\x5c\xd9\x54\x3b\x99\xb2\x48\x36\x52\xf6\x18\xcb\xaa\x3a\xc2\xe8\x02\x57\xd6\x57\xc1\x77\xf1\x5c\xbc\xe9\xb1\xbe\x4c\x90\x9a\xa0\x56\x0c\x71\xdd\x98\x6b\xf3\x99\x08\x66\x9e\xc6\xe4\x5f\x66\x76\x64\xa2\x4a\x20\x2d\xbf\xc5\xfc\x6e\x04\x4f\x07\xd9\x33\x6f\x39\xdd\x04\x7f\x3e\x9c\x98\x5d\xae\x3b\x9d\xd2\x23\x9a\x22\x42\x5e\xc0\xa5\xb1\x6c\xd2\xa4\x1e\xb9\xef\x3c\x91\xb4\x1f\xbf\x1f\xd2\xbc\x35\xa1\x6f\x47\x92\xdd\xb4\xce\xb6\xde\x33\xf3\x70\x17\xd4\x4c\xb3\x0a\xed\xe4\x3a\x34\xa7\xae\xf9\xe7\xa3\x19\x35\xed\x5a\x69\x0b\x97\xba\x08\x3f\x06\x06\xfa\xcf\x8a\x3e\xfb\x73\x51\xb4\x2f\x12\xcf\xd8\x62\x50\xb6\x33\xf9\x03\x93\x33\xa5\x04\xb2\x98\x5b\xcf\xd7\x21\x81\xbd\x5f\x97\x68\xa9\xbc\x0e\x77\x61\x6e\xa0\x53\xe1\x09\x44\x8e\xc2\x48\x61\x92\x14\xcf\x30\xe5\xb4\xcf\x7f\x33\x98\x5d\x7b\x04\x7c\x0c\x0b\xe9\x26\x72\xdc\x00\x83\x56\xc5\xa6\x6b\xef\xd4\xee\x59\x61\x19\x9e\x26\x67\x58\x7f\xf5\x00\xf3\x48\x60\x48\xc9\xcb\x29\x62\x9e\xf1\x7b\xdd\xbc\xda\xc6\x04\xb7\xbe\x6f\xae\xd2\x5b\x95\xa5\xc3\x9b\xcc\xfa\x2d\x3c\x78\x21\x9d\x3e\xdc\x0c\xcf\x00\xdf\xa3\x33\xa6\xaa\x6a\x65\xb8\x45\x7f\xe3\x59\xf9\x1c\x1b\x1e\x64\xfc\xe3\xb0\x07\xc3\x4f\x87\x9a\xa3\xd9\xfe\x18\xda\x1b\x49\x97\xb7\xad\x06\xb6\xb7\xbb\xf9\x96\x11\xee\xc9\x56\x4d\xae\x4d\x69\xdf\x88\xe3\x06\xae\x5d\xb1\xa6\x7d\x7b\x67\x73\x27\xc3\xfa\x3c\x63\xc7\xa9\x67\x7b\xa4\x83\xe1\x68\xbe\x3b\x1d\x8d\x41\x0f\x4d\x1b\xb2\x72\xb8\xf0\x75\x6f\xf7\x35\xea\x8a\xb7\xf7\x95\xb0\xd8\x8c\xdd\xde\x8c\x65\xe5\x7e\xef\x7e\xef\xff\x00\x00\x00\xff\xff\x26\x86\x18\x22\xba\x18\x00\x00")
+var _yaoModelsJobJobModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x59\xdd\x4f\x1b\x39\x10\x7f\xe7\xaf\xb0\xf2\x52\x90\x52\xd1\x56\xd7\xd3\x5d\xa5\x7b\xe8\x01\xad\x5a\xb5\x50\x11\x50\x1f\x2a\x14\x39\xbb\x93\xc4\x65\xd7\x4e\x6d\x2f\x64\x85\xf8\xdf\x6f\xc6\xf6\xee\x7a\x93\x4d\xc8\xe6\x28\xaa\xa0\x19\x7b\x3e\x7e\x9e\xf1\x7c\x38\x0f\x07\x8c\x0d\x24\xcf\x61\xf0\x8e\x0d\x7e\xaa\xc9\x60\x48\x84\x8c\x4f\x20\x23\xca\xe7\x8a\x92\x82\x49\xb4\x58\x58\xa1\x64\xa0\x33\xcb\x27\x19\xb0\xa9\xd2\x2c\xe7\x92\xcf\x84\x9c\x21\xc9\xdc\x32\x58\x42\x52\xd0\x46\xc6\x65\xca\x4c\x32\x87\xb4\xc8\x70\xd5\x0b\xb2\x7c\x66\x50\xc2\x8f\x81\x29\x8d\x85\x7c\x70\xe3\xa8\x93\x42\x64\x56\x90\x68\xab\x0b\x70\x24\x0d\x3c\x55\x32\x2b\x91\x36\xe5\x99\xf1\x44\xa3\xb4\x45\xc2\xdf\xf8\x13\xa4\xa1\x0d\x48\x78\xc0\x0f\x1d\x40\x90\x94\xa8\x3c\x07\x69\x2b\xa3\x73\x2e\xa4\xb7\x7c\x80\xeb\x8f\x4e\x48\xa2\xb2\x22\x97\xce\x2a\xc7\xe3\x85\x45\xe2\x44\x1a\xa4\x91\xc6\x72\xe1\x68\x9f\x4e\x1b\x5a\x7d\x5c\x31\x31\x52\xfc\xbe\xb0\xea\xa5\x90\x89\x06\xa2\xb0\x85\x16\x39\xd7\x25\xbb\x85\x72\xe0\x76\x3f\x0e\xbb\xf5\x22\x8c\x71\x97\x6e\x63\x75\x75\x9e\x6d\xfd\x84\x70\x83\x0d\xd7\x52\xfc\x2a\x80\x79\x56\x26\x52\x24\x8b\xa9\x00\xed\x1c\x68\xe7\xc0\x9a\x33\x23\x89\x20\x67\x76\x8e\x7c\x7f\xfe\x51\xd3\x64\x91\x65\xe1\xb8\x6b\x87\xb8\x85\xc2\x89\x0e\xbe\xdb\x0a\xc8\xfd\xed\x07\xe7\xbc\xc5\x12\x01\x3a\x15\x66\x91\xf1\x92\x91\x4c\xa6\xa6\x5b\x30\xbc\x79\xfb\xf6\x69\x10\x42\xa6\xb0\xdc\x05\x83\x48\xf0\x0a\xec\x8e\xe1\x53\x6b\x7b\x64\x3f\x2d\xec\xee\x87\xd7\xaf\x5e\x75\x61\x78\xd2\xda\xf8\xde\xae\x19\x6d\x61\x69\x3b\x4c\x3e\xed\xe2\x59\xb9\x47\x9d\x72\xfb\x18\x96\x70\x0b\x33\xa5\xcb\x7e\x01\x7e\x12\xb8\x36\x45\xf9\x25\x4c\x41\x83\x4c\x80\x59\xe5\x4e\xb3\x52\x83\x1f\x84\xa1\xb3\x65\x28\x49\xc9\x99\xc1\x0d\x7b\x86\xfb\xce\x91\x92\xf3\xe5\xf8\x5e\xe9\x5b\xd0\x63\x59\xe4\x66\x1d\xa6\x90\x68\x1c\xe8\x0e\x9c\x5f\xf9\x92\x7d\x77\xac\xec\xbc\xc8\x27\xa0\x4d\x27\x5c\xdc\x26\xf2\x22\x67\xd2\xed\xa1\x5b\x80\x41\x95\x14\x5a\x53\x9a\xf1\xaa\x4d\x08\x2c\x8f\xbe\x91\x92\xc2\x94\x17\x19\x49\x79\xbd\x11\xf2\x56\x74\xc6\x72\x5b\x74\x80\x02\x34\xa6\x03\xd1\x68\x65\xfb\x4a\x3c\xa9\x3b\xd0\x3c\xcb\xd8\xaa\x54\x55\x95\x9c\x1f\x81\x42\xa6\x6b\x3e\xc5\xb8\x65\xc7\xc7\x8c\x58\x11\xd9\x04\x28\xa9\x21\xf6\xa9\x98\x15\x1a\xd2\x21\x93\xca\x32\xaa\x20\x25\x05\x82\x2e\x64\xc3\xed\xa8\x2d\xee\x7a\xdf\x04\x42\xf1\x82\x34\xda\x5f\x48\xe9\x82\xb1\xe2\x98\x73\xc3\x78\x62\xc5\x1d\x34\xa5\xee\xd0\x1c\x35\x1c\x0b\x5e\x18\x48\x1b\x86\xa6\x20\xa2\x32\xac\x79\x0b\xa5\xb9\x16\x59\xc9\xfc\xc6\x86\x11\xcf\x64\x91\x81\x8d\x79\xa7\x42\x0a\x83\x15\x94\x99\x22\x49\xc0\x98\x29\xba\xa8\x64\x87\xe4\xd3\x17\xe8\x6a\x78\x41\x5e\x8d\x95\x4f\xb9\xc8\x5a\x02\xdc\x67\x57\x8a\xef\x95\x7c\x41\xa7\x62\xf1\x3a\x70\x59\xe6\x4a\x43\x74\xa8\xc2\x90\xeb\xd3\x41\x74\x30\x15\x8d\x4d\x4a\x86\x96\xea\x63\x5f\xb3\x03\xd3\x4d\x47\x2c\x55\xbe\x79\xc6\x4b\xa4\x52\xd8\x39\xc8\xbe\xb6\x36\xaf\x84\x58\xe3\x86\xb6\xcc\xae\x10\xfb\x78\x71\x79\x71\x7d\xf5\xe9\xfc\xcc\x1f\xe4\x99\x8f\x0a\x3c\x04\x8a\xb3\x8f\x8a\x61\x4a\x51\x28\x4b\x02\x3b\xcc\xc4\x6c\x6e\xef\x81\x7e\x0f\x11\xa4\xb1\x91\x33\xbe\x5d\x5e\x9c\x9c\x8d\x46\x83\x58\x06\x46\x0f\x81\x5f\x80\x4c\x7d\x33\xa0\xc8\xaf\xec\x50\x18\x95\x61\xb2\xc2\xd8\x9d\x03\xbf\x2b\xbd\xc4\xa3\x6d\x67\x1d\x19\xf9\x7c\xe7\x1d\xfa\x35\x18\xbb\xa3\xde\xf9\x76\x07\x2e\x76\xd5\xe2\x5a\xf1\x40\xd3\x0b\x62\xe8\x5b\x0b\x5a\x6e\xf7\x02\x05\x78\xdb\x01\x44\x71\xb1\xec\xef\x45\x74\x75\x34\x15\xa2\x78\xeb\x84\xe3\xdd\x42\x06\x46\x4b\x95\xea\x38\xe0\x39\xe4\xc8\x43\x2c\x97\x85\xa4\xe4\x81\xfe\x2c\x54\x61\xf0\x7e\xa1\x8f\xfc\x72\xe5\x9e\x6d\x6e\xf0\x56\xfe\x06\x0f\xc0\x72\xa1\x51\xb7\xe8\xd5\x6f\xd4\x9e\x38\xeb\xe0\x8e\xfc\x71\x42\xa7\xd2\x68\x70\x55\xa2\xd2\x9c\xba\xa4\xf2\x8c\x5d\x08\x55\x42\x97\x77\xc6\x89\x2a\xa4\xed\x5b\x09\x2f\x5d\xca\x3a\x69\xb3\x6e\x2d\x83\x21\xc9\x59\xca\xb6\xd6\x50\x14\x50\x1e\xc4\xd2\xd0\x55\x00\x5f\xed\x57\x00\x83\x80\xb1\x15\x39\x60\x32\xe8\x03\xea\xd4\xb3\xb2\xab\x55\xd6\xb8\xbf\x0d\x7b\x9a\xb4\x15\x14\x61\xfa\x60\x06\x30\x5e\x53\xcc\x1a\x09\x97\x54\xb6\xa8\x7c\x6a\x91\x62\x46\x61\x0b\x3c\x81\x9a\xe7\x68\xbf\xe6\x0c\x27\x14\xa5\x85\x2d\xfb\x60\xfa\xb6\xc6\xb3\x31\xff\x56\xe2\xd9\xe1\x1c\x93\x1c\xda\x1b\x1c\xf7\x0f\x0b\x9f\xab\x0d\x47\xfd\xdc\xd5\xff\xae\xe1\x48\x46\x39\x77\x3c\x29\xfb\x34\xa1\x9e\x89\xfd\xdb\x8d\xf5\x1a\x2b\x25\xbb\x9f\x2b\x16\x84\x77\x34\x5f\xcd\x85\x7a\xf3\xd7\x33\xa2\x91\xd8\xcf\x8f\xb1\x5f\x19\xf3\x8e\x68\xa4\xe8\xc1\xee\x2a\x5f\x74\x20\x3a\x47\x46\x97\x05\xdf\x77\xc7\xe2\x55\xc5\xeb\xd2\x04\xa9\x89\x72\x45\xed\xd7\x8d\xb1\xd6\x1f\x49\x86\x75\x74\x2f\x24\x5f\x90\x71\x37\x24\x98\x25\x48\xcb\x6f\x31\x3f\xb4\xe0\xe3\x5a\x76\xcf\x29\x27\x74\xf0\x67\xf5\x8d\xd9\x65\xdc\x09\x4a\x8f\xa9\x8b\x88\x71\x61\xbe\x40\xc4\xad\x22\xf5\xc4\xbc\xb3\x27\x68\xd7\x7e\xaf\xc3\xfc\x69\xe2\xb3\x6d\x40\x86\x6e\x9d\x6f\x9d\x33\x93\x78\x17\x76\x0d\x1a\x95\xd9\xd6\x38\xd4\x6f\x1a\xa6\xb6\xcb\x8c\xbb\x2a\xea\x06\x33\x4f\x43\xa7\x96\x08\xe8\x9e\x5e\xae\x17\xe8\x47\xe0\xb9\xab\x99\xd8\xbe\x3c\xf8\x57\x9b\x21\x99\x9e\x0a\x32\x7b\x88\x76\x1b\x83\x05\xd0\x60\x02\x7b\xbc\xd9\xcf\x74\x5f\x3e\x17\x2a\x13\x49\xb9\xb3\xf1\xbe\x70\x7e\x5b\x61\x6a\x05\x10\x6d\xf0\x52\xdf\xb1\x07\xaa\xd3\x55\xd1\x1c\x62\x07\x95\xdc\xaa\xe9\x74\x88\xc3\x7e\xc6\xcb\x21\xa3\xd5\xf0\xdf\x9f\x82\xda\xb7\xa1\xaf\xb3\x78\x9c\x8f\xfb\x81\x32\x19\xdf\x19\xcb\xe8\xcb\xfb\x4e\x08\x23\xd0\x77\x02\x6f\x40\x06\x77\x90\x05\x0c\x69\x08\x18\x6f\x33\x3a\xc2\xb8\xbb\x70\x07\xe3\xd0\x06\x20\xba\xd0\xd9\xa1\x9f\xe6\x5c\x4a\xc8\x70\x2f\x7e\xe4\x33\xdf\xf9\x0e\xf1\x46\xed\x09\xaa\x4b\x5d\x9f\x42\x7a\xd2\xf0\xb3\x0f\x6b\xfc\x11\xf2\x0f\x7e\xb4\xab\x2f\x3a\x0d\x16\x8c\x33\xad\xee\xc9\x31\x06\x2c\xcd\x6d\x9c\xd5\x53\x65\x57\xaa\xfb\xdf\x5d\x90\x7b\x91\xed\x81\x6e\x84\xfb\xd9\x85\x4e\xe3\xc5\xd8\x9b\xb4\xac\x68\xd9\x95\x9a\xd4\x3f\xf2\x3d\xa3\xbd\x20\xfd\x98\xbb\x66\xf2\x44\xa9\x0c\x78\x57\xe4\x9d\xad\xb2\x44\xf6\x7e\x9f\x83\xa5\x8e\xa5\x7e\x5e\xc2\x3f\x41\x85\x03\xb0\xf5\xc8\x5b\x79\xf6\x19\x06\x07\xff\xa2\xde\x03\xd9\xc8\x71\xb0\xcf\x71\x6f\xb2\x09\x1c\xfe\xe3\xcc\xab\xd8\xf4\x92\xd4\xb6\xbb\x97\x5b\xea\xd7\xfe\x1e\xd6\x5f\xae\xf1\x3c\xe1\x18\x52\xf2\xb2\xcd\xd1\xcf\xf8\x83\x30\x02\x7a\x9f\xc0\xd6\xaf\x0c\x96\x63\x2a\x04\xf5\x33\xa7\x59\x7b\xff\xaa\xbf\x74\x68\xbf\x85\xd6\x2f\x6b\x37\x9d\x63\x1b\x5e\x66\x65\x04\xce\xb7\xce\x04\x17\x63\xf5\x1b\xa7\xfb\xbe\xc5\x31\xb3\x5f\x05\x68\xaa\x58\xdb\x9f\xb2\x83\x91\xf4\x1e\xb2\xd5\x40\xff\x60\xd2\xdf\x32\xe2\xdb\xdb\xaa\xd6\x4b\xc4\xb8\xea\x6d\xbb\x0d\x5c\x79\xb5\x68\xb7\xc2\x3b\x9b\xdb\x9a\x7f\xfb\x19\xdb\x0c\x12\xdb\x3d\x1d\xcd\x1b\xfd\x8f\x93\xde\xe0\x3a\x4c\xab\xa3\xb2\x7e\x43\x09\x5f\x87\xe1\x28\x98\x0b\xff\x04\x10\x27\x9b\xa6\x81\x36\x4d\x5a\x79\x3c\x78\x3c\xf8\x0f\x67\xbc\xfa\x73\x0d\x1c\x00\x00")
 
 func yaoModelsJobJobModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -2698,7 +2868,7 @@ func yaoModelsJobJobModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/job/job.mod.yao", size: 6330, mode: os.FileMode(420), modTime: time.Unix(1758619331, 0)}
+	info := bindataFileInfo{name: "yao/models/job/job.mod.yao", size: 7181, mode: os.FileMode(420), modTime: time.Unix(1792289605, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -2723,7 +2893,7 @@ func yaoModelsJobLogModYao() (*asset, error) {
 	return a, nil
 }
 
-var _yaoModelsKbCollectionModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x98\xcd\x6e\x1b\x37\x10\xc7\xef\x7e\x8a\xc1\x9e\x1c\xc0\x81\x3f\x10\x1b\xb5\x6f\x89\x3f\x50\xa3\xb6\x63\xc4\x75\x72\x08\x02\x81\x22\x47\xd2\xd4\x5c\x72\x4b\x72\xed\xa8\x85\xdf\xbd\x20\xb9\xd2\x52\x2b\xae\x2c\xa9\xed\xc9\x01\xc9\xff\xcc\xfc\x76\xc8\x99\x51\xfe\xde\x01\x28\x14\x2b\xb1\x38\x83\x82\x6b\x29\x91\x3b\xd2\xaa\xd8\xf3\xeb\x92\x0d\x51\xfa\x8d\xf3\xce\x86\x40\xcb\x0d\x55\x61\x61\x61\x1b\x1c\x1b\x4a\x84\x91\x36\x60\x9d\x36\xa4\xc6\xf0\x9b\xd2\x2f\x12\xc5\x18\xe1\x13\xb3\x08\xad\x0f\x1b\x6d\x39\x36\xb6\xc5\x19\x7c\x2f\xec\xd4\x3a\x2c\x8b\x1f\x61\x75\x58\x93\x74\xe4\xad\x3b\x53\x63\x58\x32\xc8\x84\x56\x72\x5a\x9c\xc1\x88\x49\x1b\x17\xad\x36\xae\x38\x83\xd3\xd3\xd3\xd3\xc6\xda\x50\x7a\x16\xcf\x95\x90\x3d\x0d\x07\x1d\x38\xf0\xb8\x65\x89\xca\xcb\x8b\x4e\x90\x5d\xa0\x62\x07\xe0\x35\xd8\xe7\x5a\xd6\xa5\x0a\x01\x07\x23\xd1\x4f\xe2\x89\x44\x63\xde\x07\x33\xad\xc2\xda\xf5\x45\xbb\x36\xff\xa6\xe9\x62\x12\xc9\xc7\xda\xe9\xf7\xa4\xb8\x41\xbf\x02\x95\xa1\x92\x99\x29\x3c\xe1\xb4\x08\xa7\x5f\xf7\xf2\x7e\x5b\xbc\x41\x2e\x04\xeb\x7c\x32\x32\x61\x24\xa8\x3d\x11\x3d\x2a\xfa\xb3\x46\x88\x16\x80\x04\x2a\x47\x23\x42\x13\xb2\xec\x26\x69\x4a\x61\x77\x64\x74\x09\x1f\xef\xaf\xdf\x25\xae\x50\x8d\xdd\xa4\x38\x83\x93\x0f\xf3\x35\x55\x4b\xd9\x64\x6a\x9e\xcb\xb0\x51\x07\x67\x4d\xda\x57\x02\x87\xbf\x5b\x71\xde\x2d\x28\x13\xd2\x0b\xb2\x95\x64\x53\xf0\xa6\x41\x8f\x3a\x70\x19\xa2\xa3\xe3\xe3\xb7\x91\x48\x09\xfc\xb9\x0e\x51\xfa\xaa\x96\xc0\x1c\xfe\x74\x19\xac\x8b\x9c\x26\x41\x4a\xb0\xb3\xe6\x93\xa8\xd3\xf8\xf2\x01\x5a\xc7\x5c\x6d\x97\x63\x43\x55\x97\x99\xd8\x1e\x3a\xc7\xf3\x61\x75\x8d\xea\x59\x59\xf9\xde\xac\x78\xa5\x41\xe6\x42\x5e\x61\x7f\x3f\x7d\x9d\x43\x0c\x77\x52\x91\x23\x26\xe9\x2f\x14\xad\x86\x71\x47\xcf\x18\x15\x77\xda\x94\x4c\x82\xae\xd0\x30\xaf\xdb\x03\x5f\x4c\xa6\xe1\x06\xfb\x7f\xed\xbf\x18\x72\xd8\x6a\x4b\x46\xca\xa1\x62\x8a\x37\x06\x1e\x95\x40\x03\xc9\x72\xb4\xf0\xde\xd7\x23\x28\xb5\x48\xb4\x06\x9b\xca\x17\x95\x9f\x18\x7f\xaa\x2b\x88\xab\x08\xa4\xa0\x32\x7a\x6c\xd0\xda\x3d\x50\x1a\x18\xe7\x68\x6d\xab\x46\x63\xb4\x59\xc2\x9c\x30\x0b\x61\xc7\x8b\x10\x85\x05\xe6\x9c\x7f\x87\x5a\xb5\x52\x41\xd6\x67\x52\x14\x5e\x7c\xcb\x54\xcd\xa4\x9c\xc2\x6c\x75\xd9\xdb\x8f\xf9\x27\x17\x38\x62\xb5\x0c\x89\x69\xbf\xf4\x56\xf7\xba\xe7\xde\xc4\xca\xbe\x74\x6f\x86\x5a\x4b\x64\x2a\x77\x75\x82\x02\xce\x33\x8f\x2f\xb9\x45\xdf\x26\xe8\x26\xe8\x8b\x10\x59\x20\x0b\x0c\xa2\xa7\xec\xa3\x6d\x21\x17\x31\xba\x7c\x2b\x9f\xe8\xbc\x07\x6d\xc0\xf2\xa5\xd1\x6c\x44\x93\xd4\x54\xb2\xed\x5d\x2b\xb2\x5f\xff\xbf\x65\x0c\x2d\x75\x89\xcf\xdf\xfc\x31\x9a\x5c\xae\xb4\x71\xf0\xd9\x88\x74\x33\xc1\x0a\xdb\xda\x6f\x87\xe7\x26\x62\x8d\xcd\xe5\xe5\x60\xbb\x78\xb9\x7e\x4e\x5d\xaf\xd1\x09\x9e\xd1\xc0\x75\xc9\xc6\xf9\x2e\x90\xbc\xbb\x60\x1a\xc8\x1f\x85\xc7\x2f\x37\x99\x0e\x70\x7c\x78\xf4\x56\x2d\xcd\x06\x2d\x34\xaf\xbd\xc3\x01\xd7\xb5\xda\xe8\x73\x5f\x34\x4a\x38\x5f\x54\x26\x04\x77\x75\x39\x44\xe3\x3b\xd8\xcc\x8d\xf5\x85\xa7\xaf\x9d\xfd\xeb\x0c\x60\x39\x44\x21\x48\x8d\x07\x95\xd1\xcf\x24\xd0\x6c\x36\x83\x5c\xce\xf4\x70\xdf\xe8\xfb\x66\x91\x76\x4e\x9b\xfb\x84\xaa\xd5\xc0\x6e\xec\x1e\x4c\xe6\xe6\x8f\xc3\xa3\x5f\xb6\xca\x55\x8b\x17\xad\x6f\x0b\xf7\xb9\x5a\x35\x66\xad\x44\xd3\x33\xe9\xff\x4d\x58\x19\xdf\x24\x1d\x61\xa6\xcd\xff\x61\x75\xae\xbe\x2d\x64\x6f\x49\x9c\x10\x5e\x2e\x73\x71\xad\x46\x34\xae\x63\x57\x86\x9c\xf3\x4d\x30\xa4\xe6\x4c\x6e\x32\x14\xde\x74\x04\x49\xb0\x71\x0b\x2c\x3a\xdf\x0e\x33\xb3\x6e\xee\xf3\x67\xdf\x4f\x4f\x85\x2e\x50\xad\x9e\xe8\x05\x59\x17\x27\x90\x35\xe7\xad\x8b\x46\x00\xb7\xe8\x0c\xf1\x2c\xd6\x57\xe4\x2e\x56\xe1\x78\x94\x33\xc9\x6b\x19\x3f\x7f\x89\x6e\xa2\x45\x6e\x12\x2b\xb8\xb6\xa4\xfc\x2c\x54\x60\xcd\x25\x89\xd0\xe9\x7c\x15\x73\x45\x7e\x8c\x68\xce\x6f\x55\x4e\x42\x6b\x1b\x04\xd8\x75\xd1\xaf\xbd\x04\x7e\x5f\x90\x2c\x53\x07\xc3\xc0\xe4\x58\x1b\x72\x93\x12\x16\x5d\x24\xb8\x13\x65\x5f\x3c\x1f\x3d\x8f\xfc\x9f\x91\x64\x3d\x9c\xf1\xe0\x56\x94\x99\x79\xa8\xbf\xe8\xff\x7a\xf7\xf0\x0d\x6e\xe1\x9e\x19\x56\xa2\xeb\xe9\xb4\xe1\x50\x4b\x77\x0b\xd5\xec\x38\xec\x0e\x49\x90\x89\x17\x97\x49\x90\xa4\x9e\x2c\x54\x68\x40\x69\x81\xef\x7a\x5f\x5b\x06\xf9\xf0\x64\x75\x25\x19\x0d\xb8\x56\xd6\x99\x9a\xe7\x7f\xc7\xbc\xc1\x78\x79\x05\xe7\x59\x7d\x3f\xe7\xe5\x15\xa4\x2e\x5b\xea\x4d\xb0\x8e\x0e\x0e\xde\xe2\xb2\xc8\x0c\x9f\x6c\x43\xf4\xd0\x51\xae\x64\x89\x6e\xb6\xa3\x38\xf9\xb0\x12\x42\xd5\xe5\x40\x92\x75\x99\xda\xde\x0f\x71\xfd\xf5\x0a\xda\x81\xe2\x66\x51\x9e\x90\xf8\x73\x2d\x88\x9a\x2b\xb8\xac\xad\x43\xd3\x5f\xd2\x73\x97\xec\x8d\x6c\x78\x90\xca\xe8\x61\xae\x4b\xad\x4b\x72\xdf\xd1\x6f\x80\x02\x4e\x43\xf7\x36\xac\x03\x15\x99\x76\x9a\x9f\x5e\x6d\xc1\x69\xfe\xb3\xaa\x42\x53\x92\xb5\x71\xad\x35\x52\x38\x2a\xd1\x3a\x56\x56\xb6\x6d\x82\xaf\x3b\xaf\x3b\xff\x04\x00\x00\xff\xff\xb7\xc3\x21\xdf\xbc\x13\x00\x00")
+var _yaoModelsKbCollectionModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x58\x6d\x4f\x1b\x39\x10\xfe\xce\xaf\x18\xed\x27\x2a\x51\xf1\xa2\x82\xee\xee\x5b\x5b\xa8\x0e\x1d\x50\x04\x47\xfb\xa1\xaa\x90\xb3\x3b\x49\x7c\x78\xed\x9c\xed\xa5\x4d\x2b\xfe\xfb\x8d\xed\xcd\xae\xb3\x71\x12\x76\x7b\x20\x24\x92\xb1\x9f\x99\x79\x76\x5e\x97\x9f\x3b\x00\x99\x64\x25\x66\x7f\x40\x96\x2b\x21\x30\xb7\x5c\xc9\x6c\xcf\xc9\x05\x1b\xa1\x70\x07\xef\x3b\x07\x05\x9a\x5c\xf3\x99\x17\x2c\x1d\x83\x65\x23\x81\x30\x56\x1a\x8c\x55\x9a\xcb\x09\xfc\x25\xd5\x37\x81\xc5\x04\xe1\x1d\x33\x08\xad\x0d\x13\x74\x59\x36\x31\xa4\xe4\x4b\x66\xe6\xc6\x62\x99\x7d\xf5\xd2\x51\xc5\x85\xe5\x4e\xbb\xd5\x15\x7a\x91\x46\x56\x28\x29\xe6\x24\x1b\x33\x61\x82\xd0\x28\x6d\x49\xf0\x3b\xfd\xd4\xda\xc8\x3e\x09\x7e\xd2\x97\x88\xd9\xc3\xe8\xbe\x43\x0e\x1c\xdd\xb2\x44\xe9\xe0\x59\xc7\xc9\x2e\xa1\x8c\x00\x4f\x5e\x3f\x69\xa9\x4a\xe9\x1d\xf6\x4a\x82\x9d\xc8\x12\x2f\x6a\xf5\xce\x99\xf9\xcc\xcb\xce\x4f\x5b\x59\xf3\x4c\x63\x61\xe4\xc9\xdb\xca\xaa\xd7\x5c\xe6\x1a\x9d\x04\x66\x9a\x97\x4c\xcf\xe1\x01\xe7\x99\xbf\xfd\xb4\x97\xb6\xdb\xd2\xbb\x4f\xb9\x60\xac\x0b\x46\xc2\x8d\x88\xea\x1a\x8f\xee\x24\xff\xb7\x42\x08\x1a\x80\x17\x24\xe6\x63\x8e\xda\x47\xd9\x4e\xe3\x90\xc2\xee\x58\xab\x12\xde\x5e\x9f\xbf\x8a\x4c\xa1\x9c\xd8\x29\x69\x3a\x79\xd3\xc8\x64\x25\x44\x1d\xa9\x26\x96\xfe\xa0\xf2\xc6\xea\xb0\x6f\x24\xec\xff\x0e\xe2\x79\xb5\x84\x8c\x98\x9e\x72\x33\x13\x6c\x0e\x4e\x35\xa8\x71\x87\x5c\x82\xd1\xd1\xf1\xf1\x76\x4a\x5c\x16\xf8\xfd\x39\x8c\xe2\xaa\x5a\x21\x66\xf1\xbb\x4d\xd0\x3a\x4d\x61\x22\x4a\x11\xed\xa4\xfa\xc8\xeb\xd8\xbf\xb4\x83\xc6\x32\x5b\x99\x55\xdf\x50\x56\x65\xc2\xb7\xdb\xce\xf5\xb4\x5b\x5d\xa5\x6a\xd1\x56\xbe\xd4\x12\x87\xa4\xda\xb7\x3e\xae\xb0\xbf\x1f\x57\xe7\x08\x7d\x4e\x4a\x6e\x39\x13\xfc\x07\x16\x2d\x86\xd1\x8d\x47\x0c\x88\x2b\xa5\x4b\x26\x40\xcd\x50\x33\x87\xdb\x03\xd7\x4c\xe6\x3e\x83\xdd\xa7\xfd\x6f\x9a\x5b\x6c\xb1\x25\xe3\xd2\xa2\x64\x32\xaf\x15\xdc\x51\x0c\x35\x44\xe2\xa0\xe1\xb5\xeb\x47\x50\xaa\x22\xc2\x6a\xac\x3b\x5f\x40\xbe\x63\xf9\x43\x35\x83\x20\x45\x72\x95\x4a\x5a\x4d\xe8\xab\xd9\x03\xa9\x80\xe5\x39\x7d\x6c\xd1\xa8\xb5\xd2\x2b\x34\xa7\xcc\x80\x3f\x71\x20\xc4\xc2\x00\xb3\xd6\xd5\xa1\x92\x2d\xb4\xe0\xc6\x45\xb2\xc8\x1c\xf8\x92\xc9\x8a\x09\x72\x6e\x21\x5d\xb5\xf6\xb5\x79\xe4\x05\x8e\x59\x25\x7c\x60\xda\x27\x3d\x28\xaf\xd7\xe4\x4d\xe8\xec\x2b\x79\x33\x52\x4a\x20\x93\xa9\xd4\xf1\x08\x78\x9f\x28\xbe\x28\x8b\x3e\x4f\x91\x4a\xd4\x35\x21\x6e\x80\x7e\x19\x04\x4b\xc9\xa2\x6d\x49\x2e\xd3\xe8\xf2\xdb\x58\xa2\xcd\x0c\xea\xc1\xe5\xa6\xc6\xf4\x62\x13\xf5\x54\xfa\xd6\xe4\x5a\x96\x7c\xfa\xff\x2f\x47\x3f\x52\x57\xf8\xb9\xcc\x9f\xa0\x4e\xc5\x8a\xee\xc3\x47\x5d\xc4\x87\x11\x2d\x7f\xac\xdc\xb1\x2f\xb7\x22\xf4\xd8\x54\x5c\x0e\x86\xf9\x9b\xab\xc7\xd8\xf4\x33\x26\x01\xdd\x87\xf3\x92\x4d\x70\x5b\x6f\xf2\xaa\x81\xbb\xab\x70\x77\x73\x91\x98\x00\xc7\x87\x47\xdb\x7a\x69\xba\xd7\xab\xbc\x72\x06\x69\x2d\xa9\x64\xaf\xc7\x7d\x5a\x23\x29\x9d\x96\x90\x11\x83\xab\xaa\x1c\x91\xdf\x34\xc1\x16\x66\x8c\x6b\x3c\xeb\xc6\xd9\x2f\x47\x00\xc9\x5c\x51\xd0\xd3\xbe\xa7\xde\xf6\x48\x1b\x82\xee\xb7\x83\x9c\x2d\xf0\x70\x5d\xe3\xd7\xed\x22\xed\x9e\xd6\xd8\x84\x59\x8b\x81\xdd\x30\x3d\x98\x48\xed\x1f\x87\x47\xbf\x0d\x8a\x55\x4b\x2f\x68\x1f\x4a\xee\xe3\x6c\xd3\x9a\xb5\x91\x9a\x5a\x40\x5f\x9a\x21\x59\xa4\x21\x69\x39\x26\xc6\xfc\x3f\x46\xc9\x6d\xd1\x5b\x01\x47\x0c\xcf\x56\x79\xe5\x4a\x8e\xf9\xa4\x0a\x53\x19\x52\xc6\xfb\xd0\xd0\x34\xde\xe5\x03\x65\xdf\xb0\x34\xbc\xa9\xe1\x5b\xb3\xb0\xb9\x18\xa5\x9e\x5b\x08\x7c\x97\x33\xbe\xce\x0c\x32\x9d\x4f\xdd\xd8\xa7\xca\x32\x2f\x13\xb5\x86\xee\x90\xb4\x6c\x38\x6c\xce\xca\x55\xaa\x2f\x9c\x8a\x42\xe5\x4c\xf4\x59\xec\x2f\x3a\x80\xc8\xf9\x70\x44\xb1\xb0\x6e\xa5\x49\xbc\xaf\xa4\xfc\x3e\x58\xe7\x76\x6a\x5d\x42\xb9\xf9\xad\x8c\xa6\x9d\x0d\x5b\xe4\x33\x77\xe6\xd3\x1a\x00\x97\x48\x84\xf3\x24\xad\x4f\xe4\x7c\x98\xa4\xe1\x2a\x91\xcc\x2b\x11\x4a\xa8\xa4\x1d\x42\x15\xa9\x6d\x9a\x34\x18\x2e\xdd\x3e\x9b\x61\x95\x0b\x8a\xa5\xdb\x56\xdc\x24\xb2\x59\x7a\x15\xac\xef\x0f\x1a\x09\x7e\x3d\xb9\xf7\x64\x9f\x4b\xfd\xdc\x41\xe0\xef\x25\xc8\x2a\x6b\xaf\x18\x98\x98\xd0\x8e\x6d\xa7\x25\x2c\x9b\x88\xe8\x4e\xa5\xf9\xe6\xf8\xf1\xc7\xb1\xfb\x33\xa6\x07\x94\xe6\x19\x2e\x0e\x62\x59\xf6\x19\xdc\x7f\x5e\xdd\x7e\x86\x4b\xb8\x66\x9a\xd0\x76\xcd\xb6\xe4\x2f\xb5\xec\x2e\x61\xb6\xb8\x0e\xbb\x23\x5e\x70\x1d\x12\x97\x5e\x66\x04\x97\x0f\x06\xa8\x5f\xd2\x62\x5f\xe0\xab\xac\x47\xda\x1e\x9e\x6c\x9e\x06\x63\xda\x4a\x24\x15\x5c\x95\xa7\xdf\x45\xb7\x70\x3c\xfb\x40\xbb\x49\x0a\xbf\x9e\x27\x41\x62\x93\x2d\xeb\x3e\xb4\x8e\x0e\x0e\xb6\xf1\x0a\x6d\x79\x08\xa3\xdb\x0e\x72\x23\x97\xba\xfb\x0f\x62\x71\xf2\x66\xf3\xbf\x3d\xaa\xf2\x5e\x50\xe5\x9b\x3e\x24\xce\x3f\x7d\x80\x76\x29\xbc\x58\x86\x47\x4c\xdc\xbd\x96\x88\x6c\x10\xb9\xa8\xe8\xbd\x4a\x9b\x5e\x49\xb6\x25\x1a\x8e\x08\x0d\x95\x11\xfe\x02\x93\xeb\x0e\xbe\x07\x15\xb0\x0a\xba\xd9\xf0\x1c\x52\x81\xd3\x4e\xfd\xfa\xdc\x36\x9c\xfa\x1f\x8e\x54\x8f\x25\x37\x26\xc8\x5a\x25\x99\xe5\x25\x52\xbb\x2e\x67\xa6\x1d\x82\x4f\x3b\x4f\x3b\xff\x01\x59\xb5\x42\x93\x80\x15\x00\x00")
 
 func yaoModelsKbCollectionModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -2738,12 +2908,12 @@ func yaoModelsKbCollectionModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/kb/collection.mod.yao", size: 5052, mode: os.FileMode(420), modTime: time.Unix(1758619331, 0)}
+	info := bindataFileInfo{name: "yao/models/kb/collection.mod.yao", size: 5504, mode: os.FileMode(420), modTime: time.Unix(1792286391, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _yaoModelsKbDocumentModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x5a\x5b\x6f\xdb\xb8\x12\x7e\xcf\xaf\x18\xe8\x29\x05\xdc\xd3\x0b\xda\x83\x93\xbc\xa5\x49\x0a\xe4\x6c\x2f\x41\x93\x60\x1f\x8a\xc2\xa0\xa9\x91\xcc\x9a\x22\xb5\x24\x95\xc4\x5d\xe4\xbf\x2f\x48\xdd\x28\x9b\x92\x2d\xd5\xd9\xa7\xd6\x24\x67\x38\x1f\x67\xf8\xcd\x0c\x95\xbf\x8f\x00\x22\x41\x32\x8c\x4e\x21\x8a\x25\x2d\x32\x14\x26\x9a\xd9\x51\x4e\x16\xc8\xed\xf0\x45\x67\x38\x46\x4d\x15\xcb\x0d\x93\xc2\x9f\x04\x43\x16\x1c\x21\x91\x0a\xb4\x91\x8a\x89\x14\xfe\x10\xf2\x81\x63\x9c\x22\x7c\x20\x1a\xa1\xd6\xae\x4b\x3d\x86\xa4\x3a\x3a\x85\xef\x91\x5e\x6b\x83\x59\xf4\xc3\x8d\x2e\x0a\xc6\x0d\xb3\x9a\x8d\x2a\xd0\x0d\x29\x24\xb1\x14\x7c\x1d\x9d\x42\x42\xb8\x2e\x07\xb5\x54\x26\x3a\x85\x93\x93\x93\x93\x4a\xdb\x82\x5b\x0c\x16\x8f\x87\x68\xb5\x98\x77\x40\x01\x44\x54\x66\xee\xe7\x29\x44\x1b\x06\x76\xa1\x44\x47\x00\x4f\x4e\x37\x95\xbc\xc8\x84\x33\xd6\xa9\x28\xf7\xf0\x76\x61\x71\xa5\xdc\x1a\xb2\xce\xdd\xd8\xd5\x45\x3b\xd6\x9c\xa4\x3f\xe8\xd9\x71\x56\x18\xf9\x92\x09\xaa\xd0\x6d\x9f\x2b\x96\x11\xb5\x86\x15\xae\x23\xb7\xfa\x69\x16\xde\xb7\x86\x36\x0f\x19\xa0\x8d\x75\x42\xc0\x88\x06\x66\x8f\x35\x77\x82\xfd\x55\x20\x94\xf2\xc0\x62\x14\x86\x25\x0c\x95\xf3\xad\x59\xb6\x8e\x84\xe3\x44\xc9\x0c\xce\xae\xaf\x5e\x78\xdb\xa0\x48\xcd\x32\x3a\x85\xff\xbe\x6b\xc6\x44\xc1\x79\xe5\x9f\xc6\x83\x6e\xa2\x70\x5b\x55\xce\x1e\x84\x4a\x25\xe7\x48\x6d\xd0\x8d\x03\x7b\xde\xc8\xf5\xc1\xfd\x86\x09\x2a\x14\x14\xc1\x48\x07\xaf\xdd\x0a\xcc\x92\xe9\x16\xee\x02\xb9\x14\xa9\x06\x23\x27\xc2\x65\x22\xc6\xc7\x7d\xd0\xba\x7f\x27\x78\xf4\x4b\x47\xce\x03\x79\xc1\x74\xce\xc9\x1a\xac\x62\x90\x49\xc7\x8d\x01\x30\x6f\xdf\xbf\x3f\x20\x1a\x9f\x31\xb6\x40\x19\x7c\x34\x21\x48\x21\x19\x1f\x50\x0d\x39\xa8\xdc\xb3\x79\xa7\x75\xda\x10\x53\xe8\x6d\xc3\x50\x14\x59\xc0\xb0\x9b\x8d\xe5\x21\x9b\x72\x25\x29\x6a\x6d\xaf\xcf\xa6\x76\x59\x13\xe7\xf7\x6a\x04\x20\xca\x51\xc4\xce\xb1\xf0\xea\x55\x4b\x42\x54\x21\x31\x18\xcf\xe0\x81\x30\x63\x55\x19\x69\xb5\x29\x5f\x7d\xab\x83\x4a\x71\x8f\xca\x34\x6a\xce\x9b\xdf\x90\x30\x5e\xc6\x36\x3e\x1a\x28\x9c\x55\xd5\x6a\x54\x56\xd7\x3d\x8b\x51\x79\x9a\x96\x85\x58\x35\x7a\x6e\x72\xce\x4c\xb9\xbd\x15\x67\xc2\x48\x70\x2b\x74\xad\xaa\x5a\x1e\xd0\x84\x8f\x46\x11\xda\xda\x74\xd9\xfc\x06\xcb\x29\x86\xa1\x06\x22\x62\x50\xc8\x89\x3d\x15\xbd\x64\xb9\x86\xe3\xf2\x88\x08\x7f\xe1\x69\xca\x16\x18\xb7\x67\x74\x6e\x8f\xc6\xa9\xa9\xc7\xb5\x23\xa7\xca\x30\xab\xb3\xde\xa0\xd5\x51\x65\xa5\x0a\x56\x95\xa2\x9a\x9c\x64\x0f\xe8\x1e\xa9\x91\xca\x89\xa7\x8a\xe4\x4b\x88\x89\x21\x0b\xa2\x7d\x2d\x54\x66\x39\x47\x83\x71\xa9\xe7\x8c\xf3\xae\xb7\x31\xd7\x90\x30\xc1\xf4\x12\x63\xd0\x05\xb5\x33\x49\xc1\xf9\xba\x55\x91\x11\x26\x0c\x0a\x22\x28\x96\x4a\xee\x44\x8c\x0a\xbc\xe1\x19\xd8\x9c\xf7\xd2\x26\x3d\xc8\x64\x8c\xad\xac\xc2\x0e\x8c\x0f\x84\xae\x8a\x1c\xca\x51\x04\x26\xac\x31\xa9\x42\xad\x67\x20\x24\x10\xb7\xbd\x77\x8c\x4a\x49\x15\x59\xc1\xeb\xd6\xe6\x84\x30\x6e\xc3\x8c\x2e\x91\xae\xc0\x2d\x99\x67\xa8\x35\x49\x11\x12\x86\x3c\xae\xe4\x7f\x34\x31\x1c\x63\x42\x0a\xee\x42\xbe\x09\xdd\xc3\xb1\x85\xbb\x7f\xfb\xde\xc6\xe6\xb6\xdc\x76\xa4\xbc\x4b\x69\x27\x2c\xe3\x35\x2c\x4e\xa5\x3d\x66\x13\xba\x92\x91\xbd\x2a\xd1\xac\x26\x25\x88\x0a\xc5\xa3\x1f\x07\xc4\xa6\xd9\xaf\x00\x36\xeb\xf7\x14\xd5\x10\xbc\x9b\x8e\xa0\x07\xcf\x4e\x74\xe0\x31\x01\x8b\xb5\x41\x8f\x72\x5a\x77\xbd\xee\x85\x32\x6c\x35\xa6\xae\xca\xa0\xb2\xf0\xcf\x6d\xb7\xf9\x37\xa5\x20\x9c\x77\x05\xfd\xe3\x0a\x61\xfa\x52\x64\x0b\x54\x16\x55\xb5\xb3\x86\x14\x05\x2a\x4b\x87\xe0\x4a\x8e\x4e\x5e\x3e\x20\xd2\x9f\x72\x31\xae\xbc\xf8\xbf\x5c\xf4\xd5\x15\xde\x15\xfb\x29\x17\x5b\x75\x94\x22\x74\xe5\x33\x90\x4f\x23\x86\xe8\x95\x0e\xa4\xe5\x37\x6f\xff\xd7\x97\xe2\xc6\xc7\x62\x91\x73\x49\x62\x54\xe3\x00\xdf\x55\x52\xbd\xc5\x63\x3d\xef\xe1\x2d\xb4\xf5\x9b\x54\x65\x26\xca\x88\x20\x69\x59\xe8\x5a\xa6\x25\xc6\x10\xba\x74\x3f\xab\x46\xe0\x99\x71\xbb\xbe\x63\x0b\xf0\x4f\xed\xd7\x0f\x0d\xdc\xdb\xce\xe2\x50\xae\xb7\xea\xca\xe4\x43\x0c\xa6\x52\xb1\x5f\x2e\x97\x39\x6c\x09\xe3\x06\x55\x1f\x3f\xee\xb4\x94\x4b\x4a\xf8\x98\x3a\xf0\xd3\x86\x80\x67\x6f\x39\x05\x1a\xcb\x64\x6e\x0d\xae\x13\xb6\x4b\x36\x76\xd0\x9a\xdc\x46\x61\xc8\x0f\xc1\x6b\xd5\x71\x83\x97\x1b\x50\x0c\x77\x2f\x9b\xee\xae\xe1\x2d\xa4\xe4\x48\x42\xde\xb8\x71\x12\x70\xb1\x75\xf1\x3d\xa0\x7f\x2e\xd1\x2c\x51\x95\x14\xc1\x34\x90\x2a\xac\x06\xe9\xa2\x4b\xe7\xa3\x28\xa3\xe9\x4e\x47\xe0\xf8\x56\xc9\x8c\x40\xd2\xf2\xbb\x6e\x8b\x83\x03\x43\x71\x3d\xf5\x18\x7a\x97\xca\xc0\x57\x15\xfb\x93\x7e\x6a\xb2\xd3\xd2\x4e\xbb\x70\x8b\xcb\x16\xe4\x80\x6c\x4d\xe5\xbd\xbf\xf5\x1e\xbd\xe0\xbd\x25\xae\x8c\xa4\x3d\x4d\x52\x5b\x22\xd8\x85\xcc\x2e\x84\xbb\x6f\x9f\x02\x37\xe1\xfd\x9b\xb7\x93\xae\xb4\x65\xc0\x71\x84\xfb\xd1\x72\x66\x0f\xd9\xba\xb9\x8d\xc4\xb2\xc5\xa7\x70\xdc\x50\xaf\xdd\x2c\xd4\xaa\x1f\x94\x5f\x1d\xc4\x91\x0d\xac\x03\xd2\xdb\xbc\x7e\x55\x2c\x65\x82\xf0\x12\x84\x6b\x61\x77\x63\xea\x69\x61\xf7\x33\x3f\x27\x66\x39\xd6\xfc\xeb\x8e\x8c\x7f\x0f\x8c\x54\x36\x92\xac\xd2\xba\xf7\x76\xa6\xef\x06\xf1\x5b\x61\x96\xb1\x0c\xe7\xe1\x6a\x7a\x18\xc9\xe7\xab\xcf\x97\xfd\x05\xb5\x9b\x35\x55\x55\x3d\x02\xcb\x9b\xd7\xbd\xd9\x63\xb8\x4e\x51\x7c\x4c\x7d\xe2\x5f\xd7\x0e\x19\x15\x8a\xba\xdb\x5c\x9a\x5a\x28\xde\x1f\x3a\xaf\xdf\xf5\xde\x87\x5d\xa6\xce\x0d\x33\xa3\x72\xb6\xb5\xe8\xb6\x2b\xe3\x77\x2f\x76\x06\xaa\x46\xba\xae\x7e\xf7\x03\x31\x31\xfe\x6d\xeb\x33\xdf\xea\x91\x76\xbc\xd6\xdc\xe2\xa3\xad\xf2\x37\x84\xfc\x47\x36\xf2\x50\x3e\x20\x54\x9a\x4b\xfb\xdd\x48\x19\x4a\xaa\xae\x3c\x30\xae\xd7\xbc\x98\x56\x35\x35\x6f\x1b\xf3\xba\xc0\x19\xfb\x62\x58\xbf\x8d\x5c\xd7\x05\x52\x0f\xfd\x7a\xf9\x62\xf3\x39\x05\xae\x2e\x7e\x8f\x77\xf7\x84\x58\xf6\xae\x53\x01\x7e\xcd\x87\x1e\x46\x87\xe0\xc9\x5a\xf2\x5f\x41\x99\x2b\x99\xa3\x32\x0c\xf7\x2f\xdc\x3b\x5e\xdc\x12\xf6\x50\x9e\x6f\x83\xa3\x52\x24\x2c\x2d\x54\x59\xc8\x87\x36\x1f\xc5\xc5\x68\xe8\x72\x72\x34\x7e\x2c\xa5\x77\xc6\xa2\xe5\x84\x6a\xa7\xed\x20\x1c\xe2\x89\xa9\xde\xa9\x61\x4d\x89\xc0\x1a\xd4\x70\xfc\x05\x21\x6d\x04\xde\x73\x22\x9b\x10\x75\x9e\xb7\x86\x62\xee\xe3\x26\xaa\xc3\x46\x5c\xfd\x24\x3b\x95\x00\xeb\x17\xdd\x5d\x31\xe7\x58\x7f\xeb\xfd\xb7\xb3\x7a\x97\x33\x26\xbe\xa3\x35\x08\x27\xf1\x5f\x6d\xf1\x70\xf8\xf5\xa0\x93\xdb\x42\xbf\xcf\x74\x9e\xc3\x46\x13\x9d\xe7\xad\x41\x9e\xdb\x42\xd2\x17\x74\x70\xcc\x04\xe5\x45\x8c\x1a\x74\xce\x99\x99\x67\x32\xc6\x59\x79\x14\x73\xcd\x7e\x35\xff\xb7\x5d\x12\x27\xf9\x0c\xd0\xd0\xff\x4c\xcc\xd6\xcd\x0b\xfe\xc4\x60\xbd\xac\xe5\x77\x46\x6b\xfb\xb1\xb7\xd9\xb3\xcb\x94\xcd\x77\x87\x03\x7a\xb6\x85\x37\x25\x52\x5b\x70\xc3\xa1\x3a\x08\xcd\x63\xcc\xe7\x45\x38\x21\x78\x3b\xde\x1b\x8a\xde\xcb\x6d\x5c\x87\xe5\xcc\xfa\x5b\x95\x14\x53\x03\xb1\x51\x30\x26\x12\x5b\xa1\xe7\x0f\xc5\x16\xe1\xa4\x58\x6c\x4d\xdd\x3b\x18\x03\xe8\x9e\x3b\x1a\x3b\x6e\x1c\x1d\x8e\x1d\x1f\x0e\xc6\x63\x00\xda\x61\x03\xb2\x69\x87\xe6\x24\xd4\x87\xb1\x0c\xb5\x21\x59\x1e\x40\x71\xdd\x34\x52\x67\xe1\x66\xec\xb6\x16\x86\x87\x25\x8a\xe0\x17\x08\xef\x33\x67\x8f\xf1\xe3\x93\x76\xe7\xd3\xe2\xde\xad\xe5\xa5\x95\x82\xcf\x9b\x52\xbe\x2f\xdc\x8a\xfa\x93\x25\x4b\x7c\x1c\xe5\xc7\xcd\x1d\x1e\x38\xaa\x3e\x6e\xb6\x5f\x02\xab\xbf\x22\xca\x51\x65\x4c\xeb\x72\xac\x05\xdd\x9e\xbe\x6e\xf5\x3c\x1d\x3d\x1d\xfd\x13\x00\x00\xff\xff\x1f\xf9\xcf\x93\x4d\x25\x00\x00")
+var _yaoModelsKbDocumentModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbd\x5a\xdb\x6e\xdb\x38\x10\x7d\xef\x57\x10\x7e\x6a\x00\xb7\x4d\x83\x66\xd1\xdd\xa7\x4d\x93\x14\xed\x6e\x2f\x41\x2e\xd8\x87\xa2\x30\x68\x89\x96\xd9\x48\xa4\x96\xa4\x92\xba\x45\xfe\x7d\x67\x48\x51\xa2\x6c\x49\xb6\x54\x67\x8b\x00\x8d\x48\xce\x70\x0e\x67\x78\x86\x43\xe6\xe7\x13\x42\x26\x82\x66\x6c\xf2\x07\x99\xc4\x32\x2a\x32\x26\xcc\x64\x8a\xad\x29\x9d\xb3\x14\x9b\xcf\x1a\xcd\x31\xd3\x91\xe2\xb9\xe1\x52\x84\x9d\xc4\xd0\x79\xca\xc8\x42\x2a\xa2\x8d\x54\x5c\x24\xe4\x6f\x21\xef\x53\x16\x27\x8c\xbc\xa1\x9a\x11\xaf\x5d\x3b\x3d\x86\x26\x1a\x14\x7c\x99\xe8\x95\x36\x2c\x9b\x7c\xb5\xad\xf3\x82\xa7\x86\xa3\x66\xa3\x0a\x66\x9b\x14\xa3\xb1\x14\xe9\x0a\xda\x16\x34\xd5\xae\x51\x4b\x65\xa0\xe1\x77\xf8\x57\x6a\x83\xd9\xa1\xe1\x27\x7c\x04\x88\x6e\xe7\xb3\x06\x28\xe8\x8a\x64\x66\x3f\xa1\x77\xcd\xc0\x26\x94\x09\x0c\x7f\xb0\xba\x23\x99\x16\x99\xb0\xc6\x5a\x15\x6e\x8e\x60\x16\x1e\x97\xca\xd1\x90\x55\x6e\xdb\xde\x9f\xd5\x6d\xd5\x4a\x86\x8d\x81\x1d\x27\x85\x91\xcf\xb8\x88\x14\xb3\xd3\xe7\x8a\x67\x54\xad\xc8\x2d\x5b\x4d\xec\xe8\x87\x69\xfb\xbc\x1e\xda\xac\xcd\x00\x6d\xd0\x09\x2d\x46\x54\x30\x3b\xac\xb9\x11\xfc\xdf\x82\x11\x27\x4f\x78\x0c\xcd\x7c\xc1\x99\xb2\xbe\x35\xcb\xda\x91\xe4\xe9\x42\xc9\x8c\x9c\x5c\xbc\x3f\x08\xa6\x61\x22\x31\x4b\xd0\xf3\xdb\xab\xaa\x4d\x14\x69\x5a\xfa\xa7\xf2\xa0\xed\x28\xec\x54\xa5\xb3\x7b\xa1\x82\x0f\x52\x16\x61\xd0\x0d\x03\x7b\x5a\xc9\x75\xc1\xbd\x64\x0b\xa6\x98\x88\x18\x31\xd2\xc2\xab\xa7\x82\x4f\xae\x6b\xb8\xa0\x52\x8a\x44\xc3\xb8\x91\x70\xb9\x88\xd9\xf7\x5d\xd0\xda\xff\x47\x78\xf4\x53\x43\x2e\x00\x79\xc6\x75\x9e\xd2\x15\x41\xc5\x44\x2e\x1a\x6e\x6c\x01\x73\x74\x7c\xbc\x47\x34\x21\x63\x6c\x80\x32\xec\xbb\x69\x83\xd4\x26\x13\x02\xf2\x90\x5b\x95\x07\x36\x6f\xb5\x4e\x1b\x6a\x0a\xbd\x69\x18\x13\x45\xd6\x62\xd8\xd5\xda\xf0\x36\x9b\x72\x25\x23\xa6\x35\x6e\x9f\x75\xed\xd2\x13\xe7\x97\xb2\x05\xda\x72\x26\x62\xeb\x58\xf2\xe2\x45\x4d\x42\xc0\x06\xd4\xb0\x78\x4a\xee\x29\x37\xa8\x0a\xc2\x13\xb4\xa9\x50\x7d\xad\x23\x92\xe2\x8e\x29\x53\xa9\x39\xad\xbe\xc9\x82\xa7\x2e\xb6\x61\xa5\x49\x61\xad\x2a\x47\xc3\x9e\x06\x5d\x77\xb0\xc5\x55\xa0\x69\x59\x88\xdb\x4a\xcf\x55\x9e\x72\xe3\xa6\x47\x71\x2e\x40\x91\x1d\xa1\xbd\xaa\x72\x78\x8b\x26\x10\x50\x34\xaa\x6d\x3a\xaf\xbe\x09\x72\x8a\xe1\x4c\x13\x2a\x62\xa2\x58\x4a\x71\x55\xf4\x92\xe7\x9a\x3c\x75\x4b\x44\xd3\x83\x40\x53\x36\x67\x71\xbd\x46\xa7\xb8\x34\x56\x8d\x6f\xd7\x96\x9c\x4a\xc3\x50\xa7\x9f\xa0\xd6\x51\x66\xa5\x12\x56\x99\xa2\xaa\x9c\x84\x0b\x74\x07\xbb\x1e\xb4\xa0\x78\xa2\x68\xbe\x24\x31\x85\x4c\x00\x99\x41\x87\xeb\x9c\xe5\x29\x03\xbf\x38\x3d\x27\x69\xda\xf4\x36\x03\x04\x0b\x2e\xb8\x5e\xb2\x98\xe8\x22\xc2\x9e\x05\x44\xe3\xaa\x56\x91\x51\x58\x45\x26\x28\x50\x8e\x53\x72\x03\x1b\x49\x91\xa0\x79\x4a\x30\xe7\x3d\xc3\xa4\x47\x32\x19\xb3\x5a\x56\xb1\x06\x8c\x37\x34\xba\x2d\x72\xe2\x5a\x19\x78\x07\x8d\x49\xe0\x53\x4f\x89\x90\x84\xda\xe9\x83\x25\x58\x89\xa8\x5e\x02\x59\x28\x20\xbd\x05\x33\x11\xda\x4a\x13\x98\x7f\xea\x08\x70\x49\x45\x82\xe6\xb3\xc4\xad\x0d\x05\xdd\x45\x1e\x63\x38\x06\x3e\x51\x4a\xaa\x09\x6a\xba\xa8\x17\x60\x41\x21\xd8\x20\x66\x41\x65\x74\x4b\xec\x90\x59\x06\x7d\x14\x72\x2c\xe4\x90\xd4\xcb\x7f\xad\x36\x44\xcc\x16\xb4\x48\xed\xfe\xa9\xf6\xc1\xfe\xa8\xc7\x6e\xe6\x5d\xb7\x76\xb5\xf5\xae\x1b\x52\xc1\x0e\xc7\x0e\xa4\xcf\x2a\x25\xc0\x46\x32\x0d\x0a\xad\xf7\xf7\x04\xf7\x1d\x2c\x75\xc9\x70\x90\xec\x54\x3a\xf9\xba\x47\x6c\x9a\xff\x68\xc1\x86\x41\x94\x30\xd5\x07\xef\xaa\x21\x18\xc0\xc3\x8e\x06\x3c\x08\xa8\xf9\xca\xb0\x80\xbf\x6a\x77\x1d\x76\x42\xe9\xb7\xda\x05\xd5\x2c\x92\x45\xb8\x6e\xdb\xcd\xbf\x72\x82\xc0\x6c\x0d\xc1\x70\xb9\xda\x30\x7d\x2a\x80\x23\x14\xa2\xaa\xc2\x39\x61\x82\x29\x0c\x66\x62\xcf\x2f\x8d\x24\xbf\x47\xa4\xdf\xe4\x7c\xd8\x59\xe5\x2f\x39\xef\x3a\xa4\x04\x5b\x0c\xd4\x6e\x1c\xca\x80\x56\x6f\x43\x3a\x0b\x39\xc9\x50\x7d\xab\x5b\x72\xfc\xcb\xa3\xd7\x5d\xf9\x72\x78\x2c\x16\x79\x2a\x29\xb0\xd8\x30\xc0\x37\xa5\x54\xe7\x49\xd4\xf7\x07\x78\x0b\x8d\x7e\x03\xd0\x36\xad\x65\x54\x00\xb5\x58\xc8\x48\xdb\xd4\x18\x1a\x2d\xed\x67\x59\x55\x3c\x32\x6e\x5b\xc4\x6c\x00\xfe\xa6\xc3\xc3\x48\x05\xf7\xba\x31\xb8\xed\xe0\x80\xea\x5c\x26\x83\xf0\x4c\x80\xe8\x7f\xd8\xc4\x68\xb1\x01\x5e\x48\xd8\x5d\xfc\xb8\xd5\xd2\x54\x46\x34\x1d\x72\xa8\xfc\xb0\x26\x10\xd8\xeb\xba\x60\x43\xb9\x93\x01\x1a\xec\xb3\xbf\xcd\x5c\xd8\x88\x26\xd7\x51\xd8\xe6\x87\xc3\xad\x6e\x08\x72\x03\x13\xfd\xa5\xd0\xba\xbb\x3d\xbc\xb9\x94\x29\xa3\x6d\xde\xb8\xb2\x12\xe4\x6c\x63\xe3\x07\x40\xff\x59\x32\x48\x88\xca\x51\x04\xfc\xd0\x32\xac\x7a\xe9\xa2\x49\xe7\x83\x28\xa3\x2a\x75\x07\xe0\xb8\x2c\x65\x06\x20\xa9\xf9\x5d\xd7\x27\x8d\x3d\x43\xb1\x05\xfa\x10\x7a\x87\xf1\xe4\xb3\x8a\xc3\xce\x30\x35\x61\xb7\xc4\x6e\x1b\x6e\xb1\xab\x67\xf6\xc8\xd6\x91\xbc\x0b\xa7\xde\xa1\xb0\xbc\x43\xe2\xca\x80\x7e\xfa\xf7\xb4\x55\x4c\x38\x0e\x24\x37\x97\x1f\x5a\x76\xc2\xf1\xcb\xa3\x51\x5b\x1a\x19\x70\x18\xe1\xbe\x45\xce\xec\x20\x5b\xdb\xb7\x96\x58\x36\xf8\x14\x4a\x7e\x4f\xbd\x38\xd9\xc1\x63\xf3\xab\x85\x38\xb0\x1a\xb6\x40\x3a\x2b\xe1\xcf\x8a\x27\x1c\xaa\x0b\x07\xc2\xd6\xc3\xdb\x31\x75\xd4\xc3\xbb\x99\x9f\x53\xd0\x31\xd0\xfc\x8b\x86\x4c\xb8\x0f\xe0\xa0\x8f\x91\x84\x4a\x7d\x21\x6f\x4d\xdf\x0e\xe2\x97\xc2\x2c\xe3\x19\x9b\xb5\x9f\xa6\xfb\x91\x7c\x7c\xff\xf1\xbc\xfb\x40\x6d\x7b\x4d\x79\xaa\x1e\x80\xe5\xe5\xe1\xe1\x28\x2c\x78\x06\x1f\x70\x3e\x09\xb7\x6b\x83\x8c\x6c\xe9\x04\xdd\xce\x54\xd0\xda\x1d\x3a\x87\xaf\x5e\x8f\x35\x75\x06\x35\xec\xa0\x9c\x8d\x16\x5d\x37\x65\xc2\xea\x05\x7b\x48\x59\x95\xfb\xd3\xef\x6e\x20\x46\xc6\x3f\x96\x3e\xb3\x8d\x1a\x69\xcb\xd5\xcf\x35\x5e\x34\x9c\xae\x0b\x85\x37\x76\xf4\xde\xdd\x46\x94\x9a\x9d\xfd\xb6\xc5\x85\x92\xf2\x27\x0f\x00\x59\x8e\x39\x18\x77\x6a\xaa\x2e\x4a\x66\xfe\x80\x33\xf4\xfa\xd1\x5f\xb4\x5c\xf8\x03\x52\x07\xfd\x06\xf9\x62\xfd\x6e\x06\x44\x7e\x8d\x77\x77\x84\xe8\x6a\xd7\xb1\x00\x3f\xe7\x7d\xb7\xac\x7d\xf0\xa4\x97\xfc\x5f\x50\xc2\xb4\x39\xde\x8c\xb1\xdd\x0f\xee\x0d\x2f\x6e\x08\x07\x28\x4f\x37\xc1\xc1\xc4\x0b\x9e\x14\xca\x1d\xe4\xdb\x26\x1f\xc4\xc5\xf6\xb6\x66\x6c\x34\xbe\x75\xd2\x5b\x63\x11\x39\xa1\x9c\x69\x33\x08\xfb\x78\x62\xac\x77\x3c\xac\x31\x11\xe8\x41\xf5\xc7\x5f\x2b\xa4\xb5\xc0\x7b\x4c\x64\x23\xa2\x2e\xf0\x56\x5f\xcc\xbd\x5d\x47\xb5\xdf\x88\xf3\xf7\xbb\x63\x09\xd0\x5f\x0f\x6f\x8b\x39\xcb\xfa\x1b\x97\xc9\x8d\xd1\xdb\x9c\x31\xf2\x1e\xad\x42\x38\x8a\xff\xbc\xc5\xfd\xe1\xd7\x81\x4e\x6e\x0a\xfd\x3a\xd3\x05\x0e\x1b\x4c\x74\x81\xb7\x7a\x79\x6e\x03\x49\x57\xd0\x91\xa7\x5c\x44\x69\x11\xc3\x6f\x1a\x9f\x12\x66\x78\x9f\x3d\x75\x4b\x31\xc3\x1b\x4c\xff\x3b\x56\x49\x29\xcd\xa7\x04\xc2\xf9\xf9\xc8\x6c\x5d\x3d\x07\x8c\x0c\xd6\x73\x2f\xbf\x35\x5a\xeb\x97\xe3\x6a\xce\x26\x53\x56\x8f\x18\x7b\xf4\x6c\x0d\x6f\x4c\xa4\xd6\xe0\xfa\x43\xb5\x17\x5a\xc0\x98\x8f\x8b\x70\x44\xf0\x36\xbc\xd7\x17\xbd\xe7\x9b\xb8\xf6\xcb\x99\xfe\xe1\x0b\x5c\x34\x32\x10\x2b\x05\x43\x22\xb1\x16\x7a\xfc\x50\xac\x11\x8e\x8a\xc5\xda\xd4\x9d\x83\xb1\x05\xdd\x63\x47\x63\xc3\x8d\x83\xc3\xb1\xe1\xc3\xde\x78\x6c\x81\xb6\xdf\x80\xac\xca\xa1\x19\x6d\xab\xc3\xa0\xb6\xd7\x86\x66\x79\x0b\x8a\x8b\xaa\x90\x3a\x31\x1d\x45\x65\x29\x4c\xee\x97\x4c\xb4\xbe\x40\x04\x6f\xa6\x7b\xbb\x18\x6a\x3c\x2d\xee\x5c\x5a\x9e\xa3\x14\xf9\xb8\x2e\x15\xfa\xc2\x8e\xf0\x4f\x96\x7c\x11\xe2\x70\x8f\x9b\xa3\x0b\x49\x2c\x43\x67\x4b\xaa\x97\xc3\xca\x2b\x5b\xe1\xbe\x6b\x88\x85\x57\x11\xef\x4e\x9e\x1d\x1d\xff\xe6\xaf\x4f\x6c\x11\x5c\xfe\xae\xed\x2d\xc5\x94\x50\x7c\x1f\x5e\x89\x88\xe8\x5b\x7c\x5f\xa7\x82\x14\xa2\x7a\xe2\xb5\x63\x76\xfe\x73\x96\xed\x6f\x92\x30\xcf\x88\x28\xbb\xb2\x62\xdb\x43\xac\x44\x96\x52\x6d\x3c\xa8\x06\xd8\xa0\x80\xc4\xf7\x07\x7f\xa0\xd7\x23\xcf\x13\x6e\x8a\x99\xc6\x07\xf2\x62\xd0\x45\xcc\xa5\x33\xee\x6a\x43\x32\x3c\x44\x29\xd8\xd9\xec\x7b\x8e\xcf\xf5\xb8\xc9\x3d\x92\x52\x26\x2e\x11\xc2\x89\xe8\x79\xf2\x9c\xfc\x19\x43\xf4\xad\xf6\xc8\x6e\x25\xb8\xc1\xaf\x94\x25\xb4\x9e\xc7\x4a\x0b\x0c\x9f\x29\x55\x21\x84\x7d\x82\x6c\x81\xb5\xb7\x9b\xe3\x27\xe5\x5f\x13\xd4\x4f\xef\xe5\xdf\x00\x02\x63\x66\xdc\x2e\x6d\x43\x49\x1d\x88\xba\xd6\xf3\xf0\xe4\xe1\xc9\x7f\x88\x4b\x98\xe4\x0b\x29\x00\x00")
 
 func yaoModelsKbDocumentModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -2758,7 +2928,7 @@ func yaoModelsKbDocumentModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/kb/document.mod.yao", size: 9549, mode: os.FileMode(420), modTime: time.Unix(1758619331, 0)}
+	info := bindataFileInfo{name: "yao/models/kb/document.mod.yao", size: 10507, mode: os.FileMode(420), modTime: time.Unix(1792286850, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -2783,6 +2953,46 @@ func yaoModelsMemberModYao() (*asset, error) {
 	return a, nil
 }
 
+var _yaoModelsMessengerDeliveryModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x56\xc1\x4e\xdc\x30\x10\xbd\xf3\x15\x56\x4e\xad\x94\xae\x0a\x2d\x55\xdb\xdb\xaa\xf4\xc0\x81\xb6\x12\x48\x3d\x54\x68\xe5\x24\xb3\x59\x17\xc7\x8e\x6c\x07\x76\x85\xf6\xdf\x3b\x76\x12\xc7\xd9\x98\x65\x97\xc2\x01\xc8\xd8\x63\xbf\x37\xf3\x66\x3c\x8f\x27\x84\x24\x82\x56\x90\x7c\x25\x49\x01\x9c\xdd\x83\xda\x24\xa9\xb5\x72\x9a\x01\xb7\xe6\x2b\xd0\x1a\x44\x09\x8a\x5c\x8c\x36\x14\xa0\x73\xc5\x6a\xc3\xa4\x18\x6f\xeb\xcf\x21\x5c\x96\x29\x91\x02\x88\x92\x0f\xa4\x0e\x57\xa8\x31\x50\xd5\x86\xc8\x25\xa1\xa4\x42\x4f\x5a\x02\xa1\xa2\x70\xbb\xfa\xef\xcb\x0b\x42\xb5\x66\xa5\x80\x82\x64\x1b\x62\x56\x40\x6a\x25\xef\x59\x01\xaa\x45\x60\x68\xa9\xf1\xea\x3f\x89\xde\x68\x3c\x2f\xb9\x75\xd6\xac\x61\xdc\x30\x8b\xc9\xa8\x06\x9c\x49\x01\x2d\xa4\xe0\x1b\xb4\x2d\x29\xd7\xad\x51\x4b\x65\xd0\xf0\x05\x7f\xba\xd3\x32\x6e\xe3\xf0\x88\x1f\x41\x54\xaa\x9e\xd7\x62\x14\x1f\xdc\x91\xcb\xaa\x02\x61\xe2\x31\x22\xed\x71\xb8\x73\xeb\x4e\xcf\x25\x6f\x2a\xe1\xe0\x3a\xef\xf6\x96\xe0\x1e\x56\x74\xe7\x5a\x28\x9b\xda\xd9\x2e\x2f\x06\x9b\xcf\x47\x68\x0c\x20\xcc\x1b\x23\xdf\x31\x91\x2b\xb0\x16\x8c\x14\xab\x28\xc2\xb8\x83\x4d\xe2\x76\x6f\xd3\xf8\xbd\x5d\xb4\x17\xb1\xfb\xb5\x51\x4c\x94\x11\x0c\x57\x3e\x45\x51\x2c\x3f\x1b\x93\xc9\x75\x90\x48\x94\x81\x72\x09\xc4\x9c\x8e\x72\xae\x2d\xd6\x82\x29\xc8\x0d\xdf\x04\x17\x61\x30\xcd\x0a\x8f\xfa\xf4\xd1\xdb\x44\xc3\x79\x97\x22\x9f\x44\xb7\xc0\x44\x01\xeb\x2e\xdd\x7b\xa9\x76\xaa\x9b\xf2\x64\xc2\x40\xd9\xa9\x6a\x4c\x74\xbe\xeb\x12\x46\xbc\x13\xb1\x68\xaa\x0c\x54\x4a\xb4\xa1\x0a\x85\x57\x92\xa5\x92\x15\x39\x1d\x7c\x0a\x58\xd2\x86\x5b\x9f\xd3\x27\xd9\xec\x05\xae\x9b\x3c\xc7\x80\x4d\x81\x67\x52\x72\xa0\x22\x02\xfc\x7a\xd7\x25\x00\xfe\x7b\x05\x98\x8b\x36\x21\x7d\x25\x76\xe2\xc6\x52\xb3\xd6\x2e\x3d\x31\x0a\xe3\xd8\xff\x7f\x52\x46\x25\x7d\x98\xfa\x7e\x4d\x5c\x02\x72\xfd\x22\xf2\xa0\xcf\xd2\xf2\x3a\x3b\x3d\xfb\x1c\xe3\xd4\x77\x90\x97\x51\x5a\xbc\xa8\xb6\x3c\x81\x67\x8a\xac\x5f\xc6\xbd\xc2\xb0\x25\x43\x0f\x05\xa6\x51\x4f\xb5\xcb\x31\xe1\xb3\xf3\xf3\xd7\x23\x8c\xca\x37\x8d\x3e\x82\xa3\xef\x92\xd7\x3b\x9e\x01\x3f\xbf\xa7\x3d\x1d\xc9\xd5\xd8\xb1\xa7\xe4\xc8\x03\x64\x2b\x29\xef\x34\x79\x63\xbb\x49\x3a\xe4\x3c\x25\xb6\xf1\xa7\xa8\x4c\xc6\xed\x57\x26\x1b\x91\xdb\x7f\x66\xb3\xd9\xdb\x48\x4c\x3e\x9c\xbd\x76\x48\x16\x34\xd2\x6d\x0c\x43\x5d\x18\x5a\xd5\xb1\xb2\x6d\xb9\xce\xe3\x1d\xe7\x06\x3d\x6d\xff\xb4\xf4\x39\xd5\x66\x78\x50\xbb\x18\x35\x75\x41\x4d\xa0\xef\x1d\x1a\x7b\x11\x63\xae\x20\xa2\xd3\xbf\x5a\xc6\xfa\xcb\x8d\xdd\x4d\x7a\xad\xea\xbd\xa5\xa8\x89\x3b\x9b\x30\x81\xcf\xc0\x48\x8e\xc7\xe0\xf3\x55\xd5\xb5\x2c\x7d\x4c\x1f\xf7\x45\x35\x9f\x38\x07\x80\x7f\xb8\x4e\x6e\x23\xec\xc5\x95\x53\xce\x75\x1b\x73\xa6\xc9\xe4\x05\x19\xfa\xe2\xfb\x97\xb5\x76\x50\x4a\x46\x7a\x9f\x81\xb5\x89\xd0\xf8\x3e\xde\x1d\x40\x77\x2b\xfe\x59\x75\x6f\x6c\x2b\xfb\x29\xe6\x63\x82\x6e\x2b\xea\x78\x11\xdb\x47\xfd\x00\x09\x3f\x87\xec\xf8\xaa\xab\xc0\x50\xac\x00\x7a\xb0\x8c\xaf\x26\x0e\xe1\x03\x5f\x14\xcc\x8e\xb7\x94\x93\xfe\xe0\x43\xa1\xb7\x28\xf1\x77\x3b\x93\x3a\x02\xb0\x77\xfa\x5b\xfb\xf9\xd2\x3f\x1b\x74\x3a\x7c\xf8\x21\x72\x34\xb7\x0d\xa3\xcd\x6d\x8c\xc7\x37\x59\xd5\x52\x33\x83\x6f\x85\xc5\x41\x96\xdd\x30\xe6\xbb\x07\x4a\xdb\x48\xfc\x1b\x8e\x66\xc9\x0e\x05\xd9\x4f\xfa\xdd\x7c\xec\x05\xa0\x07\xca\xdb\x93\xed\xc9\x3f\x0a\x8b\x01\xec\x53\x0c\x00\x00")
+
+func yaoModelsMessengerDeliveryModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsMessengerDeliveryModYao,
+		"yao/models/messenger/delivery.mod.yao",
+	)
+}
+
+func yaoModelsMessengerDeliveryModYao() (*asset, error) {
+	bytes, err := yaoModelsMessengerDeliveryModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/messenger/delivery.mod.yao", size: 3155, mode: os.FileMode(420), modTime: time.Unix(1792288749, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoModelsMessengerOutboxModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x57\x4b\x6f\xdc\x36\x10\xbe\xfb\x57\x0c\xf6\x94\x02\xea\xa6\x49\x9b\xa2\xcd\xcd\x68\x7b\x28\x50\xa7\x41\xeb\x9e\x02\x63\x41\x49\xa3\x5d\x26\x14\xa9\xf2\x61\xef\x22\xf0\x7f\xcf\x90\x7a\x2c\x57\xa2\xb5\xd6\xc2\xbe\x18\x3b\xe2\x37\xfc\xbe\x79\x91\xfc\x7a\x05\xb0\x92\xac\xc6\xd5\x7b\x58\x29\x67\x73\xb5\x5f\x65\xde\x26\x58\x8e\xc2\x1b\x6f\xd0\x18\x94\x5b\xd4\xf0\x77\xf4\xb9\x44\x53\x68\xde\x58\xae\xe4\xe9\xa2\xd6\x07\x58\x96\x0b\x84\x4a\x69\xf8\xdf\xa1\xc3\x32\x03\x53\xec\xb0\x74\x02\x4b\x60\xb2\x84\x12\x05\xbf\x47\x4d\xbf\x6a\x82\xb2\x2d\x9a\xd6\xaf\x65\x5b\x43\x0e\x3f\xad\xcc\xc1\x58\xac\x57\x77\xc1\x9a\x3b\x2e\x2c\xf7\x3b\x59\xed\x30\x98\x34\xb2\x52\x49\x71\x20\x5b\xc5\x84\x69\x8d\x46\x69\x4b\x86\x5f\xe9\xaf\xf3\x46\x2c\xc8\xf0\x95\x7e\x44\x3a\xeb\x9e\xed\x26\x52\x4c\xdf\x0b\x55\xd7\x28\x6d\x4a\x75\x2b\x68\x45\xeb\x1e\x83\xe7\x42\x09\x57\xcb\x40\x35\x60\xdb\x1d\xa2\x3d\x78\xd9\x79\xf5\x34\x0e\x4d\xb0\xfd\xf9\xfb\xd1\x36\xc4\x37\x36\x46\x04\xae\x9d\x55\xdf\x73\x59\x68\xf4\x16\x68\x34\xaf\x99\x3e\xc0\x17\x3c\xac\xc2\xea\xc7\x2c\xbd\x6f\x17\xce\x4d\x6a\x7f\x63\x35\x97\xdb\x04\x87\x9b\x16\x04\x4f\x70\xf9\x4f\x72\xca\x22\xb4\x70\xe0\x25\x99\x79\xc5\x29\x36\x3e\xbf\x76\x87\x7d\x0e\x23\xcf\x14\x3b\xbb\x23\xec\xcf\x3f\x0d\x36\xe9\x84\xe8\xf2\x31\x64\x2c\x7c\x70\xc1\x7d\x97\xdc\x59\x71\xc5\x8e\x49\x49\x94\x9f\xaf\xec\xb7\x31\x22\x92\xd5\x7d\x03\x67\xa8\x0c\xad\x02\x4a\x78\x79\x46\xce\x9b\xb7\xbf\x9c\xd7\xc3\x65\x89\xfb\xe7\xc8\x09\xec\x97\x67\xe9\xf6\x04\x36\x2a\x5a\xbf\xc0\x7b\x82\x57\x58\x33\x2e\xa8\xef\x6a\x93\xc1\xc3\x8e\x59\xc3\x9a\x26\x83\xf5\x7a\xfd\x5d\x42\xd8\x8f\x6f\x5f\x50\x97\xc6\x82\x37\x9c\x48\x99\xa9\xba\xcf\x86\x26\xc6\x54\xdb\x3f\x09\x48\x42\x59\xca\xf3\x98\xef\x2c\x35\xe3\xf2\xcf\x58\xd8\x05\x51\xff\x77\x8c\x48\xd0\xea\xbc\x66\xa1\x21\x04\x37\xd6\xf7\x49\xe3\x74\xa3\x4c\xaa\x8a\xde\xbd\x49\x46\xfb\x6c\x5c\x27\x75\x79\x26\xa8\x37\xe3\xf5\x11\xf5\x5b\xaa\xf3\x8a\xf6\xee\x8b\xbd\x6f\x80\x4b\xe3\x6a\x99\x75\x89\x74\xa3\x74\x75\x2a\xa8\xa3\xe5\x89\x98\x76\x27\xc4\x01\xc6\xae\x55\x7f\xea\x7c\xea\x2c\x64\x6b\x88\x79\x48\x1f\xbc\x7e\x0d\xbd\x07\x6e\xe0\x81\xf1\x90\x0b\x12\x97\xa3\xd7\x67\x8f\x18\xf3\x04\x26\x47\x8f\xf0\x6b\x21\x3f\x00\x83\x07\xa5\xbf\xa0\x3e\xc1\xd9\x53\xd0\x03\x33\xd1\x81\x46\x7b\x31\x9a\xd7\xea\x9e\x06\x65\x04\xab\xa8\x1b\xb1\x3c\x05\xb6\x36\x60\x95\xa5\x61\xca\x28\x19\x7e\xfa\x30\x4b\x07\x5f\x63\xcd\x11\x5a\x30\x59\xa0\xf0\xe8\xf1\xae\xc3\x17\x62\x4d\xb5\x17\x24\x7a\x51\x1d\xf6\x6e\x08\x5a\x89\x15\x73\x22\x04\x78\x88\xd5\xcb\x35\x3c\x9d\x4e\x4a\x73\x7b\x98\xe6\x9f\x4b\x8b\x74\x8a\x26\x4a\xe0\xe3\x04\x93\x28\x82\xde\x71\x06\x3b\xbe\xdd\x51\x90\xee\x99\x70\x68\x80\xb5\x52\x2d\x54\x5c\x9b\xa8\x37\x8f\x32\x7f\x78\x41\x79\xc3\xd5\x65\xc3\x12\x93\xc3\x72\x6a\x21\xcb\xea\x26\x55\xe7\xc3\xa5\xe7\x3a\x3d\x41\x6e\x09\x1c\x9f\x39\x60\x76\xca\x89\xb2\xaf\x56\x2a\x86\x0c\x64\xdb\xa6\x4c\x92\x6e\x03\x46\x29\xe9\xff\xd3\x6c\x31\xdc\xcb\x7a\x6a\x94\x2c\xd7\x29\x71\x6f\x37\x5d\xf9\x2d\x96\xfa\x81\xc0\xa4\x32\x80\x67\xd5\xaa\x2a\x08\xf6\x9b\x1d\x9b\xbc\xdb\xf5\x05\xc5\xf4\x6d\xb4\xa4\x26\xaf\x27\x98\x88\xfc\x07\x57\xe7\xfe\x82\x5b\x4d\x58\x9b\x65\x05\x38\x3f\xe3\xd9\x7e\x73\x09\xf5\x1b\xb6\x87\x59\xfa\xb4\x80\xd7\xae\xa6\x6a\x5a\x24\xe3\xdd\x65\x32\xfa\xf9\xb7\xe0\xa0\xfd\x38\x81\x44\xec\xfb\x8f\x54\x3a\xcc\xc6\xc3\xf6\x92\xeb\xda\x65\x05\x25\x98\xb1\x1b\xd4\x5a\x25\x44\x59\x2a\xe6\x84\xa4\xbf\x08\x02\x7f\x9c\x42\x22\x51\xe1\x4b\xdf\x0f\xde\xfd\x70\x20\x9c\xe9\x86\xf9\x69\x45\xde\x97\x0f\x2a\x3f\x6c\x9e\x3b\xa3\x4e\xce\xbb\xd5\x85\x57\x19\xcb\x4a\x66\xd9\x82\xbb\xcc\x18\x10\x3f\x96\xca\x92\xfb\x4b\x01\xf3\x73\xb2\x5d\x97\x01\xae\xb7\xeb\x40\xdb\xc7\x52\x30\x4b\x23\xc7\x6f\x3e\x4f\xf7\xaa\x3b\x34\xdb\xb2\xc0\xd9\x07\xde\xbe\x7b\x3e\x6e\xda\x1b\xca\x46\x9e\x14\x41\xf4\x42\x1c\x6e\x47\xd3\x11\x7b\x97\x7c\x98\xa8\x9a\xa6\x3b\x27\xc6\x81\xc5\xf0\xcc\xea\xde\xd6\xed\x85\x64\xfe\x19\x38\x65\xd7\x24\xce\xdb\x14\xc3\x61\xdd\xb3\xa9\xf5\x08\x50\x9a\x3a\xd4\x37\xf7\x28\x96\xc3\x8d\xad\x7b\x87\x0f\x85\x68\x8e\xb1\x7f\xbc\x7a\xbc\xfa\x06\x15\x30\x19\xf9\x8d\x10\x00\x00")
+
+func yaoModelsMessengerOutboxModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsMessengerOutboxModYao,
+		"yao/models/messenger/outbox.mod.yao",
+	)
+}
+
+func yaoModelsMessengerOutboxModYao() (*asset, error) {
+	bytes, err := yaoModelsMessengerOutboxModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/messenger/outbox.mod.yao", size: 4237, mode: os.FileMode(420), modTime: time.Unix(1792278271, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _yaoModelsRoleModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x59\xcd\x6e\x1b\x37\x10\xbe\xfb\x29\x06\x7b\x72\x01\x23\x71\x0f\x45\x01\x03\x39\xb8\x71\x8b\xba\xa8\x13\xc3\x41\xd0\x43\x11\x2c\x46\xe4\x48\xcb\x84\x3f\x0a\xc9\x55\xb4\x08\xfc\xee\x05\x49\xad\xc4\x5d\x51\x96\xa5\xd4\xca\xc5\x08\xb9\x43\x7e\xdf\xfc\x0f\xf5\xfd\x0c\xa0\xd2\xa8\xa8\xba\x82\xea\xc1\x48\xaa\x2e\xc2\x8a\xc4\x09\xc9\xe1\x12\x27\xc7\xac\x98\x7b\x61\x74\xbf\x01\xa8\x39\xcc\xc9\x2a\xe1\x9c\x30\x1a\x14\x6a\x9c\x91\x22\xed\x93\x88\xc7\x99\xab\xae\xe0\xdf\xca\xc6\x53\xa0\xda\x7c\x1b\xfe\x87\xad\x6f\xc2\x5f\x3b\x41\x56\x7d\x5a\x49\x4c\x64\x80\x12\x60\x65\xc0\x6c\x8f\x02\xa0\x62\x46\xc5\x1b\xf6\x63\x38\x03\x78\x8c\xa7\x32\x23\x5b\xa5\x23\x94\x78\xc6\xeb\xd7\xf0\xe6\x7f\xfc\xd7\x9f\xf9\x1b\x3a\xc1\xe0\x0f\x41\x92\xbb\x17\xbb\x27\x69\x26\xd3\x8d\xe0\x2b\xcd\x04\xf5\x75\xf3\xb8\x76\x7b\xb3\x59\x5b\x9b\x32\x5f\xcc\x94\x78\x6f\x85\x42\xdb\xc1\x17\xea\x40\x70\xd2\x5e\x4c\x05\xd9\xcd\xa7\xf3\xb4\x5f\x5d\x81\xb7\x2d\xc5\xd5\xc7\x8b\x32\x94\x60\xa6\xba\x84\xc7\x79\x2b\xf4\xac\x80\x29\x5a\x70\x07\xb0\x8f\x5a\x7c\x6d\x09\xc2\xa1\x19\x30\x38\x47\xae\x84\xbe\x80\xd6\x91\xbd\x00\x65\x38\x59\xf4\xc6\x5e\x00\x79\xf6\xea\xa7\xec\x0e\xd2\x33\xdf\x54\x57\xf0\xcb\xe5\x7a\xad\x8d\x47\xae\xa8\xac\x57\x85\xe6\xb4\x1c\x2f\xea\x56\xca\x95\x33\x4e\x51\xba\xa7\x89\xc7\xbf\xcf\x67\xfd\x6e\xf0\x79\x46\xf9\x46\xb8\xb9\xc4\x0e\xc2\x79\x60\xa6\xe0\x9b\xc4\xbf\xc0\xea\xe7\xcb\xcb\xe3\xb0\xe6\x71\xbc\x05\xd9\xd3\xd2\x17\x00\xdf\x94\x64\x72\xdc\xe4\x51\x48\xe2\x90\x1d\x9e\xe3\x8f\x41\x2a\xbc\x83\x79\x6b\xe7\xc6\x65\x7c\x32\xe8\xb9\x7b\xbd\x64\x98\xde\x6f\x92\xc5\xa9\x63\x75\x93\xa7\xdc\xb6\xee\x3f\xbb\x5c\xbb\x6b\xdd\xdf\x97\x64\x32\xdd\xff\xf5\xe1\xfd\x3b\x30\x93\xcf\xc4\x3c\x30\xa3\x3d\x0a\x2d\xf4\x0c\x50\xca\x2c\x2d\xba\x68\x01\x64\x8c\x9c\x03\x2b\x66\x8d\x77\x7b\x6d\x50\x0e\x71\x0a\x5e\xcd\x3c\xf1\xfa\x18\x32\x0f\x6b\x71\x78\x16\x2f\xb4\x16\xbb\xe0\x49\xb4\x9c\x4b\xc1\x84\x97\x1d\x70\xd2\x82\xf2\x9c\xbf\x9f\xca\x4b\xba\xd3\x9f\x82\x2c\x5a\xd6\x74\xa7\xf7\x26\xb4\xa4\x7d\x7d\x78\xd6\xbd\x8f\x82\xf0\x54\xf2\x5d\x7d\x12\x83\x77\x6a\x2c\x08\xdd\x90\x15\x1e\x35\x23\x38\x37\x31\xc0\x51\xee\xc9\xb6\x23\x63\x14\xf3\xed\x93\xce\x26\x69\x41\x72\x9b\x97\xd0\x9e\x66\x79\x89\x5a\x13\xfb\x7b\x28\x30\x6e\x15\x9a\xb5\xa9\xe2\xc9\x70\xde\x88\x59\x43\x16\xde\x80\x32\x96\x72\x8f\xca\x98\x71\x9a\x62\x2b\xc3\x21\xc7\x31\x7b\x49\xdf\xbb\x5b\x37\x3b\xa7\x6f\x3b\x5c\x8d\xcc\x8b\x45\xa1\xee\x4d\x8c\x91\x84\xa5\xf0\xbf\x75\x70\x3d\x12\xca\x6c\xf4\x4f\x43\x3e\x98\xc3\x37\xc2\xad\xca\xbe\x03\xd6\xda\xe0\x89\xb2\x83\xf1\x75\x1b\xc3\x1c\xe7\x5d\xc2\xd5\xfd\x11\x87\x51\xb8\x19\x4b\xed\xe2\x20\x5c\xac\x80\xab\x5b\x36\xc1\xa4\xe9\x5b\x6c\x5f\x5c\x89\x4c\xac\xe0\x47\xb1\x71\x9d\xf3\xa4\x0e\x24\xf3\x61\x24\xf4\x04\x17\x84\x74\x43\x22\x72\xce\x50\x6b\xe3\x61\x12\x08\x4a\xf2\xc4\x8b\x41\x73\x24\x1d\x67\xac\xaf\x8d\xe5\x79\x98\xef\x8f\xff\x0f\xc6\x7a\x78\x3f\x94\x2a\xb4\x57\xf1\xdc\x68\x89\x70\xcd\x20\x47\x3e\x23\xda\x4f\x11\xd8\x3d\xd2\x53\x47\x35\x33\xd2\x14\x34\xbe\xb3\x92\xbc\x1d\x7e\x9f\xe9\x3a\xee\x00\x33\x3c\xb9\xfc\xc7\x5b\xe0\x2b\x4e\xe7\x0d\x2d\xc3\x9a\x42\x5f\x2a\x20\xbf\x1e\xd5\x97\x08\x56\x6a\x67\x77\xe2\xbe\x65\x3b\x3a\xd9\xb0\x91\x4f\x1b\x43\xec\x07\xd5\xbb\x53\xf8\xc9\x75\xea\xe8\xde\x1a\xed\xad\x91\x27\x77\x17\x85\xcb\x7a\x94\xc8\xf6\x07\xe9\x1d\x2e\xe1\xe3\x50\x28\x33\xc0\x1d\x2e\x85\x6a\x15\xe8\x56\x4d\xc8\x86\xd6\x2f\x5e\x00\xbe\x41\x0f\x0c\x35\x34\xb8\xa0\xac\x44\x9c\x5f\xc2\x1b\x68\xb5\x14\x4a\xec\x4a\x41\x7b\x2d\x54\xe4\x66\xe9\x6b\x2b\x2c\xb9\x1a\xe7\x73\x6b\x16\x58\x68\x44\x76\x27\xd6\x87\x95\x30\x5c\x6f\x09\x17\x12\x2c\x3a\x27\x66\xb1\x6b\xdf\xf0\xea\xaf\x87\x38\xee\xc2\x36\x88\x51\x8a\x7d\x92\x0b\xb6\xde\xd4\x96\x16\xe6\x0b\xd5\x1c\xbb\x83\xcc\x75\xdd\x7a\x03\x0f\x51\x16\x6e\x06\xb2\x79\x66\xc5\xce\x01\x4e\x3d\x59\xf8\xd6\x08\xd6\x0c\x6b\x78\xb8\x5e\xa1\x17\x0c\xa5\xec\x20\xe1\xe0\xc9\x72\x9a\x16\x64\x8f\xb3\xda\x4b\xc6\xd5\xef\x4b\x4f\x9a\x13\x0f\x91\x35\x15\xb3\xd6\x62\xe8\x78\x4f\x18\x58\xe4\x91\xa3\xc7\x67\x0f\x56\x77\x5b\x02\x99\x75\xae\x39\x17\xa9\x63\x4f\x26\x61\x39\xa9\x38\x19\x3a\xf2\xa1\x0e\x1e\x39\x14\x32\xa3\xd3\x05\xcf\x1f\x04\xdf\x16\x44\x06\xd5\xa3\xdf\x4e\x81\xaf\x5a\x17\x3b\x0d\x45\x1e\xbc\x59\x05\xcc\x6b\x85\x22\xce\xbb\x1b\x6f\xdb\x83\xff\x0c\x20\xbd\x33\xc6\x3e\x84\x36\x2f\x82\x85\x57\xb5\x65\x1a\xac\xc6\x5d\x67\xf6\x96\x98\xf7\xc0\x83\x8e\xe5\x53\x21\xbc\x42\xdf\x53\x2c\x38\x61\x27\x96\x99\x74\x54\xa4\xe1\xe0\x9b\xf0\x4d\xea\x53\x42\x01\x7b\xba\xf4\xf5\x50\xd7\xe3\x4d\x19\xed\x78\x5c\xec\xe7\xab\xe3\xd0\xda\xe1\x40\xf5\xb5\x25\x2b\xc8\x3d\x13\xe9\xb8\x53\x1d\x29\xb5\xdf\xce\xa7\x8c\xe3\x50\xe6\x0d\xeb\x61\x10\xb7\x46\x83\x11\xc6\xf5\xfe\x8f\x83\x9c\x0a\xcd\x43\xf2\xef\xe7\x84\xcc\x11\xaa\x91\xdf\x5a\x92\x98\xa2\x66\xfd\x46\xae\x28\x14\xca\xcd\x42\x76\x79\x83\xee\x0e\x75\xe6\x0e\xca\xf0\x14\x7e\x75\xdd\xa1\x79\x95\x44\x37\xdb\x5f\xa8\x2b\x3e\xe3\x4e\x8d\x25\x31\xd3\xf9\x5e\xae\xc4\x2a\xb5\x00\x47\x00\x08\x82\x3f\x70\xfd\xfa\x81\x7f\x81\xb2\x4d\xd1\x9c\xd4\x64\xfa\x9f\x29\xbe\x43\xe5\x85\x22\xe7\x51\xcd\x5d\x3f\x29\x86\x58\x9d\xfa\x3a\x4d\x2d\xd9\x6a\xf6\xeb\x44\x5a\x83\xc7\xb3\xc7\xb3\xff\x02\x00\x00\xff\xff\x48\xf4\x60\x22\x22\x19\x00\x00")
 
 func yaoModelsRoleModYaoBytes() ([]byte, error) {
@@ -3253,10 +3463,16 @@ var _bindata = map[string]func() (*asset, error){
 	"yao/data/kb/providers/embedding/openai/zh-cn.json":                yaoDataKbProvidersEmbeddingOpenaiZhCnJson,
 	"yao/data/kb/providers/extraction/openai/en.json":                  yaoDataKbProvidersExtractionOpenaiEnJson,
 	"yao/data/kb/providers/extraction/openai/zh-cn.json":               yaoDataKbProvidersExtractionOpenaiZhCnJson,
+	"yao/data/kb/providers/fetcher/crawler/en.json":                    yaoDataKbProvidersFetcherCrawlerEnJson,
+	"yao/data/kb/providers/fetcher/crawler/zh-cn.json":                 yaoDataKbProvidersFetcherCrawlerZhCnJson,
 	"yao/data/kb/providers/fetcher/http/en.json":                       yaoDataKbProvidersFetcherHttpEnJson,
 	"yao/data/kb/providers/fetcher/http/zh-cn.json":                    yaoDataKbProvidersFetcherHttpZhCnJson,
 	"yao/data/kb/providers/fetcher/mcp/en.json":                        yaoDataKbProvidersFetcherMcpEnJson,
 	"yao/data/kb/providers/fetcher/mcp/zh-cn.json":                     yaoDataKbProvidersFetcherMcpZhCnJson,
+	"yao/data/kb/providers/reranker/llm/en.json":                       yaoDataKbProvidersRerankerLlmEnJson,
+	"yao/data/kb/providers/reranker/llm/zh-cn.json":                    yaoDataKbProvidersRerankerLlmZhCnJson,
+	"yao/data/kb/providers/reranker/openai/en.json":                    yaoDataKbProvidersRerankerOpenaiEnJson,
+	"yao/data/kb/providers/reranker/openai/zh-cn.json":                 yaoDataKbProvidersRerankerOpenaiZhCnJson,
 	"yao/fields/model.trans.json":                                      yaoFieldsModelTransJson,
 	"yao/langs/en-US.json":                                             yaoLangsEnUsJson,
 	"yao/langs/zh-cn/global.yml":                                       yaoLangsZhCnGlobalYml,
@@ -3268,6 +3484,8 @@ var _bindata = map[string]func() (*asset, error){
 	"yao/models/agent/assistant.mod.yao":                               yaoModelsAgentAssistantModYao,
 	"yao/models/agent/chat.mod.yao":                                    yaoModelsAgentChatModYao,
 	"yao/models/agent/history.mod.yao":                                 yaoModelsAgentHistoryModYao,
+	"yao/models/attachment/blob.mod.yao":                               yaoModelsAttachmentBlobModYao,
+	"yao/models/attachment/upload.mod.yao":                             yaoModelsAttachmentUploadModYao,
 	"yao/models/attachment.mod.yao":                                    yaoModelsAttachmentModYao,
 	"yao/models/audit.mod.yao":                                         yaoModelsAuditModYao,
 	"yao/models/config.mod.yao":                                        yaoModelsConfigModYao,
@@ -3279,6 +3497,8 @@ var _bindata = map[string]func() (*asset, error){
 	"yao/models/kb/collection.mod.yao":                                 yaoModelsKbCollectionModYao,
 	"yao/models/kb/document.mod.yao":                                   yaoModelsKbDocumentModYao,
 	"yao/models/member.mod.yao":                                        yaoModelsMemberModYao,
+	"yao/models/messenger/delivery.mod.yao":                            yaoModelsMessengerDeliveryModYao,
+	"yao/models/messenger/outbox.mod.yao":                              yaoModelsMessengerOutboxModYao,
 	"yao/models/role.mod.yao":                                          yaoModelsRoleModYao,
 	"yao/models/team.mod.yao":                                          yaoModelsTeamModYao,
 	"yao/models/user/oauth_account.mod.yao":                            yaoModelsUserOauth_accountModYao,
@@ -3555,6 +3775,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 						}},
 					}},
 					"fetcher": {nil, map[string]*bintree{
+						"crawler": {nil, map[string]*bintree{
+							"en.json":    {yaoDataKbProvidersFetcherCrawlerEnJson, map[string]*bintree{}},
+							"zh-cn.json": {yaoDataKbProvidersFetcherCrawlerZhCnJson, map[string]*bintree{}},
+						}},
 						"http": {nil, map[string]*bintree{
 							"en.json":    {yaoDataKbProvidersFetcherHttpEnJson, map[string]*bintree{}},
 							"zh-cn.json": {yaoDataKbProvidersFetcherHttpZhCnJson, map[string]*bintree{}},
@@ -3564,6 +3788,16 @@ var _bintree = &bintree{nil, map[string]*bintree{
 							"zh-cn.json": {yaoDataKbProvidersFetcherMcpZhCnJson, map[string]*bintree{}},
 						}},
 					}},
+					"reranker": {nil, map[string]*bintree{
+						"llm": {nil, map[string]*bintree{
+							"en.json":    {yaoDataKbProvidersRerankerLlmEnJson, map[string]*bintree{}},
+							"zh-cn.json": {yaoDataKbProvidersRerankerLlmZhCnJson, map[string]*bintree{}},
+						}},
+						"openai": {nil, map[string]*bintree{
+							"en.json":    {yaoDataKbProvidersRerankerOpenaiEnJson, map[string]*bintree{}},
+							"zh-cn.json": {yaoDataKbProvidersRerankerOpenaiZhCnJson, map[string]*bintree{}},
+						}},
+					}},
 				}},
 			}},
 		}},
@@ -3593,6 +3827,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 				"chat.mod.yao":      {yaoModelsAgentChatModYao, map[string]*bintree{}},
 				"history.mod.yao":   {yaoModelsAgentHistoryModYao, map[string]*bintree{}},
 			}},
+			"attachment": {nil, map[string]*bintree{
+				"blob.mod.yao":   {yaoModelsAttachmentBlobModYao, map[string]*bintree{}},
+				"upload.mod.yao": {yaoModelsAttachmentUploadModYao, map[string]*bintree{}},
+			}},
 			"attachment.mod.yao": {yaoModelsAttachmentModYao, map[string]*bintree{}},
 			"audit.mod.yao":      {yaoModelsAuditModYao, map[string]*bintree{}},
 			"config.mod.yao":     {yaoModelsConfigModYao, map[string]*bintree{}},
@@ -3608,8 +3846,12 @@ var _bintree = &bintree{nil, map[string]*bintree{
 				"document.mod.yao":   {yaoModelsKbDocumentModYao, map[string]*bintree{}},
 			}},
 			"member.mod.yao": {yaoModelsMemberModYao, map[string]*bintree{}},
-			"role.mod.yao":   {yaoModelsRoleModYao, map[string]*bintree{}},
-			"team.mod.yao":   {yaoModelsTeamModYao, map[string]*bintree{}},
+			"messenger": {nil, map[string]*bintree{
+				"delivery.mod.yao": {yaoModelsMessengerDeliveryModYao, map[string]*bintree{}},
+				"outbox.mod.yao":   {yaoModelsMessengerOutboxModYao, map[string]*bintree{}},
+			}},
+			"role.mod.yao": {yaoModelsRoleModYao, map[string]*bintree{}},
+			"team.mod.yao": {yaoModelsTeamModYao, map[string]*bintree{}},
 			"user": {nil, map[string]*bintree{
 				"oauth_account.mod.yao": {yaoModelsUserOauth_accountModYao, map[string]*bintree{}},
 				"type.mod.yao":          {yaoModelsUserTypeModYao, map[string]*bintree{}},
@@ -21,20 +21,21 @@ var JobFields = []interface{}{
 	"id", "job_id", "name", "icon", "description", "category_id",
 	"max_worker_nums", "status", "mode", "schedule_type", "schedule_expression",
 	"max_retry_count", "default_timeout", "priority", "created_by",
-	"next_run_at", "last_run_at", "current_execution_id", "config",
-	"sort", "enabled", "system", "readonly", "created_at", "updated_at",
+	"next_run_at", "last_run_at", "current_execution_id", "config", "depends_on",
+	"retry_policy", "sla", "sort", "enabled", "system", "readonly", "created_at", "updated_at",
 }
 
 // CategoryFields defines the fields to select for category queries
 var CategoryFields = []interface{}{
 	"id", "category_id", "name", "icon", "description",
-	"sort", "system", "enabled", "readonly", "created_at", "updated_at",
+	"sort", "max_concurrency", "system", "enabled", "readonly", "created_at", "updated_at",
 }
 
 // ExecutionFields defines the fields to select for execution queries
 var ExecutionFields = []interface{}{
 	"id", "execution_id", "job_id", "status", "trigger_category", "trigger_source",
-	"trigger_context", "scheduled_at", "worker_id", "process_id", "retry_attempt",
+	"trigger_context", "scheduled_at", "worker_id", "node_id", "lease_expires_at", "heartbeat_at",
+	"process_id", "retry_attempt",
 	"parent_execution_id", "started_at", "ended_at", "timeout_seconds", "duration",
 	"progress", "execution_config", "execution_options", "config_snapshot",
 	"result", "error_info", "stack_trace", "metrics", "context", "created_at", "updated_at",
@@ -739,6 +740,13 @@ func SaveLog(log *Log) error {
 	}
 	log.ID = uint(id)
 
+	// Wake up the streams of the log
+	executionID := ""
+	if log.ExecutionID != nil {
+		executionID = *log.ExecutionID
+	}
+	streams.notify(log.JobID, executionID)
+
 	return nil
 }
 
@@ -975,11 +983,14 @@ func SaveExecution(execution *Execution) error {
 		}
 		execution.ID = uint(id)
 	} else {
-		// Update existing execution
+		// Update existing execution, the lease is only written by the lease methods
 		data["updated_at"] = now
 		delete(data, "id")
 		delete(data, "execution_id")
 		delete(data, "created_at")
+		delete(data, "node_id")
+		delete(data, "lease_expires_at")
+		delete(data, "heartbeat_at")
 
 		param := model.QueryParam{
 			Wheres: []model.QueryWhere{
@@ -999,6 +1010,9 @@ func SaveExecution(execution *Execution) error {
 		}
 	}
 
+	// Wake up the streams of the execution
+	streams.notify(execution.JobID, execution.ExecutionID)
+
 	// Update related Job information after execution changes
 	if err := updateJobProgress(execution.JobID); err != nil {
 		return fmt.Errorf("failed to update job progress: %w", err)
@@ -1207,7 +1221,7 @@ func mapToStruct(m maps.MapStr, v interface{}) error {
 			default:
 				cleanMap[key] = value
 			}
-		case "created_at", "updated_at", "next_run_at", "last_run_at", "scheduled_at", "started_at", "finished_at", "ended_at", "timestamp":
+		case "created_at", "updated_at", "next_run_at", "last_run_at", "scheduled_at", "started_at", "finished_at", "ended_at", "timestamp", "lease_expires_at", "heartbeat_at":
 			// Handle time fields - support multiple time formats for SQLite/MySQL compatibility
 			if str, ok := value.(string); ok && str != "" {
 				// Try multiple time formats
@@ -1268,6 +1282,7 @@ func updateJobProgress(jobID string) error {
 	completedCount := 0
 	failedCount := 0
 	runningCount := 0
+	skippedCount := 0
 	totalProgress := 0
 
 	for _, execution := range executions {
@@ -1276,10 +1291,13 @@ func updateJobProgress(jobID string) error {
 		switch execution.Status {
 		case "completed":
 			completedCount++
-		case "failed":
+		case "failed", "dead":
 			failedCount++
 		case "running":
 			runningCount++
+		case "skipped":
+			// Skipped by the dependency conditions, finished without running
+			skippedCount++
 		}
 	}
 
@@ -1288,9 +1306,9 @@ func updateJobProgress(jobID string) error {
 
 	// Determine job status
 	var jobStatus string
-	if completedCount == totalExecutions {
+	if completedCount+skippedCount == totalExecutions {
 		jobStatus = "completed"
-	} else if failedCount > 0 && runningCount == 0 && completedCount+failedCount == totalExecutions {
+	} else if failedCount > 0 && runningCount == 0 && completedCount+failedCount+skippedCount == totalExecutions {
 		jobStatus = "failed"
 	} else if runningCount > 0 || completedCount > 0 {
 		jobStatus = "running"
@@ -0,0 +1,812 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// ErrDependencyCycle is returned when the dependencies form a cycle
+var ErrDependencyCycle = errors.New("dependency cycle detected")
+
+// finishedStatuses the final execution statuses
+var finishedStatuses = map[string]bool{
+	"completed": true,
+	"failed":    true,
+	"cancelled": true,
+	"timeout":   true,
+	"killed":    true,
+	"dead":      true,
+	"skipped":   true,
+}
+
+// failedStatuses the final execution statuses matching the on_failure condition
+var failedStatuses = map[string]bool{
+	"failed":    true,
+	"cancelled": true,
+	"timeout":   true,
+	"killed":    true,
+	"dead":      true,
+}
+
+// Graph is the dependency graph of a job, the nodes are the executions of the job and its upstream and downstream jobs
+type Graph struct {
+	JobID string      `json:"job_id"`
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a node of the dependency graph
+type GraphNode struct {
+	ID     string `json:"id"`   // Execution ID or job ID
+	Type   string `json:"type"` // execution or job
+	Key    string `json:"key,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status"`
+}
+
+// GraphEdge is an edge of the dependency graph, from the upstream node to the downstream node
+type GraphEdge struct {
+	From       string              `json:"from"`
+	To         string              `json:"to"`
+	Condition  DependencyCondition `json:"condition"`
+	PassResult bool                `json:"pass_result,omitempty"`
+}
+
+// executionGraph tracks the executions of a pushed job and releases them once their dependencies are met
+type executionGraph struct {
+	nodes map[string]*graphNode // executionID -> node
+	order []string              // execution IDs in priority order
+	mutex sync.Mutex
+}
+
+// graphNode is an execution of the graph
+type graphNode struct {
+	execution  *Execution
+	upstream   []resolvedDependency
+	downstream []string
+	status     string // empty until the execution is submitted or finished
+}
+
+// resolvedDependency is a dependency with the upstream execution key resolved to its ID
+type resolvedDependency struct {
+	Dependency
+	executionID string
+}
+
+// DependOn sets the upstream jobs of the job, the job is pushed when the upstream jobs finish and the conditions are met
+func (j *Job) DependOn(dependencies ...Dependency) error {
+	for i, dependency := range dependencies {
+		if dependency.JobID == "" {
+			return fmt.Errorf("dependency %d: job_id is required", i)
+		}
+		if dependency.Execution != "" {
+			return fmt.Errorf("dependency %d: execution dependencies are declared on the execution options", i)
+		}
+		if err := validateCondition(dependency.Condition); err != nil {
+			return fmt.Errorf("dependency %d: %w", i, err)
+		}
+		if dependency.JobID == j.JobID {
+			return fmt.Errorf("%w: job %s depends on itself", ErrDependencyCycle, j.JobID)
+		}
+	}
+
+	if j.JobID != "" {
+		if err := checkJobCycle(j.JobID, dependencies); err != nil {
+			return err
+		}
+	}
+
+	j.DependsOn = dependencies
+	return nil
+}
+
+// GetGraph returns the dependency graph of a job
+func GetGraph(jobID string) (*Graph, error) {
+	job, err := GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	executions, err := GetExecutions(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executions: %w", err)
+	}
+
+	graph := &Graph{JobID: jobID, Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	keys := executionKeys(executions)
+	for _, execution := range executions {
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:     execution.ExecutionID,
+			Type:   "execution",
+			Key:    executionKey(execution),
+			Status: execution.Status,
+		})
+
+		if execution.ExecutionOptions == nil {
+			continue
+		}
+		for _, dependency := range execution.ExecutionOptions.DependsOn {
+			from, ok := keys[dependency.Execution]
+			if !ok {
+				from = dependency.Execution
+			}
+			graph.Edges = append(graph.Edges, GraphEdge{
+				From:       from,
+				To:         execution.ExecutionID,
+				Condition:  conditionOf(dependency),
+				PassResult: dependency.PassResult,
+			})
+		}
+	}
+
+	// Upstream jobs
+	for _, dependency := range job.DependsOn {
+		node := GraphNode{ID: dependency.JobID, Type: "job", Status: "unknown"}
+		if upstream, err := GetJob(dependency.JobID); err == nil {
+			node.Name = upstream.Name
+			node.Status = upstream.Status
+		}
+		graph.Nodes = append(graph.Nodes, node)
+		graph.Edges = append(graph.Edges, GraphEdge{
+			From:       dependency.JobID,
+			To:         jobID,
+			Condition:  conditionOf(dependency),
+			PassResult: dependency.PassResult,
+		})
+	}
+
+	// Downstream jobs
+	downstream, err := getDependentJobs(jobID)
+	if err != nil {
+		return nil, err
+	}
+	for _, dependent := range downstream {
+		graph.Nodes = append(graph.Nodes, GraphNode{ID: dependent.JobID, Type: "job", Name: dependent.Name, Status: dependent.Status})
+		for _, dependency := range dependent.DependsOn {
+			if dependency.JobID != jobID {
+				continue
+			}
+			graph.Edges = append(graph.Edges, GraphEdge{
+				From:       jobID,
+				To:         dependent.JobID,
+				Condition:  conditionOf(dependency),
+				PassResult: dependency.PassResult,
+			})
+		}
+	}
+
+	return graph, nil
+}
+
+// newExecutionGraph builds the graph of the executions, the executions must be sorted by priority
+func newExecutionGraph(executions []*Execution) (*executionGraph, error) {
+	graph := &executionGraph{nodes: make(map[string]*graphNode, len(executions))}
+	keys := executionKeys(executions)
+	for _, execution := range executions {
+		graph.nodes[execution.ExecutionID] = &graphNode{execution: execution}
+		graph.order = append(graph.order, execution.ExecutionID)
+	}
+
+	for _, execution := range executions {
+		if execution.ExecutionOptions == nil {
+			continue
+		}
+
+		node := graph.nodes[execution.ExecutionID]
+		for _, dependency := range execution.ExecutionOptions.DependsOn {
+			if dependency.JobID != "" {
+				return nil, fmt.Errorf("execution %s: job dependencies are declared on the job", execution.ExecutionID)
+			}
+			if err := validateCondition(dependency.Condition); err != nil {
+				return nil, fmt.Errorf("execution %s: %w", execution.ExecutionID, err)
+			}
+
+			upstreamID, ok := keys[dependency.Execution]
+			if !ok {
+				return nil, fmt.Errorf("execution %s: upstream execution %s not found", execution.ExecutionID, dependency.Execution)
+			}
+
+			node.upstream = append(node.upstream, resolvedDependency{Dependency: dependency, executionID: upstreamID})
+			graph.nodes[upstreamID].downstream = append(graph.nodes[upstreamID].downstream, execution.ExecutionID)
+		}
+	}
+
+	if err := graph.checkCycle(); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// checkCycle returns an error holding the path of the first cycle found
+func (g *executionGraph) checkCycle() error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	states := make(map[string]int, len(g.nodes))
+	path := []string{}
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch states[id] {
+		case visiting:
+			return fmt.Errorf("%w: %s -> %s", ErrDependencyCycle, strings.Join(path, " -> "), g.label(id))
+		case visited:
+			return nil
+		}
+
+		states[id] = visiting
+		path = append(path, g.label(id))
+		for _, downstream := range g.nodes[id].downstream {
+			if err := visit(downstream); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		states[id] = visited
+		return nil
+	}
+
+	for _, id := range g.order {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// label returns the key of an execution for the error messages
+func (g *executionGraph) label(id string) string {
+	return executionKey(g.nodes[id].execution)
+}
+
+// roots returns the executions without dependencies and marks them as submitted
+func (g *executionGraph) roots() []*Execution {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	roots := []*Execution{}
+	for _, id := range g.order {
+		node := g.nodes[id]
+		if len(node.upstream) == 0 {
+			node.status = "submitted"
+			roots = append(roots, node.execution)
+		}
+	}
+	return roots
+}
+
+// finish records the final status of an execution, and returns the downstream executions to submit
+// and the ones to skip because their conditions can no longer be met
+func (g *executionGraph) finish(executionID string, status string) (ready []*Execution, skipped []*Execution) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	node, ok := g.nodes[executionID]
+	if !ok {
+		return nil, nil
+	}
+	node.status = status
+
+	queue := []*graphNode{node}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, id := range current.downstream {
+			downstream := g.nodes[id]
+			if downstream.status != "" {
+				continue
+			}
+
+			met, finished := g.evaluate(downstream)
+			if !finished {
+				continue
+			}
+
+			if met {
+				downstream.status = "submitted"
+				downstream.execution.upstreamArgs = g.upstreamResults(downstream)
+				parentID := downstream.upstream[0].executionID
+				downstream.execution.ParentExecutionID = &parentID
+				ready = append(ready, downstream.execution)
+				continue
+			}
+
+			// The skipped executions release their own downstream executions
+			downstream.status = "skipped"
+			skipped = append(skipped, downstream.execution)
+			queue = append(queue, downstream)
+		}
+	}
+	return ready, skipped
+}
+
+// evaluate checks whether all the upstream executions finished and all the conditions are met
+func (g *executionGraph) evaluate(node *graphNode) (met bool, finished bool) {
+	met = true
+	for _, dependency := range node.upstream {
+		status := g.nodes[dependency.executionID].status
+		if !finishedStatuses[status] {
+			return false, false
+		}
+		if !conditionMet(dependency.Condition, status) {
+			met = false
+		}
+	}
+	return met, true
+}
+
+// upstreamResults returns the results of the upstream executions passed to the node, in declaration order
+func (g *executionGraph) upstreamResults(node *graphNode) []interface{} {
+	results := []interface{}{}
+	for _, dependency := range node.upstream {
+		if dependency.PassResult {
+			results = append(results, decodeResult(g.nodes[dependency.executionID].execution))
+		}
+	}
+	return results
+}
+
+// done returns true when all the executions finished
+func (g *executionGraph) done() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, node := range g.nodes {
+		if !finishedStatuses[node.status] {
+			return false
+		}
+	}
+	return true
+}
+
+// succeeded returns true when no execution failed
+func (g *executionGraph) succeeded() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, node := range g.nodes {
+		if failedStatuses[node.status] {
+			return false
+		}
+	}
+	return true
+}
+
+// results returns the results of the completed executions by execution key
+func (g *executionGraph) results() map[string]interface{} {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	results := map[string]interface{}{}
+	for _, node := range g.nodes {
+		if node.status == "completed" {
+			results[executionKey(node.execution)] = decodeResult(node.execution)
+		}
+	}
+	return results
+}
+
+// onExecutionFinished submits the downstream executions of a finished execution, and triggers
+// the downstream jobs once all the executions of the job finished. The graph is rebuilt from the
+// execution rows when the job was pushed by another node or before a restart.
+func (j *Job) onExecutionFinished(execution *Execution) {
+	j.executionMutex.RLock()
+	graph := j.graph
+	j.executionMutex.RUnlock()
+
+	loaded := false
+	if graph == nil {
+		var err error
+		graph, err = loadExecutionGraph(j.JobID)
+		if err != nil {
+			log.Error("Failed to load the dependency graph of job %s: %v", j.JobID, err)
+			return
+		}
+		if graph == nil {
+			return
+		}
+		loaded = true
+	}
+
+	ready, skipped := graph.finish(execution.ExecutionID, execution.Status)
+	now := time.Now()
+	for _, execution := range skipped {
+		execution.EndedAt = &now
+		if !releaseExecution(execution, "skipped") {
+			continue
+		}
+		execution.Info("Execution skipped, the dependency conditions were not met")
+	}
+
+	j.prepare()
+	wm := GetWorkerManager()
+	for _, execution := range ready {
+		if !releaseExecution(execution, "queued") {
+			continue
+		}
+		if err := j.submit(wm, execution); err != nil {
+			log.Error("Failed to submit execution %s: %v", execution.ExecutionID, err)
+		}
+	}
+
+	if !graph.done() {
+		return
+	}
+
+	if !loaded {
+		j.executionMutex.Lock()
+		if j.graph != graph {
+			// Already handled, or the job was pushed again
+			j.executionMutex.Unlock()
+			return
+		}
+		j.graph = nil
+		j.executionMutex.Unlock()
+	}
+
+	triggerDependentJobs(j, graph.succeeded(), graph.results())
+}
+
+// loadExecutionGraph rebuilds the graph of a job from its execution rows. The edges are the dependencies
+// stored with the execution options, the waiting executions are the ones not released yet.
+func loadExecutionGraph(jobID string) (*executionGraph, error) {
+	if !model.Exists("__yao.job.execution") {
+		return nil, nil
+	}
+
+	executions, err := GetExecutions(jobID)
+	if err != nil {
+		return nil, err
+	}
+	sortExecutions(executions)
+
+	graph, err := newExecutionGraph(executions)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range graph.nodes {
+		switch status := node.execution.Status; {
+		case status == "waiting":
+			node.status = ""
+		case finishedStatuses[status]:
+			node.status = status
+		default:
+			node.status = "submitted"
+		}
+	}
+	return graph, nil
+}
+
+// releaseExecution moves a waiting execution to the given status, only one node releases it when
+// several nodes rebuild the graph. The upstream arguments are not saved, they are passed when the execution runs.
+func releaseExecution(execution *Execution, status string) bool {
+	mod := model.Select("__yao.job.execution")
+	affected, err := mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: execution.ExecutionID},
+			{Column: "status", Value: "waiting"},
+		},
+		Limit: 1,
+	}, map[string]interface{}{"status": status, "updated_at": time.Now()})
+	if err != nil {
+		log.Warn("Failed to release execution %s: %v", execution.ExecutionID, err)
+		return false
+	}
+	if affected == 0 {
+		return false
+	}
+
+	execution.Status = status
+	if err := SaveExecution(execution); err != nil {
+		log.Warn("Failed to save released execution %s: %v", execution.ExecutionID, err)
+	}
+	return true
+}
+
+// waitingExecutions returns the executions with dependencies, they wait until their upstream executions finish
+func (g *executionGraph) waitingExecutions() []*Execution {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	executions := []*Execution{}
+	for _, id := range g.order {
+		if node := g.nodes[id]; len(node.upstream) > 0 {
+			executions = append(executions, node.execution)
+		}
+	}
+	return executions
+}
+
+// sortExecutions sorts the executions by priority, higher priority first
+func sortExecutions(executions []*Execution) {
+	sort.SliceStable(executions, func(i, j int) bool {
+		priorityI := 0
+		if executions[i].ExecutionOptions != nil {
+			priorityI = executions[i].ExecutionOptions.Priority
+		}
+		priorityJ := 0
+		if executions[j].ExecutionOptions != nil {
+			priorityJ = executions[j].ExecutionOptions.Priority
+		}
+		return priorityI > priorityJ
+	})
+}
+
+// triggerDependentJobs pushes the downstream jobs of a finished job whose conditions are met
+func triggerDependentJobs(upstream *Job, succeeded bool, results map[string]interface{}) {
+	dependents, err := getDependentJobs(upstream.JobID)
+	if err != nil {
+		log.Error("Failed to get the downstream jobs of %s: %v", upstream.JobID, err)
+		return
+	}
+
+	for _, dependent := range dependents {
+		if !dependent.Enabled || dependent.Status == "running" || dependent.Status == "disabled" {
+			continue
+		}
+
+		met, args := dependent.dependenciesMet(upstream.JobID, succeeded, results)
+		if !met {
+			log.Info("Job %s not triggered by %s, the dependency conditions were not met", dependent.JobID, upstream.JobID)
+			continue
+		}
+
+		dependent.upstreamArgs = args
+		if err := dependent.Push(); err != nil {
+			log.Error("Failed to push downstream job %s: %v", dependent.JobID, err)
+			continue
+		}
+		log.Info("Job %s triggered by upstream job %s", dependent.JobID, upstream.JobID)
+	}
+}
+
+// dependenciesMet checks the upstream jobs of the job after the given upstream job finished.
+// The other upstream jobs must have finished since the last run of the job.
+func (j *Job) dependenciesMet(upstreamID string, succeeded bool, results map[string]interface{}) (bool, []interface{}) {
+	args := []interface{}{}
+	for _, dependency := range j.DependsOn {
+		status := "failed"
+		if dependency.JobID == upstreamID {
+			if succeeded {
+				status = "completed"
+			}
+			if dependency.PassResult {
+				args = append(args, results)
+			}
+		} else {
+			other, err := GetJob(dependency.JobID)
+			if err != nil {
+				return false, nil
+			}
+			if other.Status != "completed" && other.Status != "failed" {
+				return false, nil
+			}
+			if other.LastRunAt == nil || (j.LastRunAt != nil && !other.LastRunAt.After(*j.LastRunAt)) {
+				return false, nil
+			}
+			status = other.Status
+			if dependency.PassResult {
+				args = append(args, jobResults(other.JobID))
+			}
+		}
+
+		if !conditionMet(dependency.Condition, status) {
+			return false, nil
+		}
+	}
+	return true, args
+}
+
+// getDependentJobs returns the jobs depending on the given job
+func getDependentJobs(jobID string) ([]*Job, error) {
+	mod := model.Select("__yao.job")
+	if mod == nil {
+		return nil, fmt.Errorf("job model not found")
+	}
+
+	results, err := mod.Get(model.QueryParam{
+		Select: JobFields,
+		Wheres: []model.QueryWhere{
+			{Column: "depends_on", OP: "notnull"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []*Job{}
+	for _, result := range results {
+		job := &Job{}
+		if err := mapToStruct(result, job); err != nil {
+			continue
+		}
+		for _, dependency := range job.DependsOn {
+			if dependency.JobID == jobID {
+				jobs = append(jobs, job)
+				break
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// checkJobCycle walks the upstream jobs and returns an error if the job is reached again
+func checkJobCycle(jobID string, dependencies []Dependency) error {
+	visited := map[string]bool{}
+	var visit func(path []string, dependencies []Dependency) error
+	visit = func(path []string, dependencies []Dependency) error {
+		for _, dependency := range dependencies {
+			current := append(append([]string{}, path...), dependency.JobID)
+			if dependency.JobID == jobID {
+				return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(current, " -> "))
+			}
+			if visited[dependency.JobID] {
+				continue
+			}
+			visited[dependency.JobID] = true
+
+			upstream, err := GetJob(dependency.JobID)
+			if err != nil {
+				return fmt.Errorf("upstream job %s: %w", dependency.JobID, err)
+			}
+			if err := visit(current, upstream.DependsOn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit([]string{jobID}, dependencies)
+}
+
+// jobResults returns the results of the completed executions of a job by execution key
+func jobResults(jobID string) map[string]interface{} {
+	results := map[string]interface{}{}
+	executions, err := GetExecutions(jobID)
+	if err != nil {
+		return results
+	}
+	for _, execution := range executions {
+		if execution.Status == "completed" {
+			results[executionKey(execution)] = decodeResult(execution)
+		}
+	}
+	return results
+}
+
+// validateCondition validates a dependency condition, empty means on_success
+func validateCondition(condition DependencyCondition) error {
+	switch condition {
+	case "", OnSuccess, OnFailure, Always:
+		return nil
+	}
+	return fmt.Errorf("invalid dependency condition: %s", condition)
+}
+
+// conditionOf returns the condition of a dependency with the default applied
+func conditionOf(dependency Dependency) DependencyCondition {
+	if dependency.Condition == "" {
+		return OnSuccess
+	}
+	return dependency.Condition
+}
+
+// conditionMet checks a condition against the final status of the upstream
+func conditionMet(condition DependencyCondition, status string) bool {
+	switch condition {
+	case Always:
+		return finishedStatuses[status]
+	case OnFailure:
+		return failedStatuses[status]
+	default:
+		return status == "completed"
+	}
+}
+
+// executionKey returns the key of an execution, the execution ID if no key is set
+func executionKey(execution *Execution) string {
+	if execution.ExecutionOptions != nil && execution.ExecutionOptions.Key != "" {
+		return execution.ExecutionOptions.Key
+	}
+	return execution.ExecutionID
+}
+
+// executionKeys maps the keys and the IDs of the executions to the execution IDs
+func executionKeys(executions []*Execution) map[string]string {
+	keys := make(map[string]string, len(executions)*2)
+	for _, execution := range executions {
+		keys[execution.ExecutionID] = execution.ExecutionID
+		if execution.ExecutionOptions != nil && execution.ExecutionOptions.Key != "" {
+			keys[execution.ExecutionOptions.Key] = execution.ExecutionID
+		}
+	}
+	return keys
+}
+
+// decodeResult decodes the result of an execution
+func decodeResult(execution *Execution) interface{} {
+	if execution.Result == nil || len(*execution.Result) == 0 {
+		return nil
+	}
+	var result interface{}
+	if err := jsoniter.Unmarshal(*execution.Result, &result); err != nil {
+		return string(*execution.Result)
+	}
+	return result
+}
+
+// loadUpstreamArgs reads the results passed to an execution from the rows of its upstream executions, for
+// an execution dispatched without the graph of its run, e.g. a retry submitted by another node
+func loadUpstreamArgs(execution *Execution) []interface{} {
+	if execution.ExecutionOptions == nil {
+		return nil
+	}
+
+	passed := false
+	for _, dependency := range execution.ExecutionOptions.DependsOn {
+		passed = passed || (dependency.PassResult && dependency.Execution != "")
+	}
+	if !passed {
+		return nil
+	}
+
+	executions, err := GetExecutions(execution.JobID)
+	if err != nil {
+		log.Warn("Failed to read the upstream results of execution %s: %v", execution.ExecutionID, err)
+		return nil
+	}
+
+	keys := executionKeys(executions)
+	byID := make(map[string]*Execution, len(executions))
+	for _, upstream := range executions {
+		byID[upstream.ExecutionID] = upstream
+	}
+
+	results := []interface{}{}
+	for _, dependency := range execution.ExecutionOptions.DependsOn {
+		if id, ok := keys[dependency.Execution]; ok && dependency.PassResult {
+			results = append(results, decodeResult(byID[id]))
+		}
+	}
+	return results
+}
+
+// config returns the config the execution runs with: the stored config with the results of the upstream
+// executions or jobs of the current run appended
+func (e *Execution) config() *ExecutionConfig {
+	return withArgs(e.ExecutionConfig, e.upstreamArgs)
+}
+
+// withArgs returns a copy of the execution config with the arguments appended,
+// the command arguments receive the JSON encoded values
+func withArgs(config *ExecutionConfig, args []interface{}) *ExecutionConfig {
+	if config == nil || len(args) == 0 {
+		return config
+	}
+
+	extended := *config
+	switch config.Type {
+	case ExecutionTypeCommand:
+		extended.CommandArgs = append([]string{}, config.CommandArgs...)
+		for _, arg := range args {
+			if str, ok := arg.(string); ok {
+				extended.CommandArgs = append(extended.CommandArgs, str)
+				continue
+			}
+			raw, _ := jsoniter.Marshal(arg)
+			extended.CommandArgs = append(extended.CommandArgs, string(raw))
+		}
+	default:
+		extended.ProcessArgs = append(append([]interface{}{}, config.ProcessArgs...), args...)
+	}
+	return &extended
+}
@@ -0,0 +1,294 @@
+package job_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// registerDependencyProcesses registers the processes of the dependency tests
+func registerDependencyProcesses() {
+	// Returns the received arguments
+	process.Register("test.job.dependency.args", func(process *process.Process) interface{} {
+		return map[string]interface{}{"args": process.Args}
+	})
+}
+
+func TestExecutionDependencies(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerDependencyProcesses()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Dependency Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// extract -> transform (pass the result) -> on_failure cleanup (skipped), always notify
+	steps := []struct {
+		options *job.ExecutionOptions
+		process string
+		args    []interface{}
+	}{
+		{job.NewExecutionOptions().WithKey("extract").WithPriority(1), "test.job.dependency.args", []interface{}{"rows"}},
+		{job.NewExecutionOptions().WithKey("transform").WithDependency("extract", job.OnSuccess, true), "test.job.dependency.args", []interface{}{"upper"}},
+		{job.NewExecutionOptions().WithKey("cleanup").WithDependency("transform", job.OnFailure, false), "test.job.dependency.args", nil},
+		{job.NewExecutionOptions().WithKey("notify").WithDependency("cleanup", job.Always, false), "test.job.dependency.args", nil},
+	}
+	for _, step := range steps {
+		if err := testJob.Add(step.options, step.process, step.args...); err != nil {
+			t.Fatalf("Failed to add execution %s: %v", step.options.Key, err)
+		}
+	}
+
+	// The keys are unique within a job
+	if err := testJob.Add(job.NewExecutionOptions().WithKey("extract"), "test.job.dependency.args"); err == nil {
+		t.Error("Expected an error for a duplicate execution key")
+	}
+
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	executions, err := testJob.GetExecutions()
+	if err != nil {
+		t.Fatalf("Failed to get executions: %v", err)
+	}
+
+	byKey := map[string]*job.Execution{}
+	for _, execution := range executions {
+		byKey[execution.ExecutionOptions.Key] = execution
+	}
+
+	expected := map[string]string{"extract": "completed", "transform": "completed", "cleanup": "skipped", "notify": "completed"}
+	for key, status := range expected {
+		if byKey[key] == nil {
+			t.Fatalf("Execution %s not found", key)
+		}
+		if byKey[key].Status != status {
+			t.Errorf("Expected execution %s to be %s, got %s", key, status, byKey[key].Status)
+		}
+	}
+
+	// The result of extract is appended to the arguments of transform
+	transform, err := job.GetExecution(byKey["transform"].ExecutionID, model.QueryParam{})
+	if err != nil {
+		t.Fatalf("Failed to get execution: %v", err)
+	}
+	if transform.Result == nil {
+		t.Fatal("Expected transform result")
+	}
+	var result struct {
+		Args []interface{} `json:"args"`
+	}
+	if err := json.Unmarshal(*transform.Result, &result); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if len(result.Args) != 2 || result.Args[0] != "upper" {
+		t.Fatalf("Expected the upstream result in the arguments, got %v", result.Args)
+	}
+	if upstream, ok := result.Args[1].(map[string]interface{}); !ok || upstream["args"] == nil {
+		t.Errorf("Expected the extract result, got %v", result.Args[1])
+	}
+
+	// A second run passes the result again, the stored arguments are not extended
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job again: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	transform, err = job.GetExecution(byKey["transform"].ExecutionID, model.QueryParam{})
+	if err != nil || transform.Result == nil {
+		t.Fatalf("Failed to get execution: %v", err)
+	}
+	if err := json.Unmarshal(*transform.Result, &result); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if len(result.Args) != 2 || result.Args[0] != "upper" {
+		t.Errorf("Expected the arguments of the second run to be the same, got %v", result.Args)
+	}
+	if args := transform.ExecutionConfig.ProcessArgs; len(args) != 1 || args[0] != "upper" {
+		t.Errorf("Expected the stored arguments to be kept, got %v", args)
+	}
+
+	// The graph holds the executions and the edges
+	graph, err := job.GetGraph(testJob.JobID)
+	if err != nil {
+		t.Fatalf("Failed to get graph: %v", err)
+	}
+	if len(graph.Nodes) != 4 || len(graph.Edges) != 3 {
+		t.Errorf("Expected 4 nodes and 3 edges, got %d nodes and %d edges", len(graph.Nodes), len(graph.Edges))
+	}
+}
+
+func TestExecutionDependencyCycle(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerDependencyProcesses()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Cycle Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	if err := testJob.Add(job.NewExecutionOptions().WithKey("a").WithDependency("a", job.OnSuccess, false), "test.job.dependency.args"); !errors.Is(err, job.ErrDependencyCycle) {
+		t.Errorf("Expected a cycle error for a self dependency, got %v", err)
+	}
+
+	testJob.Add(job.NewExecutionOptions().WithKey("a").WithDependency("b", job.OnSuccess, false), "test.job.dependency.args")
+	testJob.Add(job.NewExecutionOptions().WithKey("b").WithDependency("a", job.Always, false), "test.job.dependency.args")
+	if err := testJob.Push(); !errors.Is(err, job.ErrDependencyCycle) {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+
+	missing, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Missing Upstream Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	missing.Add(job.NewExecutionOptions().WithDependency("unknown", job.OnSuccess, false), "test.job.dependency.args")
+	if err := missing.Push(); err == nil {
+		t.Error("Expected an error for an unknown upstream execution")
+	}
+}
+
+func TestJobDependencies(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerDependencyProcesses()
+
+	upstream, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Upstream Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	upstream.Add(job.NewExecutionOptions().WithKey("export"), "test.job.dependency.args", "report")
+
+	downstream, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Downstream Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	downstream.Add(job.NewExecutionOptions(), "test.job.dependency.args", "import")
+	if err := downstream.DependOn(job.Dependency{JobID: upstream.JobID, PassResult: true}); err != nil {
+		t.Fatalf("Failed to set dependencies: %v", err)
+	}
+	if err := job.SaveJob(downstream); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+
+	// The upstream job can not depend on its downstream job
+	if err := upstream.DependOn(job.Dependency{JobID: downstream.JobID}); !errors.Is(err, job.ErrDependencyCycle) {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+	if err := upstream.DependOn(job.Dependency{JobID: "other", Condition: "sometimes"}); err == nil {
+		t.Error("Expected an error for an invalid condition")
+	}
+
+	graph, err := job.GetGraph(upstream.JobID)
+	if err != nil {
+		t.Fatalf("Failed to get graph: %v", err)
+	}
+	found := false
+	for _, edge := range graph.Edges {
+		if edge.From == upstream.JobID && edge.To == downstream.JobID && edge.Condition == job.OnSuccess {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the downstream job edge, got %v", graph.Edges)
+	}
+
+	// Pushing the upstream job triggers the downstream job
+	if err := upstream.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+	time.Sleep(3 * time.Second)
+
+	executions, err := downstream.GetExecutions()
+	if err != nil {
+		t.Fatalf("Failed to get executions: %v", err)
+	}
+	if len(executions) != 1 || executions[0].Status != "completed" {
+		t.Fatalf("Expected the downstream execution to be completed, got %+v", executions)
+	}
+
+	var result struct {
+		Args []interface{} `json:"args"`
+	}
+	if executions[0].Result == nil {
+		t.Fatal("Expected downstream result")
+	}
+	if err := json.Unmarshal(*executions[0].Result, &result); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if len(result.Args) != 2 {
+		t.Fatalf("Expected the upstream results in the arguments, got %v", result.Args)
+	}
+	if results, ok := result.Args[1].(map[string]interface{}); !ok || results["export"] == nil {
+		t.Errorf("Expected the export result, got %v", result.Args[1])
+	}
+}
+
+func TestExecutionDependenciesTakeover(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerLeaseProcesses()
+	registerDependencyProcesses()
+
+	managers := startNodes("node-b")
+	defer managers[0].Stop()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Dependency Takeover Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions().WithKey("first").WithPriority(1), "test.job.lease.count"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions().WithKey("second").WithDependency("first", job.OnSuccess, true), "test.job.dependency.args"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+
+	executions, err := testJob.GetExecutions()
+	if err != nil {
+		t.Fatalf("Failed to get executions: %v", err)
+	}
+	byKey := map[string]string{}
+	for _, execution := range executions {
+		byKey[execution.ExecutionOptions.Key] = execution.ExecutionID
+	}
+
+	// The node which pushed the job died while running first, second waits in the database only
+	mod := model.Select("__yao.job.execution")
+	updates := map[string]map[string]interface{}{
+		"first":  {"status": "running", "node_id": "dead-node", "lease_expires_at": time.Now().Add(-time.Minute)},
+		"second": {"status": "waiting"},
+	}
+	for key, data := range updates {
+		_, err := mod.UpdateWhere(model.QueryParam{Wheres: []model.QueryWhere{{Column: "execution_id", Value: byKey[key]}}}, data)
+		if err != nil {
+			t.Fatalf("Failed to update execution %s: %v", key, err)
+		}
+	}
+
+	// The new node has no graph in memory, it rebuilds it from the execution rows
+	count, err := managers[0].TakeoverExpiredLeases()
+	if err != nil || count != 1 {
+		t.Fatalf("Expected 1 execution taken over, got %d %v", count, err)
+	}
+
+	waitExecution(t, byKey["first"], 5*time.Second, "completed")
+	second := waitExecution(t, byKey["second"], 5*time.Second, "completed", "failed", "skipped")
+	if second.Status != "completed" {
+		t.Fatalf("Expected second to run after the takeover, got %s", second.Status)
+	}
+	if second.ParentExecutionID == nil || *second.ParentExecutionID != byKey["first"] {
+		t.Errorf("Expected first as the parent of second, got %v", second.ParentExecutionID)
+	}
+}
@@ -39,6 +39,11 @@ func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) e
 		}
 	}
 
+	// Validate the dependencies, the upstream executions are resolved when the job is pushed
+	if err := j.validateExecutionOptions(options); err != nil {
+		return err
+	}
+
 	// Serialize ExecutionConfig to JSON for ConfigSnapshot
 	configBytes, err := jsoniter.Marshal(config)
 	if err != nil {
@@ -69,6 +74,36 @@ func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) e
 	return nil
 }
 
+// validateExecutionOptions checks the execution key is unique within the job and the dependency conditions are valid
+func (j *Job) validateExecutionOptions(options *ExecutionOptions) error {
+	for _, dependency := range options.DependsOn {
+		if dependency.Execution == "" {
+			return fmt.Errorf("dependency execution is required")
+		}
+		if options.Key != "" && dependency.Execution == options.Key {
+			return fmt.Errorf("%w: execution %s depends on itself", ErrDependencyCycle, options.Key)
+		}
+		if err := validateCondition(dependency.Condition); err != nil {
+			return err
+		}
+	}
+
+	if options.Key == "" || j.JobID == "" {
+		return nil
+	}
+
+	executions, err := j.GetExecutions()
+	if err != nil {
+		return fmt.Errorf("failed to get executions: %w", err)
+	}
+	for _, execution := range executions {
+		if executionKey(execution) == options.Key {
+			return fmt.Errorf("execution key %s already exists in job %s", options.Key, j.JobID)
+		}
+	}
+	return nil
+}
+
 // GetExecutions get executions for this job
 func (j *Job) GetExecutions() ([]*Execution, error) {
 	return GetExecutions(j.JobID)
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/yaoapp/gou/model"
@@ -17,10 +16,15 @@ type Goroutine struct{}
 
 // ExecuteYaoProcess executes a Yao process using goroutine mode (process API)
 func (g *Goroutine) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, progress *Progress) error {
-	config := work.Execution.ExecutionConfig
+	config := work.Execution.config()
 
 	work.Execution.Info("Executing Yao process: %s (goroutine mode)", config.ProcessName)
 
+	// The process runs in the Yao process, the resource limits apply to the child processes only
+	if options := work.Execution.ExecutionOptions; options != nil && options.Limits != nil && *options.Limits != (ResourceLimits{}) {
+		return failLimits(work, fmt.Errorf("resource limits are not supported by the Yao processes of the goroutine mode jobs, use the process mode"))
+	}
+
 	// Create process with context
 	proc := process.NewWithContext(ctx, config.ProcessName, config.ProcessArgs...)
 
@@ -113,12 +117,20 @@ func (g *Goroutine) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, pr
 
 // ExecuteSystemCommand executes a system command using goroutine mode
 func (g *Goroutine) ExecuteSystemCommand(ctx context.Context, work *WorkRequest, progress *Progress) error {
-	config := work.Execution.ExecutionConfig
+	config := work.Execution.config()
 
 	work.Execution.Info("Executing command: %s (goroutine mode)", config.Command)
 
+	// Run within the resource limits of the execution, in its work_dir if set
+	limiter, err := newLimiter(work.Execution, "")
+	if err != nil {
+		return failLimits(work, err)
+	}
+	runCtx, cancel := limiter.context(ctx)
+	defer cancel()
+
 	// Create command with context for cancellation support
-	cmd := exec.CommandContext(ctx, config.Command, config.CommandArgs...)
+	cmd := limiter.command(runCtx, config.Command, config.CommandArgs...)
 
 	// Set environment variables if provided
 	if len(config.Environment) > 0 {
@@ -141,7 +153,10 @@ func (g *Goroutine) ExecuteSystemCommand(ctx context.Context, work *WorkRequest,
 		cmd.Env = env
 	}
 
+	cmd.Env = limiter.env(cmd.Env)
+
 	// Execute command with context cancellation support
+	var violation *LimitError
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Check if it was cancelled
@@ -152,12 +167,18 @@ func (g *Goroutine) ExecuteSystemCommand(ctx context.Context, work *WorkRequest,
 			work.Execution.Error("Command failed: %s, output: %s", err.Error(), string(output))
 			work.Execution.Status = "failed"
 
-			// Store error output
-			if len(output) > 0 {
+			// Store error output and the exceeded limit
+			violation = limiter.violation(ctx, runCtx, cmd, output, err)
+			if len(output) > 0 || violation != nil {
 				errorInfo := map[string]interface{}{
 					"error":  err.Error(),
 					"output": string(output),
 				}
+				if violation != nil {
+					work.Execution.Error("Command stopped: %s", violation.Error())
+					errorInfo["reason"] = violation.Reason
+					errorInfo["limit"] = violation.Limit
+				}
 				if errorBytes, jsonErr := jsoniter.Marshal(errorInfo); jsonErr == nil {
 					work.Execution.ErrorInfo = (*json.RawMessage)(&errorBytes)
 				}
@@ -172,6 +193,9 @@ func (g *Goroutine) ExecuteSystemCommand(ctx context.Context, work *WorkRequest,
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
+		if violation != nil {
+			return violation
+		}
 		return fmt.Errorf("command execution failed: %v", err)
 	}
 
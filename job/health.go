@@ -142,6 +142,12 @@ func (hc *HealthChecker) checkJobHealth(job *Job, wm *WorkerManager) error {
 		return hc.markJobAsFailed(job, fmt.Sprintf("Job status is running but execution status is %s", execution.Status))
 	}
 
+	// The executions leased by other nodes are checked by their node, or taken over when the lease expires
+	if execution.NodeID != nil && *execution.NodeID != wm.nodeID {
+		log.Debug("Job %s execution runs on node %s", job.JobID, *execution.NodeID)
+		return nil
+	}
+
 	// Check if worker exists and is working
 	if execution.WorkerID == nil || *execution.WorkerID == "" {
 		return hc.markJobAsFailed(job, "Execution is running but no worker ID assigned")
@@ -195,11 +201,23 @@ func (hc *HealthChecker) markJobAsFailed(job *Job, reason string) error {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
-	// Update execution status (if exists)
+	// Update execution status (if exists), the execution is retried by the retry policy
+	retried := false
 	if job.CurrentExecutionID != nil && *job.CurrentExecutionID != "" {
 		execution, err := GetExecution(*job.CurrentExecutionID, model.QueryParam{})
-		if err == nil {
-			execution.Status = "failed"
+		status := "failed"
+		if err == nil && execution.Status == "running" {
+			var delay time.Duration
+			cause := fmt.Errorf("%s", reason)
+			delay, status = job.retryPolicyOf(execution).decide(cause, execution.RetryAttempt)
+			if status == "" {
+				job.scheduleRetry(execution, delay, cause)
+				retried = true
+			}
+		}
+
+		if err == nil && !retried {
+			execution.Status = status
 			now := time.Now()
 			execution.EndedAt = &now
 
@@ -215,6 +233,8 @@ func (hc *HealthChecker) markJobAsFailed(job *Job, reason string) error {
 			if err := SaveExecution(execution); err != nil {
 				log.Error("Failed to update execution status: %v", err)
 			}
+			metrics.finished(job, execution)
+			job.checkFailures(execution)
 		}
 	}
 
@@ -233,8 +253,11 @@ func (hc *HealthChecker) markJobAsFailed(job *Job, reason string) error {
 		log.Error("Failed to save health check log: %v", err)
 	}
 
-	// Clear current execution ID
+	// Clear current execution ID, the job is ready again when the execution is retried
 	job.CurrentExecutionID = nil
+	if retried {
+		job.Status = "ready"
+	}
 	if err := SaveJob(job); err != nil {
 		log.Error("Failed to clear current execution ID: %v", err)
 	}
@@ -2,8 +2,8 @@ package job
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
@@ -12,6 +12,9 @@ import (
 	"github.com/yaoapp/kun/log"
 )
 
+// ErrNoExecutions is returned when a job without executions is pushed
+var ErrNoExecutions = errors.New("no executions found")
+
 // init initializes the job package
 func init() {
 	// Initialize and start health checker
@@ -20,7 +23,10 @@ func init() {
 	// Initialize and start data cleaner
 	initDataCleaner()
 
-	log.Info("Job package initialized with health checker and data cleaner")
+	// Initialize and start the cron job scheduler
+	initScheduler()
+
+	log.Info("Job package initialized with health checker, data cleaner and scheduler")
 }
 
 // initHealthChecker initializes the health checker
@@ -139,7 +145,17 @@ func Cron(mode ModeType, data map[string]interface{}, expression string) (*Job,
 	if err != nil {
 		return nil, err
 	}
-	return makeJob(raw)
+
+	job, err := makeJob(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the expression and compute the next run time
+	if err := job.SetSchedule(expression); err != nil {
+		return nil, err
+	}
+	return job, nil
 }
 
 // CronAndSave create a new cron job and save it immediately
@@ -192,30 +208,31 @@ func (j *Job) Push() error {
 	}
 
 	if len(executions) == 0 {
-		return fmt.Errorf("no executions found for job %s", j.JobID)
+		return fmt.Errorf("%w: job %s", ErrNoExecutions, j.JobID)
 	}
 
 	// Sort executions by priority (higher priority first)
-	sort.Slice(executions, func(i, j int) bool {
-		priorityI := 0
-		if executions[i].ExecutionOptions != nil {
-			priorityI = executions[i].ExecutionOptions.Priority
-		}
-		priorityJ := 0
-		if executions[j].ExecutionOptions != nil {
-			priorityJ = executions[j].ExecutionOptions.Priority
-		}
-		return priorityI > priorityJ
-	})
+	sortExecutions(executions)
 
-	// Initialize job context for cancellation
-	if j.ctx == nil {
-		j.ctx, j.cancel = context.WithCancel(context.Background())
+	// Build the dependency graph, only the executions without dependencies are submitted now
+	graph, err := newExecutionGraph(executions)
+	if err != nil {
+		return err
 	}
 
-	// Initialize execution contexts map
-	if j.executionContexts == nil {
-		j.executionContexts = make(map[string]context.CancelFunc)
+	// Initialize job context for cancellation and execution contexts map
+	j.prepare()
+	j.executionMutex.Lock()
+	j.graph = graph
+	j.executionMutex.Unlock()
+
+	// The downstream executions wait in the database, any node rebuilds the graph from the rows
+	// when an upstream execution finishes, e.g. after a lease takeover or a restart
+	for _, execution := range graph.waitingExecutions() {
+		execution.Status = "waiting"
+		if err := SaveExecution(execution); err != nil {
+			return fmt.Errorf("failed to save waiting execution %s: %w", execution.ExecutionID, err)
+		}
 	}
 
 	// Update job status to ready
@@ -229,27 +246,15 @@ func (j *Job) Push() error {
 
 	// Submit executions and ensure all are added successfully
 	var submitErrors []string
-	for _, execution := range executions {
-		// Create execution-specific context derived from job context
-		execCtx, execCancel := context.WithCancel(j.ctx)
-
-		// Store execution cancel function
-		j.executionMutex.Lock()
-		j.executionContexts[execution.ExecutionID] = execCancel
-		j.executionMutex.Unlock()
-
-		// Submit execution (non-blocking)
-		if err := wm.SubmitJob(execCtx, j, execution); err != nil {
-			// Clean up on error
-			execCancel()
-			j.executionMutex.Lock()
-			delete(j.executionContexts, execution.ExecutionID)
-			j.executionMutex.Unlock()
-
+	for _, execution := range graph.roots() {
+		// The results of the upstream jobs are passed to the root executions of this run
+		execution.upstreamArgs = j.upstreamArgs
+		if err := j.submit(wm, execution); err != nil {
 			submitErrors = append(submitErrors, fmt.Sprintf("execution %s: %v", execution.ExecutionID, err))
 			log.Error("Failed to submit execution %s: %v", execution.ExecutionID, err)
 		}
 	}
+	j.upstreamArgs = nil
 
 	// Return error if any submissions failed
 	if len(submitErrors) > 0 {
@@ -259,6 +264,39 @@ func (j *Job) Push() error {
 	return nil
 }
 
+// submit submits an execution to the worker manager with a context derived from the job context
+func (j *Job) submit(wm *WorkerManager, execution *Execution) error {
+	// Queue the finished executions again, the workers only claim the queued executions.
+	// A running execution keeps its lease, the claim fails and it is not run twice.
+	if execution.Status != "queued" && execution.Status != "running" {
+		execution.Status = "queued"
+		execution.ScheduledAt = nil
+		if err := SaveExecution(execution); err != nil {
+			log.Warn("Failed to queue execution %s (database may be closed): %v", execution.ExecutionID, err)
+		}
+	}
+
+	// Create execution-specific context derived from job context
+	execCtx, execCancel := context.WithCancel(j.ctx)
+
+	// Store execution cancel function
+	j.executionMutex.Lock()
+	j.executionContexts[execution.ExecutionID] = execCancel
+	j.executionMutex.Unlock()
+
+	// Submit execution (non-blocking)
+	if err := wm.SubmitJob(execCtx, j, execution); err != nil {
+		// Clean up on error
+		execCancel()
+		j.executionMutex.Lock()
+		delete(j.executionContexts, execution.ExecutionID)
+		j.executionMutex.Unlock()
+		return err
+	}
+
+	return nil
+}
+
 // Stop stops the job and cancels all running executions
 func (j *Job) Stop() error {
 	// Update job status
@@ -298,10 +336,23 @@ func (j *Job) Stop() error {
 			SaveLog(logEntry)
 		}
 	}
-	// Clear execution contexts
+	// Clear execution contexts, the pending downstream executions are not submitted anymore
 	j.executionContexts = make(map[string]context.CancelFunc)
+	j.graph = nil
 	j.executionMutex.Unlock()
 
+	if model.Exists("__yao.job.execution") {
+		_, err := model.Select("__yao.job.execution").UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "job_id", Value: j.JobID},
+				{Column: "status", Value: "waiting"},
+			},
+		}, map[string]interface{}{"status": "cancelled", "ended_at": time.Now()})
+		if err != nil {
+			log.Warn("Failed to cancel the waiting executions of job %s: %v", j.JobID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -377,6 +428,18 @@ func (j *Job) SetMaxRetryCount(maxRetryCount int) *Job {
 	return j
 }
 
+// SetRetryPolicy set the retry policy of the job
+func (j *Job) SetRetryPolicy(policy *RetryPolicy) *Job {
+	j.RetryPolicy = policy
+	return j
+}
+
+// SetSLA set the service level of the job
+func (j *Job) SetSLA(sla *SLA) *Job {
+	j.SLA = sla
+	return j
+}
+
 // SetDefaultTimeout set the default timeout of the job
 func (j *Job) SetDefaultTimeout(defaultTimeout int) *Job {
 	j.DefaultTimeout = &defaultTimeout
@@ -0,0 +1,414 @@
+package job
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// defaultLeaseTTL the default execution lease TTL, the workers renew the lease every third of the TTL
+const defaultLeaseTTL = 30 * time.Second
+
+// dueInterval the interval of the scans for the queued executions whose scheduled time is due
+const dueInterval = time.Second
+
+// nodeID the cluster node ID of this instance
+var nodeID = newNodeID()
+
+// NodeID returns the cluster node ID of this instance, set by YAO_JOB_NODE_ID or generated from the hostname
+func NodeID() string {
+	return nodeID
+}
+
+// newNodeID generates the node ID, the random suffix keeps the IDs unique when the instances share a hostname
+func newNodeID() string {
+	if id := os.Getenv("YAO_JOB_NODE_ID"); id != "" {
+		return id
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "node"
+	}
+	suffix, err := gonanoid.Generate("0123456789abcdefghijklmnopqrstuvwxyz", 6)
+	if err != nil {
+		suffix = fmt.Sprintf("%d", time.Now().UnixNano()%1000000)
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), suffix)
+}
+
+// ClaimExecution claims the lease of a queued execution for a node.
+// It returns false when the execution is not queued, not due yet or another node holds a valid lease.
+func ClaimExecution(executionID string, node string, ttl time.Duration) (bool, error) {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return false, fmt.Errorf("job execution model not found")
+	}
+
+	now := time.Now()
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: executionID},
+			{Column: "status", Value: "queued"},
+			{Wheres: []model.QueryWhere{
+				{Column: "lease_expires_at", OP: "null"},
+				{Column: "lease_expires_at", OP: "lt", Value: now, Method: "orwhere"},
+				{Column: "node_id", Value: node, Method: "orwhere"},
+			}},
+			{Wheres: []model.QueryWhere{
+				{Column: "scheduled_at", OP: "null"},
+				{Column: "scheduled_at", OP: "le", Value: now, Method: "orwhere"},
+			}},
+		},
+		Limit: 1,
+	}
+
+	affected, err := mod.UpdateWhere(param, map[string]interface{}{
+		"node_id":          node,
+		"lease_expires_at": now.Add(ttl),
+		"heartbeat_at":     now,
+		"admitted_at":      nil,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim execution: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// RenewLease extends the lease held by a node, it returns false when the lease was lost
+func RenewLease(executionID string, node string, ttl time.Duration) (bool, error) {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return false, fmt.Errorf("job execution model not found")
+	}
+
+	now := time.Now()
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: executionID},
+			{Column: "node_id", Value: node},
+			{Column: "lease_expires_at", OP: "ge", Value: now},
+		},
+		Limit: 1,
+	}
+
+	affected, err := mod.UpdateWhere(param, map[string]interface{}{
+		"lease_expires_at": now.Add(ttl),
+		"heartbeat_at":     now,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to renew lease: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ReleaseLease expires the lease held by a node
+func ReleaseLease(executionID string, node string) error {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return fmt.Errorf("job execution model not found")
+	}
+
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: executionID},
+			{Column: "node_id", Value: node},
+		},
+		Limit: 1,
+	}
+
+	_, err := mod.UpdateWhere(param, map[string]interface{}{"lease_expires_at": time.Now(), "admitted_at": nil})
+	if err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	return nil
+}
+
+// WithLease sets the node ID and the lease TTL of the worker manager, call it before Start.
+// Several worker managers with different node IDs sharing a database behave as a cluster.
+func (wm *WorkerManager) WithLease(node string, ttl time.Duration) *WorkerManager {
+	if node != "" {
+		wm.nodeID = node
+	}
+	if ttl > 0 {
+		wm.leaseTTL = ttl
+	}
+	return wm
+}
+
+// NodeID returns the node ID of the worker manager
+func (wm *WorkerManager) NodeID() string {
+	return wm.nodeID
+}
+
+// leaseHolder holds the lease of an execution processed by a worker
+type leaseHolder struct {
+	manager     *WorkerManager
+	executionID string
+	done        chan struct{}
+	lost        atomic.Bool
+}
+
+// claim claims the execution lease and starts the heartbeat, lost is called when another node takes the lease over.
+// It returns false when another node claimed the execution, or when the concurrency key or the category quota
+// of the execution is held by the executions of other nodes.
+func (wm *WorkerManager) claim(work *WorkRequest, lost func()) (*leaseHolder, bool) {
+	execution := work.Execution
+	claimed, err := ClaimExecution(execution.ExecutionID, wm.nodeID, wm.leaseTTL)
+	if err != nil {
+		// Run without a lease, the database may be closed
+		log.Warn("Failed to claim execution %s: %v", execution.ExecutionID, err)
+		return nil, true
+	}
+	if !claimed {
+		return nil, false
+	}
+
+	admitted, err := wm.admit(work)
+	if err != nil {
+		// Run within the limits of this node only
+		log.Warn("Failed to admit execution %s: %v", execution.ExecutionID, err)
+	} else if !admitted {
+		return nil, false
+	}
+
+	node := wm.nodeID
+	expiresAt := time.Now().Add(wm.leaseTTL)
+	execution.NodeID = &node
+	execution.LeaseExpiresAt = &expiresAt
+
+	holder := &leaseHolder{manager: wm, executionID: execution.ExecutionID, done: make(chan struct{})}
+	go holder.heartbeat(lost)
+	return holder, true
+}
+
+// heartbeat renews the lease every third of the TTL until released
+func (h *leaseHolder) heartbeat(lost func()) {
+	interval := h.manager.leaseTTL / 3
+	if interval < 100*time.Millisecond {
+		interval = 100 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			renewed, err := RenewLease(h.executionID, h.manager.nodeID, h.manager.leaseTTL)
+			if err != nil {
+				log.Warn("Failed to renew the lease of execution %s: %v", h.executionID, err)
+				continue
+			}
+			if !renewed {
+				log.Warn("Execution %s lease lost by node %s", h.executionID, h.manager.nodeID)
+				h.lost.Store(true)
+				lost()
+				return
+			}
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// stop stops the heartbeat, it returns true when another node took the lease over
+func (h *leaseHolder) stop() bool {
+	if h == nil {
+		return false
+	}
+	close(h.done)
+	return h.lost.Load()
+}
+
+// release expires the lease once the execution status is saved, the heartbeat must be stopped
+func (h *leaseHolder) release() {
+	if h == nil || h.lost.Load() {
+		return
+	}
+	if err := ReleaseLease(h.executionID, h.manager.nodeID); err != nil {
+		log.Warn("Failed to release the lease of execution %s: %v", h.executionID, err)
+	}
+}
+
+// superviseLeases takes over the expired leases and submits the due executions until the worker manager stops
+func (wm *WorkerManager) superviseLeases() {
+	interval := wm.leaseTTL / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	due := time.NewTicker(dueInterval)
+	defer due.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if count, err := wm.TakeoverExpiredLeases(); err != nil {
+				log.Debug("Lease takeover failed: %v", err)
+			} else if count > 0 {
+				log.Info("Node %s took over %d executions with expired leases", wm.nodeID, count)
+			}
+		case <-due.C:
+			if count, err := wm.SubmitDueExecutions(); err != nil {
+				log.Debug("Due executions scan failed: %v", err)
+			} else if count > 0 {
+				log.Info("Node %s submitted %d due executions", wm.nodeID, count)
+			}
+		case <-wm.quit:
+			return
+		}
+	}
+}
+
+// SubmitDueExecutions submits the queued executions whose scheduled time is due, e.g. the retries, to this
+// worker manager. The node reserves an execution with a lease before submitting it, the lease expires and
+// another node submits it again when this node stops before running it. It returns the number of executions submitted.
+func (wm *WorkerManager) SubmitDueExecutions() (int, error) {
+	if !model.Exists("__yao.job.execution") {
+		return 0, nil
+	}
+
+	mod := model.Select("__yao.job.execution")
+	now := time.Now()
+	due := []model.QueryWhere{
+		{Column: "status", Value: "queued"},
+		{Column: "scheduled_at", OP: "le", Value: now},
+		{Wheres: []model.QueryWhere{
+			{Column: "lease_expires_at", OP: "null"},
+			{Column: "lease_expires_at", OP: "lt", Value: now, Method: "orwhere"},
+		}},
+	}
+	results, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"execution_id"},
+		Wheres: due,
+		Orders: []model.QueryOrder{{Column: "scheduled_at", Option: "asc"}},
+		Limit:  100,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, result := range results {
+		executionID, _ := result["execution_id"].(string)
+		if executionID == "" {
+			continue
+		}
+
+		// Only one node reserves the execution
+		affected, err := mod.UpdateWhere(model.QueryParam{
+			Wheres: append([]model.QueryWhere{{Column: "execution_id", Value: executionID}}, due...),
+			Limit:  1,
+		}, map[string]interface{}{"node_id": wm.nodeID, "lease_expires_at": now.Add(wm.leaseTTL), "admitted_at": nil})
+		if err != nil {
+			log.Error("Failed to reserve execution %s: %v", executionID, err)
+			continue
+		}
+		if affected == 0 {
+			continue
+		}
+
+		execution, err := GetExecution(executionID, model.QueryParam{})
+		if err != nil {
+			log.Error("Failed to get execution %s: %v", executionID, err)
+			continue
+		}
+		job, err := GetJob(execution.JobID)
+		if err != nil {
+			log.Error("Failed to get job %s: %v", execution.JobID, err)
+			continue
+		}
+
+		// The job was stopped while the execution waited
+		if !job.Enabled || job.Status == "disabled" {
+			ended := time.Now()
+			execution.Status = "cancelled"
+			execution.EndedAt = &ended
+			if err := SaveExecution(execution); err != nil {
+				log.Warn("Failed to cancel execution %s: %v", executionID, err)
+			}
+			continue
+		}
+
+		job.prepare()
+		if err := job.submit(wm, execution); err != nil {
+			log.Error("Failed to submit execution %s: %v", executionID, err)
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// TakeoverExpiredLeases queues again the running executions whose lease expired, their node stopped
+// renewing it, and submits them to this worker manager. It returns the number of executions taken over.
+func (wm *WorkerManager) TakeoverExpiredLeases() (int, error) {
+	if !model.Exists("__yao.job.execution") {
+		return 0, nil
+	}
+
+	mod := model.Select("__yao.job.execution")
+	now := time.Now()
+	results, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"execution_id", "job_id", "node_id"},
+		Wheres: []model.QueryWhere{
+			{Column: "status", Value: "running"},
+			{Column: "lease_expires_at", OP: "lt", Value: now},
+		},
+		Limit: 100,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, result := range results {
+		executionID, _ := result["execution_id"].(string)
+		if executionID == "" {
+			continue
+		}
+
+		// Only one node requeues the execution, the others find it queued
+		affected, err := mod.UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", Value: executionID},
+				{Column: "status", Value: "running"},
+				{Column: "lease_expires_at", OP: "lt", Value: now},
+			},
+			Limit: 1,
+		}, map[string]interface{}{"status": "queued", "updated_at": now})
+		if err != nil {
+			log.Error("Failed to requeue execution %s: %v", executionID, err)
+			continue
+		}
+		if affected == 0 {
+			continue
+		}
+
+		execution, err := GetExecution(executionID, model.QueryParam{})
+		if err != nil {
+			log.Error("Failed to get execution %s: %v", executionID, err)
+			continue
+		}
+		job, err := GetJob(execution.JobID)
+		if err != nil {
+			log.Error("Failed to get job %s: %v", execution.JobID, err)
+			continue
+		}
+
+		execution.Warn("Execution taken over by node %s, the lease of node %v expired", wm.nodeID, result["node_id"])
+		job.prepare()
+		if err := job.submit(wm, execution); err != nil {
+			log.Error("Failed to submit execution %s: %v", executionID, err)
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,150 @@
+package job_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// leaseCalls counts the calls of the test.job.lease.count process
+var leaseCalls int32
+
+// registerLeaseProcesses registers the processes of the lease tests
+func registerLeaseProcesses() {
+	process.Register("test.job.lease.count", func(process *process.Process) interface{} {
+		atomic.AddInt32(&leaseCalls, 1)
+		time.Sleep(200 * time.Millisecond)
+		return map[string]interface{}{"status": "success"}
+	})
+}
+
+// startNodes starts worker managers sharing the test database, one per node
+func startNodes(nodes ...string) []*job.WorkerManager {
+	managers := []*job.WorkerManager{}
+	for _, node := range nodes {
+		wm := job.NewWorkerManagerForTest(2).WithLease(node, 2*time.Second)
+		wm.Start()
+		managers = append(managers, wm)
+	}
+	return managers
+}
+
+func TestExecutionLease(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerLeaseProcesses()
+	atomic.StoreInt32(&leaseCalls, 0)
+
+	managers := startNodes("node-a", "node-b")
+	defer func() {
+		for _, wm := range managers {
+			wm.Stop()
+		}
+	}()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Lease Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.lease.count"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	executionID := onlyExecution(t, testJob).ExecutionID
+
+	// Both nodes receive the execution, only one claims it
+	for _, wm := range managers {
+		execution, err := job.GetExecution(executionID, model.QueryParam{})
+		if err != nil {
+			t.Fatalf("Failed to get execution: %v", err)
+		}
+		if err := wm.SubmitJob(context.Background(), testJob, execution); err != nil {
+			t.Fatalf("Failed to submit execution: %v", err)
+		}
+	}
+
+	execution := waitExecution(t, executionID, 5*time.Second, "completed", "failed")
+	time.Sleep(500 * time.Millisecond)
+	if calls := atomic.LoadInt32(&leaseCalls); calls != 1 {
+		t.Errorf("Expected the execution to run once, got %d runs", calls)
+	}
+	if execution.NodeID == nil || (*execution.NodeID != "node-a" && *execution.NodeID != "node-b") {
+		t.Errorf("Expected the node of the execution, got %v", execution.NodeID)
+	}
+
+	// The lease is released, a finished execution can not be claimed
+	claimed, err := job.ClaimExecution(executionID, "node-c", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to claim execution: %v", err)
+	}
+	if claimed {
+		t.Error("Expected the completed execution not to be claimed")
+	}
+}
+
+func TestExecutionLeaseTakeover(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerLeaseProcesses()
+	atomic.StoreInt32(&leaseCalls, 0)
+
+	managers := startNodes("node-b")
+	defer managers[0].Stop()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Takeover Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.lease.count"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	executionID := onlyExecution(t, testJob).ExecutionID
+
+	// A node died while running the execution, its lease expired
+	_, err = model.Select("__yao.job.execution").UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "execution_id", Value: executionID}},
+	}, map[string]interface{}{
+		"status":           "running",
+		"node_id":          "dead-node",
+		"lease_expires_at": time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Failed to update execution: %v", err)
+	}
+
+	// A running execution is not claimed, even with an expired lease
+	claimed, err := job.ClaimExecution(executionID, "node-c", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to claim execution: %v", err)
+	}
+	if claimed {
+		t.Error("Expected the running execution not to be claimed")
+	}
+
+	count, err := managers[0].TakeoverExpiredLeases()
+	if err != nil {
+		t.Fatalf("Failed to take over leases: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected 1 execution taken over, got %d", count)
+	}
+
+	execution := waitExecution(t, executionID, 5*time.Second, "completed", "failed")
+	if execution.Status != "completed" {
+		t.Errorf("Expected the execution to complete on node-b, got %s", execution.Status)
+	}
+	if execution.NodeID == nil || *execution.NodeID != "node-b" {
+		t.Errorf("Expected node-b, got %v", execution.NodeID)
+	}
+
+	// The execution is taken over once
+	if count, _ := managers[0].TakeoverExpiredLeases(); count != 0 {
+		t.Errorf("Expected no execution to take over, got %d", count)
+	}
+}
@@ -0,0 +1,255 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/yao/config"
+)
+
+// Resource limit violation reasons, reported as the failure reason of the execution
+const (
+	LimitCPUTime   = "cpu_time"
+	LimitMemory    = "memory"
+	LimitOpenFiles = "open_files"
+	LimitWallClock = "wall_clock"
+	LimitWorkDir   = "work_dir"
+)
+
+// unsetMarker the line the command shell writes when ulimit rejects a limit, followed by the limit reason
+const unsetMarker = "job limit not set: "
+
+// memoryErrors the lower case errors of the failed allocations: ENOMEM, the Go and Node.js runtimes, Python, C++
+var memoryErrors = []string{"cannot allocate memory", "out of memory", "memoryerror", "bad_alloc"}
+
+// errLimitNotSet the cause of the violations of the limits rejected by ulimit, e.g. above the hard limit
+var errLimitNotSet = errors.New("rejected by ulimit")
+
+// LimitError is returned when an execution is stopped by a resource limit
+type LimitError struct {
+	Reason string // One of the Limit* reasons
+	Limit  int    // The limit value, 0 for the work_dir violations
+	Err    error
+}
+
+// Error returns the error message
+func (e *LimitError) Error() string {
+	if e.Reason == LimitWorkDir {
+		return fmt.Sprintf("%s violation: %v", e.Reason, e.Err)
+	}
+	if errors.Is(e.Err, errLimitNotSet) {
+		return fmt.Sprintf("%s limit (%d) could not be set: %v", e.Reason, e.Limit, e.Err)
+	}
+	return fmt.Sprintf("%s limit (%d) exceeded: %v", e.Reason, e.Limit, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// FailureReason returns the resource limit that stopped the execution, empty if it was not stopped by a limit
+func (e *Execution) FailureReason() string {
+	if e.ErrorInfo == nil {
+		return ""
+	}
+	var info struct {
+		Reason string `json:"reason"`
+	}
+	if err := jsoniter.Unmarshal(*e.ErrorInfo, &info); err != nil {
+		return ""
+	}
+	return info.Reason
+}
+
+// limiter runs the child processes of an execution in its working directory, within the rlimits of the execution.
+// It is not a sandbox: the processes run as the Yao user and reach the whole file system.
+type limiter struct {
+	limits *ResourceLimits
+	dir    string // Working directory, limits.WorkDir resolved in <data_root>/jobs when set
+}
+
+// newLimiter returns the limiter of an execution running in dir, or in its work_dir, created if missing
+func newLimiter(execution *Execution, dir string) (*limiter, error) {
+	l := &limiter{limits: &ResourceLimits{}}
+	if execution.ExecutionOptions != nil && execution.ExecutionOptions.Limits != nil {
+		l.limits = execution.ExecutionOptions.Limits
+	}
+
+	limits := l.limits
+	if limits.CPUTime < 0 || limits.Memory < 0 || limits.OpenFiles < 0 || limits.WallClock < 0 {
+		return nil, fmt.Errorf("resource limits must not be negative")
+	}
+	if (limits.CPUTime > 0 || limits.Memory > 0 || limits.OpenFiles > 0) && runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("cpu_time, memory and open_files limits are not supported on %s", runtime.GOOS)
+	}
+
+	if limits.WorkDir == "" {
+		l.dir = dir
+		return l, nil
+	}
+
+	dir, err := resolveWorkDir(limits.WorkDir)
+	if err != nil {
+		return nil, &LimitError{Reason: LimitWorkDir, Err: err}
+	}
+	l.dir = dir
+	return l, nil
+}
+
+// resolveWorkDir resolves a working directory within <data_root>/jobs, the paths leaving it are rejected
+func resolveWorkDir(workDir string) (string, error) {
+	root := config.Conf.DataRoot
+	if root == "" {
+		root = filepath.Join(config.Conf.Root, "data")
+	}
+	root, err := filepath.Abs(filepath.Join(root, "jobs"))
+	if err != nil {
+		return "", err
+	}
+
+	if filepath.IsAbs(workDir) {
+		return "", fmt.Errorf("work_dir %s must be relative to the jobs data directory", workDir)
+	}
+	dir := filepath.Join(root, workDir)
+	if !within(root, dir) {
+		return "", fmt.Errorf("work_dir %s leaves the jobs data directory", workDir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create work_dir %s: %w", workDir, err)
+	}
+
+	// A symbolic link must not lead out of the jobs data directory
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	if !within(realRoot, realDir) {
+		return "", fmt.Errorf("work_dir %s links out of the jobs data directory", workDir)
+	}
+	return realDir, nil
+}
+
+// within checks the path is the root or one of its descendants
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// context returns the context of the execution bounded by the wall clock limit
+func (l *limiter) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l.limits.WallClock <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(l.limits.WallClock)*time.Second)
+}
+
+// command returns the command run within the limits. The shell ulimit builtin sets RLIMIT_CPU,
+// RLIMIT_DATA (the heap and the anonymous mappings) and RLIMIT_NOFILE before the command replaces the shell,
+// a limit rejected by ulimit stops the shell with the unsetMarker line.
+func (l *limiter) command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	limits := []string{}
+	ulimit := func(option string, value int, reason string) string {
+		return fmt.Sprintf(`{ ulimit %s %d || { echo "%s%s" >&2; exit 1; }; }`, option, value, unsetMarker, reason)
+	}
+	if l.limits.CPUTime > 0 {
+		limits = append(limits, ulimit("-t", l.limits.CPUTime, LimitCPUTime))
+	}
+	if l.limits.Memory > 0 {
+		limits = append(limits, ulimit("-d", l.limits.Memory*1024, LimitMemory))
+	}
+	if l.limits.OpenFiles > 0 {
+		limits = append(limits, ulimit("-n", l.limits.OpenFiles, LimitOpenFiles))
+	}
+
+	var cmd *exec.Cmd
+	if len(limits) == 0 {
+		cmd = exec.CommandContext(ctx, name, args...)
+	} else {
+		script := strings.Join(limits, " && ") + ` && exec "$@"`
+		cmd = exec.CommandContext(ctx, "/bin/sh", append([]string{"-c", script, "sh", name}, args...)...)
+	}
+	cmd.Dir = l.dir
+	return cmd
+}
+
+// env returns the environment of the command, the commands with a work_dir get their home and temp directory in it
+func (l *limiter) env(env []string) []string {
+	if l.limits.WorkDir == "" {
+		return env
+	}
+	if env == nil {
+		env = os.Environ()
+	}
+	return append(env, "HOME="+l.dir, "TMPDIR="+l.dir, "PWD="+l.dir)
+}
+
+// violation returns the limit the failed command exceeded, nil if the failure is not caused by a limit.
+// It reads the wait status and the resource usage of the command, and the errors of the exhausted limits in its
+// output: ENOMEM for the memory, EMFILE for the open files. The parent context is checked first: a stopped
+// execution is not a violation.
+func (l *limiter) violation(parent context.Context, ctx context.Context, cmd *exec.Cmd, output []byte, err error) *LimitError {
+	if err == nil || parent.Err() != nil {
+		return nil
+	}
+
+	limits := l.limits
+	if limits.WallClock > 0 && ctx.Err() == context.DeadlineExceeded {
+		return &LimitError{Reason: LimitWallClock, Limit: limits.WallClock, Err: err}
+	}
+
+	// A limit rejected by ulimit, the command did not run
+	text := strings.ToLower(string(output))
+	for reason, value := range map[string]int{LimitCPUTime: limits.CPUTime, LimitMemory: limits.Memory, LimitOpenFiles: limits.OpenFiles} {
+		if value > 0 && strings.Contains(text, unsetMarker+reason) {
+			return &LimitError{Reason: reason, Limit: value, Err: fmt.Errorf("%w: %v", errLimitNotSet, err)}
+		}
+	}
+
+	state := cmd.ProcessState
+	if state == nil {
+		return nil
+	}
+	if limits.CPUTime > 0 && cpuExceeded(state, limits.CPUTime) {
+		return &LimitError{Reason: LimitCPUTime, Limit: limits.CPUTime, Err: err}
+	}
+	if limits.Memory > 0 && (memoryExceeded(state, limits.Memory) || reports(text, memoryErrors...)) {
+		return &LimitError{Reason: LimitMemory, Limit: limits.Memory, Err: err}
+	}
+	if limits.OpenFiles > 0 && reports(text, "too many open files") {
+		return &LimitError{Reason: LimitOpenFiles, Limit: limits.OpenFiles, Err: err}
+	}
+	return nil
+}
+
+// reports checks the lower case output of a command reports one of the errors
+func reports(output string, messages ...string) bool {
+	for _, message := range messages {
+		if strings.Contains(output, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// appRoot returns the Yao application root, the working directory of the process mode commands
+func appRoot() string {
+	if config.Conf.Root != "" {
+		return config.Conf.Root
+	}
+	// Fallback to current directory if config is not available
+	dir, _ := os.Getwd()
+	return dir
+}
@@ -0,0 +1,50 @@
+//go:build !windows
+
+package job
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// cpuExceeded checks the process was stopped by the kernel on its CPU time limit:
+// SIGXCPU at the soft limit, SIGKILL at the hard limit once the CPU time is used up
+func cpuExceeded(state *os.ProcessState, seconds int) bool {
+	switch exitSignal(state) {
+	case syscall.SIGXCPU:
+		return true
+	case syscall.SIGKILL:
+		used := state.UserTime() + state.SystemTime()
+		return used >= time.Duration(seconds)*time.Second*9/10
+	}
+	return false
+}
+
+// memoryExceeded checks the peak resident memory of the process reached its limit. A crash alone is no evidence:
+// the failed allocations under RLIMIT_DATA are read from the output of the command.
+func memoryExceeded(state *os.ProcessState, mb int) bool {
+	return maxRSS(state) >= int64(mb)*1024*1024*9/10
+}
+
+// exitSignal returns the signal that terminated the process, 0 if it exited
+func exitSignal(state *os.ProcessState) syscall.Signal {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return 0
+	}
+	return status.Signal()
+}
+
+// maxRSS returns the peak resident memory of the process in bytes, reported by wait4
+func maxRSS(state *os.ProcessState) int64 {
+	usage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return 0
+	}
+	if runtime.GOOS == "darwin" {
+		return int64(usage.Maxrss)
+	}
+	return int64(usage.Maxrss) * 1024
+}
@@ -0,0 +1,153 @@
+package job_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// runLimited runs a command within the limits and waits for the execution to finish
+func runLimited(t *testing.T, mode job.ModeType, limits *job.ResourceLimits, command string, args ...string) *job.Execution {
+	testJob, err := job.OnceAndSave(mode, map[string]interface{}{"name": "Limited Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.AddCommand(job.NewExecutionOptions().WithLimits(limits), command, args, nil); err != nil {
+		t.Fatalf("Failed to add command: %v", err)
+	}
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+	return waitExecution(t, onlyExecution(t, testJob).ExecutionID, 10*time.Second, "completed", "failed")
+}
+
+func TestResourceLimits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The resource limits are not supported on windows")
+	}
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	cases := []struct {
+		name    string
+		mode    job.ModeType
+		limits  *job.ResourceLimits
+		command string
+		reason  string
+	}{
+		{"cpu time", job.PROCESS, &job.ResourceLimits{CPUTime: 1}, "while :; do :; done", job.LimitCPUTime},
+		{"wall clock", job.GOROUTINE, &job.ResourceLimits{WallClock: 1}, "sleep 5", job.LimitWallClock},
+		{"open files", job.PROCESS, &job.ResourceLimits{OpenFiles: 5}, "exec 3</dev/null 4</dev/null 5</dev/null 6</dev/null", job.LimitOpenFiles},
+		{"open files not set", job.GOROUTINE, &job.ResourceLimits{OpenFiles: 1 << 30}, "true", job.LimitOpenFiles},
+		{"work dir", job.GOROUTINE, &job.ResourceLimits{WorkDir: "../outside"}, "true", job.LimitWorkDir},
+	}
+
+	for _, c := range cases {
+		execution := runLimited(t, c.mode, c.limits, "sh", "-c", c.command)
+		if execution.Status != "failed" {
+			t.Errorf("%s: expected the execution to fail, got %s", c.name, execution.Status)
+			continue
+		}
+		if reason := execution.FailureReason(); reason != c.reason {
+			t.Errorf("%s: expected the %s failure reason, got %q", c.name, c.reason, reason)
+		}
+	}
+
+	// A plain failure has no failure reason
+	execution := runLimited(t, job.GOROUTINE, &job.ResourceLimits{CPUTime: 5}, "sh", "-c", "exit 3")
+	if execution.Status != "failed" || execution.FailureReason() != "" {
+		t.Errorf("Expected a failure without reason, got %s %q", execution.Status, execution.FailureReason())
+	}
+
+	// The commands with a work_dir run in it
+	execution = runLimited(t, job.PROCESS, &job.ResourceLimits{WorkDir: "tenant-42", OpenFiles: 64}, "sh", "-c", "pwd; echo $HOME")
+	if execution.Status != "completed" {
+		t.Fatalf("Expected the command to complete in its work_dir, got %s", execution.Status)
+	}
+	if execution.Result == nil || !strings.Contains(string(*execution.Result), filepath.Join("jobs", "tenant-42")) {
+		t.Errorf("Expected the command to run in its work_dir, got %v", execution.Result)
+	}
+}
+
+// yaoStub stands in for the yao binary of the process mode: yao run <process> [args...]
+const yaoStub = `#!/bin/sh
+case "$2" in
+scripts.job.spin) while :; do :; done ;;
+scripts.job.files) exec 3</dev/null 4</dev/null 5</dev/null 6</dev/null ;;
+*) pwd ;;
+esac
+`
+
+// runLimitedProcess runs a Yao process within the limits and waits for the execution to finish
+func runLimitedProcess(t *testing.T, mode job.ModeType, limits *job.ResourceLimits, name string) *job.Execution {
+	testJob, err := job.OnceAndSave(mode, map[string]interface{}{"name": "Limited Process Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions().WithLimits(limits), name); err != nil {
+		t.Fatalf("Failed to add process: %v", err)
+	}
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+	return waitExecution(t, onlyExecution(t, testJob).ExecutionID, 10*time.Second, "completed", "failed")
+}
+
+func TestProcessModeLimits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The resource limits are not supported on windows")
+	}
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	// The process mode runs the Yao processes with yao run, found in the PATH
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "yao"), []byte(yaoStub), 0755); err != nil {
+		t.Fatalf("Failed to write the yao stub: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cases := []struct {
+		name    string
+		limits  *job.ResourceLimits
+		process string
+		reason  string
+	}{
+		{"cpu time", &job.ResourceLimits{CPUTime: 1}, "scripts.job.spin", job.LimitCPUTime},
+		{"wall clock", &job.ResourceLimits{WallClock: 1}, "scripts.job.spin", job.LimitWallClock},
+		{"open files", &job.ResourceLimits{OpenFiles: 5}, "scripts.job.files", job.LimitOpenFiles},
+		{"work dir", &job.ResourceLimits{WorkDir: "../outside"}, "scripts.job.pwd", job.LimitWorkDir},
+	}
+	for _, c := range cases {
+		execution := runLimitedProcess(t, job.PROCESS, c.limits, c.process)
+		if execution.Status != "failed" {
+			t.Errorf("%s: expected the execution to fail, got %s", c.name, execution.Status)
+			continue
+		}
+		if reason := execution.FailureReason(); reason != c.reason {
+			t.Errorf("%s: expected the %s failure reason, got %q", c.name, c.reason, reason)
+		}
+	}
+
+	// The Yao process runs in its work_dir
+	execution := runLimitedProcess(t, job.PROCESS, &job.ResourceLimits{WorkDir: "tenant-7", CPUTime: 5}, "scripts.job.pwd")
+	if execution.Status != "completed" {
+		t.Fatalf("Expected the process to complete in its work_dir, got %s", execution.Status)
+	}
+	if execution.Result == nil || !strings.Contains(string(*execution.Result), filepath.Join("jobs", "tenant-7")) {
+		t.Errorf("Expected the process to run in its work_dir, got %v", execution.Result)
+	}
+
+	// The goroutine mode can not bound a Yao process, the execution fails instead of running without its limits
+	execution = runLimitedProcess(t, job.GOROUTINE, &job.ResourceLimits{CPUTime: 1}, "scripts.job.spin")
+	if execution.Status != "failed" {
+		t.Errorf("Expected the limited goroutine mode process to fail, got %s", execution.Status)
+	}
+}
@@ -0,0 +1,13 @@
+package job
+
+import "os"
+
+// cpuExceeded the CPU time limit is not supported on windows
+func cpuExceeded(state *os.ProcessState, seconds int) bool {
+	return false
+}
+
+// memoryExceeded the memory limit is not supported on windows
+func memoryExceeded(state *os.ProcessState, mb int) bool {
+	return false
+}
@@ -0,0 +1,381 @@
+package job
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets the upper bounds of the duration and queue wait histograms in seconds
+var durationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600, 7200}
+
+// retryBuckets the upper bounds of the retries histogram
+var retryBuckets = []float64{0, 1, 2, 3, 5, 10}
+
+// metrics the run history metrics of the jobs executed on this node
+var metrics = &metricsRegistry{jobs: map[string]*jobMetrics{}}
+
+// Summary sums up a histogram, the percentiles are estimated from the buckets
+type Summary struct {
+	Count int64   `json:"count"`
+	Sum   float64 `json:"sum"`
+	Avg   float64 `json:"avg"`
+	P50   float64 `json:"p50"`
+	P95   float64 `json:"p95"`
+	P99   float64 `json:"p99"`
+}
+
+// JobMetrics holds the run history metrics of a job since the node started.
+// The outcomes, the histograms and the breaches are counted on this node only, sum them up across the nodes;
+// the failures in a row are read from the job row, shared by the nodes.
+type JobMetrics struct {
+	JobID         string           `json:"job_id"`
+	Name          string           `json:"name"`
+	Outcomes      map[string]int64 `json:"outcomes"`     // Finished executions by status: completed, failed, dead
+	SuccessRate   float64          `json:"success_rate"` // Completed / finished executions (0-1)
+	Duration      Summary          `json:"duration"`     // Execution duration in seconds
+	QueueWait     Summary          `json:"queue_wait"`   // Time spent in the work queue in seconds
+	Retries       Summary          `json:"retries"`      // Retries of the finished executions
+	MissedRuns    int64            `json:"missed_runs"`  // Cron runs skipped while the scheduler was late
+	Failures      int              `json:"failures"`     // Consecutive failed executions of the job, on all the nodes
+	SLABreaches   map[string]int64 `json:"sla_breaches"` // SLA breaches by type
+	LastSuccessAt *time.Time       `json:"last_success_at,omitempty"`
+	LastFailureAt *time.Time       `json:"last_failure_at,omitempty"`
+}
+
+// metricsRegistry holds the metrics of the jobs
+type metricsRegistry struct {
+	mu   sync.RWMutex
+	jobs map[string]*jobMetrics
+}
+
+// jobMetrics holds the metrics of a job
+type jobMetrics struct {
+	jobID         string
+	name          string
+	outcomes      map[string]int64
+	duration      *histogram
+	queueWait     *histogram
+	retries       *histogram
+	missedRuns    int64
+	failures      int
+	breaches      map[string]int64
+	lastSuccessAt *time.Time
+	lastFailureAt *time.Time
+}
+
+// histogram counts the observations per bucket, the last count is the +Inf bucket
+type histogram struct {
+	bounds []float64
+	counts []int64
+	count  int64
+	sum    float64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]int64, len(bounds)+1)}
+}
+
+// observe adds an observation
+func (h *histogram) observe(value float64) {
+	i := sort.SearchFloat64s(h.bounds, value)
+	h.counts[i]++
+	h.count++
+	h.sum += value
+}
+
+// quantile estimates the q-quantile (0-1) by a linear interpolation within its bucket
+func (h *histogram) quantile(q float64) float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := q * float64(h.count)
+	var cumulative int64
+	for i, count := range h.counts {
+		if float64(cumulative+count) < rank || count == 0 {
+			cumulative += count
+			continue
+		}
+		if i == len(h.bounds) {
+			// +Inf bucket, the highest finite bound is the best estimate
+			return h.bounds[len(h.bounds)-1]
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = h.bounds[i-1]
+		}
+		return lower + (h.bounds[i]-lower)*(rank-float64(cumulative))/float64(count)
+	}
+	return h.bounds[len(h.bounds)-1]
+}
+
+// summary returns the summary of the histogram
+func (h *histogram) summary() Summary {
+	summary := Summary{Count: h.count, Sum: h.sum, P50: h.quantile(0.5), P95: h.quantile(0.95), P99: h.quantile(0.99)}
+	if h.count > 0 {
+		summary.Avg = h.sum / float64(h.count)
+	}
+	return summary
+}
+
+// job returns the metrics of a job, created on first use
+func (r *metricsRegistry) job(j *Job) *jobMetrics {
+	m, has := r.jobs[j.JobID]
+	if !has {
+		m = &jobMetrics{
+			jobID:     j.JobID,
+			outcomes:  map[string]int64{},
+			duration:  newHistogram(durationBuckets),
+			queueWait: newHistogram(durationBuckets),
+			retries:   newHistogram(retryBuckets),
+			breaches:  map[string]int64{},
+		}
+		r.jobs[j.JobID] = m
+	}
+	if j.Name != "" {
+		m.name = j.Name
+	}
+	return m
+}
+
+// started records the time an execution waited in the work queue
+func (r *metricsRegistry) started(j *Job, waited time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.job(j).queueWait.observe(waited.Seconds())
+}
+
+// finished records the outcome, the duration and the retries of a finished execution
+func (r *metricsRegistry) finished(j *Job, execution *Execution) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := r.job(j)
+	m.outcomes[execution.Status]++
+	m.retries.observe(float64(execution.RetryAttempt))
+	if execution.Duration != nil {
+		m.duration.observe(float64(*execution.Duration) / 1000)
+	}
+
+	now := time.Now()
+	if execution.Status == "completed" {
+		m.lastSuccessAt = &now
+	} else {
+		m.lastFailureAt = &now
+	}
+}
+
+// streak records the failures in a row of the job, read from the job row
+func (r *metricsRegistry) streak(j *Job, failures int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.job(j).failures = failures
+}
+
+// missed records the cron runs skipped by the scheduler
+func (r *metricsRegistry) missed(j *Job, runs int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.job(j).missedRuns += int64(runs)
+}
+
+// breached records an SLA breach
+func (r *metricsRegistry) breached(j *Job, breach string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.job(j).breaches[breach]++
+}
+
+// export returns the metrics of a job
+func (m *jobMetrics) export() *JobMetrics {
+	result := &JobMetrics{
+		JobID:         m.jobID,
+		Name:          m.name,
+		Outcomes:      map[string]int64{},
+		Duration:      m.duration.summary(),
+		QueueWait:     m.queueWait.summary(),
+		Retries:       m.retries.summary(),
+		MissedRuns:    m.missedRuns,
+		Failures:      m.failures,
+		SLABreaches:   map[string]int64{},
+		LastSuccessAt: m.lastSuccessAt,
+		LastFailureAt: m.lastFailureAt,
+	}
+
+	var finished int64
+	for status, count := range m.outcomes {
+		result.Outcomes[status] = count
+		finished += count
+	}
+	if finished > 0 {
+		result.SuccessRate = float64(m.outcomes["completed"]) / float64(finished)
+	}
+	for breach, count := range m.breaches {
+		result.SLABreaches[breach] = count
+	}
+	return result
+}
+
+// GetJobMetrics returns the run history metrics of a job recorded on this node, nil if the job did not run
+func GetJobMetrics(jobID string) *JobMetrics {
+	metrics.mu.RLock()
+	defer metrics.mu.RUnlock()
+
+	m, has := metrics.jobs[jobID]
+	if !has {
+		return nil
+	}
+	return m.export()
+}
+
+// GetMetrics returns the run history metrics of all the jobs recorded on this node, sorted by job ID
+func GetMetrics() []*JobMetrics {
+	metrics.mu.RLock()
+	defer metrics.mu.RUnlock()
+
+	result := make([]*JobMetrics, 0, len(metrics.jobs))
+	for _, m := range metrics.jobs {
+		result = append(result, m.export())
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].JobID < result[j].JobID })
+	return result
+}
+
+// ResetMetrics clears the metrics recorded on this node
+func ResetMetrics() {
+	metrics.mu.Lock()
+	metrics.jobs = map[string]*jobMetrics{}
+	metrics.mu.Unlock()
+}
+
+// WritePrometheus writes the job metrics and the work queue status in the Prometheus text format
+func WritePrometheus(w io.Writer) error {
+	metrics.mu.RLock()
+	jobs := make([]*jobMetrics, 0, len(metrics.jobs))
+	for _, m := range metrics.jobs {
+		jobs = append(jobs, m)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].jobID < jobs[j].jobID })
+
+	p := &promWriter{}
+	p.header("yao_job_executions_total", "counter", "Finished executions by status")
+	for _, m := range jobs {
+		statuses := make([]string, 0, len(m.outcomes))
+		for status := range m.outcomes {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			p.sample("yao_job_executions_total", m.labels("status", status), float64(m.outcomes[status]))
+		}
+	}
+
+	p.header("yao_job_execution_duration_seconds", "histogram", "Duration of the finished executions")
+	for _, m := range jobs {
+		p.histogram("yao_job_execution_duration_seconds", m.labels(), m.duration)
+	}
+
+	p.header("yao_job_queue_wait_seconds", "histogram", "Time the executions waited in the work queue")
+	for _, m := range jobs {
+		p.histogram("yao_job_queue_wait_seconds", m.labels(), m.queueWait)
+	}
+
+	p.header("yao_job_execution_retries", "histogram", "Retries of the finished executions")
+	for _, m := range jobs {
+		p.histogram("yao_job_execution_retries", m.labels(), m.retries)
+	}
+
+	p.header("yao_job_missed_runs_total", "counter", "Cron runs skipped while the scheduler was late")
+	for _, m := range jobs {
+		p.sample("yao_job_missed_runs_total", m.labels(), float64(m.missedRuns))
+	}
+
+	p.header("yao_job_sla_breaches_total", "counter", "SLA breaches by type")
+	for _, m := range jobs {
+		breaches := make([]string, 0, len(m.breaches))
+		for breach := range m.breaches {
+			breaches = append(breaches, breach)
+		}
+		sort.Strings(breaches)
+		for _, breach := range breaches {
+			p.sample("yao_job_sla_breaches_total", m.labels("type", breach), float64(m.breaches[breach]))
+		}
+	}
+
+	p.header("yao_job_last_success_timestamp_seconds", "gauge", "Unix time of the last completed execution")
+	for _, m := range jobs {
+		if m.lastSuccessAt != nil {
+			p.sample("yao_job_last_success_timestamp_seconds", m.labels(), float64(m.lastSuccessAt.Unix()))
+		}
+	}
+	metrics.mu.RUnlock()
+
+	status := GetWorkerManager().GetQueueStatus()
+	p.header("yao_job_queue_length", "gauge", "Work requests waiting in the queue")
+	p.sample("yao_job_queue_length", "", float64(status.Length))
+	p.header("yao_job_queue_blocked", "gauge", "Queued work requests blocked by a concurrency key or a category quota")
+	p.sample("yao_job_queue_blocked", "", float64(status.Blocked))
+	p.header("yao_job_running_executions", "gauge", "Executions running on this node")
+	p.sample("yao_job_running_executions", "", float64(status.Running))
+
+	_, err := io.WriteString(w, p.String())
+	return err
+}
+
+// labels returns the Prometheus labels of the job, followed by the extra name/value pairs
+func (m *jobMetrics) labels(pairs ...string) string {
+	labels := fmt.Sprintf(`job_id="%s",name="%s"`, escapeLabel(m.jobID), escapeLabel(m.name))
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels += fmt.Sprintf(`,%s="%s"`, pairs[i], escapeLabel(pairs[i+1]))
+	}
+	return labels
+}
+
+// escapeLabel escapes a Prometheus label value
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// promWriter builds the Prometheus text format
+type promWriter struct {
+	strings.Builder
+}
+
+func (p *promWriter) header(name, kind, help string) {
+	fmt.Fprintf(p, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (p *promWriter) sample(name, labels string, value float64) {
+	if labels != "" {
+		name = name + "{" + labels + "}"
+	}
+	fmt.Fprintf(p, "%s %s\n", name, formatFloat(value))
+}
+
+func (p *promWriter) histogram(name, labels string, h *histogram) {
+	var cumulative int64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		p.sample(name+"_bucket", labels+`,le="`+formatFloat(bound)+`"`, float64(cumulative))
+	}
+	p.sample(name+"_bucket", labels+`,le="+Inf"`, float64(h.count))
+	p.sample(name+"_sum", labels, h.sum)
+	p.sample(name+"_count", labels, float64(h.count))
+}
+
+// formatFloat formats a sample value
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	if value == math.Trunc(value) && math.Abs(value) < 1e15 {
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,211 @@
+package job_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// alerts receives the alerts of the test.job.sla.alert process
+var alerts = make(chan map[string]interface{}, 10)
+
+// registerMetricsProcesses registers the processes of the metrics tests
+func registerMetricsProcesses() {
+	process.Register("test.job.metrics.quick", func(process *process.Process) interface{} {
+		return map[string]interface{}{"status": "success"}
+	})
+
+	process.Register("test.job.metrics.slow", func(process *process.Process) interface{} {
+		time.Sleep(1500 * time.Millisecond)
+		return map[string]interface{}{"status": "success"}
+	})
+
+	process.Register("test.job.metrics.fail", func(process *process.Process) interface{} {
+		exception.New("upstream unavailable", 500).Throw()
+		return nil
+	})
+
+	process.Register("test.job.sla.alert", func(process *process.Process) interface{} {
+		alerts <- process.ArgsMap(0)
+		return nil
+	})
+}
+
+// waitAlert waits for an alert of the given type
+func waitAlert(t *testing.T, breach string, timeout time.Duration) map[string]interface{} {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case alert := <-alerts:
+			if alert["type"] == breach {
+				return alert
+			}
+		case <-deadline:
+			t.Fatalf("Expected a %s alert", breach)
+			return nil
+		}
+	}
+}
+
+func TestJobMetrics(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerMetricsProcesses()
+	job.ResetMetrics()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Metrics Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.metrics.quick"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.metrics.fail"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+	executions, err := testJob.GetExecutions()
+	if err != nil {
+		t.Fatalf("Failed to get executions: %v", err)
+	}
+	for _, execution := range executions {
+		waitExecution(t, execution.ExecutionID, 5*time.Second, "completed", "failed")
+	}
+
+	metrics := job.GetJobMetrics(testJob.JobID)
+	if metrics == nil {
+		t.Fatal("Expected the metrics of the job")
+	}
+	if metrics.Outcomes["completed"] != 1 || metrics.Outcomes["failed"] != 1 {
+		t.Errorf("Expected 1 completed and 1 failed execution, got %v", metrics.Outcomes)
+	}
+	if metrics.SuccessRate != 0.5 {
+		t.Errorf("Expected a 0.5 success rate, got %v", metrics.SuccessRate)
+	}
+	if metrics.Duration.Count != 2 || metrics.QueueWait.Count != 2 {
+		t.Errorf("Expected 2 durations and queue waits, got %d %d", metrics.Duration.Count, metrics.QueueWait.Count)
+	}
+	if metrics.Duration.P50 <= 0 || metrics.Duration.P99 < metrics.Duration.P50 {
+		t.Errorf("Expected increasing duration percentiles, got %+v", metrics.Duration)
+	}
+	if metrics.Name != "Metrics Job" || metrics.LastSuccessAt == nil || metrics.LastFailureAt == nil {
+		t.Errorf("Expected the name and the last run times, got %+v", metrics)
+	}
+
+	var buf bytes.Buffer
+	if err := job.WritePrometheus(&buf); err != nil {
+		t.Fatalf("Failed to write the Prometheus metrics: %v", err)
+	}
+	output := buf.String()
+	for _, line := range []string{
+		"# TYPE yao_job_execution_duration_seconds histogram",
+		`yao_job_executions_total{job_id="` + testJob.JobID + `",name="Metrics Job",status="completed"} 1`,
+		`yao_job_execution_duration_seconds_count{job_id="` + testJob.JobID + `",name="Metrics Job"} 2`,
+		`yao_job_queue_wait_seconds_bucket{job_id="` + testJob.JobID + `",name="Metrics Job",le="+Inf"} 2`,
+		"yao_job_queue_length 0",
+	} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected the Prometheus output to contain %q", line)
+		}
+	}
+
+	if job.GetJobMetrics("unknown") != nil {
+		t.Error("Expected no metrics for a job that did not run")
+	}
+}
+
+func TestJobSLA(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerMetricsProcesses()
+	job.ResetMetrics()
+
+	if err := (&job.SLA{MaxDuration: 1}).Validate(); err == nil {
+		t.Error("Expected an error for an SLA without a process or a channel")
+	}
+
+	// A slow execution breaches the max duration while it runs
+	slow, err := job.Once(job.GOROUTINE, map[string]interface{}{"name": "Slow Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	slow.SetSLA(&job.SLA{MaxDuration: 1, Process: "test.job.sla.alert"})
+	if err := job.SaveJob(slow); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+	if err := slow.Add(job.NewExecutionOptions(), "test.job.metrics.slow"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	if err := slow.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	alert := waitAlert(t, job.BreachMaxDuration, 5*time.Second)
+	if alert["job_id"] != slow.JobID || alert["execution_id"] != onlyExecution(t, slow).ExecutionID {
+		t.Errorf("Expected the alert of the slow execution, got %v", alert)
+	}
+	waitExecution(t, onlyExecution(t, slow).ExecutionID, 5*time.Second, "completed")
+
+	// The failures in a row breach the max consecutive failures once per streak
+	failing, err := job.Once(job.GOROUTINE, map[string]interface{}{"name": "Failing Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	failing.SetSLA(&job.SLA{MaxConsecutiveFailures: 2, Process: "test.job.sla.alert"})
+	if err := job.SaveJob(failing); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+	if err := failing.Add(job.NewExecutionOptions(), "test.job.metrics.fail"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+
+	executionID := onlyExecution(t, failing).ExecutionID
+	for i := 0; i < 3; i++ {
+		if err := failing.Push(); err != nil {
+			t.Fatalf("Failed to push job: %v", err)
+		}
+		waitExecution(t, executionID, 5*time.Second, "failed")
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	alert = waitAlert(t, job.BreachConsecutiveFailures, 5*time.Second)
+	if alert["value"] != float64(2) {
+		t.Errorf("Expected the alert after 2 failures, got %v", alert["value"])
+	}
+	select {
+	case extra := <-alerts:
+		t.Errorf("Expected a single alert per streak, got %v", extra)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	metrics := job.GetJobMetrics(failing.JobID)
+	if metrics == nil || metrics.Failures != 3 || metrics.SLABreaches[job.BreachConsecutiveFailures] != 1 {
+		t.Errorf("Expected 3 failures and 1 breach, got %+v", metrics)
+	}
+
+	// The streak is kept on the job row when the node restarts, the alert is sent again every 2 failures
+	job.ResetMetrics()
+	if err := failing.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+	waitExecution(t, executionID, 5*time.Second, "failed")
+
+	alert = waitAlert(t, job.BreachConsecutiveFailures, 5*time.Second)
+	if alert["value"] != float64(4) {
+		t.Errorf("Expected the alert after 4 failures, got %v", alert["value"])
+	}
+	metrics = job.GetJobMetrics(failing.JobID)
+	if metrics == nil || metrics.Failures != 4 {
+		t.Errorf("Expected 4 failures after the restart, got %+v", metrics)
+	}
+}
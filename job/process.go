@@ -5,10 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 
 	jsoniter "github.com/json-iterator/go"
-	"github.com/yaoapp/yao/config"
 )
 
 // Process the process mode
@@ -16,7 +14,7 @@ type Process struct{}
 
 // ExecuteYaoProcess executes a Yao process using independent process mode (yao run command)
 func (p *Process) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, progress *Progress) error {
-	execConfig := work.Execution.ExecutionConfig
+	execConfig := work.Execution.config()
 
 	work.Execution.Info("Executing Yao process: %s (process mode)", execConfig.ProcessName)
 
@@ -27,16 +25,16 @@ func (p *Process) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, prog
 	convertedArgs := convertArgsForYaoRun(execConfig.ProcessArgs)
 	args = append(args, convertedArgs...)
 
-	// Create command with context for cancellation support
-	cmd := exec.CommandContext(ctx, "yao", args...)
-
-	// Set working directory to Yao application root
-	if config.Conf.Root != "" {
-		cmd.Dir = config.Conf.Root
-	} else {
-		// Fallback to current directory if config is not available
-		cmd.Dir, _ = os.Getwd()
+	// Run within the resource limits of the execution, in the application root or its work_dir
+	limiter, err := newLimiter(work.Execution, appRoot())
+	if err != nil {
+		return failLimits(work, err)
 	}
+	runCtx, cancel := limiter.context(ctx)
+	defer cancel()
+
+	// Create command with context for cancellation support
+	cmd := limiter.command(runCtx, "yao", args...)
 
 	// Set environment variables
 	env := os.Environ()
@@ -55,9 +53,10 @@ func (p *Process) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, prog
 			}
 		}
 	}
-	cmd.Env = env
+	cmd.Env = limiter.env(env)
 
 	// Execute command
+	var violation *LimitError
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Check if it was cancelled
@@ -68,12 +67,18 @@ func (p *Process) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, prog
 			work.Execution.Error("Yao process failed: %s, output: %s", err.Error(), string(output))
 			work.Execution.Status = "failed"
 
-			// Store error output
-			if len(output) > 0 {
+			// Store error output and the exceeded limit
+			violation = limiter.violation(ctx, runCtx, cmd, output, err)
+			if len(output) > 0 || violation != nil {
 				errorInfo := map[string]interface{}{
 					"error":  err.Error(),
 					"output": string(output),
 				}
+				if violation != nil {
+					work.Execution.Error("Yao process stopped: %s", violation.Error())
+					errorInfo["reason"] = violation.Reason
+					errorInfo["limit"] = violation.Limit
+				}
 				if errorBytes, jsonErr := jsoniter.Marshal(errorInfo); jsonErr == nil {
 					work.Execution.ErrorInfo = (*json.RawMessage)(&errorBytes)
 				}
@@ -88,6 +93,9 @@ func (p *Process) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, prog
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
+		if violation != nil {
+			return violation
+		}
 		return fmt.Errorf("yao process execution failed: %v", err)
 	}
 
@@ -115,20 +123,20 @@ func (p *Process) ExecuteYaoProcess(ctx context.Context, work *WorkRequest, prog
 
 // ExecuteSystemCommand executes a system command using independent process mode
 func (p *Process) ExecuteSystemCommand(ctx context.Context, work *WorkRequest, progress *Progress) error {
-	execConfig := work.Execution.ExecutionConfig
+	execConfig := work.Execution.config()
 
 	work.Execution.Info("Executing command: %s (process mode)", execConfig.Command)
 
-	// Create command with context for cancellation support
-	cmd := exec.CommandContext(ctx, execConfig.Command, execConfig.CommandArgs...)
-
-	// Set working directory to Yao application root directory
-	if config.Conf.Root != "" {
-		cmd.Dir = config.Conf.Root
-	} else {
-		// Fallback to current directory
-		cmd.Dir, _ = os.Getwd()
+	// Run within the resource limits of the execution, in the application root or its work_dir
+	limiter, err := newLimiter(work.Execution, appRoot())
+	if err != nil {
+		return failLimits(work, err)
 	}
+	runCtx, cancel := limiter.context(ctx)
+	defer cancel()
+
+	// Create command with context for cancellation support
+	cmd := limiter.command(runCtx, execConfig.Command, execConfig.CommandArgs...)
 
 	// Set environment variables
 	env := os.Environ()
@@ -154,9 +162,10 @@ func (p *Process) ExecuteSystemCommand(ctx context.Context, work *WorkRequest, p
 			}
 		}
 	}
-	cmd.Env = env
+	cmd.Env = limiter.env(env)
 
 	// Execute command
+	var violation *LimitError
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Check if it was cancelled
@@ -167,12 +176,18 @@ func (p *Process) ExecuteSystemCommand(ctx context.Context, work *WorkRequest, p
 			work.Execution.Error("Command failed: %s, output: %s", err.Error(), string(output))
 			work.Execution.Status = "failed"
 
-			// Store error output
-			if len(output) > 0 {
+			// Store error output and the exceeded limit
+			violation = limiter.violation(ctx, runCtx, cmd, output, err)
+			if len(output) > 0 || violation != nil {
 				errorInfo := map[string]interface{}{
 					"error":  err.Error(),
 					"output": string(output),
 				}
+				if violation != nil {
+					work.Execution.Error("Command stopped: %s", violation.Error())
+					errorInfo["reason"] = violation.Reason
+					errorInfo["limit"] = violation.Limit
+				}
 				if errorBytes, jsonErr := jsoniter.Marshal(errorInfo); jsonErr == nil {
 					work.Execution.ErrorInfo = (*json.RawMessage)(&errorBytes)
 				}
@@ -187,6 +202,9 @@ func (p *Process) ExecuteSystemCommand(ctx context.Context, work *WorkRequest, p
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
+		if violation != nil {
+			return violation
+		}
 		return fmt.Errorf("command execution failed: %v", err)
 	}
 
@@ -212,6 +230,24 @@ func (p *Process) ExecuteSystemCommand(ctx context.Context, work *WorkRequest, p
 	return nil
 }
 
+// failLimits fails the execution when its resource limits cannot be applied
+func failLimits(work *WorkRequest, err error) error {
+	work.Execution.Error("Failed to apply the resource limits: %s", err.Error())
+	work.Execution.Status = "failed"
+
+	errorInfo := map[string]interface{}{"error": err.Error()}
+	if violation, ok := err.(*LimitError); ok {
+		errorInfo["reason"] = violation.Reason
+	}
+	if errorBytes, jsonErr := jsoniter.Marshal(errorInfo); jsonErr == nil {
+		work.Execution.ErrorInfo = (*json.RawMessage)(&errorBytes)
+	}
+	if saveErr := SaveExecution(work.Execution); saveErr != nil {
+		work.Execution.Error("Failed to save execution error: %s", saveErr.Error())
+	}
+	return err
+}
+
 // convertArgsForYaoRun converts arguments to proper format for yao run command
 func convertArgsForYaoRun(args []interface{}) []string {
 	result := make([]string, 0, len(args))
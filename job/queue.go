@@ -0,0 +1,359 @@
+package job
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// quotaTTL how long the category quotas loaded from the database are cached
+const quotaTTL = time.Minute
+
+// admitDelay the mean delay before an execution refused for the concurrency key or the category quota is due again
+const admitDelay = time.Second
+
+// QueueStatus the work queue status of a worker manager
+type QueueStatus struct {
+	Length     int            `json:"length"`      // Queued executions
+	Capacity   int            `json:"capacity"`    // Max queued executions
+	Blocked    int            `json:"blocked"`     // Queued executions waiting for a concurrency key or a category quota
+	Running    int            `json:"running"`     // Dispatched executions
+	ByPriority map[int]int    `json:"by_priority"` // Queued executions by priority
+	ByCategory map[string]int `json:"by_category"` // Queued executions by category ID
+	Categories map[string]int `json:"categories"`  // Running executions by category ID
+	Quotas     map[string]int `json:"quotas"`      // Max concurrency of the categories with a quota
+}
+
+// queueItem a work request waiting in the queue
+type queueItem struct {
+	work     *WorkRequest
+	priority int
+	category string
+	key      string
+}
+
+// categoryQuota the cached max concurrency of a category
+type categoryQuota struct {
+	max      int
+	loadedAt time.Time
+	fixed    bool // Set by SetCategoryQuota, not reloaded
+}
+
+// workQueue the priority queue of the worker manager.
+// The items are sorted by priority then by arrival, an item waits while an execution with the same
+// concurrency key runs, or while its category runs the max concurrency of the category. The queue only
+// knows the executions of this node, admit checks the executions of all the nodes when one is claimed.
+type workQueue struct {
+	items      []*queueItem
+	capacity   int
+	keys       map[string]bool // Running concurrency keys
+	categories map[string]int  // Running executions by category
+	running    map[*WorkRequest]*queueItem
+	quotas     map[string]*categoryQuota
+	notify     chan struct{}
+	mu         sync.Mutex
+}
+
+// newWorkQueue creates a work queue
+func newWorkQueue(capacity int) *workQueue {
+	return &workQueue{
+		items:      []*queueItem{},
+		capacity:   capacity,
+		keys:       map[string]bool{},
+		categories: map[string]int{},
+		running:    map[*WorkRequest]*queueItem{},
+		quotas:     map[string]*categoryQuota{},
+		notify:     make(chan struct{}, 1),
+	}
+}
+
+// push queues a work request, it returns an error when the queue is full
+func (q *workQueue) push(work *WorkRequest) error {
+	item := &queueItem{work: work, priority: work.Job.Priority, category: work.Job.CategoryID}
+	if options := work.Execution.ExecutionOptions; options != nil {
+		if options.Priority != 0 {
+			item.priority = options.Priority
+		}
+		item.key = options.ConcurrencyKey
+	}
+	if item.category != "" {
+		q.loadQuota(item.category)
+	}
+
+	q.mu.Lock()
+	if len(q.items) >= q.capacity {
+		length := len(q.items)
+		q.mu.Unlock()
+		return fmt.Errorf("work queue is full (%d/%d), please retry later", length, q.capacity)
+	}
+
+	work.queuedAt = time.Now()
+	i := sort.Search(len(q.items), func(i int) bool { return q.items[i].priority < item.priority })
+	q.items = append(q.items, nil)
+	copy(q.items[i+1:], q.items[i:])
+	q.items[i] = item
+	q.mu.Unlock()
+
+	q.wake()
+	return nil
+}
+
+// next waits for the first runnable work request, it returns nil when quit is closed
+func (q *workQueue) next(quit chan bool) *WorkRequest {
+	for {
+		if work := q.pop(); work != nil {
+			return work
+		}
+		select {
+		case <-q.notify:
+		case <-quit:
+			return nil
+		}
+	}
+}
+
+// pop removes the first runnable work request and marks it running, the cancelled requests are dropped
+func (q *workQueue) pop() *WorkRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := 0; i < len(q.items); i++ {
+		item := q.items[i]
+		if item.work.Context.Err() != nil {
+			log.Warn("Job %s execution %s submission cancelled", item.work.Job.JobID, item.work.Execution.ExecutionID)
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			i--
+			continue
+		}
+		if q.blocked(item) {
+			continue
+		}
+
+		q.items = append(q.items[:i], q.items[i+1:]...)
+		if item.key != "" {
+			q.keys[item.key] = true
+		}
+		if item.category != "" {
+			q.categories[item.category]++
+		}
+		q.running[item.work] = item
+		return item.work
+	}
+	return nil
+}
+
+// blocked checks the concurrency key and the category quota of an item, the lock must be held
+func (q *workQueue) blocked(item *queueItem) bool {
+	if item.key != "" && q.keys[item.key] {
+		return true
+	}
+	if quota, has := q.quotas[item.category]; has && quota.max > 0 && q.categories[item.category] >= quota.max {
+		return true
+	}
+	return false
+}
+
+// done releases the concurrency key and the category slot of a finished work request
+func (q *workQueue) done(work *WorkRequest) {
+	q.mu.Lock()
+	item, has := q.running[work]
+	if has {
+		delete(q.running, work)
+		if item.key != "" {
+			delete(q.keys, item.key)
+		}
+		if item.category != "" {
+			q.categories[item.category]--
+			if q.categories[item.category] <= 0 {
+				delete(q.categories, item.category)
+			}
+		}
+	}
+	q.mu.Unlock()
+
+	if has {
+		q.wake()
+	}
+}
+
+// wake wakes up the dispatcher
+func (q *workQueue) wake() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// loadQuota loads the max concurrency of a category from the database, cached for quotaTTL
+func (q *workQueue) loadQuota(categoryID string) {
+	q.mu.Lock()
+	quota, has := q.quotas[categoryID]
+	q.mu.Unlock()
+	if has && (quota.fixed || time.Since(quota.loadedAt) < quotaTTL) {
+		return
+	}
+
+	max := 0
+	if model.Exists("__yao.job.category") {
+		categories, err := GetCategories(model.QueryParam{
+			Select: []interface{}{"category_id", "max_concurrency"},
+			Wheres: []model.QueryWhere{{Column: "category_id", Value: categoryID}},
+			Limit:  1,
+		})
+		if err != nil {
+			log.Warn("Failed to load the quota of category %s: %v", categoryID, err)
+		} else if len(categories) > 0 {
+			max = categories[0].MaxConcurrency
+		}
+	}
+
+	q.mu.Lock()
+	if current, has := q.quotas[categoryID]; !has || !current.fixed {
+		q.quotas[categoryID] = &categoryQuota{max: max, loadedAt: time.Now()}
+	}
+	q.mu.Unlock()
+}
+
+// status returns the queue status
+func (q *workQueue) status() QueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	status := QueueStatus{
+		Length:     len(q.items),
+		Capacity:   q.capacity,
+		Running:    len(q.running),
+		ByPriority: map[int]int{},
+		ByCategory: map[string]int{},
+		Categories: map[string]int{},
+		Quotas:     map[string]int{},
+	}
+	for _, item := range q.items {
+		status.ByPriority[item.priority]++
+		status.ByCategory[item.category]++
+		if q.blocked(item) {
+			status.Blocked++
+		}
+	}
+	for category, count := range q.categories {
+		status.Categories[category] = count
+	}
+	for category, quota := range q.quotas {
+		if quota.max > 0 {
+			status.Quotas[category] = quota.max
+		}
+	}
+	return status
+}
+
+// admit checks the concurrency key and the category quota of a claimed execution against the executions admitted
+// by all the nodes sharing the database. The execution is marked admitted before the others are counted, of two
+// nodes admitting at the same time the last one sees the other. A refused execution releases its lease and is due
+// again after about admitDelay, SubmitDueExecutions submits it to a node then.
+func (wm *WorkerManager) admit(work *WorkRequest) (bool, error) {
+	key := ""
+	if options := work.Execution.ExecutionOptions; options != nil {
+		key = options.ConcurrencyKey
+	}
+	category := work.Job.CategoryID
+	max := 0
+	if category != "" {
+		wm.queue.mu.Lock()
+		if quota, has := wm.queue.quotas[category]; has {
+			max = quota.max
+		}
+		wm.queue.mu.Unlock()
+	}
+	if key == "" && max <= 0 {
+		return true, nil
+	}
+
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return false, fmt.Errorf("job execution model not found")
+	}
+
+	now := time.Now()
+	mine := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: work.Execution.ExecutionID},
+			{Column: "node_id", Value: wm.nodeID},
+		},
+		Limit: 1,
+	}
+	data := map[string]interface{}{"admitted_at": now, "concurrency_key": nil, "category_id": nil}
+	if key != "" {
+		data["concurrency_key"] = key
+	}
+	if category != "" {
+		data["category_id"] = category
+	}
+	if _, err := mod.UpdateWhere(mine, data); err != nil {
+		return false, fmt.Errorf("failed to admit execution: %w", err)
+	}
+
+	// The other executions admitted by a node holding a valid lease
+	admitted := func(where model.QueryWhere, limit int) (int, error) {
+		rows, err := mod.Get(model.QueryParam{
+			Select: []interface{}{"execution_id"},
+			Wheres: []model.QueryWhere{
+				{Column: "execution_id", OP: "ne", Value: work.Execution.ExecutionID},
+				{Column: "status", OP: "in", Value: []string{"queued", "running"}},
+				{Column: "admitted_at", OP: "notnull"},
+				{Column: "lease_expires_at", OP: "ge", Value: now},
+				where,
+			},
+			Limit: limit,
+		})
+		return len(rows), err
+	}
+
+	refused := ""
+	if key != "" {
+		count, err := admitted(model.QueryWhere{Column: "concurrency_key", Value: key}, 1)
+		if err != nil {
+			return false, fmt.Errorf("failed to count the executions of concurrency key %s: %w", key, err)
+		}
+		if count > 0 {
+			refused = fmt.Sprintf("concurrency key %s", key)
+		}
+	}
+	if refused == "" && max > 0 {
+		count, err := admitted(model.QueryWhere{Column: "category_id", Value: category}, max)
+		if err != nil {
+			return false, fmt.Errorf("failed to count the executions of category %s: %w", category, err)
+		}
+		if count >= max {
+			refused = fmt.Sprintf("category %s quota", category)
+		}
+	}
+	if refused == "" {
+		return true, nil
+	}
+
+	delay := admitDelay/2 + time.Duration(rand.Int63n(int64(admitDelay)))
+	_, err := mod.UpdateWhere(mine, map[string]interface{}{
+		"admitted_at":      nil,
+		"lease_expires_at": now,
+		"scheduled_at":     now.Add(delay),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to refuse execution: %w", err)
+	}
+	log.Debug("Execution %s waits for the %s on another node", work.Execution.ExecutionID, refused)
+	return false, nil
+}
+
+// SetCategoryQuota sets the max concurrency of a category on this worker manager, 0 for unlimited.
+// It overrides the max_concurrency of the category saved in the database.
+func (wm *WorkerManager) SetCategoryQuota(categoryID string, max int) {
+	wm.queue.mu.Lock()
+	wm.queue.quotas[categoryID] = &categoryQuota{max: max, loadedAt: time.Now(), fixed: true}
+	wm.queue.mu.Unlock()
+	wm.queue.wake()
+}
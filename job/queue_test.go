@@ -0,0 +1,238 @@
+package job_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// queueTracker records the runs of the test.job.queue.track process
+type queueTracker struct {
+	order      []string
+	running    int
+	maxRunning int
+	mu         sync.Mutex
+}
+
+var tracker = &queueTracker{}
+
+// reset resets the tracker
+func (tr *queueTracker) reset() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.order = []string{}
+	tr.running = 0
+	tr.maxRunning = 0
+}
+
+// registerQueueProcesses registers the processes of the queue tests
+func registerQueueProcesses() {
+	process.Register("test.job.queue.track", func(process *process.Process) interface{} {
+		tracker.mu.Lock()
+		tracker.order = append(tracker.order, process.ArgsString(0))
+		tracker.running++
+		if tracker.running > tracker.maxRunning {
+			tracker.maxRunning = tracker.running
+		}
+		tracker.mu.Unlock()
+
+		time.Sleep(300 * time.Millisecond)
+
+		tracker.mu.Lock()
+		tracker.running--
+		tracker.mu.Unlock()
+		return map[string]interface{}{"status": "success"}
+	})
+}
+
+// submitTracked adds a tracked execution to the job and submits it to the worker manager
+func submitTracked(t *testing.T, wm *job.WorkerManager, testJob *job.Job, options *job.ExecutionOptions, name string) {
+	if err := testJob.Add(options, "test.job.queue.track", name); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	executions, err := testJob.GetExecutions()
+	if err != nil {
+		t.Fatalf("Failed to get executions: %v", err)
+	}
+	for _, execution := range executions {
+		if len(execution.ExecutionConfig.ProcessArgs) > 0 && execution.ExecutionConfig.ProcessArgs[0] == name {
+			if err := wm.SubmitJob(context.Background(), testJob, execution); err != nil {
+				t.Fatalf("Failed to submit execution: %v", err)
+			}
+			return
+		}
+	}
+	t.Fatalf("Execution %s not found", name)
+}
+
+func TestQueuePriority(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerQueueProcesses()
+	tracker.reset()
+
+	wm := job.NewWorkerManagerForTest(1).WithLease("queue-node", 5*time.Second)
+	wm.Start()
+	defer wm.Stop()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Priority Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// The first execution keeps the only worker busy while the others queue
+	submitTracked(t, wm, testJob, job.NewExecutionOptions(), "first")
+	time.Sleep(100 * time.Millisecond)
+	submitTracked(t, wm, testJob, job.NewExecutionOptions().WithPriority(1), "report")
+	submitTracked(t, wm, testJob, job.NewExecutionOptions().WithPriority(10), "interactive")
+
+	status := wm.GetQueueStatus()
+	if status.Length != 2 || status.ByPriority[1] != 1 || status.ByPriority[10] != 1 {
+		t.Errorf("Expected 2 queued executions by priority, got %+v", status)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	tracker.mu.Lock()
+	order := append([]string{}, tracker.order...)
+	tracker.mu.Unlock()
+	if len(order) != 3 || order[1] != "interactive" || order[2] != "report" {
+		t.Errorf("Expected the higher priority first, got %v", order)
+	}
+}
+
+func TestQueueConcurrencyKey(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerQueueProcesses()
+	tracker.reset()
+
+	wm := job.NewWorkerManagerForTest(3).WithLease("queue-node", 5*time.Second)
+	wm.Start()
+	defer wm.Stop()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Tenant Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// Only one execution of the tenant runs at a time
+	for _, name := range []string{"tenant-1", "tenant-2", "tenant-3"} {
+		submitTracked(t, wm, testJob, job.NewExecutionOptions().WithConcurrencyKey("tenant:42"), name)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if status := wm.GetQueueStatus(); status.Blocked != 2 || status.Running != 1 {
+		t.Errorf("Expected 2 blocked executions and 1 running, got %+v", status)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if len(tracker.order) != 3 {
+		t.Fatalf("Expected 3 runs, got %v", tracker.order)
+	}
+	if tracker.maxRunning != 1 {
+		t.Errorf("Expected one execution at a time, got %d", tracker.maxRunning)
+	}
+}
+
+func TestQueueCategoryQuota(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerQueueProcesses()
+	tracker.reset()
+
+	category, err := job.GetOrCreateCategory("Reports", "Heavy report jobs")
+	if err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+	category.MaxConcurrency = 1
+	if err := job.SaveCategory(category); err != nil {
+		t.Fatalf("Failed to save category: %v", err)
+	}
+
+	wm := job.NewWorkerManagerForTest(3).WithLease("queue-node", 5*time.Second)
+	wm.Start()
+	defer wm.Stop()
+
+	reports, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Report Job", "category_id": category.CategoryID})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	interactive, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Interactive Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// The second report waits for the quota, the interactive execution runs on a free worker
+	submitTracked(t, wm, reports, job.NewExecutionOptions(), "report-1")
+	submitTracked(t, wm, reports, job.NewExecutionOptions(), "report-2")
+	submitTracked(t, wm, interactive, job.NewExecutionOptions(), "interactive")
+
+	time.Sleep(150 * time.Millisecond)
+	status := wm.GetQueueStatus()
+	if status.Quotas[category.CategoryID] != 1 || status.Categories[category.CategoryID] != 1 {
+		t.Errorf("Expected 1 running report under the quota, got %+v", status)
+	}
+	if status.Length != 1 || status.ByCategory[category.CategoryID] != 1 {
+		t.Errorf("Expected the second report queued, got %+v", status)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if len(tracker.order) != 3 || tracker.order[2] != "report-2" {
+		t.Errorf("Expected the second report last, got %v", tracker.order)
+	}
+}
+
+func TestQueueConcurrencyKeyCluster(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerQueueProcesses()
+	tracker.reset()
+
+	managers := startNodes("node-a", "node-b")
+	defer func() {
+		for _, wm := range managers {
+			wm.Stop()
+		}
+	}()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Cluster Tenant Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// The executions of the tenant are spread over the nodes, they still run one at a time
+	for i, name := range []string{"tenant-1", "tenant-2", "tenant-3", "tenant-4"} {
+		submitTracked(t, managers[i%2], testJob, job.NewExecutionOptions().WithConcurrencyKey("tenant:42"), name)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		tracker.mu.Lock()
+		runs := len(tracker.order)
+		tracker.mu.Unlock()
+		if runs >= 4 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if len(tracker.order) != 4 {
+		t.Fatalf("Expected 4 runs, got %v", tracker.order)
+	}
+	if tracker.maxRunning != 1 {
+		t.Errorf("Expected one execution of the key at a time across the nodes, got %d", tracker.maxRunning)
+	}
+}
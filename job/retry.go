@@ -0,0 +1,190 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+)
+
+// ErrExecutionStatus is returned when the status of an execution does not allow the action
+var ErrExecutionStatus = errors.New("invalid execution status")
+
+// Default retry delays
+const (
+	defaultRetryDelay    = 1 * time.Second
+	defaultRetryMaxDelay = 5 * time.Minute
+)
+
+// retryPolicyOf returns the retry policy of an execution: the execution policy, the job policy,
+// or a default exponential policy when only the job max retry count is set
+func (j *Job) retryPolicyOf(execution *Execution) *RetryPolicy {
+	if execution.ExecutionOptions != nil && execution.ExecutionOptions.RetryPolicy != nil {
+		return execution.ExecutionOptions.RetryPolicy
+	}
+	if j.RetryPolicy != nil {
+		return j.RetryPolicy
+	}
+	if j.MaxRetryCount > 0 {
+		return &RetryPolicy{MaxAttempts: j.MaxRetryCount}
+	}
+	return nil
+}
+
+// decide returns the delay before the next attempt of a failed execution, or its final status:
+// "failed" when the error is not retryable, "dead" when the attempts are exhausted.
+// The attempt is the number of retries already made.
+func (p *RetryPolicy) decide(err error, attempt int) (time.Duration, string) {
+	if p == nil || p.MaxAttempts <= 0 || !p.retryable(err) {
+		return 0, "failed"
+	}
+	if attempt >= p.MaxAttempts {
+		return 0, "dead"
+	}
+	return p.delay(attempt + 1), ""
+}
+
+// retryable checks the error against the retry_on patterns
+func (p *RetryPolicy) retryable(err error) bool {
+	if len(p.RetryOn) == 0 {
+		return true
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+
+	for _, pattern := range p.RetryOn {
+		re, compileErr := regexp.Compile(pattern)
+		if compileErr != nil {
+			// Not a valid regexp, match as a substring
+			if strings.Contains(message, pattern) {
+				return true
+			}
+			continue
+		}
+		if re.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns the backoff delay before the given retry (1 for the first retry)
+func (p *RetryPolicy) delay(retry int) time.Duration {
+	base := defaultRetryDelay
+	if p.Delay > 0 {
+		base = time.Duration(p.Delay) * time.Second
+	}
+	max := defaultRetryMaxDelay
+	if p.MaxDelay > 0 {
+		max = time.Duration(p.MaxDelay) * time.Second
+	}
+
+	delay := base
+	if p.Backoff != BackoffFixed && retry > 1 {
+		delay = time.Duration(float64(base) * math.Pow(2, float64(retry-1)))
+	}
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay = time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+	}
+	return delay
+}
+
+// prepare initializes the job context and the execution contexts
+func (j *Job) prepare() {
+	j.executionMutex.Lock()
+	defer j.executionMutex.Unlock()
+
+	if j.ctx == nil {
+		j.ctx, j.cancel = context.WithCancel(context.Background())
+	}
+	if j.executionContexts == nil {
+		j.executionContexts = make(map[string]context.CancelFunc)
+	}
+}
+
+// scheduleRetry queues a failed execution again with the time of the retry. The saved row is the only
+// record of the retry: the lease supervisor of any node submits the execution once it is due, so the
+// retry survives a restart of this node.
+func (j *Job) scheduleRetry(execution *Execution, delay time.Duration, cause error) {
+	policy := j.retryPolicyOf(execution)
+	scheduledAt := time.Now().Add(delay)
+	execution.Status = "queued"
+	execution.RetryAttempt++
+	execution.ScheduledAt = &scheduledAt
+	if err := SaveExecution(execution); err != nil {
+		log.Warn("Failed to save retried execution %s: %v", execution.ExecutionID, err)
+	}
+	execution.Warn("Execution failed, retry %d/%d in %v: %v", execution.RetryAttempt, policy.MaxAttempts, delay.Round(time.Millisecond), cause)
+}
+
+// ListDeadExecutions lists the executions whose retries are exhausted, jobID is optional
+func ListDeadExecutions(jobID string, page int, pagesize int) (maps.MapStrAny, error) {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return nil, fmt.Errorf("job execution model not found")
+	}
+
+	param := model.QueryParam{
+		Select: ExecutionFields,
+		Wheres: []model.QueryWhere{
+			{Column: "status", Value: "dead"},
+		},
+		Orders: []model.QueryOrder{
+			{Column: "ended_at", Option: "desc"},
+		},
+	}
+	if jobID != "" {
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "job_id", Value: jobID})
+	}
+
+	return mod.Paginate(param, page, pagesize)
+}
+
+// RequeueExecution queues a dead or failed execution again with a fresh retry budget
+func RequeueExecution(executionID string) (*Execution, error) {
+	execution, err := GetExecution(executionID, model.QueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status != "dead" && execution.Status != "failed" {
+		return nil, fmt.Errorf("%w: execution %s is %s, only dead or failed executions can be requeued", ErrExecutionStatus, executionID, execution.Status)
+	}
+
+	job, err := GetJob(execution.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	execution.Status = "queued"
+	execution.RetryAttempt = 0
+	execution.ScheduledAt = nil
+	execution.EndedAt = nil
+	execution.Progress = 0
+	if err := SaveExecution(execution); err != nil {
+		return nil, fmt.Errorf("failed to save execution: %w", err)
+	}
+	execution.Info("Execution requeued")
+
+	job.prepare()
+	if err := job.submit(GetWorkerManager(), execution); err != nil {
+		return nil, fmt.Errorf("failed to submit execution: %w", err)
+	}
+	return execution, nil
+}
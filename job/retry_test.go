@@ -0,0 +1,238 @@
+package job_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// flakyCalls counts the calls of the test.job.retry.flaky process
+var flakyCalls int32
+
+// registerRetryProcesses registers the processes of the retry tests
+func registerRetryProcesses() {
+	// Fails the first two calls with a transient error
+	process.Register("test.job.retry.flaky", func(process *process.Process) interface{} {
+		if atomic.AddInt32(&flakyCalls, 1) <= 2 {
+			exception.New("database is locked", 500).Throw()
+		}
+		return map[string]interface{}{"status": "success"}
+	})
+
+	// Always fails with the given message
+	process.Register("test.job.retry.fail", func(process *process.Process) interface{} {
+		exception.New(process.ArgsString(0), 500).Throw()
+		return nil
+	})
+}
+
+// waitExecution waits until the execution reaches one of the statuses
+func waitExecution(t *testing.T, executionID string, timeout time.Duration, statuses ...string) *job.Execution {
+	deadline := time.Now().Add(timeout)
+	for {
+		execution, err := job.GetExecution(executionID, model.QueryParam{})
+		if err != nil {
+			t.Fatalf("Failed to get execution: %v", err)
+		}
+		for _, status := range statuses {
+			if execution.Status == status {
+				return execution
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Execution %s is %s, expected %v", executionID, execution.Status, statuses)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// onlyExecution returns the single execution of a job
+func onlyExecution(t *testing.T, testJob *job.Job) *job.Execution {
+	executions, err := testJob.GetExecutions()
+	if err != nil {
+		t.Fatalf("Failed to get executions: %v", err)
+	}
+	if len(executions) != 1 {
+		t.Fatalf("Expected 1 execution, got %d", len(executions))
+	}
+	return executions[0]
+}
+
+func TestRetryPolicy(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerRetryProcesses()
+	atomic.StoreInt32(&flakyCalls, 0)
+
+	testJob, err := job.Once(job.GOROUTINE, map[string]interface{}{"name": "Retry Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	testJob.SetRetryPolicy(&job.RetryPolicy{MaxAttempts: 3, Backoff: job.BackoffFixed, Delay: 1, RetryOn: []string{"(?i)locked|timeout"}})
+	if err := job.SaveJob(testJob); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.retry.flaky"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	execution := waitExecution(t, onlyExecution(t, testJob).ExecutionID, 10*time.Second, "completed", "dead")
+	if execution.Status != "completed" {
+		t.Fatalf("Expected the execution to complete after the retries, got %s", execution.Status)
+	}
+	if execution.RetryAttempt != 2 {
+		t.Errorf("Expected 2 retries, got %d", execution.RetryAttempt)
+	}
+	if calls := atomic.LoadInt32(&flakyCalls); calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	if _, err := job.RequeueExecution(execution.ExecutionID); !errors.Is(err, job.ErrExecutionStatus) {
+		t.Errorf("Expected ErrExecutionStatus when requeueing a completed execution, got %v", err)
+	}
+
+	// The policy of the execution overrides the policy of the job, the errors not matching retry_on fail at once
+	testJob, err = job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "No Retry Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	testJob.SetMaxRetryCount(3)
+	options := job.NewExecutionOptions().WithRetryPolicy(&job.RetryPolicy{MaxAttempts: 3, RetryOn: []string{"timeout"}})
+	if err := testJob.Add(options, "test.job.retry.fail", "invalid argument"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	execution = waitExecution(t, onlyExecution(t, testJob).ExecutionID, 5*time.Second, "completed", "failed", "dead")
+	if execution.Status != "failed" || execution.RetryAttempt != 0 {
+		t.Errorf("Expected a failed execution without retry, got %s after %d retries", execution.Status, execution.RetryAttempt)
+	}
+}
+
+func TestDeadExecutions(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerRetryProcesses()
+
+	testJob, err := job.Once(job.GOROUTINE, map[string]interface{}{"name": "Dead Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	testJob.SetMaxRetryCount(1)
+	if err := job.SaveJob(testJob); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.retry.fail", "connection refused"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	executionID := onlyExecution(t, testJob).ExecutionID
+	execution := waitExecution(t, executionID, 10*time.Second, "dead", "completed")
+	if execution.Status != "dead" || execution.RetryAttempt != 1 {
+		t.Fatalf("Expected a dead execution after 1 retry, got %s after %d retries", execution.Status, execution.RetryAttempt)
+	}
+
+	result, err := job.ListDeadExecutions(testJob.JobID, 1, 20)
+	if err != nil {
+		t.Fatalf("Failed to list dead executions: %v", err)
+	}
+	if total, ok := result["total"].(int); !ok || total != 1 {
+		t.Errorf("Expected 1 dead execution, got %v", result["total"])
+	}
+
+	// Requeue with a fresh retry budget, the execution dies again
+	if _, err := job.RequeueExecution(executionID); err != nil {
+		t.Fatalf("Failed to requeue execution: %v", err)
+	}
+
+	execution = waitExecution(t, executionID, 10*time.Second, "dead")
+	if execution.RetryAttempt != 1 {
+		t.Errorf("Expected 1 retry after the requeue, got %d", execution.RetryAttempt)
+	}
+}
+
+func TestRetryAfterRestart(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerRetryProcesses()
+	atomic.StoreInt32(&flakyCalls, 2)
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Restart Retry Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.retry.flaky"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	executionID := onlyExecution(t, testJob).ExecutionID
+
+	// The node scheduled a retry and restarted, only the row of the retry is left
+	_, err = model.Select("__yao.job.execution").UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "execution_id", Value: executionID}},
+	}, map[string]interface{}{
+		"status":           "queued",
+		"retry_attempt":    1,
+		"scheduled_at":     time.Now().Add(-time.Second),
+		"node_id":          "restarted-node",
+		"lease_expires_at": time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Failed to update execution: %v", err)
+	}
+
+	// A retry is not claimed before its time
+	later := time.Now().Add(time.Hour)
+	other, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Later Retry Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := other.Add(job.NewExecutionOptions(), "test.job.retry.flaky"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	laterID := onlyExecution(t, other).ExecutionID
+	_, err = model.Select("__yao.job.execution").UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "execution_id", Value: laterID}},
+	}, map[string]interface{}{"status": "queued", "scheduled_at": later})
+	if err != nil {
+		t.Fatalf("Failed to update execution: %v", err)
+	}
+	if claimed, err := job.ClaimExecution(laterID, "node-c", time.Second); err != nil || claimed {
+		t.Errorf("Expected the retry not to be claimed before its time, got %v %v", claimed, err)
+	}
+
+	// Any node submits the due retry from the database
+	managers := startNodes("node-b")
+	defer managers[0].Stop()
+	if _, err := managers[0].SubmitDueExecutions(); err != nil {
+		t.Fatalf("Failed to submit the due executions: %v", err)
+	}
+
+	execution := waitExecution(t, executionID, 5*time.Second, "completed", "failed", "dead")
+	if execution.Status != "completed" || execution.RetryAttempt != 1 {
+		t.Errorf("Expected the retry to complete after the restart, got %s after %d retries", execution.Status, execution.RetryAttempt)
+	}
+
+	pending, err := job.GetExecution(laterID, model.QueryParam{})
+	if err != nil {
+		t.Fatalf("Failed to get execution: %v", err)
+	}
+	if pending.Status != "queued" {
+		t.Errorf("Expected the later retry to stay queued, got %s", pending.Status)
+	}
+}
@@ -0,0 +1,251 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// ErrJobStatus is returned when the status of a job does not allow the action
+var ErrJobStatus = errors.New("invalid job status")
+
+// schedulerInterval how often the scheduler checks the due cron jobs
+const schedulerInterval = 30 * time.Second
+
+// scheduledStatuses the statuses of the cron jobs the scheduler runs, the paused and disabled jobs are skipped
+var scheduledStatuses = []string{"ready", "completed", "failed"}
+
+// maxMissedRuns caps the missed runs counted for a due job
+const maxMissedRuns = 10000
+
+// globalScheduler the cron job scheduler
+var globalScheduler *Scheduler
+
+// Scheduler runs the cron jobs when they are due
+type Scheduler struct {
+	interval time.Duration
+	quit     chan bool
+}
+
+// ParseSchedule parses a cron expression: 5 fields (minute hour day month weekday),
+// a descriptor such as @daily or @every 1h, optionally prefixed with TZ=<zone>
+func ParseSchedule(expression string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule expression %q: %w", expression, err)
+	}
+	return schedule, nil
+}
+
+// NextRunTime returns the next run time of a cron expression after the given time
+func NextRunTime(expression string, from time.Time) (time.Time, error) {
+	schedule, err := ParseSchedule(expression)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(from), nil
+}
+
+// SetSchedule validates the cron expression, makes the job a cron job and computes its next run time
+func (j *Job) SetSchedule(expression string) error {
+	next, err := NextRunTime(expression, time.Now())
+	if err != nil {
+		return err
+	}
+
+	j.ScheduleType = string(ScheduleTypeCron)
+	j.ScheduleExpression = &expression
+	if j.Status != "paused" && j.Status != "disabled" {
+		j.NextRunAt = &next
+	}
+	return nil
+}
+
+// Pause pauses a job, the scheduler skips it and the running executions finish.
+// The next run time is computed again on resume.
+func (j *Job) Pause() error {
+	if j.Status == "paused" {
+		return nil
+	}
+	if j.Status == "disabled" {
+		return fmt.Errorf("%w: job %s is disabled, only ready jobs can be paused", ErrJobStatus, j.JobID)
+	}
+
+	j.Status = "paused"
+	if err := SaveJob(j); err != nil {
+		return fmt.Errorf("failed to pause job: %w", err)
+	}
+	log.Info("Job %s paused", j.JobID)
+	return nil
+}
+
+// Resume resumes a paused or stopped job, the next run time of a cron job is computed from now
+func (j *Job) Resume() error {
+	if j.Status != "paused" && j.Status != "disabled" {
+		return fmt.Errorf("%w: job %s is %s, only paused or disabled jobs can be resumed", ErrJobStatus, j.JobID, j.Status)
+	}
+
+	j.Status = "ready"
+	if j.ScheduleType == string(ScheduleTypeCron) && j.ScheduleExpression != nil {
+		next, err := NextRunTime(*j.ScheduleExpression, time.Now())
+		if err != nil {
+			return err
+		}
+		j.NextRunAt = &next
+	}
+	if err := SaveJob(j); err != nil {
+		return fmt.Errorf("failed to resume job: %w", err)
+	}
+	log.Info("Job %s resumed", j.JobID)
+	return nil
+}
+
+// Trigger runs the executions of a job now, outside of its schedule
+func (j *Job) Trigger() error {
+	if j.Status == "paused" || j.Status == "disabled" {
+		return fmt.Errorf("%w: job %s is %s, resume it before running it", ErrJobStatus, j.JobID, j.Status)
+	}
+	return j.Push()
+}
+
+// savedStatus returns the status to save for a job, a job paused or stopped meanwhile keeps its status
+func savedStatus(j *Job, status string) string {
+	current, err := GetJob(j.JobID)
+	if err == nil && (current.Status == "paused" || current.Status == "disabled") {
+		return current.Status
+	}
+	return status
+}
+
+// initScheduler initializes the cron job scheduler
+func initScheduler() {
+	globalScheduler = NewScheduler(schedulerInterval)
+	go globalScheduler.Start()
+	log.Info("Job scheduler started with %v interval", schedulerInterval)
+}
+
+// NewScheduler creates a cron job scheduler
+func NewScheduler(interval time.Duration) *Scheduler {
+	return &Scheduler{interval: interval, quit: make(chan bool)}
+}
+
+// Start runs the due cron jobs until the scheduler stops
+func (s *Scheduler) Start() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if count, err := RunDueJobs(); err != nil {
+				log.Error("Job scheduler failed: %v", err)
+			} else if count > 0 {
+				log.Info("Job scheduler ran %d due jobs", count)
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler
+func (s *Scheduler) Stop() {
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+}
+
+// RunDueJobs pushes the cron jobs whose next run time passed and moves their next run time forward.
+// The next run time is moved by a conditional update, only one node runs each due job.
+func RunDueJobs() (int, error) {
+	if !model.Exists("__yao.job") {
+		return 0, nil
+	}
+
+	mod := model.Select("__yao.job")
+	now := time.Now()
+	results, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"job_id", "schedule_expression", "next_run_at"},
+		Wheres: []model.QueryWhere{
+			{Column: "schedule_type", Value: string(ScheduleTypeCron)},
+			{Column: "enabled", Value: true},
+			{Column: "status", OP: "in", Value: scheduledStatuses},
+			{Column: "next_run_at", OP: "le", Value: now},
+		},
+		Limit: 100,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, result := range results {
+		due := &Job{}
+		if err := mapToStruct(result, due); err != nil {
+			log.Warn("Failed to parse due job data: %v", err)
+			continue
+		}
+		if due.ScheduleExpression == nil {
+			continue
+		}
+
+		jobID := due.JobID
+		schedule, err := ParseSchedule(*due.ScheduleExpression)
+		if err != nil {
+			log.Error("Job %s: %v", jobID, err)
+			continue
+		}
+		next := schedule.Next(now)
+
+		affected, err := mod.UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "job_id", Value: jobID},
+				{Column: "next_run_at", OP: "le", Value: now},
+			},
+			Limit: 1,
+		}, map[string]interface{}{"next_run_at": next})
+		if err != nil {
+			log.Error("Failed to schedule job %s: %v", jobID, err)
+			continue
+		}
+		if affected == 0 {
+			continue
+		}
+
+		job, err := GetJob(jobID)
+		if err != nil {
+			log.Error("Failed to get job %s: %v", jobID, err)
+			continue
+		}
+		// The runs due while the scheduler was late are not caught up, the job runs once
+		if missed := missedRuns(schedule, due.NextRunAt, now); missed > 0 {
+			log.Warn("Job %s missed %d scheduled runs", jobID, missed)
+			metrics.missed(job, missed)
+		}
+
+		if err := job.Push(); err != nil {
+			log.Error("Failed to run job %s: %v", jobID, err)
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// missedRuns counts the scheduled runs after the due run time and until now
+func missedRuns(schedule cron.Schedule, due *time.Time, now time.Time) int {
+	if due == nil {
+		return 0
+	}
+	missed := 0
+	for next := schedule.Next(*due); !next.After(now) && missed < maxMissedRuns; next = schedule.Next(next) {
+		missed++
+	}
+	return missed
+}
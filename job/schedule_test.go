@@ -0,0 +1,161 @@
+package job_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// registerScheduleProcesses registers the processes of the schedule tests
+func registerScheduleProcesses() {
+	process.Register("test.job.schedule.report", func(process *process.Process) interface{} {
+		return map[string]interface{}{"status": "success"}
+	})
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, expression := range []string{"0 0 * * *", "*/5 * * * *", "@daily", "@every 1h", "TZ=UTC 30 8 * * 1-5"} {
+		if _, err := job.ParseSchedule(expression); err != nil {
+			t.Errorf("Expected %q to be valid: %v", expression, err)
+		}
+	}
+	for _, expression := range []string{"", "every day", "61 * * * *", "* * * *"} {
+		if _, err := job.ParseSchedule(expression); err == nil {
+			t.Errorf("Expected %q to be invalid", expression)
+		}
+	}
+
+	from := time.Date(2025, 1, 1, 10, 30, 0, 0, time.Local)
+	next, err := job.NextRunTime("0 0 * * *", from)
+	if err != nil {
+		t.Fatalf("Failed to compute the next run time: %v", err)
+	}
+	if !next.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected the next midnight, got %v", next)
+	}
+
+	if _, err := job.Cron(job.GOROUTINE, map[string]interface{}{}, "not a cron"); err == nil {
+		t.Error("Expected an error for an invalid expression")
+	}
+}
+
+func TestPauseResumeJob(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerScheduleProcesses()
+
+	testJob, err := job.CronAndSave(job.GOROUTINE, map[string]interface{}{"name": "Nightly Report"}, "0 0 * * *")
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if testJob.NextRunAt == nil || !testJob.NextRunAt.After(time.Now()) {
+		t.Fatalf("Expected a next run time, got %v", testJob.NextRunAt)
+	}
+
+	if err := testJob.Pause(); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+	paused, err := job.GetJob(testJob.JobID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if paused.Status != "paused" {
+		t.Errorf("Expected a paused job, got %s", paused.Status)
+	}
+	if err := paused.Trigger(); err == nil {
+		t.Error("Expected an error when running a paused job")
+	}
+
+	if err := paused.Resume(); err != nil {
+		t.Fatalf("Failed to resume job: %v", err)
+	}
+	resumed, err := job.GetJob(testJob.JobID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if resumed.Status != "ready" || resumed.NextRunAt == nil {
+		t.Errorf("Expected a ready job with a next run, got %s %v", resumed.Status, resumed.NextRunAt)
+	}
+	if err := resumed.Resume(); err == nil {
+		t.Error("Expected an error when resuming a ready job")
+	}
+
+	// Editing the schedule computes the next run time
+	if err := resumed.SetSchedule("@every 1h"); err != nil {
+		t.Fatalf("Failed to set schedule: %v", err)
+	}
+	if delay := time.Until(*resumed.NextRunAt); delay < 59*time.Minute || delay > time.Hour {
+		t.Errorf("Expected the next run in an hour, got %v", delay)
+	}
+	if err := resumed.SetSchedule("every hour"); err == nil {
+		t.Error("Expected an error for an invalid expression")
+	}
+}
+
+func TestRunDueJobs(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerScheduleProcesses()
+
+	due := func(name string) *job.Job {
+		testJob, err := job.CronAndSave(job.GOROUTINE, map[string]interface{}{"name": name}, "*/5 * * * *")
+		if err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		if err := testJob.Add(job.NewExecutionOptions(), "test.job.schedule.report"); err != nil {
+			t.Fatalf("Failed to add execution: %v", err)
+		}
+		testJob.SetStatus("ready")
+		if err := job.SaveJob(testJob); err != nil {
+			t.Fatalf("Failed to save job: %v", err)
+		}
+
+		_, err = model.Select("__yao.job").UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{{Column: "job_id", Value: testJob.JobID}},
+		}, map[string]interface{}{"next_run_at": time.Now().Add(-time.Minute)})
+		if err != nil {
+			t.Fatalf("Failed to update job: %v", err)
+		}
+		return testJob
+	}
+
+	scheduled := due("Scheduled Report")
+	paused := due("Paused Report")
+	if err := paused.Pause(); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+
+	count, err := job.RunDueJobs()
+	if err != nil {
+		t.Fatalf("Failed to run due jobs: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected 1 due job, got %d", count)
+	}
+
+	waitExecution(t, onlyExecution(t, scheduled).ExecutionID, 5*time.Second, "completed")
+	if execution := onlyExecution(t, paused); execution.Status != "queued" {
+		t.Errorf("Expected the paused job not to run, got %s", execution.Status)
+	}
+
+	reloaded, err := job.GetJob(scheduled.JobID)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if reloaded.NextRunAt == nil || !reloaded.NextRunAt.After(time.Now()) {
+		t.Errorf("Expected the next run time to move forward, got %v", reloaded.NextRunAt)
+	}
+	if reloaded.Status != "ready" {
+		t.Errorf("Expected the cron job to be ready for the next run, got %s", reloaded.Status)
+	}
+
+	// The job is not due anymore
+	if count, _ := job.RunDueJobs(); count != 0 {
+		t.Errorf("Expected no due job, got %d", count)
+	}
+}
@@ -0,0 +1,215 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/messenger"
+	messengerTypes "github.com/yaoapp/yao/messenger/types"
+)
+
+// SLA breach types
+const (
+	BreachMaxDuration         = "max_duration"         // An execution runs longer than max_duration
+	BreachConsecutiveFailures = "consecutive_failures" // max_consecutive_failures executions failed in a row
+)
+
+// alertTimeout the timeout of the alert process and message
+const alertTimeout = 30 * time.Second
+
+// Alert is sent when the SLA of a job is breached
+type Alert struct {
+	Type        string    `json:"type"`
+	JobID       string    `json:"job_id"`
+	JobName     string    `json:"job_name"`
+	ExecutionID string    `json:"execution_id,omitempty"`
+	Message     string    `json:"message"`
+	Value       int       `json:"value"`     // Seconds run, or failures in a row
+	Threshold   int       `json:"threshold"` // The SLA limit
+	Time        time.Time `json:"time"`
+}
+
+// Validate checks the SLA limits and its alert targets
+func (s *SLA) Validate() error {
+	if s.MaxDuration < 0 || s.MaxConsecutiveFailures < 0 {
+		return fmt.Errorf("sla limits must not be negative")
+	}
+	if s.Process == "" && s.Channel == "" {
+		return fmt.Errorf("sla requires a process or a channel to send the alerts to")
+	}
+	return nil
+}
+
+// watchDuration alerts when the execution runs longer than the max duration of the SLA, the returned function stops watching
+func (j *Job) watchDuration(execution *Execution) func() {
+	sla := j.SLA
+	if sla == nil || sla.MaxDuration <= 0 {
+		return func() {}
+	}
+
+	timer := time.AfterFunc(time.Duration(sla.MaxDuration)*time.Second, func() {
+		j.alert(&Alert{
+			Type:        BreachMaxDuration,
+			ExecutionID: execution.ExecutionID,
+			Message:     fmt.Sprintf("Execution %s of job %s is running for more than %ds", execution.ExecutionID, j.Name, sla.MaxDuration),
+			Value:       sla.MaxDuration,
+			Threshold:   sla.MaxDuration,
+		})
+	})
+	return func() { timer.Stop() }
+}
+
+// maxStreakAttempts the conditional updates tried before giving up recording a failure in a row
+const maxStreakAttempts = 10
+
+// checkFailures records the outcome of a finished execution in the failures in a row of the job,
+// and alerts when they reach the max consecutive failures of the SLA, then again every max consecutive failures.
+// The streak is saved on the job row, shared by the nodes and kept on restart. The alert is sent in the background.
+func (j *Job) checkFailures(execution *Execution) {
+	failures, err := recordFailure(j.JobID, execution.Status != "completed")
+	if err != nil {
+		log.Warn("Failed to record the failures in a row of job %s: %v", j.JobID, err)
+		return
+	}
+	metrics.streak(j, failures)
+
+	sla := j.SLA
+	if sla == nil || sla.MaxConsecutiveFailures <= 0 || failures < sla.MaxConsecutiveFailures || failures%sla.MaxConsecutiveFailures != 0 {
+		return
+	}
+
+	go j.alert(&Alert{
+		Type:        BreachConsecutiveFailures,
+		ExecutionID: execution.ExecutionID,
+		Message:     fmt.Sprintf("Job %s failed %d times in a row", j.Name, failures),
+		Value:       failures,
+		Threshold:   sla.MaxConsecutiveFailures,
+	})
+}
+
+// recordFailure updates the consecutive_failures of the job row and returns it: a failure increments it
+// with a conditional update, so the concurrent executions of several nodes count once each, a success resets it.
+func recordFailure(jobID string, failed bool) (int, error) {
+	mod := model.Select("__yao.job")
+	if mod == nil {
+		return 0, fmt.Errorf("job model not found")
+	}
+
+	if !failed {
+		_, err := mod.UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{{Column: "job_id", Value: jobID}},
+			Limit:  1,
+		}, map[string]interface{}{"consecutive_failures": 0})
+		return 0, err
+	}
+
+	for attempt := 0; attempt < maxStreakAttempts; attempt++ {
+		rows, err := mod.Get(model.QueryParam{
+			Select: []interface{}{"consecutive_failures"},
+			Wheres: []model.QueryWhere{{Column: "job_id", Value: jobID}},
+			Limit:  1,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if len(rows) == 0 {
+			return 0, fmt.Errorf("job not found: %s", jobID)
+		}
+
+		failures := 0
+		switch v := rows[0]["consecutive_failures"].(type) {
+		case int:
+			failures = v
+		case int64:
+			failures = int(v)
+		case float64:
+			failures = int(v)
+		}
+
+		affected, err := mod.UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{
+				{Column: "job_id", Value: jobID},
+				{Column: "consecutive_failures", Value: failures},
+			},
+			Limit: 1,
+		}, map[string]interface{}{"consecutive_failures": failures + 1})
+		if err != nil {
+			return 0, err
+		}
+		if affected > 0 {
+			return failures + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("the failures in a row changed %d times while recording them", maxStreakAttempts)
+}
+
+// alert records the SLA breach and sends the alert to the process and the messenger channel of the SLA
+func (j *Job) alert(alert *Alert) {
+	alert.JobID = j.JobID
+	alert.JobName = j.Name
+	alert.Time = time.Now()
+
+	metrics.breached(j, alert.Type)
+	log.Warn("Job %s SLA breached: %s", j.JobID, alert.Message)
+
+	logEntry := &Log{
+		JobID:     j.JobID,
+		Level:     "warning",
+		Message:   "SLA breached: " + alert.Message,
+		Source:    stringPtr("sla"),
+		Timestamp: alert.Time,
+	}
+	if alert.ExecutionID != "" {
+		logEntry.ExecutionID = &alert.ExecutionID
+	}
+	if err := SaveLog(logEntry); err != nil {
+		log.Warn("Failed to save SLA log (database may be closed): %v", err)
+	}
+
+	sla := j.SLA
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+
+	if sla.Process != "" {
+		proc := process.NewWithContext(ctx, sla.Process, structToMap(alert))
+		err := proc.Execute()
+		proc.Release()
+		if err != nil {
+			log.Error("Job %s SLA alert process %s failed: %v", j.JobID, sla.Process, err)
+		}
+	}
+
+	if sla.Channel != "" {
+		if err := sendAlertMessage(ctx, sla, alert); err != nil {
+			log.Error("Job %s SLA alert to channel %s failed: %v", j.JobID, sla.Channel, err)
+		}
+	}
+}
+
+// sendAlertMessage sends the alert to the messenger channel of the SLA
+func sendAlertMessage(ctx context.Context, sla *SLA, alert *Alert) error {
+	if messenger.Instance == nil {
+		return fmt.Errorf("messenger is not loaded")
+	}
+
+	messageType := messengerTypes.MessageType(sla.MessageType)
+	if messageType == "" {
+		messageType = messengerTypes.MessageTypeChat
+		if len(sla.To) > 0 {
+			messageType = messengerTypes.MessageTypeEmail
+		}
+	}
+
+	_, err := messenger.Instance.Send(ctx, sla.Channel, &messengerTypes.Message{
+		Type:     messageType,
+		To:       sla.To,
+		Subject:  fmt.Sprintf("[SLA] %s: %s", alert.JobName, alert.Type),
+		Body:     alert.Message,
+		Metadata: structToMap(alert),
+	})
+	return err
+}
@@ -0,0 +1,290 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+)
+
+// Stream event types
+const (
+	EventLog      = "log"      // A log line, the event ID is the log ID
+	EventProgress = "progress" // The status or the progress of an execution changed
+	EventEnd      = "end"      // The execution finished, the stream is closed
+	EventPing     = "ping"     // Keeps the connection alive
+)
+
+// Default stream intervals
+const (
+	defaultStreamPoll      = 2 * time.Second
+	defaultStreamHeartbeat = 15 * time.Second
+	streamBatchSize        = 500
+)
+
+// Event a stream event of a job or an execution
+type Event struct {
+	ID          string      `json:"id,omitempty"` // Log ID, resume the stream from it
+	Type        string      `json:"type"`
+	JobID       string      `json:"job_id"`
+	ExecutionID string      `json:"execution_id,omitempty"`
+	Data        interface{} `json:"data,omitempty"`
+}
+
+// ExecutionState the status and the progress of an execution
+type ExecutionState struct {
+	Status   string `json:"status"`
+	Progress int    `json:"progress"`
+}
+
+// StreamOptions the options of a stream
+type StreamOptions struct {
+	JobID        string        // Required unless ExecutionID is set
+	ExecutionID  string        // Streams a single execution, the stream ends when it finishes
+	LastEventID  string        // Replays the logs after this event ID
+	PollInterval time.Duration // Polls the database for the changes of the other nodes, default: 2s
+	Heartbeat    time.Duration // Ping interval, default: 15s
+}
+
+// streamHub wakes up the streams when the logs or the executions are saved
+type streamHub struct {
+	subscribers map[*streamSubscriber]struct{}
+	mu          sync.RWMutex
+}
+
+// streamSubscriber a stream waiting for the changes of a job or an execution
+type streamSubscriber struct {
+	jobID       string
+	executionID string
+	wake        chan struct{}
+}
+
+// streams the global stream hub
+var streams = &streamHub{subscribers: map[*streamSubscriber]struct{}{}}
+
+// subscribe registers a stream
+func (h *streamHub) subscribe(jobID, executionID string) *streamSubscriber {
+	sub := &streamSubscriber{jobID: jobID, executionID: executionID, wake: make(chan struct{}, 1)}
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// unsubscribe removes a stream
+func (h *streamHub) unsubscribe(sub *streamSubscriber) {
+	h.mu.Lock()
+	delete(h.subscribers, sub)
+	h.mu.Unlock()
+}
+
+// notify wakes up the streams of a job or an execution, the wake-ups are coalesced
+func (h *streamHub) notify(jobID, executionID string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if sub.jobID != jobID {
+			continue
+		}
+		if sub.executionID != "" && executionID != "" && sub.executionID != executionID {
+			continue
+		}
+		select {
+		case sub.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Stream sends the logs and the progress changes of a job or an execution until the context is done.
+// The logs after options.LastEventID are replayed first, an execution stream ends with an EventEnd
+// once the execution finished. The changes saved by other nodes are picked up by polling.
+func Stream(ctx context.Context, options StreamOptions, send func(*Event) error) error {
+	if options.ExecutionID == "" && options.JobID == "" {
+		return fmt.Errorf("job_id or execution_id is required")
+	}
+	if options.JobID == "" {
+		execution, err := GetExecution(options.ExecutionID, model.QueryParam{Select: []interface{}{"execution_id", "job_id"}})
+		if err != nil {
+			return err
+		}
+		options.JobID = execution.JobID
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultStreamPoll
+	}
+	if options.Heartbeat <= 0 {
+		options.Heartbeat = defaultStreamHeartbeat
+	}
+
+	// Subscribe before the replay, the changes saved meanwhile wake the stream up
+	sub := streams.subscribe(options.JobID, options.ExecutionID)
+	defer streams.unsubscribe(sub)
+
+	s := &stream{options: options, send: send, states: map[string]ExecutionState{}}
+	if options.LastEventID != "" {
+		lastID, err := strconv.ParseUint(options.LastEventID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid last event ID %q", options.LastEventID)
+		}
+		s.lastID = uint(lastID)
+	}
+
+	poll := time.NewTicker(options.PollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(options.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		finished, err := s.flush()
+		if err != nil || finished {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.wake:
+		case <-poll.C:
+		case <-heartbeat.C:
+			if err := send(&Event{Type: EventPing, JobID: options.JobID, ExecutionID: options.ExecutionID}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// stream the state of a running stream
+type stream struct {
+	options StreamOptions
+	send    func(*Event) error
+	lastID  uint
+	states  map[string]ExecutionState
+	ending  time.Time // When the execution was first seen finished
+}
+
+// flush sends the new logs and the changed states, it returns true when the execution stream ended
+func (s *stream) flush() (bool, error) {
+	if err := s.flushLogs(); err != nil {
+		return false, err
+	}
+	return s.flushStates()
+}
+
+// flushLogs sends the logs saved after the last sent log, in order
+func (s *stream) flushLogs() error {
+	mod := model.Select("__yao.job.log")
+	for {
+		param := model.QueryParam{
+			Select: LogFields,
+			Wheres: []model.QueryWhere{
+				{Column: "job_id", Value: s.options.JobID},
+				{Column: "id", OP: "gt", Value: s.lastID},
+			},
+			Orders: []model.QueryOrder{{Column: "id", Option: "asc"}},
+			Limit:  streamBatchSize,
+		}
+		if s.options.ExecutionID != "" {
+			param.Wheres = append(param.Wheres, model.QueryWhere{Column: "execution_id", Value: s.options.ExecutionID})
+		}
+
+		results, err := mod.Get(param)
+		if err != nil {
+			return fmt.Errorf("failed to get logs: %w", err)
+		}
+
+		for _, result := range results {
+			entry := &Log{}
+			if err := mapToStruct(result, entry); err != nil {
+				continue
+			}
+			executionID := ""
+			if entry.ExecutionID != nil {
+				executionID = *entry.ExecutionID
+			}
+			event := &Event{ID: strconv.FormatUint(uint64(entry.ID), 10), Type: EventLog, JobID: entry.JobID, ExecutionID: executionID, Data: entry}
+			if err := s.send(event); err != nil {
+				return err
+			}
+			s.lastID = entry.ID
+		}
+
+		if len(results) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+// flushStates sends the executions whose status or progress changed
+func (s *stream) flushStates() (bool, error) {
+	param := model.QueryParam{
+		Select: []interface{}{"execution_id", "job_id", "status", "progress"},
+		Wheres: []model.QueryWhere{{Column: "job_id", Value: s.options.JobID}},
+	}
+	if s.options.ExecutionID != "" {
+		param.Wheres = append(param.Wheres, model.QueryWhere{Column: "execution_id", Value: s.options.ExecutionID})
+	} else {
+		// The active executions, and the known ones to send their final status
+		known := []string{}
+		for executionID := range s.states {
+			known = append(known, executionID)
+		}
+		where := model.QueryWhere{Wheres: []model.QueryWhere{
+			{Column: "status", OP: "in", Value: []string{"queued", "waiting", "running"}},
+		}}
+		if len(known) > 0 {
+			where.Wheres = append(where.Wheres, model.QueryWhere{Column: "execution_id", OP: "in", Value: known, Method: "orwhere"})
+		}
+		param.Wheres = append(param.Wheres, where)
+	}
+
+	results, err := model.Select("__yao.job.execution").Get(param)
+	if err != nil {
+		return false, fmt.Errorf("failed to get executions: %w", err)
+	}
+
+	for _, result := range results {
+		execution := &Execution{}
+		if err := mapToStruct(result, execution); err != nil {
+			continue
+		}
+		state := ExecutionState{Status: execution.Status, Progress: execution.Progress}
+		if previous, has := s.states[execution.ExecutionID]; !has || previous != state {
+			event := &Event{Type: EventProgress, JobID: execution.JobID, ExecutionID: execution.ExecutionID, Data: state}
+			if err := s.send(event); err != nil {
+				return false, err
+			}
+		}
+
+		if !finishedStatuses[state.Status] {
+			s.states[execution.ExecutionID] = state
+			if execution.ExecutionID == s.options.ExecutionID {
+				s.ending = time.Time{}
+			}
+			continue
+		}
+
+		if s.options.ExecutionID == "" {
+			delete(s.states, execution.ExecutionID)
+			continue
+		}
+
+		// A failed execution may be retried, the stream ends when it is still finished at the next poll
+		s.states[execution.ExecutionID] = state
+		if s.ending.IsZero() {
+			s.ending = time.Now()
+		}
+		if time.Since(s.ending) < s.options.PollInterval/2 {
+			continue
+		}
+		if err := s.flushLogs(); err != nil {
+			return false, err
+		}
+		return true, s.send(&Event{Type: EventEnd, JobID: execution.JobID, ExecutionID: execution.ExecutionID, Data: state})
+	}
+	return false, nil
+}
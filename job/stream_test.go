@@ -0,0 +1,156 @@
+package job_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+// registerStreamProcesses registers the processes of the stream tests
+func registerStreamProcesses() {
+	process.Register("test.job.stream.import", func(process *process.Process) interface{} {
+		time.Sleep(300 * time.Millisecond)
+		return map[string]interface{}{"imported": 3}
+	})
+}
+
+// collect streams the events until the stream ends or the timeout
+func collect(t *testing.T, options job.StreamOptions, timeout time.Duration) []*job.Event {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	events := []*job.Event{}
+	err := job.Stream(ctx, options, func(event *job.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to stream: %v", err)
+	}
+	return events
+}
+
+func TestStreamExecution(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerStreamProcesses()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Stream Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	if err := testJob.Add(job.NewExecutionOptions(), "test.job.stream.import"); err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	execution := onlyExecution(t, testJob)
+	execution.Info("Import started")
+
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	options := job.StreamOptions{ExecutionID: execution.ExecutionID, PollInterval: 200 * time.Millisecond}
+	events := collect(t, options, 10*time.Second)
+
+	logs := []*job.Event{}
+	statuses := map[string]bool{}
+	for _, event := range events {
+		switch event.Type {
+		case job.EventLog:
+			logs = append(logs, event)
+		case job.EventProgress:
+			statuses[event.Data.(job.ExecutionState).Status] = true
+		}
+	}
+
+	if len(events) == 0 || events[len(events)-1].Type != job.EventEnd {
+		t.Fatalf("Expected the stream to end, got %d events", len(events))
+	}
+	if state := events[len(events)-1].Data.(job.ExecutionState); state.Status != "completed" {
+		t.Errorf("Expected the execution to complete, got %s", state.Status)
+	}
+	if !statuses["completed"] {
+		t.Errorf("Expected a completed progress event, got %v", statuses)
+	}
+	if len(logs) < 2 {
+		t.Fatalf("Expected the logs of the execution, got %d", len(logs))
+	}
+
+	// The log IDs are increasing, the stream resumes after the last event ID
+	for i := 1; i < len(logs); i++ {
+		previous, _ := strconv.Atoi(logs[i-1].ID)
+		current, _ := strconv.Atoi(logs[i].ID)
+		if current <= previous {
+			t.Errorf("Expected increasing log IDs, got %s after %s", logs[i].ID, logs[i-1].ID)
+		}
+	}
+
+	options.LastEventID = logs[0].ID
+	resumed := collect(t, options, 5*time.Second)
+	replayed := 0
+	for _, event := range resumed {
+		if event.Type == job.EventLog {
+			if event.ID == logs[0].ID {
+				t.Errorf("Expected the logs after event %s only", logs[0].ID)
+			}
+			replayed++
+		}
+	}
+	if replayed != len(logs)-1 {
+		t.Errorf("Expected %d replayed logs, got %d", len(logs)-1, replayed)
+	}
+
+	options.LastEventID = "invalid"
+	if err := job.Stream(context.Background(), options, func(*job.Event) error { return nil }); err == nil {
+		t.Error("Expected an error for an invalid last event ID")
+	}
+}
+
+func TestStreamJob(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	registerStreamProcesses()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Stream Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := testJob.Add(job.NewExecutionOptions(), "test.job.stream.import"); err != nil {
+			t.Fatalf("Failed to add execution: %v", err)
+		}
+	}
+
+	// The job stream stays open until the client leaves
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	completed := map[string]bool{}
+	done := make(chan error, 1)
+	go func() {
+		done <- job.Stream(ctx, job.StreamOptions{JobID: testJob.JobID, PollInterval: 200 * time.Millisecond}, func(event *job.Event) error {
+			if event.Type == job.EventProgress && event.Data.(job.ExecutionState).Status == "completed" {
+				completed[event.ExecutionID] = true
+			}
+			return nil
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Failed to stream: %v", err)
+	}
+	if len(completed) != 2 {
+		t.Errorf("Expected 2 completed executions, got %d", len(completed))
+	}
+}
@@ -61,10 +61,72 @@ const (
 	ExecutionTypeCommand ExecutionType = "command" // System command
 )
 
+// DependencyCondition the condition of a dependency edge
+type DependencyCondition string
+
+// DependencyCondition constants
+const (
+	OnSuccess DependencyCondition = "on_success" // Run when the upstream completed (default)
+	OnFailure DependencyCondition = "on_failure" // Run when the upstream failed, was cancelled or timed out
+	Always    DependencyCondition = "always"     // Run when the upstream finished, whatever the outcome
+)
+
+// Dependency is an edge of the dependency graph, from an upstream execution or job to the declaring one
+type Dependency struct {
+	Execution  string              `json:"execution,omitempty"`   // Upstream execution key or ID (executions of the same job)
+	JobID      string              `json:"job_id,omitempty"`      // Upstream job ID (job dependencies)
+	Condition  DependencyCondition `json:"condition,omitempty"`   // default: on_success
+	PassResult bool                `json:"pass_result,omitempty"` // Append the upstream result to the arguments
+}
+
+// BackoffType the retry backoff strategy
+type BackoffType string
+
+// BackoffType constants
+const (
+	BackoffFixed       BackoffType = "fixed"       // Wait the same delay before each retry
+	BackoffExponential BackoffType = "exponential" // Double the delay after each retry (default)
+)
+
+// RetryPolicy holds the retry policy of a job or an execution
+type RetryPolicy struct {
+	MaxAttempts int         `json:"max_attempts"`        // Retries after the first attempt, 0 disables the retries
+	Backoff     BackoffType `json:"backoff,omitempty"`   // default: exponential
+	Delay       int         `json:"delay,omitempty"`     // Delay before the first retry in seconds, default: 1
+	MaxDelay    int         `json:"max_delay,omitempty"` // Max delay in seconds, default: 300
+	Jitter      float64     `json:"jitter,omitempty"`    // Random delay variation ratio (0-1), e.g. 0.2 = ±20%
+	RetryOn     []string    `json:"retry_on,omitempty"`  // Error patterns (regexp) to retry, empty retries all errors
+}
+
+// SLA holds the service level of a job, an alert is sent when it is breached
+type SLA struct {
+	MaxDuration            int      `json:"max_duration,omitempty"`             // Max duration of an execution in seconds, 0 disables the check
+	MaxConsecutiveFailures int      `json:"max_consecutive_failures,omitempty"` // Max failed executions in a row, 0 disables the check
+	Process                string   `json:"process,omitempty"`                  // Yao process called with the alert
+	Channel                string   `json:"channel,omitempty"`                  // Messenger channel the alert is sent to
+	MessageType            string   `json:"message_type,omitempty"`             // default: "email" with recipients, "chat" without
+	To                     []string `json:"to,omitempty"`                       // Recipients of the alert message
+}
+
+// ResourceLimits holds the resource limits of an execution.
+// The CPU, memory and open files limits apply to the child processes (process mode and system commands).
+type ResourceLimits struct {
+	CPUTime   int    `json:"cpu_time,omitempty"`   // Max CPU time in seconds
+	Memory    int    `json:"memory,omitempty"`     // Max data segment (heap and anonymous mappings) in MB
+	OpenFiles int    `json:"open_files,omitempty"` // Max open file descriptors
+	WallClock int    `json:"wall_clock,omitempty"` // Max elapsed time in seconds
+	WorkDir   string `json:"work_dir,omitempty"`   // Working directory, relative to <data_root>/jobs
+}
+
 // ExecutionOptions holds common execution options
 type ExecutionOptions struct {
-	Priority   int                    `json:"priority"`    // Execution priority (higher = more important)
-	SharedData map[string]interface{} `json:"shared_data"` // Shared data (session, context, etc.)
+	Priority       int                    `json:"priority"`                  // Execution priority (higher = more important), the job priority is used if 0
+	SharedData     map[string]interface{} `json:"shared_data"`               // Shared data (session, context, etc.)
+	Key            string                 `json:"key,omitempty"`             // Execution key, referenced by the dependencies
+	DependsOn      []Dependency           `json:"depends_on,omitempty"`      // Upstream executions of the same job
+	RetryPolicy    *RetryPolicy           `json:"retry_policy,omitempty"`    // Overrides the retry policy of the job
+	ConcurrencyKey string                 `json:"concurrency_key,omitempty"` // Executions sharing the key run one at a time, e.g. "tenant:42"
+	Limits         *ResourceLimits        `json:"limits,omitempty"`          // Resource limits and working directory
 }
 
 // NewExecutionOptions creates a new ExecutionOptions with default values
@@ -96,6 +158,36 @@ func (o *ExecutionOptions) AddSharedData(key string, value interface{}) *Executi
 	return o
 }
 
+// WithConcurrencyKey sets the concurrency key and returns the options for chaining
+func (o *ExecutionOptions) WithConcurrencyKey(key string) *ExecutionOptions {
+	o.ConcurrencyKey = key
+	return o
+}
+
+// WithLimits sets the resource limits and returns the options for chaining
+func (o *ExecutionOptions) WithLimits(limits *ResourceLimits) *ExecutionOptions {
+	o.Limits = limits
+	return o
+}
+
+// WithKey sets the execution key and returns the options for chaining
+func (o *ExecutionOptions) WithKey(key string) *ExecutionOptions {
+	o.Key = key
+	return o
+}
+
+// WithDependency adds an upstream execution and returns the options for chaining
+func (o *ExecutionOptions) WithDependency(execution string, condition DependencyCondition, passResult bool) *ExecutionOptions {
+	o.DependsOn = append(o.DependsOn, Dependency{Execution: execution, Condition: condition, PassResult: passResult})
+	return o
+}
+
+// WithRetryPolicy sets the retry policy and returns the options for chaining
+func (o *ExecutionOptions) WithRetryPolicy(policy *RetryPolicy) *ExecutionOptions {
+	o.RetryPolicy = policy
+	return o
+}
+
 // ExecutionConfig holds execution configuration based on type
 type ExecutionConfig struct {
 	Type        ExecutionType     `json:"type"`
@@ -128,6 +220,9 @@ type Job struct {
 	LastRunAt          *time.Time             `json:"last_run_at,omitempty"`          // nullable: true
 	CurrentExecutionID *string                `json:"current_execution_id,omitempty"` // nullable: true
 	Config             map[string]interface{} `json:"config,omitempty"`               // nullable: true
+	DependsOn          []Dependency           `json:"depends_on,omitempty"`           // nullable: true
+	RetryPolicy        *RetryPolicy           `json:"retry_policy,omitempty"`         // nullable: true, max_retry_count is used if not set
+	SLA                *SLA                   `json:"sla,omitempty"`                  // nullable: true
 	Sort               int                    `json:"sort"`                           // default: 0
 	Enabled            bool                   `json:"enabled"`                        // default: true
 	System             bool                   `json:"system"`                         // default: false
@@ -146,21 +241,26 @@ type Job struct {
 	// Job-level cancellation for running executions
 	executionContexts map[string]context.CancelFunc // executionID -> cancel function
 	executionMutex    sync.RWMutex
+
+	// Dependency graph of the pushed executions
+	graph        *executionGraph
+	upstreamArgs []interface{} // Results of the upstream jobs, appended to the root executions
 }
 
 // Category represents job categories for organization
 type Category struct {
-	ID          uint      `json:"id"`
-	CategoryID  string    `json:"category_id"`
-	Name        string    `json:"name"`
-	Icon        *string   `json:"icon,omitempty"`        // nullable: true
-	Description *string   `json:"description,omitempty"` // nullable: true
-	Sort        int       `json:"sort"`                  // default: 0
-	System      bool      `json:"system"`                // default: false
-	Enabled     bool      `json:"enabled"`               // default: true
-	Readonly    bool      `json:"readonly"`              // default: false
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             uint      `json:"id"`
+	CategoryID     string    `json:"category_id"`
+	Name           string    `json:"name"`
+	Icon           *string   `json:"icon,omitempty"`        // nullable: true
+	Description    *string   `json:"description,omitempty"` // nullable: true
+	Sort           int       `json:"sort"`                  // default: 0
+	MaxConcurrency int       `json:"max_concurrency"`       // default: 0, unlimited
+	System         bool      `json:"system"`                // default: false
+	Enabled        bool      `json:"enabled"`               // default: true
+	Readonly       bool      `json:"readonly"`              // default: false
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 
 	// Relationships
 	Jobs []Job `json:"jobs,omitempty"`
@@ -177,6 +277,9 @@ type Execution struct {
 	TriggerContext    *json.RawMessage  `json:"trigger_context,omitempty"`     // nullable: true
 	ScheduledAt       *time.Time        `json:"scheduled_at,omitempty"`        // nullable: true
 	WorkerID          *string           `json:"worker_id,omitempty"`           // nullable: true
+	NodeID            *string           `json:"node_id,omitempty"`             // nullable: true, node holding the lease
+	LeaseExpiresAt    *time.Time        `json:"lease_expires_at,omitempty"`    // nullable: true
+	HeartbeatAt       *time.Time        `json:"heartbeat_at,omitempty"`        // nullable: true
 	ProcessID         *string           `json:"process_id,omitempty"`          // nullable: true
 	RetryAttempt      int               `json:"retry_attempt"`                 // default: 0
 	ParentExecutionID *string           `json:"parent_execution_id,omitempty"` // nullable: true
@@ -201,6 +304,10 @@ type Execution struct {
 	ParentExecution *Execution  `json:"parent_execution,omitempty"`
 	ChildExecutions []Execution `json:"child_executions,omitempty"`
 	Logs            []Log       `json:"logs,omitempty"`
+
+	// Results of the upstream executions or jobs of the current run, appended to the arguments when
+	// the execution runs, the stored config is never extended
+	upstreamArgs []interface{}
 }
 
 // Log represents job execution logs and events
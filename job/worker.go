@@ -3,6 +3,7 @@ package job
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -17,10 +18,12 @@ import (
 type WorkerManager struct {
 	maxWorkers    int
 	activeWorkers map[string]*Worker
-	workQueue     chan *WorkRequest
+	queue         *workQueue
 	workerPool    chan chan *WorkRequest
 	quit          chan bool
 	mu            sync.RWMutex
+	nodeID        string
+	leaseTTL      time.Duration
 }
 
 // Worker represents a single worker instance
@@ -32,6 +35,7 @@ type Worker struct {
 	Mode       ModeType
 	ctx        context.Context
 	cancel     context.CancelFunc
+	manager    *WorkerManager
 }
 
 // WorkRequest represents a job execution request
@@ -39,6 +43,7 @@ type WorkRequest struct {
 	Job       *Job
 	Execution *Execution
 	Context   context.Context
+	queuedAt  time.Time // Time the request was pushed to the work queue
 }
 
 // Global worker manager instance
@@ -78,9 +83,11 @@ func NewWorkerManager(maxWorkers int) *WorkerManager {
 	return &WorkerManager{
 		maxWorkers:    maxWorkers,
 		activeWorkers: make(map[string]*Worker),
-		workQueue:     make(chan *WorkRequest, maxWorkers*4), // Allow 200% overload (4x buffer)
+		queue:         newWorkQueue(maxWorkers * 4), // Allow 200% overload (4x buffer)
 		workerPool:    make(chan chan *WorkRequest, maxWorkers),
 		quit:          make(chan bool),
+		nodeID:        NodeID(),
+		leaseTTL:      defaultLeaseTTL,
 	}
 }
 
@@ -89,6 +96,7 @@ func (wm *WorkerManager) Start() {
 	// Start workers
 	for i := 0; i < wm.maxWorkers; i++ {
 		worker := NewWorker(wm.workerPool, GOROUTINE)
+		worker.manager = wm
 		worker.Start()
 
 		wm.mu.Lock()
@@ -98,7 +106,10 @@ func (wm *WorkerManager) Start() {
 
 	// Start dispatcher
 	go wm.dispatch()
-	log.Info("Worker manager started with %d workers", wm.maxWorkers)
+
+	// Take over the executions of the nodes that stopped renewing their leases
+	go wm.superviseLeases()
+	log.Info("Worker manager started with %d workers on node %s", wm.maxWorkers, wm.nodeID)
 }
 
 // Stop stops the worker manager
@@ -136,16 +147,6 @@ func (wm *WorkerManager) Stop() {
 
 // SubmitJob submits a job execution for processing with context (non-blocking)
 func (wm *WorkerManager) SubmitJob(ctx context.Context, job *Job, execution *Execution) error {
-	// Check queue capacity before submitting
-	queueLen := len(wm.workQueue)
-	queueCap := cap(wm.workQueue)
-
-	// Allow reasonable backlog but prevent unlimited accumulation
-	// Reject only when queue is completely full to maximize throughput
-	if queueLen >= queueCap {
-		return fmt.Errorf("work queue is full (%d/%d), please retry later", queueLen, queueCap)
-	}
-
 	// Create work request
 	workRequest := &WorkRequest{
 		Job:       job,
@@ -153,32 +154,29 @@ func (wm *WorkerManager) SubmitJob(ctx context.Context, job *Job, execution *Exe
 		Context:   ctx,
 	}
 
-	// Submit asynchronously to avoid blocking
-	go func() {
-		select {
-		case wm.workQueue <- workRequest:
-			log.Debug("Job %s execution %s submitted to work queue", job.JobID, execution.ExecutionID)
-		case <-ctx.Done():
-			log.Warn("Job %s execution %s submission cancelled", job.JobID, execution.ExecutionID)
-		}
-	}()
+	// Allow reasonable backlog but prevent unlimited accumulation
+	// Reject only when queue is completely full to maximize throughput
+	if err := wm.queue.push(workRequest); err != nil {
+		return err
+	}
 
+	log.Debug("Job %s execution %s submitted to work queue", job.JobID, execution.ExecutionID)
 	return nil
 }
 
-// dispatch dispatches work requests to available workers
+// dispatch dispatches work requests to available workers, by priority
 func (wm *WorkerManager) dispatch() {
 	for {
 		select {
-		case work := <-wm.workQueue:
-			// Get an available worker
-			select {
-			case jobChannel := <-wm.workerPool:
-				// Send work to worker
-				jobChannel <- work
-			case <-wm.quit:
+		case jobChannel := <-wm.workerPool:
+			// Wait for the first runnable work request
+			work := wm.queue.next(wm.quit)
+			if work == nil {
 				return
 			}
+
+			// Send work to worker
+			jobChannel <- work
 		case <-wm.quit:
 			return
 		}
@@ -192,9 +190,9 @@ func (wm *WorkerManager) GetActiveWorkers() int {
 	return len(wm.activeWorkers)
 }
 
-// GetQueueStatus returns queue length and capacity for monitoring
-func (wm *WorkerManager) GetQueueStatus() (length int, capacity int) {
-	return len(wm.workQueue), cap(wm.workQueue)
+// GetQueueStatus returns the queue length, capacity and breakdown by priority and category for monitoring
+func (wm *WorkerManager) GetQueueStatus() QueueStatus {
+	return wm.queue.status()
 }
 
 // NewWorker creates a new worker
@@ -246,6 +244,39 @@ func (w *Worker) Stop() {
 func (w *Worker) processWork(work *WorkRequest) {
 	log.Debug("Worker %s processing job %s", w.ID, work.Job.JobID)
 
+	// Release the concurrency key and the category slot
+	if w.manager != nil {
+		defer w.manager.queue.done(work)
+	}
+
+	// Claim the execution lease, another node may be running the execution
+	ctx, loseLease := context.WithCancel(work.Context)
+	defer loseLease()
+
+	var lease *leaseHolder
+	if w.manager != nil {
+		var claimed bool
+		lease, claimed = w.manager.claim(work, loseLease)
+		if !claimed {
+			log.Debug("Execution %s is claimed by another node or waits for another node", work.Execution.ExecutionID)
+			if work.Job.executionContexts != nil {
+				work.Job.executionMutex.Lock()
+				delete(work.Job.executionContexts, work.Execution.ExecutionID)
+				work.Job.executionMutex.Unlock()
+			}
+			return
+		}
+	}
+
+	if !work.queuedAt.IsZero() {
+		metrics.started(work.Job, time.Since(work.queuedAt))
+	}
+
+	// The upstream results of an execution submitted without the graph of its run are read from the rows
+	if work.Execution.upstreamArgs == nil {
+		work.Execution.upstreamArgs = loadUpstreamArgs(work.Execution)
+	}
+
 	// Update execution status
 	work.Execution.Status = "running"
 	work.Execution.WorkerID = &w.ID
@@ -257,8 +288,8 @@ func (w *Worker) processWork(work *WorkRequest) {
 		log.Warn("Failed to save execution status (database may be closed): %v", err)
 	}
 
-	// Update job status
-	work.Job.Status = "running"
+	// Update job status, a paused job keeps its status
+	work.Job.Status = savedStatus(work.Job, "running")
 	work.Job.CurrentExecutionID = &work.Execution.ExecutionID
 	work.Job.LastRunAt = work.Execution.StartedAt
 
@@ -268,10 +299,9 @@ func (w *Worker) processWork(work *WorkRequest) {
 	}
 
 	// Create execution context with timeout
-	ctx := work.Context
 	if work.Job.DefaultTimeout != nil && *work.Job.DefaultTimeout > 0 {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(work.Context, time.Duration(*work.Job.DefaultTimeout)*time.Second)
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*work.Job.DefaultTimeout)*time.Second)
 		defer cancel()
 	}
 
@@ -287,17 +317,24 @@ func (w *Worker) processWork(work *WorkRequest) {
 
 	var err error
 	startTime := time.Now()
+	stopWatching := work.Job.watchDuration(work.Execution)
 
-	// Execute based on mode
-	switch w.Mode {
+	// Execute based on the mode of the job, the worker mode is the default
+	mode := work.Job.Mode
+	if mode == "" {
+		mode = w.Mode
+	}
+	switch mode {
 	case GOROUTINE:
 		err = w.executeInGoroutine(ctx, work, progress)
 	case PROCESS:
 		err = w.executeInProcess(ctx, work, progress)
 	default:
-		err = fmt.Errorf("unsupported execution mode: %s", w.Mode)
+		err = fmt.Errorf("unsupported execution mode: %s", mode)
 	}
 
+	stopWatching()
+
 	// Calculate duration
 	duration := int(time.Since(startTime).Milliseconds())
 	endTime := time.Now()
@@ -306,12 +343,42 @@ func (w *Worker) processWork(work *WorkRequest) {
 	work.Execution.EndedAt = &endTime
 	work.Execution.Duration = &duration
 
+	// The node taking the lease over owns the execution now
+	if lease.stop() {
+		log.Warn("Execution %s lease lost, the result is discarded: %v", work.Execution.ExecutionID, err)
+		if work.Job.executionContexts != nil {
+			work.Job.executionMutex.Lock()
+			delete(work.Job.executionContexts, work.Execution.ExecutionID)
+			work.Job.executionMutex.Unlock()
+		}
+		return
+	}
+
+	// Retry the failed executions by the retry policy, unless the job was stopped
+	var retryDelay time.Duration
+	retry := false
+
 	if err != nil {
 		work.Execution.Status = "failed"
+		if work.Context.Err() == nil {
+			var status string
+			retryDelay, status = work.Job.retryPolicyOf(work.Execution).decide(err, work.Execution.RetryAttempt)
+			retry = status == ""
+			if !retry {
+				work.Execution.Status = status
+			}
+		}
+
 		errorInfo := map[string]interface{}{
-			"error":  err.Error(),
-			"time":   endTime,
-			"worker": w.ID,
+			"error":   err.Error(),
+			"time":    endTime,
+			"worker":  w.ID,
+			"attempt": work.Execution.RetryAttempt,
+		}
+		var violation *LimitError
+		if errors.As(err, &violation) {
+			errorInfo["reason"] = violation.Reason
+			errorInfo["limit"] = violation.Limit
 		}
 		errorData, _ := jsoniter.Marshal(errorInfo)
 		work.Execution.ErrorInfo = (*json.RawMessage)(&errorData)
@@ -319,10 +386,14 @@ func (w *Worker) processWork(work *WorkRequest) {
 		log.Error("Job %s execution failed: %v", work.Job.JobID, err)
 
 		// Log error
+		message := fmt.Sprintf("Execution failed: %v", err)
+		if work.Execution.Status == "dead" {
+			message = fmt.Sprintf("Execution dead, %d retries exhausted: %v", work.Execution.RetryAttempt, err)
+		}
 		logEntry := &Log{
 			JobID:       work.Job.JobID,
 			Level:       "error",
-			Message:     fmt.Sprintf("Execution failed: %v", err),
+			Message:     message,
 			ExecutionID: &work.Execution.ExecutionID,
 			WorkerID:    &w.ID,
 			Timestamp:   time.Now(),
@@ -354,16 +425,31 @@ func (w *Worker) processWork(work *WorkRequest) {
 		}
 	}
 
+	// Queue the execution again, the job is still running
+	if retry {
+		if work.Job.executionContexts != nil {
+			work.Job.executionMutex.Lock()
+			delete(work.Job.executionContexts, work.Execution.ExecutionID)
+			work.Job.executionMutex.Unlock()
+		}
+		work.Job.scheduleRetry(work.Execution, retryDelay, err)
+		lease.release()
+		return
+	}
+
 	// Update execution in database
 	if err := SaveExecution(work.Execution); err != nil {
 		log.Warn("Failed to save final execution status (database may be closed): %v", err)
 	}
+	lease.release()
+	metrics.finished(work.Job, work.Execution)
+	work.Job.checkFailures(work.Execution)
 
 	// Update job status
 	if work.Job.ScheduleType == string(ScheduleTypeOnce) {
-		work.Job.Status = "completed"
+		work.Job.Status = savedStatus(work.Job, "completed")
 	} else {
-		work.Job.Status = "ready" // Ready for next execution
+		work.Job.Status = savedStatus(work.Job, "ready") // Ready for next execution
 	}
 	work.Job.CurrentExecutionID = nil
 	if err := SaveJob(work.Job); err != nil {
@@ -377,6 +463,9 @@ func (w *Worker) processWork(work *WorkRequest) {
 		work.Job.executionMutex.Unlock()
 	}
 
+	// Release the downstream executions and jobs
+	work.Job.onExecutionFinished(work.Execution)
+
 	log.Debug("Worker %s finished processing job %s", w.ID, work.Job.JobID)
 }
 
@@ -0,0 +1,254 @@
+package kb
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// The format and the version of the backup archives. The version is increased when the layout changes, a
+// restore reads the archives of its version and the former ones.
+const (
+	BackupFormat  = "yao-kb-backup"
+	BackupVersion = 1
+)
+
+// ErrInvalidBackup the content is not a backup archive or a backup that cannot be restored
+var ErrInvalidBackup = errors.New("invalid backup")
+
+// The entries of a backup archive, a gzip compressed tar:
+//
+//	manifest.json               the format, the version and the embedding of the collection
+//	collection.json             the collection record
+//	documents/000001.jsonl      the document records, a page per entry
+//	files/<doc_id>/<filename>   the source file of a document, after the page of the document
+//	segments/000001.jsonl       the segments with their vectors, a page per entry
+//	graph/000001.jsonl          the entities and the relationships of the segments of the page
+//	votes/000001.jsonl          the votes of the documents, after all the segments
+//	hits/000001.jsonl           the hits of the documents, after all the segments
+const (
+	manifestEntry   = "manifest.json"
+	collectionEntry = "collection.json"
+	documentsDir    = "documents"
+	filesDir        = "files"
+	segmentsDir     = "segments"
+	graphDir        = "graph"
+	votesDir        = "votes"
+	hitsDir         = "hits"
+)
+
+// BackupManifest the first entry of a backup archive
+type BackupManifest struct {
+	Format              string    `json:"format"`
+	Version             int       `json:"version"`
+	CreatedAt           time.Time `json:"created_at"`
+	CollectionID        string    `json:"collection_id"`
+	EmbeddingProviderID string    `json:"embedding_provider_id"`
+	EmbeddingOptionID   string    `json:"embedding_option_id"`
+	Locale              string    `json:"locale,omitempty"`
+	Dimension           int       `json:"dimension"` // Dimension of the vectors of the segments
+}
+
+// validate checks the archive can be restored
+func (manifest *BackupManifest) validate() error {
+	if manifest.Format != BackupFormat {
+		return fmt.Errorf("%w: not a knowledge base backup", ErrInvalidBackup)
+	}
+	if manifest.Version < 1 || manifest.Version > BackupVersion {
+		return fmt.Errorf("%w: unsupported backup version %d, the max version is %d", ErrInvalidBackup, manifest.Version, BackupVersion)
+	}
+	if manifest.CollectionID == "" {
+		return fmt.Errorf("%w: the backup has no collection", ErrInvalidBackup)
+	}
+	return nil
+}
+
+// archiveWriter writes the entries of a backup archive. The entries of a tar have their size in their header,
+// the pages are buffered before they are written, the files are streamed.
+type archiveWriter struct {
+	gz    *gzip.Writer
+	tw    *tar.Writer
+	pages map[string]int
+	now   time.Time
+}
+
+// newArchiveWriter creates an archive writer
+func newArchiveWriter(w io.Writer) *archiveWriter {
+	gz := gzip.NewWriter(w)
+	return &archiveWriter{gz: gz, tw: tar.NewWriter(gz), pages: map[string]int{}, now: time.Now()}
+}
+
+// writeJSON writes a value as a JSON entry
+func (archive *archiveWriter) writeJSON(name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return archive.writeEntry(name, int64(len(data)), bytes.NewReader(data))
+}
+
+// writePage writes the lines of a page as the next JSON lines entry of a directory, an empty page is skipped
+func (archive *archiveWriter) writePage(dir string, lines []interface{}) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return err
+		}
+	}
+
+	archive.pages[dir]++
+	name := fmt.Sprintf("%s/%06d.jsonl", dir, archive.pages[dir])
+	return archive.writeEntry(name, int64(buf.Len()), buf)
+}
+
+// writeFile streams the source file of a document
+func (archive *archiveWriter) writeFile(docID string, filename string, size int64, reader io.Reader) error {
+	return archive.writeEntry(filePath(docID, filename), size, reader)
+}
+
+// writeEntry writes an entry of the given size
+func (archive *archiveWriter) writeEntry(name string, size int64, reader io.Reader) error {
+	err := archive.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: archive.now,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.CopyN(archive.tw, reader, size)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Close flushes the archive, the underlying writer is not closed
+func (archive *archiveWriter) Close() error {
+	if err := archive.tw.Close(); err != nil {
+		return err
+	}
+	return archive.gz.Close()
+}
+
+// archiveReader reads the entries of a backup archive in order
+type archiveReader struct {
+	gz *gzip.Reader
+	tr *tar.Reader
+}
+
+// newArchiveReader creates an archive reader
+func newArchiveReader(r io.Reader) (*archiveReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a knowledge base backup: %v", ErrInvalidBackup, err)
+	}
+	return &archiveReader{gz: gz, tr: tar.NewReader(gz)}, nil
+}
+
+// next returns the name of the next entry, io.EOF at the end of the archive
+func (archive *archiveReader) next() (string, error) {
+	for {
+		header, err := archive.tr.Next()
+		if err != nil {
+			return "", err
+		}
+		if header.Typeflag == tar.TypeReg {
+			return path.Clean(header.Name), nil
+		}
+	}
+}
+
+// readManifest reads and checks the manifest, the first entry of the archive
+func (archive *archiveReader) readManifest() (*BackupManifest, error) {
+	name, err := archive.next()
+	if err != nil || name != manifestEntry {
+		return nil, fmt.Errorf("%w: the manifest is missing", ErrInvalidBackup)
+	}
+
+	manifest := &BackupManifest{}
+	if err := archive.decode(manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest: %v", ErrInvalidBackup, err)
+	}
+	return manifest, manifest.validate()
+}
+
+// decode decodes the current entry as a JSON value
+func (archive *archiveReader) decode(value interface{}) error {
+	return json.NewDecoder(archive.tr).Decode(value)
+}
+
+// eachLine reads the JSON lines of the current entry one by one
+func (archive *archiveReader) eachLine(handle func(line json.RawMessage) error) error {
+	decoder := json.NewDecoder(archive.tr)
+	for {
+		var line json.RawMessage
+		err := decoder.Decode(&line)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := handle(line); err != nil {
+			return err
+		}
+	}
+}
+
+// content returns the content of the current entry
+func (archive *archiveReader) content() io.Reader {
+	return archive.tr
+}
+
+// Close closes the archive, the underlying reader is not closed
+func (archive *archiveReader) Close() error {
+	return archive.gz.Close()
+}
+
+// filePath returns the entry of the source file of a document
+func filePath(docID string, filename string) string {
+	return path.Join(filesDir, safeName(docID), safeName(filename))
+}
+
+// parseFilePath returns the document of a source file entry
+func parseFilePath(name string) (string, string, bool) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 || parts[0] != filesDir || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// entryDir returns the directory of an entry, empty for the top level entries
+func entryDir(name string) string {
+	dir, _, found := strings.Cut(name, "/")
+	if !found {
+		return ""
+	}
+	return dir
+}
+
+// safeName keeps a name in a single path element
+func safeName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
@@ -0,0 +1,144 @@
+package kb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := newArchiveWriter(buf)
+
+	manifest := &BackupManifest{Format: BackupFormat, Version: BackupVersion, CollectionID: "docs", Dimension: 3}
+	if err := writer.writeJSON(manifestEntry, manifest); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.writeJSON(collectionEntry, map[string]interface{}{"collection_id": "docs"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, page := range [][]interface{}{{"a", "b"}, {}, {"c"}} {
+		if err := writer.writePage(segmentsDir, page); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.writeFile("docs__1", "../report.pdf", 5, strings.NewReader("%PDF-")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := newArchiveReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	restored, err := reader.readManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.CollectionID != "docs" || restored.Dimension != 3 {
+		t.Errorf("Expected the manifest of the backup, got %+v", restored)
+	}
+
+	names := []string{}
+	lines := []string{}
+	content := ""
+	for {
+		name, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+
+		switch entryDir(name) {
+		case segmentsDir:
+			err = reader.eachLine(func(line json.RawMessage) error {
+				var text string
+				err := json.Unmarshal(line, &text)
+				lines = append(lines, text)
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+		case filesDir:
+			data, err := io.ReadAll(reader.content())
+			if err != nil {
+				t.Fatal(err)
+			}
+			content = string(data)
+		}
+	}
+
+	// The empty page is skipped
+	expected := []string{collectionEntry, "segments/000001.jsonl", "segments/000002.jsonl", "files/docs__1/.._report.pdf"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected the entries %v, got %v", expected, names)
+	}
+	if strings.Join(lines, ",") != "a,b,c" {
+		t.Errorf("Expected the lines a,b,c, got %v", lines)
+	}
+	if content != "%PDF-" {
+		t.Errorf("Expected the content of the file, got %q", content)
+	}
+
+	docID, filename, ok := parseFilePath(names[3])
+	if !ok || docID != "docs__1" || filename != ".._report.pdf" {
+		t.Errorf("Expected the document of the file, got %q %q %v", docID, filename, ok)
+	}
+}
+
+func TestArchiveManifest(t *testing.T) {
+	tests := []struct {
+		manifest *BackupManifest
+		err      string
+	}{
+		{&BackupManifest{Format: "zip", Version: 1, CollectionID: "docs"}, "not a knowledge base backup"},
+		{&BackupManifest{Format: BackupFormat, Version: BackupVersion + 1, CollectionID: "docs"}, "unsupported backup version"},
+		{&BackupManifest{Format: BackupFormat, Version: BackupVersion}, "the backup has no collection"},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		writer := newArchiveWriter(buf)
+		if err := writer.writeJSON(manifestEntry, test.manifest); err != nil {
+			t.Fatal(err)
+		}
+		writer.Close()
+
+		reader, err := newArchiveReader(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = reader.readManifest()
+		if !errors.Is(err, ErrInvalidBackup) || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Expected the error %q, got %v", test.err, err)
+		}
+	}
+
+	// Not a gzip
+	_, err := newArchiveReader(strings.NewReader("collection"))
+	if err == nil {
+		t.Error("Expected an error for a content that is not an archive")
+	}
+
+	// No manifest
+	buf := &bytes.Buffer{}
+	writer := newArchiveWriter(buf)
+	writer.writeJSON(collectionEntry, map[string]interface{}{})
+	writer.Close()
+	reader, _ := newArchiveReader(buf)
+	if _, err := reader.readManifest(); err == nil {
+		t.Error("Expected an error for an archive without manifest")
+	}
+}
@@ -0,0 +1,861 @@
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/gou/graphrag/utils"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/attachment"
+)
+
+// backupPageSize the number of documents and segments of a page of the archive
+const backupPageSize = 200
+
+// feedbackPageSize the number of votes and hits scrolled at once
+const feedbackPageSize = 500
+
+// graphWorkers the number of segments of a page whose graph is read at once
+const graphWorkers = 8
+
+// ErrCollectionExists the collection of a restore already exists
+var ErrCollectionExists = errors.New("collection already exists")
+
+// The fields of the records managed by the database, not restored
+var recordFields = []string{"id", "created_at", "updated_at", "deleted_at"}
+
+// The fields of the collection record set when the vector collection is created
+var collectionOptionFields = []string{"distance", "index_type", "m", "ef_construction", "ef_search", "num_lists", "num_probes"}
+
+// RestoreOptions the options of a restore
+type RestoreOptions struct {
+	CollectionID        string `json:"collection_id,omitempty"`         // The collection to create, default is the collection of the backup
+	EmbeddingProviderID string `json:"embedding_provider_id,omitempty"` // The embedding of the restored collection, default is the one of the backup
+	EmbeddingOptionID   string `json:"embedding_option_id,omitempty"`
+	Locale              string `json:"locale,omitempty"`
+	Reembed             bool   `json:"reembed,omitempty"` // Embeds the segments again even if the embedding is unchanged
+}
+
+// RestoreResult the summary of a restore
+type RestoreResult struct {
+	CollectionID  string `json:"collection_id"`
+	Documents     int    `json:"documents"`
+	Files         int    `json:"files"`
+	Segments      int    `json:"segments"`
+	Entities      int    `json:"entities"`
+	Relationships int    `json:"relationships"`
+	Votes         int    `json:"votes"`
+	Hits          int    `json:"hits"`
+	Reembedded    bool   `json:"reembedded"`
+}
+
+// backupSegment a line of the segments entries
+type backupSegment struct {
+	ID         string                 `json:"id"`
+	DocumentID string                 `json:"doc_id"`
+	Text       string                 `json:"text"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Vector     []float64              `json:"vector,omitempty"`
+}
+
+// backupGraph a line of the graph entries
+type backupGraph struct {
+	DocumentID    string                    `json:"doc_id"`
+	SegmentID     string                    `json:"segment_id"`
+	Entities      []types.GraphNode         `json:"entities,omitempty"`
+	Relationships []types.GraphRelationship `json:"relationships,omitempty"`
+}
+
+// backupVotes a line of the votes entries
+type backupVotes struct {
+	DocumentID string              `json:"doc_id"`
+	Votes      []types.SegmentVote `json:"votes"`
+}
+
+// backupHits a line of the hits entries
+type backupHits struct {
+	DocumentID string             `json:"doc_id"`
+	Hits       []types.SegmentHit `json:"hits"`
+}
+
+// Backup writes the archive of a collection. The archive is streamed: the documents and the segments are
+// read and written a page at a time and the source files are copied from the attachment storage, the size of
+// the collection does not matter.
+func Backup(ctx context.Context, collectionID string, w io.Writer) error {
+	knowledgeBase, ok := Instance.(*KnowledgeBase)
+	if !ok || knowledgeBase.GraphRag == nil {
+		return fmt.Errorf("knowledge base not initialized")
+	}
+
+	collection, err := knowledgeBase.Config.FindCollection(collectionID, model.QueryParam{})
+	if err != nil {
+		return err
+	}
+
+	manifest := &BackupManifest{
+		Format:       BackupFormat,
+		Version:      BackupVersion,
+		CreatedAt:    time.Now(),
+		CollectionID: collectionID,
+	}
+	manifest.EmbeddingProviderID, _ = collection["embedding_provider_id"].(string)
+	manifest.EmbeddingOptionID, _ = collection["embedding_option_id"].(string)
+	manifest.Locale, _ = collection["locale"].(string)
+	manifest.Dimension, err = embeddingDimension(manifest.EmbeddingProviderID, manifest.EmbeddingOptionID, manifest.Locale)
+	if err != nil {
+		return err
+	}
+
+	archive := newArchiveWriter(w)
+	if err := archive.writeJSON(manifestEntry, manifest); err != nil {
+		return err
+	}
+	if err := archive.writeJSON(collectionEntry, record(collection)); err != nil {
+		return err
+	}
+	if err := backupDocuments(ctx, knowledgeBase, collectionID, archive); err != nil {
+		return err
+	}
+	if err := backupSegments(ctx, knowledgeBase, collectionID, archive); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// backupDocuments writes the document records a page at a time, each page is followed by the source files
+func backupDocuments(ctx context.Context, knowledgeBase *KnowledgeBase, collectionID string, archive *archiveWriter) error {
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "collection_id", Value: collectionID}},
+		Orders: []model.QueryOrder{{Column: "id", Option: "asc"}},
+	}
+
+	for page := 1; ; page++ {
+		res, err := knowledgeBase.Config.SearchDocuments(param, page, backupPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to read the documents: %w", err)
+		}
+
+		documents, _ := res["data"].([]maps.MapStrAny)
+		lines := make([]interface{}, len(documents))
+		for i, document := range documents {
+			lines[i] = record(document)
+		}
+		if err := archive.writePage(documentsDir, lines); err != nil {
+			return err
+		}
+
+		for _, document := range documents {
+			if err := backupFile(ctx, document, archive); err != nil {
+				return err
+			}
+		}
+
+		if len(documents) < backupPageSize {
+			return nil
+		}
+	}
+}
+
+// backupFile copies the source file of a document. A file missing from the attachment storage is skipped,
+// the document is restored without it.
+func backupFile(ctx context.Context, document maps.MapStrAny, archive *archiveWriter) error {
+	docID, _ := document["document_id"].(string)
+	uploaderID, _ := document["uploader_id"].(string)
+	fileID, _ := document["file_id"].(string)
+	if fileID == "" {
+		return nil
+	}
+
+	manager, ok := attachment.Managers[uploaderID]
+	if !ok {
+		log.Warn("[Knowledge Base] backup: the uploader %s of document %s not found, the file is skipped", uploaderID, docID)
+		return nil
+	}
+
+	content, err := manager.Open(ctx, fileID)
+	if err != nil {
+		log.Warn("[Knowledge Base] backup: failed to open the file of document %s, the file is skipped: %v", docID, err)
+		return nil
+	}
+	defer content.Close()
+
+	return archive.writeFile(docID, content.Filename, content.Size, content)
+}
+
+// backupSegments writes the segments with their vectors a page at a time, each page is followed by the graph of
+// its segments. The votes and the hits are written after the segments, scrolled once per document.
+func backupSegments(ctx context.Context, knowledgeBase *KnowledgeBase, collectionID string, archive *archiveWriter) error {
+	documents := []string{}
+	seen := map[string]bool{}
+	scrollID := ""
+	for {
+		res, err := knowledgeBase.Vector.ScrollDocuments(ctx, &types.ScrollOptions{
+			CollectionName:  collectionID,
+			Limit:           backupPageSize,
+			ScrollID:        scrollID,
+			IncludeMetadata: true,
+			IncludeContent:  true,
+			IncludeVector:   true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read the segments: %w", err)
+		}
+
+		segments := make([]interface{}, 0, len(res.Documents))
+		linked := []backupSegment{}
+		for _, document := range res.Documents {
+			segment := backupSegment{ID: document.ID, Text: document.Content, Metadata: document.Metadata, Vector: document.Vector}
+			segment.DocumentID, _ = document.Metadata["doc_id"].(string)
+			segments = append(segments, segment)
+			if segment.DocumentID == "" {
+				continue
+			}
+
+			linked = append(linked, segment)
+			if !seen[segment.DocumentID] {
+				seen[segment.DocumentID] = true
+				documents = append(documents, segment.DocumentID)
+			}
+		}
+
+		if err := archive.writePage(segmentsDir, segments); err != nil {
+			return err
+		}
+
+		if knowledgeBase.Graph != nil {
+			graph, err := pageGraph(ctx, linked)
+			if err != nil {
+				return err
+			}
+			if err := archive.writePage(graphDir, graph); err != nil {
+				return err
+			}
+		}
+
+		if !res.HasMore || res.NextScrollID == "" {
+			break
+		}
+		scrollID = res.NextScrollID
+	}
+
+	if err := backupFeedback(ctx, documents, votesDir, documentVotes, archive); err != nil {
+		return err
+	}
+	return backupFeedback(ctx, documents, hitsDir, documentHits, archive)
+}
+
+// pageGraph returns the entities and the relationships of the segments of a page, the segments are read by
+// graphWorkers at once
+func pageGraph(ctx context.Context, segments []backupSegment) ([]interface{}, error) {
+	lines := make([]*backupGraph, len(segments))
+	errs := make([]error, len(segments))
+	slots := make(chan struct{}, graphWorkers)
+	var wg sync.WaitGroup
+	for i, segment := range segments {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, segment backupSegment) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			lines[i], errs[i] = segmentGraph(ctx, segment.DocumentID, segment.ID)
+		}(i, segment)
+	}
+	wg.Wait()
+
+	graph := []interface{}{}
+	for i, line := range lines {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if len(line.Entities) > 0 || len(line.Relationships) > 0 {
+			graph = append(graph, line)
+		}
+	}
+	return graph, nil
+}
+
+// backupFeedback writes the votes or the hits of the documents, the lines are written a page at a time
+func backupFeedback(ctx context.Context, documents []string, dir string, read func(context.Context, string) ([]interface{}, error), archive *archiveWriter) error {
+	page := []interface{}{}
+	for _, docID := range documents {
+		lines, err := read(ctx, docID)
+		if err != nil {
+			return err
+		}
+
+		page = append(page, lines...)
+		if len(page) >= backupPageSize {
+			if err := archive.writePage(dir, page); err != nil {
+				return err
+			}
+			page = []interface{}{}
+		}
+	}
+	return archive.writePage(dir, page)
+}
+
+// segmentGraph returns the entities and the relationships extracted from a segment
+func segmentGraph(ctx context.Context, docID string, segmentID string) (*backupGraph, error) {
+	entities, err := Instance.GetSegmentEntities(ctx, docID, segmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the entities of segment %s: %w", segmentID, err)
+	}
+
+	relationships, err := Instance.GetSegmentRelationships(ctx, docID, segmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the relationships of segment %s: %w", segmentID, err)
+	}
+
+	return &backupGraph{DocumentID: docID, SegmentID: segmentID, Entities: entities, Relationships: relationships}, nil
+}
+
+// documentVotes returns the votes of the segments of a document, a line per scrolled page
+func documentVotes(ctx context.Context, docID string) ([]interface{}, error) {
+	lines := []interface{}{}
+	cursor := ""
+	for {
+		res, err := Instance.ScrollVotes(ctx, docID, &types.ScrollVotesOptions{Limit: feedbackPageSize, Cursor: cursor})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the votes of document %s: %w", docID, err)
+		}
+		if len(res.Votes) > 0 {
+			lines = append(lines, backupVotes{DocumentID: docID, Votes: res.Votes})
+		}
+		if !res.HasMore || res.NextCursor == "" {
+			return lines, nil
+		}
+		cursor = res.NextCursor
+	}
+}
+
+// documentHits returns the hits of the segments of a document, a line per scrolled page
+func documentHits(ctx context.Context, docID string) ([]interface{}, error) {
+	lines := []interface{}{}
+	cursor := ""
+	for {
+		res, err := Instance.ScrollHits(ctx, docID, &types.ScrollHitsOptions{Limit: feedbackPageSize, Cursor: cursor})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the hits of document %s: %w", docID, err)
+		}
+		if len(res.Hits) > 0 {
+			lines = append(lines, backupHits{DocumentID: docID, Hits: res.Hits})
+		}
+		if !res.HasMore || res.NextCursor == "" {
+			return lines, nil
+		}
+		cursor = res.NextCursor
+	}
+}
+
+// restore the state of a restore in progress
+type restore struct {
+	knowledgeBase *KnowledgeBase
+	manifest      *BackupManifest
+	result        *RestoreResult
+	providerID    string
+	optionID      string
+	locale        string
+	embedding     types.Embedding                // Set when the segments are embedded again
+	documents     map[string]string              // The new IDs of the documents by their archived ID
+	files         map[string]string              // The archived IDs of the documents by the names of their file entries
+	records       map[string]maps.MapStrAny      // The uploader and the content type of the restored documents
+	segments      map[string]int                 // The number of segments of the restored documents
+	uploads       map[string]*attachment.Manager // The uploaders of the restored source files by their file ID
+}
+
+// Restore creates a collection from an archive. The entries are read in order, the archive is never held in
+// memory. The collection can be restored with another embedding or in another vector store: the segments are
+// embedded again when the embedding or the dimension differs from the ones of the backup, or when asked.
+// A failed restore removes the collection and the restored source files.
+func Restore(ctx context.Context, r io.Reader, options RestoreOptions) (*RestoreResult, error) {
+	knowledgeBase, ok := Instance.(*KnowledgeBase)
+	if !ok || knowledgeBase.GraphRag == nil {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+
+	archive, err := newArchiveReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	manifest, err := archive.readManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &restore{
+		knowledgeBase: knowledgeBase,
+		manifest:      manifest,
+		result:        &RestoreResult{CollectionID: options.CollectionID},
+		providerID:    options.EmbeddingProviderID,
+		optionID:      options.EmbeddingOptionID,
+		locale:        options.Locale,
+		documents:     map[string]string{},
+		files:         map[string]string{},
+		records:       map[string]maps.MapStrAny{},
+		segments:      map[string]int{},
+		uploads:       map[string]*attachment.Manager{},
+	}
+	if state.result.CollectionID == "" {
+		state.result.CollectionID = manifest.CollectionID
+	}
+	if state.providerID == "" {
+		state.providerID = manifest.EmbeddingProviderID
+		state.optionID = manifest.EmbeddingOptionID
+	}
+	if state.locale == "" {
+		state.locale = manifest.Locale
+	}
+
+	if _, err := knowledgeBase.Config.FindCollection(state.result.CollectionID, model.QueryParam{}); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCollectionExists, state.result.CollectionID)
+	}
+
+	dimension, err := embeddingDimension(state.providerID, state.optionID, state.locale)
+	if err != nil {
+		return nil, err
+	}
+
+	state.result.Reembedded = options.Reembed || dimension != manifest.Dimension ||
+		state.providerID != manifest.EmbeddingProviderID || state.optionID != manifest.EmbeddingOptionID
+	if state.result.Reembedded {
+		state.embedding, err = makeEmbedding(state.providerID, state.optionID, state.locale)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	name, err := archive.next()
+	if err != nil || name != collectionEntry {
+		return nil, fmt.Errorf("%w: the collection is missing", ErrInvalidBackup)
+	}
+
+	collection := maps.MapStrAny{}
+	if err := archive.decode(&collection); err != nil {
+		return nil, fmt.Errorf("%w: invalid collection: %v", ErrInvalidBackup, err)
+	}
+
+	if err := state.createCollection(ctx, collection, dimension); err != nil {
+		return nil, err
+	}
+
+	err = state.restoreEntries(ctx, archive)
+	if err == nil {
+		err = state.finish(ctx)
+	}
+	if err != nil {
+		state.rollback(ctx)
+		return nil, err
+	}
+	return state.result, nil
+}
+
+// createCollection creates the collection record and the vector collection
+func (state *restore) createCollection(ctx context.Context, collection maps.MapStrAny, dimension int) error {
+	collectionID := state.result.CollectionID
+	collection["collection_id"] = collectionID
+	collection["status"] = "restoring"
+	collection["document_count"] = 0
+	collection["embedding_provider_id"] = state.providerID
+	collection["embedding_option_id"] = state.optionID
+	collection["locale"] = state.locale
+	if state.result.Reembedded {
+		option, err := embeddingOption(state.providerID, state.optionID, state.locale)
+		if err != nil {
+			return err
+		}
+		collection["embedding_properties"] = option.Properties
+	}
+
+	// The options of the vector collection are the columns of the record
+	config := map[string]interface{}{"dimension": dimension}
+	for _, field := range collectionOptionFields {
+		if value, ok := collection[field]; ok && value != nil {
+			config[field] = value
+		}
+	}
+
+	var collectionOptions types.CreateCollectionOptions
+	if err := convert(config, &collectionOptions); err != nil {
+		return fmt.Errorf("invalid collection options: %w", err)
+	}
+
+	metadata := map[string]interface{}{
+		"name":                   collection["name"],
+		"description":            collection["description"],
+		"__embedding_provider":   state.providerID,
+		"__embedding_option":     state.optionID,
+		"__embedding_properties": collection["embedding_properties"],
+	}
+	if state.locale != "" {
+		metadata["__locale"] = state.locale
+	}
+
+	if _, err := state.knowledgeBase.Config.CreateCollection(collection); err != nil {
+		return fmt.Errorf("failed to save the collection: %w", err)
+	}
+
+	_, err := Instance.CreateCollection(ctx, types.CollectionConfig{ID: collectionID, Metadata: metadata, Config: &collectionOptions})
+	if err != nil {
+		if removeErr := state.knowledgeBase.Config.RemoveCollection(collectionID); removeErr != nil {
+			log.Error("[Knowledge Base] restore: failed to remove the collection record %s: %v", collectionID, removeErr)
+		}
+		return fmt.Errorf("failed to create the collection: %w", err)
+	}
+	return nil
+}
+
+// restoreEntries restores the entries following the collection
+func (state *restore) restoreEntries(ctx context.Context, archive *archiveReader) error {
+	for {
+		name, err := archive.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+
+		switch entryDir(name) {
+		case documentsDir:
+			err = archive.eachLine(state.restoreDocument)
+
+		case filesDir:
+			err = state.restoreFile(ctx, name, archive.content())
+
+		case segmentsDir:
+			err = state.restoreSegments(ctx, archive)
+
+		case graphDir:
+			err = archive.eachLine(func(line json.RawMessage) error { return state.restoreGraph(ctx, line) })
+
+		case votesDir:
+			err = archive.eachLine(func(line json.RawMessage) error { return state.restoreVotes(ctx, line) })
+
+		case hitsDir:
+			err = archive.eachLine(func(line json.RawMessage) error { return state.restoreHits(ctx, line) })
+
+		default:
+			log.Warn("[Knowledge Base] restore: unknown entry %s is skipped", name)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+	}
+}
+
+// restoreDocument creates a document record. The documents restored in another collection get new IDs, the
+// IDs of the documents carry the ID of their collection.
+func (state *restore) restoreDocument(line json.RawMessage) error {
+	document := maps.MapStrAny{}
+	if err := json.Unmarshal(line, &document); err != nil {
+		return err
+	}
+
+	archivedID, _ := document["document_id"].(string)
+	docID := archivedID
+	if state.result.CollectionID != state.manifest.CollectionID {
+		docID = utils.GenDocIDWithCollectionID(state.result.CollectionID)
+	}
+
+	document["document_id"] = docID
+	document["collection_id"] = state.result.CollectionID
+	document["segment_count"] = 0
+	if state.result.Reembedded {
+		document["embedding_provider_id"] = state.providerID
+		document["embedding_option_id"] = state.optionID
+	}
+
+	// The file is restored by its entry
+	delete(document, "file_id")
+	delete(document, "file_path")
+
+	// The resync job belongs to the archived document, the schedule is kept and scheduled again
+	delete(document, "resync_job_id")
+
+	if _, err := state.knowledgeBase.Config.CreateDocument(document); err != nil {
+		return err
+	}
+
+	state.documents[archivedID] = docID
+	state.files[safeName(archivedID)] = archivedID
+	state.records[docID] = maps.MapStrAny{
+		"uploader_id":    document["uploader_id"],
+		"file_mime_type": document["file_mime_type"],
+	}
+	state.result.Documents++
+	return nil
+}
+
+// restoreFile uploads the source file of a document with the uploader of the document
+func (state *restore) restoreFile(ctx context.Context, name string, reader io.Reader) error {
+	safeID, filename, ok := parseFilePath(name)
+	if !ok {
+		return fmt.Errorf("%w: invalid file entry", ErrInvalidBackup)
+	}
+
+	docID, ok := state.documents[state.files[safeID]]
+	if !ok {
+		log.Warn("[Knowledge Base] restore: the document of %s not found, the file is skipped", name)
+		return nil
+	}
+
+	uploaderID, _ := state.records[docID]["uploader_id"].(string)
+	contentType, _ := state.records[docID]["file_mime_type"].(string)
+	manager, ok := attachment.Managers[uploaderID]
+	if !ok {
+		log.Warn("[Knowledge Base] restore: the uploader %s of document %s not found, the file is skipped", uploaderID, docID)
+		return nil
+	}
+
+	header := &attachment.FileHeader{FileHeader: &multipart.FileHeader{Filename: filename, Header: make(map[string][]string)}}
+	if contentType != "" {
+		header.Header.Set("Content-Type", contentType)
+	}
+
+	file, err := manager.Upload(ctx, header, reader, attachment.UploadOption{
+		OriginalFilename: filename,
+		Groups:           []string{"kb", state.result.CollectionID},
+	})
+	if err != nil {
+		return err
+	}
+	state.uploads[file.ID] = manager
+
+	path, _, err := manager.LocalPath(ctx, file.ID)
+	if err != nil {
+		return err
+	}
+
+	err = state.knowledgeBase.Config.UpdateDocument(docID, maps.MapStrAny{"file_id": file.ID, "file_path": path})
+	if err != nil {
+		return err
+	}
+	state.result.Files++
+	return nil
+}
+
+// restoreSegments adds a page of segments to the vector store, with their archived vectors or embedded again
+func (state *restore) restoreSegments(ctx context.Context, archive *archiveReader) error {
+	segments := []*backupSegment{}
+	err := archive.eachLine(func(line json.RawMessage) error {
+		segment := &backupSegment{}
+		if err := json.Unmarshal(line, segment); err != nil {
+			return err
+		}
+		segments = append(segments, segment)
+		return nil
+	})
+	if err != nil || len(segments) == 0 {
+		return err
+	}
+
+	if state.embedding != nil {
+		texts := make([]string, len(segments))
+		for i, segment := range segments {
+			texts[i] = segment.Text
+		}
+
+		res, err := state.embedding.EmbedDocuments(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("failed to embed the segments: %w", err)
+		}
+		if len(res.Embeddings) != len(segments) {
+			return fmt.Errorf("failed to embed the segments: %d embeddings for %d segments", len(res.Embeddings), len(segments))
+		}
+		for i, segment := range segments {
+			segment.Vector = res.Embeddings[i]
+		}
+	}
+
+	documents := make([]*types.Document, len(segments))
+	for i, segment := range segments {
+		docID := state.docID(segment.DocumentID)
+		if segment.Metadata == nil {
+			segment.Metadata = map[string]interface{}{}
+		}
+		if docID != "" {
+			segment.Metadata["doc_id"] = docID
+			state.segments[docID]++
+		}
+		documents[i] = &types.Document{ID: segment.ID, Content: segment.Text, Metadata: segment.Metadata, Vector: segment.Vector}
+	}
+
+	_, err = state.knowledgeBase.Vector.AddDocuments(ctx, &types.AddDocumentOptions{
+		CollectionName: state.result.CollectionID,
+		Documents:      documents,
+		Upsert:         true,
+	})
+	if err != nil {
+		return err
+	}
+	state.result.Segments += len(segments)
+	return nil
+}
+
+// restoreGraph adds the entities and the relationships of a segment to the graph store. The graph is skipped
+// when the knowledge base has no graph store.
+func (state *restore) restoreGraph(ctx context.Context, line json.RawMessage) error {
+	graph := backupGraph{}
+	if err := json.Unmarshal(line, &graph); err != nil {
+		return err
+	}
+
+	if state.knowledgeBase.Graph == nil {
+		return nil
+	}
+
+	ids, err := utils.GetCollectionIDs(state.result.CollectionID)
+	if err != nil {
+		return err
+	}
+
+	if len(graph.Entities) > 0 {
+		nodes := make([]*types.GraphNode, len(graph.Entities))
+		for i := range graph.Entities {
+			nodes[i] = &graph.Entities[i]
+		}
+		_, err := state.knowledgeBase.Graph.AddNodes(ctx, &types.AddNodesOptions{GraphName: ids.Graph, Nodes: nodes, Upsert: true})
+		if err != nil {
+			return err
+		}
+		state.result.Entities += len(nodes)
+	}
+
+	if len(graph.Relationships) > 0 {
+		relationships := make([]*types.GraphRelationship, len(graph.Relationships))
+		for i := range graph.Relationships {
+			relationships[i] = &graph.Relationships[i]
+		}
+		_, err := state.knowledgeBase.Graph.AddRelationships(ctx, &types.AddRelationshipsOptions{GraphName: ids.Graph, Relationships: relationships, Upsert: true})
+		if err != nil {
+			return err
+		}
+		state.result.Relationships += len(relationships)
+	}
+	return nil
+}
+
+// restoreVotes adds the votes of a segment, each vote keeps its reaction
+func (state *restore) restoreVotes(ctx context.Context, line json.RawMessage) error {
+	votes := backupVotes{}
+	if err := json.Unmarshal(line, &votes); err != nil {
+		return err
+	}
+
+	count, err := Instance.UpdateVotes(ctx, state.docID(votes.DocumentID), votes.Votes, types.UpdateVoteOptions{})
+	if err != nil {
+		return err
+	}
+	state.result.Votes += count
+	return nil
+}
+
+// restoreHits adds the hits of a segment, each hit keeps its reaction
+func (state *restore) restoreHits(ctx context.Context, line json.RawMessage) error {
+	hits := backupHits{}
+	if err := json.Unmarshal(line, &hits); err != nil {
+		return err
+	}
+
+	count, err := Instance.UpdateHits(ctx, state.docID(hits.DocumentID), hits.Hits, types.UpdateHitOptions{})
+	if err != nil {
+		return err
+	}
+	state.result.Hits += count
+	return nil
+}
+
+// finish updates the counts and activates the collection
+func (state *restore) finish(ctx context.Context) error {
+	config := state.knowledgeBase.Config
+	for docID, count := range state.segments {
+		if err := config.UpdateSegmentCount(docID, count); err != nil {
+			return err
+		}
+	}
+
+	if err := config.UpdateDocumentCount(state.result.CollectionID); err != nil {
+		return err
+	}
+
+	collectionID := state.result.CollectionID
+	if err := config.UpdateCollection(collectionID, maps.MapStrAny{"status": "active"}); err != nil {
+		return err
+	}
+	return Instance.UpdateCollectionMetadata(ctx, collectionID, map[string]interface{}{
+		"status":         "active",
+		"document_count": state.result.Documents,
+	})
+}
+
+// rollback removes the collection and the uploaded source files of a failed restore
+func (state *restore) rollback(ctx context.Context) {
+	collectionID := state.result.CollectionID
+	for fileID, manager := range state.uploads {
+		if err := manager.Delete(ctx, fileID); err != nil {
+			log.Error("[Knowledge Base] restore: failed to remove the file %s: %v", fileID, err)
+		}
+	}
+	if _, err := Instance.RemoveCollection(ctx, collectionID); err != nil {
+		log.Error("[Knowledge Base] restore: failed to remove the collection %s: %v", collectionID, err)
+	}
+	if err := RemoveResyncJobs(state.knowledgeBase.Config, collectionID); err != nil {
+		log.Error("[Knowledge Base] restore: failed to remove the resync jobs of %s: %v", collectionID, err)
+	}
+	if err := state.knowledgeBase.Config.RemoveDocumentsByCollectionID(collectionID); err != nil {
+		log.Error("[Knowledge Base] restore: failed to remove the documents of %s: %v", collectionID, err)
+	}
+	if err := state.knowledgeBase.Config.RemoveCollection(collectionID); err != nil {
+		log.Error("[Knowledge Base] restore: failed to remove the collection record %s: %v", collectionID, err)
+	}
+}
+
+// docID returns the restored ID of an archived document
+func (state *restore) docID(archivedID string) string {
+	if docID, ok := state.documents[archivedID]; ok {
+		return docID
+	}
+	return archivedID
+}
+
+// embeddingDimension returns the dimension of the vectors of an embedding option
+func embeddingDimension(providerID string, optionID string, locale string) (int, error) {
+	option, err := embeddingOption(providerID, optionID, locale)
+	if err != nil {
+		return 0, err
+	}
+	return int(toFloat(option.Properties["dimensions"])), nil
+}
+
+// record copies a database record without the fields managed by the database
+func record(data map[string]interface{}) maps.MapStrAny {
+	copied := maps.MapStrAny{}
+	for key, value := range data {
+		copied[key] = value
+	}
+	for _, field := range recordFields {
+		delete(copied, field)
+	}
+	return copied
+}
+
+// convert converts a value to another type through JSON
+func convert(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
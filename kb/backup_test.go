@@ -0,0 +1,293 @@
+package kb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestBackupRestore(t *testing.T) {
+	knowledgeBase, uploaderID := prepareBackup(t)
+	defer test.Clean()
+
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+	source := fmt.Sprintf("kb_backup_a_%d", suffix)
+	target := fmt.Sprintf("kb_backup_b_%d", suffix)
+	defer removeBackupCollection(source)
+	defer removeBackupCollection(target)
+
+	// The vectors of the archive have another dimension, the segments are embedded again
+	archive := testBackupArchive(t, uploaderID, false)
+	restored, err := Restore(ctx, archive, RestoreOptions{CollectionID: source})
+	if err != nil {
+		t.Fatalf("Failed to restore: %v", err)
+	}
+
+	entities, relationships := 0, 0
+	if knowledgeBase.Graph != nil {
+		entities, relationships = 2, 1
+	}
+	if !restored.Reembedded || restored.Documents != 2 || restored.Files != 1 || restored.Segments != 3 ||
+		restored.Votes != 2 || restored.Hits != 1 || restored.Entities != entities || restored.Relationships != relationships {
+		t.Errorf("Unexpected restore of the archive %+v", restored)
+	}
+
+	dimension, err := embeddingDimension("__yao.openai", "text-embedding-3-small", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, segment := range scrollBackupSegments(t, knowledgeBase, source) {
+		if len(segment.Vector) != dimension || !strings.HasPrefix(fmt.Sprint(segment.Metadata["doc_id"]), source+"__") {
+			t.Errorf("Expected the segment %s embedded again in a document of %s, got %d dimensions %v", segment.ID, source, len(segment.Vector), segment.Metadata["doc_id"])
+		}
+	}
+
+	// The backup of the restored collection is restored in another collection with the same vectors
+	buf := &bytes.Buffer{}
+	if err := Backup(ctx, source, buf); err != nil {
+		t.Fatalf("Failed to back up: %v", err)
+	}
+	counts := countBackupEntries(t, bytes.NewReader(buf.Bytes()))
+	if counts[segmentsDir] != 3 || counts[votesDir] != 2 || counts[hitsDir] != 1 || counts[filesDir] != 1 {
+		t.Errorf("Unexpected entries of the backup %v", counts)
+	}
+
+	roundtrip, err := Restore(ctx, buf, RestoreOptions{CollectionID: target})
+	if err != nil {
+		t.Fatalf("Failed to restore the backup: %v", err)
+	}
+	if roundtrip.Reembedded || roundtrip.Documents != 2 || roundtrip.Files != 1 || roundtrip.Segments != 3 ||
+		roundtrip.Votes != counts[votesDir] || roundtrip.Hits != counts[hitsDir] || roundtrip.Entities != counts[graphDir] {
+		t.Errorf("Unexpected restore of the backup %+v, the backup has %v", roundtrip, counts)
+	}
+
+	vectors := map[string][]float64{}
+	for _, segment := range scrollBackupSegments(t, knowledgeBase, source) {
+		vectors[segment.Content] = segment.Vector
+	}
+	for _, segment := range scrollBackupSegments(t, knowledgeBase, target) {
+		if len(vectors[segment.Content]) != len(segment.Vector) || len(segment.Vector) == 0 || vectors[segment.Content][0] != segment.Vector[0] {
+			t.Errorf("Expected the vector of %q to be restored", segment.Content)
+		}
+		if !strings.HasPrefix(fmt.Sprint(segment.Metadata["doc_id"]), target+"__") {
+			t.Errorf("Expected the segment %s in a document of %s, got %v", segment.ID, target, segment.Metadata["doc_id"])
+		}
+	}
+}
+
+func TestRestoreRollback(t *testing.T) {
+	knowledgeBase, uploaderID := prepareBackup(t)
+	defer test.Clean()
+
+	// The segments of the archive are invalid, the restore fails after the file is uploaded
+	collectionID := fmt.Sprintf("kb_backup_broken_%d", time.Now().UnixNano())
+	defer removeBackupCollection(collectionID)
+	_, err := Restore(context.Background(), testBackupArchive(t, uploaderID, true), RestoreOptions{CollectionID: collectionID})
+	if err == nil {
+		t.Fatal("Expected the restore of the invalid archive to fail")
+	}
+
+	if _, err := knowledgeBase.Config.FindCollection(collectionID, model.QueryParam{}); err == nil {
+		t.Error("Expected the collection to be removed")
+	}
+
+	files := 0
+	filepath.Walk(filepath.Join(config.Conf.DataRoot, uploaderID), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files++
+		}
+		return nil
+	})
+	if files != 0 {
+		t.Errorf("Expected the uploaded files to be removed, got %d files", files)
+	}
+}
+
+// prepareBackup loads the knowledge base and registers the uploader of the archived files
+func prepareBackup(t *testing.T) (*KnowledgeBase, string) {
+	test.Prepare(t, config.Conf)
+	if _, err := Load(config.Conf); err != nil {
+		t.Fatalf("Failed to load the knowledge base: %v", err)
+	}
+
+	knowledgeBase, ok := Instance.(*KnowledgeBase)
+	if !ok || knowledgeBase.GraphRag == nil {
+		test.Clean()
+		t.Skip("the knowledge base is not configured")
+	}
+
+	uploaderID := "kb-backup"
+	_, err := attachment.Register(uploaderID, "local", attachment.ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, uploaderID)},
+		AllowedTypes: []string{"text/*"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register the uploader: %v", err)
+	}
+	return knowledgeBase, uploaderID
+}
+
+// testBackupArchive returns the archive of a collection with a text document and a file document, the segments
+// are invalid when broken
+func testBackupArchive(t *testing.T, uploaderID string, broken bool) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	archive := newArchiveWriter(buf)
+	decode := func(data string, value interface{}) {
+		if err := json.Unmarshal([]byte(data), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	text, file := "kb_backup__text", "kb_backup__file"
+	s1, s2, s3 := "3b6f2a0e-7c41-4f3a-9a55-1d2e3f4a5b01", "3b6f2a0e-7c41-4f3a-9a55-1d2e3f4a5b02", "3b6f2a0e-7c41-4f3a-9a55-1d2e3f4a5b03"
+	write(archive.writeJSON(manifestEntry, &BackupManifest{
+		Format:              BackupFormat,
+		Version:             BackupVersion,
+		CollectionID:        "kb_backup",
+		EmbeddingProviderID: "__yao.openai",
+		EmbeddingOptionID:   "text-embedding-3-small",
+		Locale:              "en",
+		Dimension:           3,
+	}))
+	write(archive.writeJSON(collectionEntry, map[string]interface{}{"collection_id": "kb_backup", "name": "Backup", "distance": "cosine", "index_type": "hnsw"}))
+	write(archive.writePage(documentsDir, []interface{}{
+		map[string]interface{}{"document_id": text, "collection_id": "kb_backup", "name": "Install", "type": "text", "status": "completed", "chunking_provider_id": "__yao.structured"},
+		map[string]interface{}{"document_id": file, "collection_id": "kb_backup", "name": "guide.txt", "type": "file", "status": "completed", "chunking_provider_id": "__yao.structured", "uploader_id": uploaderID, "file_mime_type": "text/plain"},
+	}))
+	guide := "Run the server with yao start."
+	write(archive.writeFile(file, "guide.txt", int64(len(guide)), strings.NewReader(guide)))
+
+	if broken {
+		write(archive.writePage(segmentsDir, []interface{}{"not a segment"}))
+		write(archive.Close())
+		return buf
+	}
+
+	write(archive.writePage(segmentsDir, []interface{}{
+		backupSegment{ID: s1, DocumentID: text, Text: "Install the package with the installer.", Vector: []float64{0.1, 0.2, 0.3}},
+		backupSegment{ID: s2, DocumentID: text, Text: "Configure the application in app.yao.", Vector: []float64{0.3, 0.2, 0.1}},
+		backupSegment{ID: s3, DocumentID: file, Text: guide, Vector: []float64{0.2, 0.2, 0.2}},
+	}))
+
+	graph := &backupGraph{DocumentID: text, SegmentID: s1}
+	decode(`[{"id": "package", "labels": ["Software"], "properties": {"name": "package"}}, {"id": "installer", "labels": ["Software"], "properties": {"name": "installer"}}]`, &graph.Entities)
+	decode(`[{"id": "package-installer", "type": "INSTALLED_BY", "start_node": "package", "end_node": "installer"}]`, &graph.Relationships)
+	write(archive.writePage(graphDir, []interface{}{graph}))
+
+	votes := &backupVotes{DocumentID: text}
+	decode(fmt.Sprintf(`[{"id": %q, "vote": "positive"}, {"id": %q, "vote": "negative"}]`, s1, s2), &votes.Votes)
+	write(archive.writePage(votesDir, []interface{}{votes}))
+
+	hits := &backupHits{DocumentID: file}
+	decode(fmt.Sprintf(`[{"id": %q}]`, s3), &hits.Hits)
+	write(archive.writePage(hitsDir, []interface{}{hits}))
+
+	write(archive.Close())
+	return buf
+}
+
+// countBackupEntries counts the segments, the entities, the votes, the hits and the files of an archive
+func countBackupEntries(t *testing.T, r io.Reader) map[string]int {
+	archive, err := newArchiveReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+	if _, err := archive.readManifest(); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := map[string]int{}
+	for {
+		name, err := archive.next()
+		if err == io.EOF {
+			return counts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dir := entryDir(name)
+		if dir == filesDir {
+			counts[dir]++
+			continue
+		}
+		if name == collectionEntry {
+			continue
+		}
+
+		err = archive.eachLine(func(line json.RawMessage) error {
+			switch dir {
+			case graphDir:
+				graph := backupGraph{}
+				err := json.Unmarshal(line, &graph)
+				counts[dir] += len(graph.Entities)
+				return err
+			case votesDir:
+				votes := backupVotes{}
+				err := json.Unmarshal(line, &votes)
+				counts[dir] += len(votes.Votes)
+				return err
+			case hitsDir:
+				hits := backupHits{}
+				err := json.Unmarshal(line, &hits)
+				counts[dir] += len(hits.Hits)
+				return err
+			}
+			counts[dir]++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// scrollBackupSegments returns the segments of a collection with their vectors
+func scrollBackupSegments(t *testing.T, knowledgeBase *KnowledgeBase, collectionID string) []*types.Document {
+	res, err := knowledgeBase.Vector.ScrollDocuments(context.Background(), &types.ScrollOptions{
+		CollectionName:  collectionID,
+		Limit:           100,
+		IncludeMetadata: true,
+		IncludeContent:  true,
+		IncludeVector:   true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to scroll the segments of %s: %v", collectionID, err)
+	}
+	if len(res.Documents) != 3 {
+		t.Errorf("Expected 3 segments in %s, got %d", collectionID, len(res.Documents))
+	}
+	return res.Documents
+}
+
+// removeBackupCollection removes a restored collection
+func removeBackupCollection(collectionID string) {
+	if Instance != nil {
+		Instance.RemoveCollection(context.Background(), collectionID)
+	}
+	if knowledgeBase, ok := Instance.(*KnowledgeBase); ok {
+		knowledgeBase.Config.RemoveDocumentsByCollectionID(collectionID)
+		knowledgeBase.Config.RemoveCollection(collectionID)
+	}
+}
@@ -0,0 +1,455 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/graphrag/types"
+)
+
+// maxBodySize the size of a page read at most, 10MB
+const maxBodySize = 10 << 20
+
+// Options the options of a crawler
+type Options struct {
+	MaxDepth     int               // Depth of the links followed from the seed, 0 crawls the seed (or the urls of the sitemap) only
+	MaxPages     int               // Pages crawled at most, default is 100
+	Include      []string          // Glob patterns of the urls to crawl, default is the host of the seed
+	Exclude      []string          // Glob patterns of the urls to skip
+	IgnoreRobots bool              // Crawl the urls disallowed by robots.txt
+	Delay        time.Duration     // Delay between two requests to a host, the Crawl-delay of robots.txt is used if longer
+	UserAgent    string            // Default is GraphRAG-Crawler/1.0
+	Headers      map[string]string // Custom headers of the requests
+	Timeout      time.Duration     // Timeout of a request, default is 30 seconds
+}
+
+// Page a crawled page
+type Page struct {
+	URL         string // Canonical url of the page
+	Title       string
+	Text        string // Readable text, the scripts, styles and tags are removed
+	ContentType string
+	Depth       int // Depth of the page from the seed
+}
+
+// Result the statistics of a crawl
+type Result struct {
+	Pages      int `json:"pages"`      // Pages passed to the handler
+	Skipped    int `json:"skipped"`    // Urls disallowed by robots.txt, noindex pages, empty or binary contents
+	Duplicates int `json:"duplicates"` // Pages of an url or a canonical url already crawled
+	Failed     int `json:"failed"`     // Requests failed
+}
+
+// Crawler crawls a site from a seed url or a sitemap.xml, it is also a fetcher of one page
+type Crawler struct {
+	options Options
+	include []glob
+	exclude []glob
+	client  *http.Client
+
+	mu     sync.Mutex
+	robots map[string]*robots   // robots.txt by scheme://host
+	last   map[string]time.Time // Last request by host
+}
+
+// response a fetched url
+type response struct {
+	url         *url.URL // Url after the redirects
+	contentType string
+	body        []byte
+}
+
+// queued an url to crawl
+type queued struct {
+	url     string
+	depth   int
+	sitemap bool // A sitemap of a sitemap index
+}
+
+// New creates a crawler
+func New(options Options) (*Crawler, error) {
+	if options.MaxDepth < 0 {
+		options.MaxDepth = 0
+	}
+	if options.MaxPages <= 0 {
+		options.MaxPages = 100
+	}
+	if options.UserAgent == "" {
+		options.UserAgent = "GraphRAG-Crawler/1.0"
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 30 * time.Second
+	}
+
+	include, err := compileGlobs(options.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := compileGlobs(options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Crawler{
+		options: options,
+		include: include,
+		exclude: exclude,
+		client:  &http.Client{Timeout: options.Timeout},
+		robots:  map[string]*robots{},
+		last:    map[string]time.Time{},
+	}, nil
+}
+
+// Options returns the options of the crawler
+func (c *Crawler) Options() Options {
+	return c.options
+}
+
+// Crawl crawls the pages from a seed url, breadth first. A seed which is a sitemap.xml, or a sitemap index,
+// seeds the crawl with its urls. The handler is called for each page, an error of the handler stops the crawl.
+func (c *Crawler) Crawl(ctx context.Context, seed string, handler func(page *Page) error) (*Result, error) {
+	start, err := normalizeURL(seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed url %s: %w", seed, err)
+	}
+
+	seedURL, _ := url.Parse(start)
+	result := &Result{}
+	queue := []queued{{url: start}}
+	seen := map[string]bool{start: true}
+
+	enqueue := func(link string, depth int, sitemap bool) {
+		if seen[link] || !c.inScopeURL(seedURL, link) {
+			return
+		}
+		seen[link] = true
+		queue = append(queue, queued{url: link, depth: depth, sitemap: sitemap})
+	}
+
+	for len(queue) > 0 && result.Pages < c.options.MaxPages {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		item := queue[0]
+		queue = queue[1:]
+
+		link, _ := url.Parse(item.url)
+		if !c.allowed(ctx, link) {
+			result.Skipped++
+			continue
+		}
+
+		res, err := c.get(ctx, link)
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			result.Failed++
+			continue
+		}
+
+		// The seed and the sitemaps of an index are read as sitemaps, their urls are seeds
+		if (item.sitemap || item.url == start) && isXML(res.contentType) {
+			if sm, ok := parseSitemap(bytes.NewReader(res.body)); ok {
+				for _, loc := range sm.sitemaps {
+					if next, err := normalizeURL(loc); err == nil {
+						enqueue(next, 0, true)
+					}
+				}
+				for _, loc := range sm.urls {
+					if next, err := normalizeURL(loc); err == nil {
+						enqueue(next, 0, false)
+					}
+				}
+				continue
+			}
+		}
+
+		// A redirect to an url already crawled or out of the scope is skipped
+		final, err := normalize(res.url)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		if final != item.url {
+			if seen[final] {
+				result.Duplicates++
+				continue
+			}
+			if item.url != start && !c.inScope(seedURL, res.url) {
+				result.Skipped++
+				continue
+			}
+			seen[final] = true
+		}
+
+		page := &Page{URL: final, ContentType: res.contentType, Depth: item.depth}
+		switch {
+		case isHTML(res.contentType):
+			doc, err := parseHTML(bytes.NewReader(res.body))
+			if err != nil {
+				result.Failed++
+				continue
+			}
+
+			base := res.url
+			if doc.base != "" {
+				if ref, err := url.Parse(doc.base); err == nil {
+					base = res.url.ResolveReference(ref)
+				}
+			}
+
+			// The page of a canonical url already crawled is a duplicate, a canonical url out of the scope is ignored
+			if doc.canonical != "" {
+				if canonical, ok := resolveURL(base, doc.canonical); ok && canonical != final && c.inScopeURL(seedURL, canonical) {
+					if seen[canonical] {
+						result.Duplicates++
+						continue
+					}
+					seen[canonical] = true
+					page.URL = canonical
+				}
+			}
+
+			if !doc.nofollow && item.depth < c.options.MaxDepth {
+				for _, href := range doc.links {
+					if next, ok := resolveURL(base, href); ok {
+						enqueue(next, item.depth+1, false)
+					}
+				}
+			}
+
+			if doc.noindex {
+				result.Skipped++
+				continue
+			}
+			page.Title = doc.title
+			page.Text = doc.text
+
+		case isText(res.contentType):
+			page.Text = strings.TrimSpace(string(res.body))
+
+		default:
+			result.Skipped++
+			continue
+		}
+
+		if page.Text == "" {
+			result.Skipped++
+			continue
+		}
+
+		result.Pages++
+		if err := handler(page); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Fetch fetches one page, respecting robots.txt. The text of an html page is returned as text/plain, the same
+// text as a crawled page, other contents are returned as the HTTP fetcher does.
+func (c *Crawler) Fetch(ctx context.Context, link string, callback ...types.FetcherProgress) (string, string, error) {
+	normalized, err := normalizeURL(link)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid url %s: %w", link, err)
+	}
+
+	u, _ := url.Parse(normalized)
+	if !c.allowed(ctx, u) {
+		return "", "", fmt.Errorf("%s is disallowed by robots.txt", link)
+	}
+
+	res, err := c.get(ctx, u)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case isHTML(res.contentType):
+		doc, err := parseHTML(bytes.NewReader(res.body))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to parse %s: %w", link, err)
+		}
+		return doc.text, "text/plain", nil
+
+	case isText(res.contentType):
+		return string(res.body), res.contentType, nil
+	}
+
+	return base64.StdEncoding.EncodeToString(res.body), res.contentType, nil
+}
+
+// inScope checks if an url is crawled: it matches the include patterns, the host of the seed by default, and
+// does not match the exclude patterns
+func (c *Crawler) inScope(seed *url.URL, link *url.URL) bool {
+	if len(c.include) == 0 {
+		if !strings.EqualFold(link.Host, seed.Host) {
+			return false
+		}
+	} else if !matchGlobs(c.include, link) {
+		return false
+	}
+	return !matchGlobs(c.exclude, link)
+}
+
+// inScopeURL checks if an url string is crawled
+func (c *Crawler) inScopeURL(seed *url.URL, link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && c.inScope(seed, u)
+}
+
+// allowed checks robots.txt of the host of an url, a robots.txt which can not be read allows everything
+func (c *Crawler) allowed(ctx context.Context, link *url.URL) bool {
+	if c.options.IgnoreRobots {
+		return true
+	}
+
+	path := link.EscapedPath()
+	if link.RawQuery != "" {
+		path += "?" + link.RawQuery
+	}
+	return c.robotsOf(ctx, link).allowed(path)
+}
+
+// robotsOf returns the robots.txt of the host of an url, read once
+func (c *Crawler) robotsOf(ctx context.Context, link *url.URL) *robots {
+	origin := link.Scheme + "://" + link.Host
+
+	c.mu.Lock()
+	r, ok := c.robots[origin]
+	c.mu.Unlock()
+	if ok {
+		return r
+	}
+
+	r = &robots{}
+	robotsURL, _ := url.Parse(origin + "/robots.txt")
+	if res, err := c.get(ctx, robotsURL); err == nil {
+		r = parseRobots(bytes.NewReader(res.body), c.options.UserAgent)
+	}
+
+	c.mu.Lock()
+	c.robots[origin] = r
+	c.mu.Unlock()
+	return r
+}
+
+// get requests an url, waiting for the delay of its host
+func (c *Crawler) get(ctx context.Context, link *url.URL) (*response, error) {
+	if err := c.wait(ctx, link.Host); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.options.UserAgent)
+	for key, value := range c.options.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.client.Do(req)
+	c.mu.Lock()
+	c.last[link.Host] = time.Now()
+	c.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", link, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", link, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", link, err)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	return &response{url: resp.Request.URL, contentType: contentType, body: body}, nil
+}
+
+// wait waits for the delay of a host since its last request
+func (c *Crawler) wait(ctx context.Context, host string) error {
+	delay := c.options.Delay
+
+	c.mu.Lock()
+	last := c.last[host]
+	for origin, r := range c.robots {
+		if strings.HasSuffix(origin, "://"+host) && r.delay > delay {
+			delay = r.delay
+		}
+	}
+	c.mu.Unlock()
+
+	if last.IsZero() || delay <= 0 {
+		return nil
+	}
+
+	wait := time.Until(last.Add(delay))
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// mediaType returns the media type of a content type, lower case
+func mediaType(contentType string) string {
+	media, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return media
+}
+
+// isHTML checks if a content type is html
+func isHTML(contentType string) bool {
+	media := mediaType(contentType)
+	return media == "text/html" || media == "application/xhtml+xml"
+}
+
+// isXML checks if a content type is xml
+func isXML(contentType string) bool {
+	media := mediaType(contentType)
+	return media == "application/xml" || media == "text/xml" || strings.HasSuffix(media, "+xml")
+}
+
+// isText checks if a content type is a readable text
+func isText(contentType string) bool {
+	media := mediaType(contentType)
+	if strings.HasPrefix(media, "text/") {
+		return true
+	}
+	switch media {
+	case "application/json", "application/xml", "application/x-yaml", "application/yaml":
+		return true
+	}
+	return strings.HasSuffix(media, "+json") || strings.HasSuffix(media, "+xml")
+}
@@ -0,0 +1,236 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSite a local site: / links the pages, /private is disallowed by robots.txt, /alias is canonical to /about,
+// /moved is canonical to another host and listed by the sitemap only
+func testSite(t *testing.T, robots string) (*httptest.Server, *[]string) {
+	requests := []string{}
+	mux := http.NewServeMux()
+	page := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, body)
+		}
+	}
+
+	var server *httptest.Server
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		if robots == "" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, robots)
+	})
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>%[1]s/about</loc></url>
+  <url><loc>%[1]s/docs/guide</loc></url>
+  <url><loc>%[1]s/moved</loc></url>
+  <url><loc>https://other.example.com/page</loc></url>
+</urlset>`, server.URL)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		page(`<html><head><title>Home</title><style>body{}</style></head><body>
+<nav><a href="/about">About</a> <a href="/docs/guide#intro">Guide</a> <a href="/alias">Alias</a></nav>
+<p>Welcome home</p><script>var x = 1;</script>
+<a href="/private/secret">Secret</a> <a href="mailto:a@b.c">Mail</a> <a href="https://other.example.com/">Other</a>
+</body></html>`)(w, r)
+	})
+	mux.HandleFunc("/about", page(`<html><head><title>About</title></head><body><p>About us</p><a href="/">Home</a></body></html>`))
+	mux.HandleFunc("/alias", page(`<html><head><link rel="canonical" href="/about"></head><body><p>About us</p></body></html>`))
+	mux.HandleFunc("/moved", page(`<html><head><link rel="canonical" href="https://other.example.com/moved"></head><body><p>Moved</p></body></html>`))
+	mux.HandleFunc("/docs/guide", page(`<html><head><title>Guide</title></head><body><h1>Guide</h1><p>Read <a href="/docs/api">the API</a></p></body></html>`))
+	mux.HandleFunc("/docs/api", page(`<html><head><title>API</title></head><body><p>API reference</p></body></html>`))
+	mux.HandleFunc("/private/secret", page(`<html><body><p>Secret</p></body></html>`))
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// crawl crawls a site and returns the urls of the pages, without the host, sorted
+func crawl(t *testing.T, server *httptest.Server, seed string, options Options) ([]string, map[string]*Page, *Result) {
+	c, err := New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages := map[string]*Page{}
+	urls := []string{}
+	result, err := c.Crawl(context.Background(), server.URL+seed, func(page *Page) error {
+		path := strings.TrimPrefix(page.URL, server.URL)
+		urls = append(urls, path)
+		pages[path] = page
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(urls)
+	return urls, pages, result
+}
+
+func TestCrawl(t *testing.T) {
+	server, requests := testSite(t, "User-agent: *\nDisallow: /private\n")
+
+	urls, pages, result := crawl(t, server, "/", Options{MaxDepth: 2})
+	expected := []string{"/", "/about", "/docs/api", "/docs/guide"}
+	if strings.Join(urls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected the pages %v, got %v", expected, urls)
+	}
+
+	if result.Pages != 4 || result.Skipped != 1 || result.Duplicates != 1 {
+		t.Errorf("Expected 4 pages, 1 skipped and 1 duplicate, got %+v", result)
+	}
+
+	home := pages["/"]
+	if home.Title != "Home" || home.Depth != 0 {
+		t.Errorf("Expected the home page at depth 0, got %+v", home)
+	}
+	if strings.Contains(home.Text, "var x") || strings.Contains(home.Text, "body{}") || !strings.Contains(home.Text, "Welcome home") {
+		t.Errorf("Expected the text without scripts and styles, got %q", home.Text)
+	}
+	if pages["/docs/api"].Depth != 2 {
+		t.Errorf("Expected the API page at depth 2, got %d", pages["/docs/api"].Depth)
+	}
+
+	for _, path := range *requests {
+		if strings.HasPrefix(path, "/private") {
+			t.Errorf("Expected %s not requested, it is disallowed by robots.txt", path)
+		}
+	}
+}
+
+func TestCrawlLimits(t *testing.T) {
+	server, _ := testSite(t, "")
+
+	t.Run("depth", func(t *testing.T) {
+		urls, _, _ := crawl(t, server, "/", Options{MaxDepth: 1})
+		for _, url := range urls {
+			if url == "/docs/api" {
+				t.Errorf("Expected the pages of depth 1 only, got %v", urls)
+			}
+		}
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		urls, _, result := crawl(t, server, "/", Options{MaxDepth: 3, MaxPages: 2})
+		if len(urls) != 2 || result.Pages != 2 {
+			t.Errorf("Expected 2 pages, got %v", urls)
+		}
+	})
+
+	t.Run("include and exclude", func(t *testing.T) {
+		urls, _, _ := crawl(t, server, "/", Options{MaxDepth: 3, Include: []string{"/docs/**"}, Exclude: []string{"/docs/api"}})
+		expected := []string{"/", "/docs/guide"}
+		if strings.Join(urls, ",") != strings.Join(expected, ",") {
+			t.Errorf("Expected the pages %v, got %v", expected, urls)
+		}
+	})
+
+	t.Run("ignore robots", func(t *testing.T) {
+		urls, _, _ := crawl(t, server, "/", Options{MaxDepth: 1, IgnoreRobots: true})
+		found := false
+		for _, url := range urls {
+			found = found || url == "/private/secret"
+		}
+		if !found {
+			t.Errorf("Expected the private page crawled, got %v", urls)
+		}
+	})
+}
+
+func TestCrawlSitemap(t *testing.T) {
+	server, _ := testSite(t, "")
+
+	// The urls of another host are out of the scope, the canonical url of /moved too
+	urls, pages, result := crawl(t, server, "/sitemap.xml", Options{MaxDepth: 0})
+	expected := []string{"/about", "/docs/guide", "/moved"}
+	if strings.Join(urls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected the pages of the sitemap %v, got %v", expected, urls)
+	}
+	if pages["/docs/guide"].Depth != 0 {
+		t.Errorf("Expected the urls of the sitemap at depth 0, got %d", pages["/docs/guide"].Depth)
+	}
+	if result.Failed != 0 || result.Duplicates != 0 {
+		t.Errorf("Expected no request out of the scope, got %+v", result)
+	}
+
+	urls, _, _ = crawl(t, server, "/sitemap.xml", Options{MaxDepth: 0, Exclude: []string{"/docs/**"}})
+	expected = []string{"/about", "/moved"}
+	if strings.Join(urls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected the pages of the sitemap in the scope %v, got %v", expected, urls)
+	}
+}
+
+func TestCrawlDelay(t *testing.T) {
+	server, _ := testSite(t, "User-agent: *\nCrawl-delay: 0.1\n")
+
+	start := time.Now()
+	urls, _, _ := crawl(t, server, "/", Options{MaxDepth: 1, MaxPages: 3})
+	if elapsed := time.Since(start); elapsed < time.Duration(len(urls))*100*time.Millisecond {
+		t.Errorf("Expected a delay of 100ms between the requests, %d pages crawled in %s", len(urls), elapsed)
+	}
+}
+
+func TestCrawlCancel(t *testing.T) {
+	server, _ := testSite(t, "")
+
+	c, err := New(Options{MaxDepth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := fmt.Errorf("stop")
+	result, err := c.Crawl(context.Background(), server.URL, func(page *Page) error { return stop })
+	if err != stop || result.Pages != 1 {
+		t.Errorf("Expected the crawl stopped by the handler, got %v %+v", err, result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Crawl(ctx, server.URL, func(page *Page) error { return nil }); err != context.Canceled {
+		t.Errorf("Expected the crawl canceled, got %v", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server, _ := testSite(t, "User-agent: *\nDisallow: /private\n")
+
+	c, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, mimeType, err := c.Fetch(context.Background(), server.URL+"/about")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "About us\nHome" || mimeType != "text/plain" {
+		t.Errorf("Expected the text of the page, got %q %s", text, mimeType)
+	}
+
+	if _, _, err := c.Fetch(context.Background(), server.URL+"/private/secret"); err == nil {
+		t.Error("Expected an error for a page disallowed by robots.txt")
+	}
+}
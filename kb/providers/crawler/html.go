@@ -0,0 +1,161 @@
+package crawler
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// document the content of an html page
+type document struct {
+	title     string
+	text      string
+	canonical string   // href of the <link rel="canonical">
+	base      string   // href of the <base>
+	links     []string // href of the <a> not marked nofollow
+	noindex   bool     // <meta name="robots" content="noindex">
+	nofollow  bool     // <meta name="robots" content="nofollow">
+}
+
+// skippedElements the elements without readable text
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Svg:      true,
+	atom.Iframe:   true,
+}
+
+// blockElements the elements starting a new line of the text
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Br: true,
+	atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true,
+	atom.Footer: true, atom.Form: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true,
+	atom.Nav: true, atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true, atom.Table: true,
+	atom.Td: true, atom.Th: true, atom.Tr: true, atom.Ul: true,
+}
+
+// parseHTML reads the title, the text, the canonical url and the links of a page
+func parseHTML(r io.Reader) (*document, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &document{links: []string{}}
+	var text strings.Builder
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			switch node.DataAtom {
+			case atom.Title:
+				if doc.title == "" {
+					doc.title = strings.Join(strings.Fields(nodeText(node)), " ")
+				}
+
+			case atom.Link:
+				if hasToken(attr(node, "rel"), "canonical") && doc.canonical == "" {
+					doc.canonical = attr(node, "href")
+				}
+
+			case atom.Base:
+				if doc.base == "" {
+					doc.base = attr(node, "href")
+				}
+
+			case atom.Meta:
+				if strings.EqualFold(attr(node, "name"), "robots") {
+					content := attr(node, "content")
+					doc.noindex = doc.noindex || hasToken(content, "noindex") || hasToken(content, "none")
+					doc.nofollow = doc.nofollow || hasToken(content, "nofollow") || hasToken(content, "none")
+				}
+
+			case atom.A:
+				if href := attr(node, "href"); href != "" && !hasToken(attr(node, "rel"), "nofollow") {
+					doc.links = append(doc.links, href)
+				}
+			}
+
+			if skippedElements[node.DataAtom] {
+				return
+			}
+		}
+
+		// The head is walked for the metadata, its text is not read
+		if node.Type == html.TextNode && !inHead(node) {
+			text.WriteString(node.Data)
+		}
+
+		block := node.Type == html.ElementNode && blockElements[node.DataAtom]
+		if block {
+			text.WriteString("\n")
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if block {
+			text.WriteString("\n")
+		}
+	}
+	walk(root)
+
+	doc.text = cleanText(text.String())
+	return doc, nil
+}
+
+// cleanText collapses the spaces of each line and removes the empty lines
+func cleanText(text string) string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// nodeText returns the text of the children of a node
+func nodeText(node *html.Node) string {
+	var text strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.TextNode {
+			text.WriteString(child.Data)
+		}
+	}
+	return text.String()
+}
+
+// inHead checks if a node is in the head of the page
+func inHead(node *html.Node) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent.Type == html.ElementNode && parent.DataAtom == atom.Head {
+			return true
+		}
+	}
+	return false
+}
+
+// attr returns the value of an attribute of a node
+func attr(node *html.Node, name string) string {
+	for _, a := range node.Attr {
+		if strings.EqualFold(a.Key, name) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasToken checks if a space or comma separated list contains a token, case insensitive
+func hasToken(list string, token string) bool {
+	for _, value := range strings.FieldsFunc(list, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if strings.EqualFold(value, token) {
+			return true
+		}
+	}
+	return false
+}
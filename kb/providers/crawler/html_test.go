@@ -0,0 +1,50 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html>
+<head>
+  <title> Yao
+  Guide </title>
+  <base href="/docs/">
+  <link rel="canonical" href="https://example.com/docs/guide">
+  <meta name="robots" content="noindex, nofollow">
+  <style>h1 { color: red; }</style>
+</head>
+<body>
+  <h1>Getting   started</h1>
+  <p>Install <b>Yao</b> first.</p>
+  <ul><li><a href="install">Install</a></li><li><a href="/blog" rel="nofollow">Blog</a></li></ul>
+  <script>console.log("hidden")</script>
+  <noscript>Enable JavaScript</noscript>
+</body>
+</html>`
+
+	doc, err := parseHTML(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.title != "Yao Guide" {
+		t.Errorf("Expected the title Yao Guide, got %q", doc.title)
+	}
+	if doc.canonical != "https://example.com/docs/guide" || doc.base != "/docs/" {
+		t.Errorf("Expected the canonical and base urls, got %q %q", doc.canonical, doc.base)
+	}
+	if !doc.noindex || !doc.nofollow {
+		t.Error("Expected the robots meta read")
+	}
+	if len(doc.links) != 1 || doc.links[0] != "install" {
+		t.Errorf("Expected the links without nofollow, got %v", doc.links)
+	}
+
+	expected := "Getting started\nInstall Yao first.\nInstall\nBlog"
+	if doc.text != expected {
+		t.Errorf("Expected the text %q, got %q", expected, doc.text)
+	}
+}
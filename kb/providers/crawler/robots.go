@@ -0,0 +1,154 @@
+package crawler
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robots the rules of a robots.txt for the user agent of the crawler
+type robots struct {
+	rules []robotsRule
+	delay time.Duration // Crawl-delay of the group, 0 if not set
+}
+
+// robotsRule an Allow or Disallow rule
+type robotsRule struct {
+	allow   bool
+	length  int // Length of the pattern, the longest matching rule wins
+	pattern *regexp.Regexp
+}
+
+// robotsGroup a group of rules of one or several user agents
+type robotsGroup struct {
+	agents []string
+	rules  []robotsRule
+	delay  time.Duration
+}
+
+// parseRobots parses a robots.txt and keeps the group of the user agent: the group of the longest user agent
+// token found in the user agent of the crawler, the * group otherwise.
+func parseRobots(r io.Reader, userAgent string) *robots {
+	groups := []*robotsGroup{}
+	var group *robotsGroup
+	inAgents := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// Consecutive user agents share the rules of the group
+			if !inAgents || group == nil {
+				group = &robotsGroup{}
+				groups = append(groups, group)
+			}
+			group.agents = append(group.agents, strings.ToLower(value))
+			inAgents = true
+
+		case "allow", "disallow":
+			inAgents = false
+			if group == nil {
+				continue
+			}
+			// An empty Disallow allows everything
+			if value == "" {
+				continue
+			}
+			group.rules = append(group.rules, robotsRule{
+				allow:   key == "allow",
+				length:  len(value),
+				pattern: robotsPattern(value),
+			})
+
+		case "crawl-delay":
+			inAgents = false
+			if group == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				group.delay = time.Duration(seconds * float64(time.Second))
+			}
+
+		default:
+			inAgents = false
+		}
+	}
+
+	agent := strings.ToLower(userAgent)
+	var matched, wildcard *robotsGroup
+	matchedLength := 0
+	for _, g := range groups {
+		for _, token := range g.agents {
+			if token == "*" {
+				if wildcard == nil {
+					wildcard = g
+				}
+				continue
+			}
+			if token != "" && strings.Contains(agent, token) && len(token) > matchedLength {
+				matched = g
+				matchedLength = len(token)
+			}
+		}
+	}
+
+	if matched == nil {
+		matched = wildcard
+	}
+	if matched == nil {
+		return &robots{}
+	}
+	return &robots{rules: matched.rules, delay: matched.delay}
+}
+
+// robotsPattern compiles the path of a rule: * matches any characters, a trailing $ anchors the end of the url
+func robotsPattern(value string) *regexp.Regexp {
+	anchored := strings.HasSuffix(value, "$")
+	value = strings.TrimSuffix(value, "$")
+
+	parts := strings.Split(value, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// allowed checks if a path, with its query, may be crawled. The longest matching rule wins, Allow wins a tie.
+func (r *robots) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	allowed := true
+	length := -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > length || (rule.length == length && rule.allow) {
+			allowed = rule.allow
+			length = rule.length
+		}
+	}
+	return allowed
+}
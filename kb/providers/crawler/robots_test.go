@@ -0,0 +1,66 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRobots(t *testing.T) {
+	txt := `# Robots
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+Crawl-delay: 2
+
+User-agent: BadBot
+User-agent: GraphRAG-Crawler
+Disallow: /
+Allow: /docs
+Crawl-delay: 0.5
+`
+
+	t.Run("wildcard group", func(t *testing.T) {
+		r := parseRobots(strings.NewReader(txt), "Mozilla/5.0")
+		if r.delay != 2*time.Second {
+			t.Errorf("Expected a delay of 2s, got %s", r.delay)
+		}
+
+		cases := map[string]bool{
+			"/":                    true,
+			"/private":             false,
+			"/private/page":        false,
+			"/private/public/page": true,
+			"/file.pdf":            false,
+			"/file.pdf?download=1": true,
+		}
+		for path, expected := range cases {
+			if r.allowed(path) != expected {
+				t.Errorf("Expected %s allowed to be %v", path, expected)
+			}
+		}
+	})
+
+	t.Run("user agent group", func(t *testing.T) {
+		r := parseRobots(strings.NewReader(txt), "GraphRAG-Crawler/1.0")
+		if r.delay != 500*time.Millisecond {
+			t.Errorf("Expected a delay of 500ms, got %s", r.delay)
+		}
+		if r.allowed("/blog") || !r.allowed("/docs/guide") {
+			t.Error("Expected /docs allowed only")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		r := parseRobots(strings.NewReader("User-agent: *\nDisallow:\n"), "GraphRAG-Crawler/1.0")
+		if !r.allowed("/anything") {
+			t.Error("Expected an empty Disallow to allow everything")
+		}
+
+		var none *robots
+		if !none.allowed("/anything") {
+			t.Error("Expected no robots.txt to allow everything")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package crawler
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// sitemap the urls of a sitemap.xml, or the sitemaps of a sitemap index
+type sitemap struct {
+	urls     []string
+	sitemaps []string
+}
+
+// sitemapXML a <urlset> or a <sitemapindex>
+type sitemapXML struct {
+	XMLName xml.Name
+	URLs    []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// parseSitemap parses a sitemap.xml, a document which is not a sitemap returns false
+func parseSitemap(r io.Reader) (*sitemap, bool) {
+	var data sitemapXML
+	if err := xml.NewDecoder(r).Decode(&data); err != nil {
+		return nil, false
+	}
+
+	if data.XMLName.Local != "urlset" && data.XMLName.Local != "sitemapindex" {
+		return nil, false
+	}
+
+	result := &sitemap{urls: []string{}, sitemaps: []string{}}
+	for _, u := range data.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			result.urls = append(result.urls, loc)
+		}
+	}
+	for _, s := range data.Sitemaps {
+		if loc := strings.TrimSpace(s.Loc); loc != "" {
+			result.sitemaps = append(result.sitemaps, loc)
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,133 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// normalizeURL returns the canonical form of an url: the scheme and the host are lower case, the default port,
+// the fragment and the empty query are removed, the query parameters are sorted.
+func normalizeURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	return normalize(u)
+}
+
+// normalize returns the canonical form of a parsed url
+func normalize(u *url.URL) (string, error) {
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme of %s", u.String())
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("the host of %s is required", u.String())
+	}
+
+	n := *u
+	n.Scheme = scheme
+	n.Host = strings.ToLower(u.Host)
+	n.Fragment = ""
+	n.RawFragment = ""
+	n.User = nil
+
+	if port := n.Port(); (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		n.Host = strings.TrimSuffix(n.Host, ":"+port)
+	}
+
+	if n.Path == "" {
+		n.Path = "/"
+		n.RawPath = ""
+	}
+
+	if n.RawQuery != "" {
+		n.RawQuery = n.Query().Encode()
+	}
+	n.ForceQuery = false
+	return n.String(), nil
+}
+
+// resolveURL resolves a link of a page, the links to other schemes (mailto:, javascript:...) are ignored
+func resolveURL(base *url.URL, href string) (string, bool) {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return "", false
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+
+	link, err := normalize(base.ResolveReference(ref))
+	if err != nil {
+		return "", false
+	}
+	return link, true
+}
+
+// glob a compiled glob pattern of the urls
+type glob struct {
+	re       *regexp.Regexp
+	absolute bool // The pattern has a scheme and matches the whole url
+}
+
+// compileGlobs compiles the glob patterns of the urls
+func compileGlobs(patterns []string) ([]glob, error) {
+	globs := []glob{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		re, err := globRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		globs = append(globs, glob{re: re, absolute: strings.Contains(pattern, "://")})
+	}
+	return globs, nil
+}
+
+// globRegexp converts a glob pattern to a regexp: ** matches any characters, * matches any characters but /,
+// ? matches one character but /. A pattern with a scheme matches the whole url, other patterns match the path.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				expr.WriteString(".*")
+				i++
+				continue
+			}
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
+
+// matchGlobs checks if an url matches one of the patterns
+func matchGlobs(globs []glob, link *url.URL) bool {
+	for _, g := range globs {
+		subject := link.Path
+		if g.absolute {
+			subject = link.String()
+		}
+		if g.re.MatchString(subject) {
+			return true
+		}
+	}
+	return false
+}
@@ -21,6 +21,8 @@ const (
 	ProviderTypeExtraction ProviderType = "extraction"
 	// ProviderTypeFetcher is a type for fetcher providers
 	ProviderTypeFetcher ProviderType = "fetcher"
+	// ProviderTypeReranker is a type for reranker providers
+	ProviderTypeReranker ProviderType = "reranker"
 )
 
 // DetectMatch is a match for auto detect
@@ -44,6 +46,9 @@ var Extractions = map[string]Extraction{}
 // Fetchers is a map of fetcher providers
 var Fetchers = map[string]Fetcher{}
 
+// Rerankers is a map of reranker providers
+var Rerankers = map[string]Reranker{}
+
 // === Chunking API ===
 
 // MakeChunking creates a new chunking provider
@@ -126,6 +131,17 @@ func MakeFetcher(id string, option *kbtypes.ProviderOption) (types.Fetcher, erro
 	return fetcher.Make(option)
 }
 
+// === Reranker API ===
+
+// MakeReranker creates a new reranker provider
+func MakeReranker(id string, option *kbtypes.ProviderOption) (kbtypes.Reranker, error) {
+	reranker, ok := Rerankers[id]
+	if !ok {
+		return nil, fmt.Errorf("reranker provider %s not found", id)
+	}
+	return reranker.Make(option)
+}
+
 // === Schema API ===
 
 // GetSchema returns the schema for a provider
@@ -143,6 +159,8 @@ func GetSchema(typ ProviderType, provider *kbtypes.Provider, locale string) (*kb
 		schema, exists = Extractions[provider.ID]
 	case ProviderTypeFetcher:
 		schema, exists = Fetchers[provider.ID]
+	case ProviderTypeReranker:
+		schema, exists = Rerankers[provider.ID]
 	}
 	if !exists {
 		return nil, fmt.Errorf("%s provider %s not found", typ, provider.ID)
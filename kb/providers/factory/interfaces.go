@@ -37,6 +37,12 @@ type Fetcher interface {
 	Schema
 }
 
+// Reranker is a factory for reranker providers
+type Reranker interface {
+	Make(option *kbtypes.ProviderOption) (kbtypes.Reranker, error)
+	Schema
+}
+
 // Schema interface for providers
 type Schema interface {
 	Schema(provider *kbtypes.Provider, locale string) (*kbtypes.ProviderSchema, error)
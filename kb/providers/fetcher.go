@@ -1,10 +1,12 @@
 package providers
 
 import (
+	"strings"
 	"time"
 
 	"github.com/yaoapp/gou/graphrag/fetcher"
 	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/yao/kb/providers/crawler"
 	"github.com/yaoapp/yao/kb/providers/factory"
 	kbtypes "github.com/yaoapp/yao/kb/types"
 )
@@ -15,10 +17,14 @@ type FetcherHTTP struct{}
 // FetcherMCP is an MCP fetcher provider
 type FetcherMCP struct{}
 
+// FetcherCrawler is a web crawler fetcher provider
+type FetcherCrawler struct{}
+
 // AutoRegister registers the fetcher providers
 func init() {
 	factory.Fetchers["__yao.http"] = &FetcherHTTP{}
 	factory.Fetchers["__yao.mcp"] = &FetcherMCP{}
+	factory.Fetchers["__yao.crawler"] = &FetcherCrawler{}
 }
 
 // === FetcherHTTP ===
@@ -158,3 +164,126 @@ func (f *FetcherMCP) Make(option *kbtypes.ProviderOption) (types.Fetcher, error)
 func (f *FetcherMCP) Schema(provider *kbtypes.Provider, locale string) (*kbtypes.ProviderSchema, error) {
 	return factory.GetSchemaFromBindata(factory.ProviderTypeFetcher, "mcp", locale)
 }
+
+// === FetcherCrawler ===
+
+// Make creates a new web crawler, a fetcher of one page and the crawler of a site
+func (f *FetcherCrawler) Make(option *kbtypes.ProviderOption) (types.Fetcher, error) {
+	// Start with default values
+	options := crawler.Options{
+		MaxDepth:     2,                       // Default 2 levels of links
+		MaxPages:     100,                     // Default 100 pages
+		Include:      nil,                     // Default is the host of the seed
+		Exclude:      nil,                     // Optional patterns to skip
+		IgnoreRobots: false,                   // Respect robots.txt by default
+		Delay:        0,                       // Crawl-delay of robots.txt only
+		UserAgent:    "GraphRAG-Crawler/1.0",  // Default user agent
+		Headers:      make(map[string]string), // Custom headers
+		Timeout:      30 * time.Second,        // Default 30 seconds per page
+	}
+
+	// Extract values from Properties map
+	if option != nil && option.Properties != nil {
+		// Set max depth
+		if maxDepth, ok := option.Properties["max_depth"]; ok {
+			if maxDepthInt, ok := maxDepth.(int); ok {
+				options.MaxDepth = maxDepthInt
+			} else if maxDepthFloat, ok := maxDepth.(float64); ok {
+				options.MaxDepth = int(maxDepthFloat)
+			}
+		}
+
+		// Set max pages
+		if maxPages, ok := option.Properties["max_pages"]; ok {
+			if maxPagesInt, ok := maxPages.(int); ok {
+				options.MaxPages = maxPagesInt
+			} else if maxPagesFloat, ok := maxPages.(float64); ok {
+				options.MaxPages = int(maxPagesFloat)
+			}
+		}
+
+		// Set include and exclude patterns, a list or a text of one pattern per line
+		if include, ok := option.Properties["include"]; ok {
+			options.Include = crawlerPatterns(include)
+		}
+		if exclude, ok := option.Properties["exclude"]; ok {
+			options.Exclude = crawlerPatterns(exclude)
+		}
+
+		// Set robots.txt
+		if respectRobots, ok := option.Properties["respect_robots"]; ok {
+			if respectRobotsBool, ok := respectRobots.(bool); ok {
+				options.IgnoreRobots = !respectRobotsBool
+			}
+		}
+
+		// Set delay (in seconds)
+		if delay, ok := option.Properties["delay"]; ok {
+			if delayInt, ok := delay.(int); ok {
+				options.Delay = time.Duration(delayInt) * time.Second
+			} else if delayFloat, ok := delay.(float64); ok {
+				options.Delay = time.Duration(delayFloat * float64(time.Second))
+			}
+		}
+
+		// Set user agent
+		if userAgent, ok := option.Properties["user_agent"]; ok {
+			if userAgentStr, ok := userAgent.(string); ok && userAgentStr != "" {
+				options.UserAgent = userAgentStr
+			}
+		}
+
+		// Set headers
+		if headers, ok := option.Properties["headers"]; ok {
+			if headersMap, ok := headers.(map[string]interface{}); ok {
+				for key, value := range headersMap {
+					if valueStr, ok := value.(string); ok && valueStr != "" {
+						options.Headers[key] = valueStr
+					}
+				}
+			}
+		}
+
+		// Set timeout (in seconds)
+		if timeout, ok := option.Properties["timeout"]; ok {
+			if timeoutInt, ok := timeout.(int); ok {
+				options.Timeout = time.Duration(timeoutInt) * time.Second
+			} else if timeoutFloat, ok := timeout.(float64); ok {
+				options.Timeout = time.Duration(timeoutFloat * float64(time.Second))
+			}
+		}
+	}
+
+	c, err := crawler.New(options)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Schema returns the schema for the web crawler fetcher provider
+func (f *FetcherCrawler) Schema(provider *kbtypes.Provider, locale string) (*kbtypes.ProviderSchema, error) {
+	return factory.GetSchemaFromBindata(factory.ProviderTypeFetcher, "crawler", locale)
+}
+
+// crawlerPatterns reads the glob patterns of the crawler, a list or a text of one pattern per line
+func crawlerPatterns(value interface{}) []string {
+	patterns := []string{}
+	switch v := value.(type) {
+	case string:
+		for _, line := range strings.Split(v, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				patterns = append(patterns, line)
+			}
+		}
+	case []string:
+		patterns = append(patterns, v...)
+	case []interface{}:
+		for _, item := range v {
+			if pattern, ok := item.(string); ok && pattern != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+	return patterns
+}
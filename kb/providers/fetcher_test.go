@@ -2,7 +2,9 @@ package providers
 
 import (
 	"testing"
+	"time"
 
+	"github.com/yaoapp/yao/kb/providers/crawler"
 	kbtypes "github.com/yaoapp/yao/kb/types"
 )
 
@@ -449,3 +451,73 @@ func TestFetcherMCP_Schema(t *testing.T) {
 		t.Error("Expected non-nil schema from factory.GetSchemaFromBindata")
 	}
 }
+
+func TestFetcherCrawler_Make(t *testing.T) {
+	fetcher := &FetcherCrawler{}
+
+	t.Run("nil option should return default crawler", func(t *testing.T) {
+		result, err := fetcher.Make(nil)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		c, ok := result.(*crawler.Crawler)
+		if !ok {
+			t.Fatalf("Expected crawler, got %T", result)
+		}
+		if options := c.Options(); options.MaxDepth != 2 || options.MaxPages != 100 || options.IgnoreRobots {
+			t.Errorf("Expected the default options, got %+v", options)
+		}
+	})
+
+	t.Run("empty option should return default crawler", func(t *testing.T) {
+		option := &kbtypes.ProviderOption{}
+		result, err := fetcher.Make(option)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if result == nil {
+			t.Error("Expected crawler, got nil")
+		}
+	})
+
+	t.Run("option with limits and patterns should work", func(t *testing.T) {
+		option := &kbtypes.ProviderOption{
+			Properties: map[string]interface{}{
+				"max_depth":      3,
+				"max_pages":      50.0,
+				"include":        "/docs/**\n\n/blog/*",
+				"exclude":        []interface{}{"/docs/internal/**"},
+				"respect_robots": false,
+				"delay":          0.5,
+				"user_agent":     "Custom-Crawler/1.0",
+				"timeout":        10,
+			},
+		}
+		result, err := fetcher.Make(option)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		options := result.(*crawler.Crawler).Options()
+		if options.MaxDepth != 3 || options.MaxPages != 50 || !options.IgnoreRobots {
+			t.Errorf("Expected the limits set, got %+v", options)
+		}
+		if len(options.Include) != 2 || options.Include[1] != "/blog/*" || len(options.Exclude) != 1 {
+			t.Errorf("Expected the patterns set, got %v %v", options.Include, options.Exclude)
+		}
+		if options.Delay != 500*time.Millisecond || options.Timeout != 10*time.Second || options.UserAgent != "Custom-Crawler/1.0" {
+			t.Errorf("Expected the delay, timeout and user agent set, got %+v", options)
+		}
+	})
+}
+
+func TestFetcherCrawler_Schema(t *testing.T) {
+	fetcher := &FetcherCrawler{}
+	schema, err := fetcher.Schema(nil, "en")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if schema == nil {
+		t.Error("Expected non-nil schema from factory.GetSchemaFromBindata")
+	}
+}
@@ -0,0 +1,145 @@
+package providers
+
+import (
+	"time"
+
+	"github.com/yaoapp/yao/kb/providers/factory"
+	"github.com/yaoapp/yao/kb/providers/rerankers"
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+// RerankerOpenAI is an OpenAI compatible rerank API provider
+type RerankerOpenAI struct{}
+
+// RerankerLLM is a LLM reranker provider
+type RerankerLLM struct{}
+
+// AutoRegister registers the reranker providers
+func init() {
+	factory.Rerankers["__yao.openai"] = &RerankerOpenAI{}
+	factory.Rerankers["__yao.llm"] = &RerankerLLM{}
+}
+
+// === RerankerOpenAI ===
+
+// Make creates a new OpenAI compatible reranker
+func (r *RerankerOpenAI) Make(option *kbtypes.ProviderOption) (kbtypes.Reranker, error) {
+	// Start with default values
+	options := rerankers.OpenAIOptions{
+		Connector: "",               // Optional - host, key and model of the connector
+		Host:      "",               // Required without connector
+		Key:       "",               // Optional API key
+		Model:     "",               // Model of the connector or of the API
+		Timeout:   60 * time.Second, // Default 1 minute
+	}
+
+	// Extract values from Properties map
+	if option != nil && option.Properties != nil {
+		// Set connector name
+		if connector, ok := option.Properties["connector"]; ok {
+			if connectorStr, ok := connector.(string); ok {
+				options.Connector = connectorStr
+			}
+		}
+
+		// Set host
+		if host, ok := option.Properties["host"]; ok {
+			if hostStr, ok := host.(string); ok {
+				options.Host = hostStr
+			}
+		}
+
+		// Set key
+		if key, ok := option.Properties["key"]; ok {
+			if keyStr, ok := key.(string); ok {
+				options.Key = keyStr
+			}
+		}
+
+		// Set model
+		if model, ok := option.Properties["model"]; ok {
+			if modelStr, ok := model.(string); ok {
+				options.Model = modelStr
+			}
+		}
+
+		// Set timeout (in seconds)
+		if timeout, ok := option.Properties["timeout"]; ok {
+			if timeoutInt, ok := timeout.(int); ok {
+				options.Timeout = time.Duration(timeoutInt) * time.Second
+			} else if timeoutFloat, ok := timeout.(float64); ok {
+				options.Timeout = time.Duration(timeoutFloat * float64(time.Second))
+			}
+		}
+	}
+
+	return rerankers.NewOpenAI(options)
+}
+
+// Schema returns the schema for the OpenAI compatible reranker provider
+func (r *RerankerOpenAI) Schema(provider *kbtypes.Provider, locale string) (*kbtypes.ProviderSchema, error) {
+	return factory.GetSchemaFromBindata(factory.ProviderTypeReranker, "openai", locale)
+}
+
+// === RerankerLLM ===
+
+// Make creates a new LLM reranker
+func (r *RerankerLLM) Make(option *kbtypes.ProviderOption) (kbtypes.Reranker, error) {
+	// Start with default values
+	options := rerankers.LLMOptions{
+		Connector: "",   // Required - will be set from option
+		Model:     "",   // Model of the connector
+		Prompt:    "",   // Default scoring prompt
+		BatchSize: 10,   // Default passages per request
+		MaxLength: 1000, // Default passage length
+	}
+
+	// Extract values from Properties map
+	if option != nil && option.Properties != nil {
+		// Set connector name
+		if connector, ok := option.Properties["connector"]; ok {
+			if connectorStr, ok := connector.(string); ok {
+				options.Connector = connectorStr
+			}
+		}
+
+		// Set model
+		if model, ok := option.Properties["model"]; ok {
+			if modelStr, ok := model.(string); ok {
+				options.Model = modelStr
+			}
+		}
+
+		// Set custom prompt
+		if prompt, ok := option.Properties["prompt"]; ok {
+			if promptStr, ok := prompt.(string); ok {
+				options.Prompt = promptStr
+			}
+		}
+
+		// Set batch size
+		if batchSize, ok := option.Properties["batch_size"]; ok {
+			if batchSizeInt, ok := batchSize.(int); ok {
+				options.BatchSize = batchSizeInt
+			} else if batchSizeFloat, ok := batchSize.(float64); ok {
+				options.BatchSize = int(batchSizeFloat)
+			}
+		}
+
+		// Set max length
+		if maxLength, ok := option.Properties["max_length"]; ok {
+			if maxLengthInt, ok := maxLength.(int); ok {
+				options.MaxLength = maxLengthInt
+			} else if maxLengthFloat, ok := maxLength.(float64); ok {
+				options.MaxLength = int(maxLengthFloat)
+			}
+		}
+	}
+
+	return rerankers.NewLLM(options)
+}
+
+// Schema returns the schema for the LLM reranker provider
+func (r *RerankerLLM) Schema(provider *kbtypes.Provider, locale string) (*kbtypes.ProviderSchema, error) {
+	return factory.GetSchemaFromBindata(factory.ProviderTypeReranker, "llm", locale)
+}
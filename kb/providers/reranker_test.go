@@ -0,0 +1,114 @@
+package providers
+
+import (
+	"testing"
+
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+func TestRerankerOpenAI_Make(t *testing.T) {
+	reranker := &RerankerOpenAI{}
+
+	t.Run("nil option should return error due to missing host", func(t *testing.T) {
+		_, err := reranker.Make(nil)
+		if err == nil {
+			t.Error("Expected error due to missing host")
+		}
+	})
+
+	t.Run("option with host should work", func(t *testing.T) {
+		option := &kbtypes.ProviderOption{
+			Properties: map[string]interface{}{
+				"host":  "http://127.0.0.1:9997/v1",
+				"key":   "sk-test",
+				"model": "bge-reranker-v2-m3",
+			},
+		}
+		result, err := reranker.Make(option)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if result == nil {
+			t.Error("Expected OpenAI reranker, got nil")
+		}
+	})
+
+	t.Run("option with timeout as float should work", func(t *testing.T) {
+		option := &kbtypes.ProviderOption{
+			Properties: map[string]interface{}{
+				"host":    "https://api.jina.ai",
+				"timeout": 30.5,
+			},
+		}
+		result, err := reranker.Make(option)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if result == nil {
+			t.Error("Expected OpenAI reranker, got nil")
+		}
+	})
+
+	t.Run("option with connector should return error due to missing connector", func(t *testing.T) {
+		option := &kbtypes.ProviderOption{
+			Properties: map[string]interface{}{
+				"connector": "openai.rerank",
+			},
+		}
+		_, err := reranker.Make(option)
+		if err == nil {
+			t.Error("Expected error due to missing connector")
+		}
+		// Error is expected because openai.rerank connector is not loaded
+	})
+}
+
+func TestRerankerOpenAI_Schema(t *testing.T) {
+	reranker := &RerankerOpenAI{}
+	schema, err := reranker.Schema(nil, "en")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if schema == nil {
+		t.Error("Expected non-nil schema from factory.GetSchemaFromBindata")
+	}
+}
+
+func TestRerankerLLM_Make(t *testing.T) {
+	reranker := &RerankerLLM{}
+
+	// Note: LLM reranker requires connectors to be loaded
+
+	t.Run("nil option should return error due to missing connector", func(t *testing.T) {
+		_, err := reranker.Make(nil)
+		if err == nil {
+			t.Error("Expected error due to missing connector")
+		}
+	})
+
+	t.Run("option with connector should return error due to missing connector", func(t *testing.T) {
+		option := &kbtypes.ProviderOption{
+			Properties: map[string]interface{}{
+				"connector":  "openai.gpt-4o-mini",
+				"batch_size": 5,
+				"max_length": 500.0,
+			},
+		}
+		_, err := reranker.Make(option)
+		if err == nil {
+			t.Error("Expected error due to missing connector")
+		}
+		// Error is expected because openai.gpt-4o-mini connector is not loaded
+	})
+}
+
+func TestRerankerLLM_Schema(t *testing.T) {
+	reranker := &RerankerLLM{}
+	schema, err := reranker.Schema(nil, "en")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if schema == nil {
+		t.Error("Expected non-nil schema from factory.GetSchemaFromBindata")
+	}
+}
@@ -0,0 +1,184 @@
+package rerankers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kbtypes "github.com/yaoapp/yao/kb/types"
+	"github.com/yaoapp/yao/openai"
+)
+
+// DefaultLLMPrompt the system prompt of the LLM reranker
+const DefaultLLMPrompt = `You are a relevance judge for a search engine.
+Score how well each numbered passage answers the query, from 0 (not relevant) to 10 (answers the query exactly).
+Reply with a JSON array only, one item per passage: [{"index": 0, "score": 7}, ...]`
+
+// LLMOptions the options of the LLM reranker
+type LLMOptions struct {
+	Connector string                 // OpenAI connector of the chat model
+	Setting   map[string]interface{} // Setting of the connector: host, key and model, used without connector
+	Model     string                 // Overrides the model of the connector
+	Prompt    string                 // System prompt, default is DefaultLLMPrompt
+	BatchSize int                    // Passages scored by a request, default is 10
+	MaxLength int                    // Passages are cut to this number of characters, default is 1000
+}
+
+// LLM reranks by asking a chat model to score the passages, for the deployments without a cross-encoder
+type LLM struct {
+	options  LLMOptions
+	complete func(ctx context.Context, messages []map[string]interface{}) (string, error)
+}
+
+// llmScore an item of the reply of the chat model
+type llmScore struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// NewLLM creates a LLM reranker
+func NewLLM(options LLMOptions) (*LLM, error) {
+	setting := options.Setting
+	if options.Connector != "" {
+		var err error
+		setting, err = connectorSetting(options.Connector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if setting == nil {
+		return nil, fmt.Errorf("the connector of the LLM reranker is required")
+	}
+
+	if options.Model != "" {
+		setting["model"] = options.Model
+	}
+
+	client, err := openai.NewOpenAI(setting)
+	if err != nil {
+		return nil, err
+	}
+
+	reranker := newLLM(options)
+	reranker.complete = func(ctx context.Context, messages []map[string]interface{}) (string, error) {
+		resp, ex := client.ChatCompletionsWith(ctx, messages, map[string]interface{}{"temperature": 0}, nil)
+		if ex != nil {
+			return "", fmt.Errorf("%s", ex.Message)
+		}
+
+		content, ex := client.GetContent(resp)
+		if ex != nil {
+			return "", fmt.Errorf("%s", ex.Message)
+		}
+		return content, nil
+	}
+	return reranker, nil
+}
+
+// newLLM applies the default options
+func newLLM(options LLMOptions) *LLM {
+	if options.Prompt == "" {
+		options.Prompt = DefaultLLMPrompt
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 10
+	}
+	if options.MaxLength <= 0 {
+		options.MaxLength = 1000
+	}
+	return &LLM{options: options}
+}
+
+// Rerank scores the documents against the query, a batch of documents per request. The scores are between 0
+// and 1, a document the model does not score gets 0.
+func (reranker *LLM) Rerank(ctx context.Context, query string, documents []string, topN int) ([]kbtypes.RerankResult, error) {
+	results := make([]kbtypes.RerankResult, len(documents))
+	for i := range documents {
+		results[i] = kbtypes.RerankResult{Index: i}
+	}
+
+	for start := 0; start < len(documents); start += reranker.options.BatchSize {
+		end := start + reranker.options.BatchSize
+		if end > len(documents) {
+			end = len(documents)
+		}
+
+		messages := []map[string]interface{}{
+			{"role": "system", "content": reranker.options.Prompt},
+			{"role": "user", "content": reranker.message(query, documents[start:end])},
+		}
+
+		content, err := reranker.complete(ctx, messages)
+		if err != nil {
+			return nil, fmt.Errorf("rerank request failed: %w", err)
+		}
+
+		scores, err := parseScores(content)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, score := range scores {
+			if score.Index < 0 || score.Index >= end-start {
+				continue
+			}
+			results[start+score.Index].Score = clampScore(score.Score) / 10
+		}
+	}
+
+	return sortResults(results, topN), nil
+}
+
+// message writes the query and the numbered passages of a batch
+func (reranker *LLM) message(query string, documents []string) string {
+	var sb strings.Builder
+	sb.WriteString("Query: ")
+	sb.WriteString(query)
+	sb.WriteString("\n")
+
+	for i, document := range documents {
+		text := []rune(strings.TrimSpace(document))
+		if len(text) > reranker.options.MaxLength {
+			text = text[:reranker.options.MaxLength]
+		}
+		fmt.Fprintf(&sb, "\nPassage %d:\n%s\n", i, string(text))
+	}
+	return sb.String()
+}
+
+// parseScores reads the JSON array of the reply, the models wrap it in code fences or sentences
+func parseScores(content string) ([]llmScore, error) {
+	start := strings.Index(content, "[")
+	end := strings.LastIndex(content, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid rerank reply: no JSON array in %q", truncate(content, 200))
+	}
+
+	var scores []llmScore
+	if err := json.Unmarshal([]byte(content[start:end+1]), &scores); err != nil {
+		return nil, fmt.Errorf("invalid rerank reply: %w", err)
+	}
+	return scores, nil
+}
+
+// clampScore keeps a score of the model between 0 and 10
+func clampScore(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 10 {
+		return 10
+	}
+	return score
+}
+
+// truncate cuts a text for the error messages
+func truncate(text string, size int) string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return text
+	}
+	return string(runes[:size]) + "..."
+}
@@ -0,0 +1,74 @@
+package rerankers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLLMRerank(t *testing.T) {
+	reranker := newLLM(LLMOptions{BatchSize: 2, MaxLength: 5})
+
+	messages := []string{}
+	replies := []string{
+		"```json\n[{\"index\": 0, \"score\": 3}, {\"index\": 1, \"score\": 9}]\n```",
+		"Scores: [{\"index\": 0, \"score\": 12}, {\"index\": 4, \"score\": 10}]",
+	}
+	reranker.complete = func(ctx context.Context, msgs []map[string]interface{}) (string, error) {
+		messages = append(messages, msgs[1]["content"].(string))
+		reply := replies[0]
+		replies = replies[1:]
+		return reply, nil
+	}
+
+	results, err := reranker.Rerank(context.Background(), "yao", []string{"first passage", "second", "third"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The scores are clamped and normalized, the index out of the batch is ignored
+	expected := "2:1.00,1:0.90,0:0.30"
+	got := []string{}
+	for _, result := range results {
+		got = append(got, fmt.Sprintf("%d:%.2f", result.Index, result.Score))
+	}
+	if strings.Join(got, ",") != expected {
+		t.Errorf("Expected %s, got %s", expected, strings.Join(got, ","))
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(messages))
+	}
+	if !strings.Contains(messages[0], "Query: yao") || !strings.Contains(messages[0], "Passage 0:\nfirst\n") {
+		t.Errorf("Expected the query and the cut passage, got %q", messages[0])
+	}
+
+	// The top N
+	replies = []string{`[{"index": 0, "score": 1}, {"index": 1, "score": 2}]`}
+	results, err = reranker.Rerank(context.Background(), "yao", []string{"a", "b"}, 1)
+	if err != nil || len(results) != 1 || results[0].Index != 1 {
+		t.Errorf("Expected the document 1, got %v %v", results, err)
+	}
+}
+
+func TestLLMRerankErrors(t *testing.T) {
+	reranker := newLLM(LLMOptions{})
+	reranker.complete = func(ctx context.Context, msgs []map[string]interface{}) (string, error) {
+		return "I cannot score these passages", nil
+	}
+	if _, err := reranker.Rerank(context.Background(), "yao", []string{"a"}, 0); err == nil {
+		t.Error("Expected an error for a reply without scores")
+	}
+
+	reranker.complete = func(ctx context.Context, msgs []map[string]interface{}) (string, error) {
+		return "", fmt.Errorf("rate limited")
+	}
+	if _, err := reranker.Rerank(context.Background(), "yao", []string{"a"}, 0); err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("Expected the error of the model, got %v", err)
+	}
+
+	if _, err := NewLLM(LLMOptions{}); err == nil {
+		t.Error("Expected an error without connector")
+	}
+}
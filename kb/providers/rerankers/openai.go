@@ -0,0 +1,151 @@
+package rerankers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+// OpenAIOptions the options of an OpenAI compatible rerank API
+type OpenAIOptions struct {
+	Connector string        // OpenAI connector, its host, key and model are used when they are not set
+	Host      string        // Base URL of the API, e.g. https://api.jina.ai/v1, /v1 is added to a host without path
+	Key       string        // API key, sent as a bearer token
+	Model     string        // Reranking model, e.g. jina-reranker-v2-base-multilingual
+	Timeout   time.Duration // Request timeout, default is 60 seconds
+}
+
+// OpenAI reranks with the /rerank API served by the OpenAI compatible gateways (Jina, vLLM, Xinference,
+// SiliconFlow...). The API scores the query and each document with a cross-encoder.
+type OpenAI struct {
+	endpoint string
+	options  OpenAIOptions
+	client   *http.Client
+}
+
+// rerankResponse the response of the /rerank API
+type rerankResponse struct {
+	Results []struct {
+		Index          int      `json:"index"`
+		RelevanceScore *float64 `json:"relevance_score"`
+		Score          *float64 `json:"score"`
+	} `json:"results"`
+}
+
+// NewOpenAI creates a reranker of an OpenAI compatible rerank API
+func NewOpenAI(options OpenAIOptions) (*OpenAI, error) {
+	if options.Connector != "" {
+		setting, err := connectorSetting(options.Connector)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := setting["host"].(string); ok && options.Host == "" {
+			options.Host = v
+		}
+		if v, ok := setting["key"].(string); ok && options.Key == "" {
+			options.Key = v
+		}
+		if v, ok := setting["model"].(string); ok && options.Model == "" {
+			options.Model = v
+		}
+	}
+
+	host := strings.TrimRight(options.Host, "/")
+	if host == "" {
+		return nil, fmt.Errorf("the host of the rerank API is required")
+	}
+
+	base, err := url.Parse(host)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid host of the rerank API: %s", options.Host)
+	}
+
+	// Same as the OpenAI connectors, the API version is the path of the host
+	if base.Path == "" {
+		host = host + "/v1"
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = 60 * time.Second
+	}
+
+	return &OpenAI{
+		endpoint: host + "/rerank",
+		options:  options,
+		client:   &http.Client{Timeout: options.Timeout},
+	}, nil
+}
+
+// Rerank scores the documents against the query
+func (reranker *OpenAI) Rerank(ctx context.Context, query string, documents []string, topN int) ([]kbtypes.RerankResult, error) {
+	if len(documents) == 0 {
+		return []kbtypes.RerankResult{}, nil
+	}
+
+	payload := map[string]interface{}{
+		"query":            query,
+		"documents":        documents,
+		"return_documents": false,
+	}
+	if reranker.options.Model != "" {
+		payload["model"] = reranker.options.Model
+	}
+	if topN > 0 {
+		payload["top_n"] = topN
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reranker.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if reranker.options.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+reranker.options.Key)
+	}
+
+	resp, err := reranker.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rerank request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("rerank request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var res rerankResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("invalid rerank response: %w", err)
+	}
+
+	results := make([]kbtypes.RerankResult, 0, len(res.Results))
+	for _, item := range res.Results {
+		if item.Index < 0 || item.Index >= len(documents) {
+			return nil, fmt.Errorf("invalid rerank response: index %d out of %d documents", item.Index, len(documents))
+		}
+
+		result := kbtypes.RerankResult{Index: item.Index}
+		switch {
+		case item.RelevanceScore != nil:
+			result.Score = *item.RelevanceScore
+		case item.Score != nil:
+			result.Score = *item.Score
+		}
+		results = append(results, result)
+	}
+	return sortResults(results, topN), nil
+}
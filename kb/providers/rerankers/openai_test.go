@@ -0,0 +1,86 @@
+package rerankers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIRerank(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/rerank" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"results":[{"index":1,"relevance_score":0.2},{"index":2,"relevance_score":0.9},{"index":0,"score":0.5}]}`))
+	}))
+	defer server.Close()
+
+	reranker, err := NewOpenAI(OpenAIOptions{Host: server.URL, Key: "sk-test", Model: "bge-reranker"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := reranker.Rerank(context.Background(), "yao", []string{"a", "b", "c"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0].Index != 2 || results[1].Index != 0 || results[1].Score != 0.5 {
+		t.Errorf("Expected the documents 2 and 0, got %+v", results)
+	}
+	if payload["model"] != "bge-reranker" || payload["query"] != "yao" || payload["top_n"] != float64(2) {
+		t.Errorf("Expected the model, the query and top_n in the request, got %v", payload)
+	}
+
+	// No document, no request
+	results, err = reranker.Rerank(context.Background(), "yao", []string{}, 2)
+	if err != nil || len(results) != 0 {
+		t.Errorf("Expected no result, got %v %v", results, err)
+	}
+}
+
+func TestOpenAIRerankErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/bad") {
+			w.Write([]byte(`{"results":[{"index":5,"relevance_score":0.2}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"model not found"}`))
+	}))
+	defer server.Close()
+
+	reranker, err := NewOpenAI(OpenAIOptions{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = reranker.Rerank(context.Background(), "yao", []string{"a"}, 0)
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Errorf("Expected the error of the API, got %v", err)
+	}
+
+	reranker, err = NewOpenAI(OpenAIOptions{Host: server.URL + "/bad"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = reranker.Rerank(context.Background(), "yao", []string{"a"}, 0)
+	if err == nil || !strings.Contains(err.Error(), "out of 1 documents") {
+		t.Errorf("Expected an error for an invalid index, got %v", err)
+	}
+
+	for _, host := range []string{"", "localhost:8080"} {
+		if _, err := NewOpenAI(OpenAIOptions{Host: host}); err == nil {
+			t.Errorf("Expected an error for the host %q", host)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package rerankers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yaoapp/gou/connector"
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+// connectorSetting returns a copy of the setting of an OpenAI connector: host, key and model
+func connectorSetting(name string) (map[string]interface{}, error) {
+	conn, err := connector.Select(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !conn.Is(connector.OPENAI) {
+		return nil, fmt.Errorf("the connector %s is not an OpenAI connector", name)
+	}
+
+	setting := map[string]interface{}{}
+	for key, value := range conn.Setting() {
+		setting[key] = value
+	}
+	return setting, nil
+}
+
+// sortResults orders the results by descending score, the ties keep the order of the candidates, then keeps
+// the topN first ones
+func sortResults(results []kbtypes.RerankResult, topN int) []kbtypes.RerankResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Index < results[j].Index
+	})
+
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+	return results
+}
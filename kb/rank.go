@@ -0,0 +1,190 @@
+package kb
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// rrfK the rank constant of the reciprocal rank fusion, dampens the lead of the top ranks
+const rrfK = 60
+
+// The BM25 parameters: term frequency saturation and length normalization
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// ranking the results of a retrieval in rank order
+type ranking []*SearchResult
+
+// fuse merges the rankings with the reciprocal rank fusion, a segment found by several retrievals adds up
+// 1/(k+rank) of each of them
+func fuse(rankings []ranking) []*SearchResult {
+	merged := map[string]*SearchResult{}
+	results := []*SearchResult{}
+	for _, list := range rankings {
+		for i, result := range list {
+			key := result.CollectionID + "/" + result.ID
+			fused, ok := merged[key]
+			if !ok {
+				copied := *result
+				fused = &copied
+				fused.Score = 0
+				merged[key] = fused
+				results = append(results, fused)
+			}
+
+			fused.Similarity = math.Max(fused.Similarity, result.Similarity)
+			fused.Keyword = math.Max(fused.Keyword, result.Keyword)
+			fused.Score += 1 / float64(rrfK+i+1)
+		}
+	}
+	return results
+}
+
+// boost multiplies the scores by the weight and the score of the segments, set by UpdateWeights and UpdateScores
+func boost(results []*SearchResult, strength float64) {
+	if strength <= 0 {
+		return
+	}
+
+	for _, result := range results {
+		weight := math.Max(toFloat(result.Metadata["weight"]), 0)
+		score := math.Max(toFloat(result.Metadata["score"]), 0)
+		result.Score *= (1 + strength*weight) * (1 + strength*score)
+	}
+}
+
+// rank orders the results by score, the similarity breaks the ties
+func rank(results []*SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Similarity > results[j].Similarity
+	})
+}
+
+// bm25 scores the texts against the terms of the query, the corpus statistics are the ones of the texts
+func bm25(query string, texts []string) []float64 {
+	terms := unique(tokenize(query))
+	scores := make([]float64, len(texts))
+	if len(terms) == 0 || len(texts) == 0 {
+		return scores
+	}
+
+	frequencies := make([]map[string]int, len(texts))
+	lengths := make([]int, len(texts))
+	documents := map[string]int{}
+	total := 0
+	for i, text := range texts {
+		tokens := tokenize(text)
+		frequencies[i] = map[string]int{}
+		for _, token := range tokens {
+			frequencies[i][token]++
+		}
+		for _, term := range terms {
+			if frequencies[i][term] > 0 {
+				documents[term]++
+			}
+		}
+		lengths[i] = len(tokens)
+		total += len(tokens)
+	}
+
+	count := float64(len(texts))
+	average := math.Max(float64(total)/count, 1)
+	for i := range texts {
+		for _, term := range terms {
+			tf := float64(frequencies[i][term])
+			if tf == 0 {
+				continue
+			}
+			df := float64(documents[term])
+			idf := math.Log(1 + (count-df+0.5)/(df+0.5))
+			scores[i] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(lengths[i])/average))
+		}
+	}
+	return scores
+}
+
+// tokenize splits a text into lower case terms. The words are split on the non alphanumeric characters, the
+// CJK runs have no spaces and are split into bigrams.
+func tokenize(text string) []string {
+	tokens := []string{}
+	word := []rune{}
+	cjk := []rune{}
+
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, string(word))
+			word = word[:0]
+		}
+	}
+
+	flushCJK := func() {
+		if len(cjk) == 1 {
+			tokens = append(tokens, string(cjk))
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, string(cjk[i:i+2]))
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// isCJK checks if a rune is a Chinese, Japanese or Korean character
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// unique removes the duplicated terms, the order is kept
+func unique(terms []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	return result
+}
+
+// toFloat converts a metadata value to a float, the other values are 0
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case int32:
+		return float64(v)
+	}
+	return 0
+}
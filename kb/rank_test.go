@@ -0,0 +1,159 @@
+package kb
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Hello, World! v2.0", []string{"hello", "world", "v2", "0"}},
+		{"知识库搜索", []string{"知识", "识库", "库搜", "搜索"}},
+		{"Yao 知识库 API", []string{"yao", "知识", "识库", "api"}},
+		{"字", []string{"字"}},
+		{"", []string{}},
+	}
+
+	for _, test := range tests {
+		tokens := tokenize(test.text)
+		if !reflect.DeepEqual(tokens, test.expected) {
+			t.Errorf("tokenize(%q) = %v, expected %v", test.text, tokens, test.expected)
+		}
+	}
+}
+
+func TestBM25(t *testing.T) {
+	texts := []string{
+		"The vector database stores the embeddings",
+		"Keyword search ranks the documents with BM25, BM25 is a bag of words model",
+		"An unrelated sentence about cooking",
+		"BM25",
+	}
+
+	scores := bm25("bm25 ranking", texts)
+	if scores[0] != 0 || scores[2] != 0 {
+		t.Errorf("Expected no score for the texts without the terms, got %v", scores)
+	}
+	if scores[1] <= 0 || scores[3] <= 0 {
+		t.Errorf("Expected a score for the texts with the terms, got %v", scores)
+	}
+
+	// The short text matching the term scores higher than the long one
+	if scores[3] <= scores[1] {
+		t.Errorf("Expected the length normalization to favor the short text, got %v", scores)
+	}
+
+	// The rare terms weigh more
+	scores = bm25("vector cooking", []string{"vector cooking", "vector", "vector", "cooking"})
+	if scores[3] <= scores[1] {
+		t.Errorf("Expected the rare term to score higher, got %v", scores)
+	}
+}
+
+func TestFuse(t *testing.T) {
+	semantic := ranking{
+		{ID: "a", CollectionID: "docs", Similarity: 0.9},
+		{ID: "b", CollectionID: "docs", Similarity: 0.8},
+		{ID: "c", CollectionID: "docs", Similarity: 0.7},
+	}
+	keyword := ranking{
+		{ID: "c", CollectionID: "docs", Keyword: 3.2},
+		{ID: "d", CollectionID: "docs", Keyword: 1.5},
+	}
+
+	results := fuse([]ranking{semantic, keyword})
+	rank(results)
+
+	// c is found by both retrievals
+	if len(results) != 4 || results[0].ID != "c" {
+		t.Fatalf("Expected c first out of 4 results, got %v", ids(results))
+	}
+	if results[0].Similarity != 0.7 || results[0].Keyword != 3.2 {
+		t.Errorf("Expected the scores of both retrievals, got %+v", results[0])
+	}
+	if semantic[2].Keyword != 0 {
+		t.Error("Expected the rankings to be left unchanged")
+	}
+
+	// The same segment ID in another collection is another segment
+	results = fuse([]ranking{semantic, {{ID: "a", CollectionID: "faq"}}})
+	if len(results) != 4 {
+		t.Errorf("Expected 4 results, got %v", ids(results))
+	}
+}
+
+func TestBoost(t *testing.T) {
+	results := []*SearchResult{
+		{ID: "a", Score: 1},
+		{ID: "b", Score: 0.9, Metadata: map[string]interface{}{"weight": 1.0}},
+		{ID: "c", Score: 0.8, Metadata: map[string]interface{}{"score": 0.5, "weight": -1}},
+	}
+
+	boost(results, 1)
+	rank(results)
+	if got := ids(results); !reflect.DeepEqual(got, []string{"b", "c", "a"}) {
+		t.Errorf("Expected the boosted order [b c a], got %v", got)
+	}
+	if math.Abs(results[1].Score-1.2) > 1e-9 {
+		t.Errorf("Expected the negative weight to be ignored, got %v", results[1].Score)
+	}
+
+	// No boost
+	results = []*SearchResult{{ID: "a", Score: 1, Metadata: map[string]interface{}{"weight": 5}}}
+	boost(results, 0)
+	if results[0].Score != 1 {
+		t.Errorf("Expected the score to be unchanged, got %v", results[0].Score)
+	}
+}
+
+func ids(results []*SearchResult) []string {
+	list := []string{}
+	for _, result := range results {
+		list = append(list, result.ID)
+	}
+	return list
+}
+
+type stubReranker struct {
+	scores []kbtypes.RerankResult
+	texts  []string
+}
+
+func (reranker *stubReranker) Rerank(ctx context.Context, query string, documents []string, topN int) ([]kbtypes.RerankResult, error) {
+	reranker.texts = documents
+	return reranker.scores, nil
+}
+
+func TestRerank(t *testing.T) {
+	results := []*SearchResult{{ID: "a", Text: "A"}, {ID: "b", Text: "B"}, {ID: "c", Text: "C"}, {ID: "d", Text: "D"}}
+	reranker := &stubReranker{scores: []kbtypes.RerankResult{{Index: 2, Score: 0.9}, {Index: 0, Score: 0.4}, {Index: 7, Score: 1}}}
+
+	reranked, err := rerank(context.Background(), reranker, "query", results, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The candidate the reranker did not return follows the reranked ones, the tail keeps its order
+	if got := ids(reranked); !reflect.DeepEqual(got, []string{"c", "a", "b", "d"}) {
+		t.Errorf("Expected the reranked order [c a b d], got %v", got)
+	}
+	if !reflect.DeepEqual(reranker.texts, []string{"A", "B", "C"}) {
+		t.Errorf("Expected the texts of the window, got %v", reranker.texts)
+	}
+	if reranked[0].Rerank != 0.9 || reranked[2].Rerank != 0 {
+		t.Errorf("Expected the reranker scores, got %v %v", reranked[0].Rerank, reranked[2].Rerank)
+	}
+
+	// No result
+	reranked, err = rerank(context.Background(), reranker, "query", []*SearchResult{}, 3)
+	if err != nil || len(reranked) != 0 {
+		t.Errorf("Expected no result, got %v %v", reranked, err)
+	}
+}
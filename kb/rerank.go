@@ -0,0 +1,104 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/kb/providers/factory"
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+// MakeReranker creates a reranker by its provider and option
+func MakeReranker(providerID string, optionID string, locale string) (kbtypes.Reranker, error) {
+	provider, err := GetProviderWithLanguage("reranker", providerID, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reranker provider %s: %w", providerID, err)
+	}
+
+	option, ok := provider.GetOption(optionID)
+	if !ok {
+		return nil, fmt.Errorf("option %s not found in provider %s", optionID, providerID)
+	}
+	return factory.MakeReranker(providerID, option)
+}
+
+// searchReranker returns the reranker of a search: the one of the options, else the one of the first collection
+// configuring a reranker. nil when the search is not reranked.
+func searchReranker(options SearchOptions) (kbtypes.Reranker, error) {
+	if options.Rerank != nil && !*options.Rerank {
+		return nil, nil
+	}
+
+	if options.RerankerProviderID != "" {
+		return MakeReranker(options.RerankerProviderID, options.RerankerOptionID, options.Locale)
+	}
+
+	knowledgeBase, ok := Instance.(*KnowledgeBase)
+	if !ok {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+
+	for _, collectionID := range options.CollectionIDs {
+		collection, err := knowledgeBase.Config.FindCollection(collectionID, model.QueryParam{
+			Select: []interface{}{"reranker_provider_id", "reranker_option_id", "locale"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		providerID, _ := collection["reranker_provider_id"].(string)
+		optionID, _ := collection["reranker_option_id"].(string)
+		if providerID == "" || optionID == "" {
+			continue
+		}
+
+		locale, _ := collection["locale"].(string)
+		return MakeReranker(providerID, optionID, locale)
+	}
+
+	if options.Rerank != nil {
+		return nil, fmt.Errorf("no reranker is configured for the collections")
+	}
+	return nil, nil
+}
+
+// rerank scores the top window of the results with the reranker and orders them by the reranker score, the
+// results after the window keep their order after the reranked ones
+func rerank(ctx context.Context, reranker kbtypes.Reranker, query string, results []*SearchResult, window int) ([]*SearchResult, error) {
+	if window <= 0 || window > len(results) {
+		window = len(results)
+	}
+	if window == 0 {
+		return results, nil
+	}
+
+	texts := make([]string, window)
+	for i, result := range results[:window] {
+		texts[i] = result.Text
+	}
+
+	scores, err := reranker.Rerank(ctx, query, texts, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := make([]*SearchResult, 0, len(results))
+	seen := make([]bool, window)
+	for _, score := range scores {
+		if score.Index < 0 || score.Index >= window || seen[score.Index] {
+			continue
+		}
+		seen[score.Index] = true
+		results[score.Index].Rerank = score.Score
+		reranked = append(reranked, results[score.Index])
+	}
+
+	// The candidates the reranker did not return keep their order
+	for i, result := range results[:window] {
+		if !seen[i] {
+			reranked = append(reranked, result)
+		}
+	}
+	return append(reranked, results[window:]...), nil
+}
@@ -0,0 +1,310 @@
+package kb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"os"
+	"strings"
+
+	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/kb/providers/factory"
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+// resyncPageSize the number of segments scrolled at once by a resync
+const resyncPageSize = 500
+
+// RemoveResyncJobs removes the scheduled resync jobs of the documents of a collection, called before the
+// documents are removed
+func RemoveResyncJobs(config *kbtypes.Config, collectionID string) error {
+	ids, err := config.ResyncJobIDs(collectionID)
+	if err != nil {
+		return fmt.Errorf("failed to read the resync jobs of %s: %w", collectionID, err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return job.RemoveJobs(ids)
+}
+
+// ResyncOptions the options of a resync of a document
+type ResyncOptions struct {
+	DocumentID  string               // Document to sync again
+	URL         string               // Source of an url document, fetched with the fetcher of the upsert options
+	Path        string               // Source of a file document, converted with the converter of the upsert options
+	Text        string               // Text of the source already read, e.g. a page crawled again
+	ContentHash string               // Hash of the text of the last sync, an unchanged source is skipped
+	Force       bool                 // Diff the segments even if the source is unchanged
+	Upsert      *types.UpsertOptions // Providers of the document: fetcher, converter, chunking, embedding and extraction
+}
+
+// ResyncResult the result of a resync
+type ResyncResult struct {
+	DocumentID  string   `json:"document_id"`
+	Changed     bool     `json:"changed"`      // false when the source is unchanged, the segments are not read
+	ContentHash string   `json:"content_hash"` // Hash of the text of the source
+	Kept        int      `json:"kept"`         // Unchanged segments, their votes, hits and weights are kept
+	Added       int      `json:"added"`        // Segments of the new or changed chunks, embedded again
+	Removed     int      `json:"removed"`      // Segments of the chunks no longer in the source
+	SegmentIDs  []string `json:"segment_ids,omitempty"`
+}
+
+// segmentDigest the hash of the text of a stored segment
+type segmentDigest struct {
+	ID   string
+	Hash string
+}
+
+// segmentDiff the changes of the segments of a document
+type segmentDiff struct {
+	Keep   []string // IDs of the segments found in the new chunks
+	Remove []string // IDs of the segments not found in the new chunks
+	Add    []string // Texts of the new chunks not found in the segments
+}
+
+// Resync fetches the source of a document again and updates the segments of the changed chunks only. The chunks
+// are compared with the segments by the hash of their text: the segments of the unchanged chunks are kept with
+// their feedback, the new chunks are embedded and added, the segments of the removed chunks are removed.
+func Resync(ctx context.Context, options ResyncOptions) (*ResyncResult, error) {
+	if Instance == nil {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+
+	if options.Upsert == nil || options.Upsert.Chunking == nil {
+		return nil, fmt.Errorf("the chunking of the document is required")
+	}
+
+	text, err := sourceText(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResyncResult{DocumentID: options.DocumentID, ContentHash: ContentHash(text)}
+	if !options.Force && options.ContentHash != "" && options.ContentHash == result.ContentHash {
+		return result, nil
+	}
+	result.Changed = true
+
+	chunks, err := chunkText(ctx, text, options.Upsert)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := documentDigests(ctx, options.Upsert.CollectionID, options.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffSegments(segments, chunks)
+	result.Kept = len(diff.Keep)
+
+	// The new segments are added first, a failure leaves the former segments searchable
+	if len(diff.Add) > 0 {
+		texts := make([]types.SegmentText, len(diff.Add))
+		for i, chunk := range diff.Add {
+			texts[i] = types.SegmentText{Text: chunk}
+		}
+
+		ids, err := Instance.AddSegments(ctx, options.DocumentID, texts, options.Upsert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add the changed segments: %w", err)
+		}
+		result.Added = len(ids)
+		result.SegmentIDs = ids
+	}
+
+	if len(diff.Remove) > 0 {
+		removed, err := Instance.RemoveSegments(ctx, options.DocumentID, diff.Remove)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove the former segments: %w", err)
+		}
+		result.Removed = removed
+	}
+
+	return result, nil
+}
+
+// sourceText reads the text of the source: an url is fetched, a file is converted
+func sourceText(ctx context.Context, options ResyncOptions) (string, error) {
+	switch {
+	case options.Text != "":
+		return options.Text, nil
+
+	case options.URL != "":
+		return fetchText(ctx, options.URL, options.Upsert)
+
+	case options.Path != "":
+		if options.Upsert.Converter != nil {
+			res, err := options.Upsert.Converter.Convert(ctx, options.Path)
+			if err != nil {
+				return "", fmt.Errorf("failed to convert %s: %w", options.Path, err)
+			}
+			return res.Text, nil
+		}
+
+		data, err := os.ReadFile(options.Path)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	return "", fmt.Errorf("the document has no url or file to sync")
+}
+
+// fetchText fetches an url with the fetcher of the document, the default is the HTTP fetcher. A binary content,
+// base64 encoded by the fetcher, is converted with the converter of the document.
+func fetchText(ctx context.Context, url string, upsert *types.UpsertOptions) (string, error) {
+	fetcher := upsert.Fetcher
+	if fetcher == nil {
+		var err error
+		fetcher, err = factory.MakeFetcher("__yao.http", nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	content, mimeType, err := fetcher.Fetch(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+
+	if isTextType(mimeType) || upsert.Converter == nil {
+		return content, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		data = []byte(content)
+	}
+
+	ext := ""
+	if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
+		ext = exts[0]
+	}
+
+	file, err := os.CreateTemp("", "kb-resync-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	res, err := upsert.Converter.Convert(ctx, file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to convert %s: %w", url, err)
+	}
+	return res.Text, nil
+}
+
+// chunkText splits the text with the chunking of the document
+func chunkText(ctx context.Context, text string, upsert *types.UpsertOptions) ([]string, error) {
+	chunks := []string{}
+	err := upsert.Chunking.Chunk(ctx, text, upsert.ChunkingOptions, func(chunk *types.Chunk) error {
+		if strings.TrimSpace(chunk.Text) != "" {
+			chunks = append(chunks, chunk.Text)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to chunk the document: %w", err)
+	}
+	return chunks, nil
+}
+
+// documentDigests returns the hashes of the segments of a document
+func documentDigests(ctx context.Context, collectionID string, docID string) ([]segmentDigest, error) {
+	store, err := vectorStore()
+	if err != nil {
+		return nil, err
+	}
+
+	digests := []segmentDigest{}
+	scrollID := ""
+	for {
+		res, err := store.ScrollDocuments(ctx, &types.ScrollOptions{
+			CollectionName: collectionID,
+			Filter:         map[string]interface{}{"doc_id": docID},
+			Limit:          resyncPageSize,
+			ScrollID:       scrollID,
+			IncludeContent: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the segments: %w", err)
+		}
+
+		for _, document := range res.Documents {
+			digests = append(digests, segmentDigest{ID: document.ID, Hash: ContentHash(document.Content)})
+		}
+
+		if !res.HasMore || res.NextScrollID == "" {
+			break
+		}
+		scrollID = res.NextScrollID
+	}
+	return digests, nil
+}
+
+// diffSegments matches the chunks with the segments by the hash of their text. A text found several times
+// matches as many segments.
+func diffSegments(segments []segmentDigest, chunks []string) segmentDiff {
+	stored := map[string][]string{}
+	for _, segment := range segments {
+		stored[segment.Hash] = append(stored[segment.Hash], segment.ID)
+	}
+
+	diff := segmentDiff{Keep: []string{}, Remove: []string{}, Add: []string{}}
+	for _, chunk := range chunks {
+		hash := ContentHash(chunk)
+		if ids := stored[hash]; len(ids) > 0 {
+			diff.Keep = append(diff.Keep, ids[0])
+			stored[hash] = ids[1:]
+			continue
+		}
+		diff.Add = append(diff.Add, chunk)
+	}
+
+	// The segments not matched are not in the chunks anymore
+	kept := map[string]bool{}
+	for _, id := range diff.Keep {
+		kept[id] = true
+	}
+	for _, segment := range segments {
+		if !kept[segment.ID] {
+			diff.Remove = append(diff.Remove, segment.ID)
+		}
+	}
+	return diff
+}
+
+// ContentHash hashes a text, the whitespace changes do not change the hash
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// isTextType checks if a content type is text
+func isTextType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	if mediaType == "" || strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/xhtml+xml", "application/javascript", "application/x-yaml":
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
@@ -0,0 +1,71 @@
+package kb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDiffSegments(t *testing.T) {
+	segments := []segmentDigest{
+		{ID: "s1", Hash: ContentHash("Install the package")},
+		{ID: "s2", Hash: ContentHash("Run the server")},
+		{ID: "s3", Hash: ContentHash("FAQ")},
+		{ID: "s4", Hash: ContentHash("FAQ")},
+	}
+	chunks := []string{"Install  the\npackage", "Configure the server", "FAQ", "Run the server"}
+
+	diff := diffSegments(segments, chunks)
+	if !reflect.DeepEqual(diff.Keep, []string{"s1", "s3", "s2"}) {
+		t.Errorf("Expected to keep [s1 s3 s2], got %v", diff.Keep)
+	}
+	if !reflect.DeepEqual(diff.Add, []string{"Configure the server"}) {
+		t.Errorf("Expected to add the changed chunk, got %v", diff.Add)
+	}
+	if !reflect.DeepEqual(diff.Remove, []string{"s4"}) {
+		t.Errorf("Expected to remove the duplicate s4, got %v", diff.Remove)
+	}
+
+	// A new document
+	diff = diffSegments(nil, []string{"a", "a"})
+	if len(diff.Keep) != 0 || len(diff.Remove) != 0 || !reflect.DeepEqual(diff.Add, []string{"a", "a"}) {
+		t.Errorf("Expected to add all the chunks, got %+v", diff)
+	}
+
+	// An empty source
+	diff = diffSegments(segments[:2], []string{})
+	if !reflect.DeepEqual(diff.Remove, []string{"s1", "s2"}) || len(diff.Add) != 0 {
+		t.Errorf("Expected to remove all the segments, got %+v", diff)
+	}
+}
+
+func TestIsTextType(t *testing.T) {
+	tests := map[string]bool{
+		"":                         true,
+		"text/html; charset=utf-8": true,
+		"application/json":         true,
+		"application/ld+json":      true,
+		"application/pdf":          false,
+		"image/png":                false,
+		"application/octet-stream": false,
+		"TEXT/PLAIN":               true,
+	}
+	for contentType, expected := range tests {
+		if got := isTextType(contentType); got != expected {
+			t.Errorf("isTextType(%q) = %v, expected %v", contentType, got, expected)
+		}
+	}
+}
+
+func TestSourceText(t *testing.T) {
+	// The text already read is used as is, the url is not fetched
+	text, err := sourceText(context.Background(), ResyncOptions{Text: "Crawled page", URL: "http://127.0.0.1:0/page"})
+	if err != nil || text != "Crawled page" {
+		t.Errorf("Expected the text of the options, got %q %v", text, err)
+	}
+
+	_, err = sourceText(context.Background(), ResyncOptions{})
+	if err == nil {
+		t.Error("Expected an error without source")
+	}
+}
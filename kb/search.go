@@ -0,0 +1,381 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/kb/providers/factory"
+	kbtypes "github.com/yaoapp/yao/kb/types"
+)
+
+// Search modes
+const (
+	SearchHybrid   = "hybrid"
+	SearchSemantic = "semantic"
+	SearchKeyword  = "keyword"
+)
+
+// keywordPageSize the number of segments scrolled at once by the keyword search
+const keywordPageSize = 500
+
+// maxKeywordSegments the max number of segments of a collection scored by the keyword search. The segments are
+// scored in process, the larger collections are narrowed with document_ids or a filter.
+const maxKeywordSegments = 5000
+
+// SearchOptions the options of a search across one or more collections
+type SearchOptions struct {
+	CollectionIDs []string               `json:"collection_ids"`
+	Query         string                 `json:"query"`
+	Mode          string                 `json:"mode,omitempty"`           // hybrid, semantic or keyword, default is hybrid
+	Limit         int                    `json:"limit,omitempty"`          // Max number of results, default is 10
+	Candidates    int                    `json:"candidates,omitempty"`     // Number of candidates of each retrieval, default is 50
+	Filter        map[string]interface{} `json:"filter,omitempty"`         // Metadata filter of the segments, e.g. {"source": "manual"}
+	DocumentIDs   []string               `json:"document_ids,omitempty"`   // Search in these documents only
+	MinSimilarity float64                `json:"min_similarity,omitempty"` // Min vector similarity of the semantic candidates
+	Boost         *float64               `json:"boost,omitempty"`          // Strength of the segment weight and score boost, default is 1, 0 disables it
+
+	// Reranking, by default the reranker of the first collection configuring one reranks the candidates
+	Rerank             *bool  `json:"rerank,omitempty"`               // false disables the reranking, true requires a reranker
+	RerankerProviderID string `json:"reranker_provider_id,omitempty"` // Reranker of the search instead of the one of the collections
+	RerankerOptionID   string `json:"reranker_option_id,omitempty"`
+	Locale             string `json:"locale,omitempty"` // Locale of the reranker provider
+}
+
+// SearchResponse the results of a search, the warnings tell the results may be incomplete
+type SearchResponse struct {
+	Results  []*SearchResult `json:"results"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+// SearchResult a segment matching a search
+type SearchResult struct {
+	ID           string                 `json:"id"`
+	CollectionID string                 `json:"collection_id"`
+	DocumentID   string                 `json:"document_id"`
+	Text         string                 `json:"text"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Score        float64                `json:"score"`      // Fused and boosted score, the results are ordered by it unless reranked
+	Similarity   float64                `json:"similarity"` // Vector similarity, 0 when the semantic search did not find the segment
+	Keyword      float64                `json:"keyword"`    // BM25 score, 0 when the keyword search did not find the segment
+	Rerank       float64                `json:"rerank"`     // Reranker score, the reranked results are ordered by it
+}
+
+// Search searches the segments of the collections. The semantic search ranks the segments by vector similarity,
+// the keyword search by BM25. The rankings of each collection are merged with the reciprocal rank fusion, then the
+// scores are boosted by the weight and the score of the segments. A reranker configured by the collections or the
+// options reorders the candidates at last.
+func Search(ctx context.Context, options SearchOptions) (*SearchResponse, error) {
+	if Instance == nil {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+
+	err := options.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rankings := []ranking{}
+	warnings := []string{}
+	for _, collectionID := range options.CollectionIDs {
+		if options.Mode != SearchKeyword {
+			results, err := semanticSearch(ctx, collectionID, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search %s: %w", collectionID, err)
+			}
+			rankings = append(rankings, results)
+		}
+
+		if options.Mode != SearchSemantic {
+			results, truncated, err := keywordSearch(ctx, collectionID, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search %s: %w", collectionID, err)
+			}
+			if truncated {
+				warnings = append(warnings, fmt.Sprintf("the keyword search of %s scored the first %d segments only, narrow the search with document_ids or filter", collectionID, maxKeywordSegments))
+			}
+			rankings = append(rankings, results)
+		}
+	}
+
+	strength := 1.0
+	if options.Boost != nil {
+		strength = *options.Boost
+	}
+
+	results := fuse(rankings)
+	boost(results, strength)
+	rank(results)
+
+	reranker, err := searchReranker(options)
+	if err != nil {
+		return nil, err
+	}
+	if reranker != nil {
+		results, err = rerank(ctx, reranker, options.Query, results, options.Candidates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rerank: %w", err)
+		}
+	}
+
+	if len(results) > options.Limit {
+		results = results[:options.Limit]
+	}
+	return &SearchResponse{Results: results, Warnings: warnings}, nil
+}
+
+// MultiSearch runs several searches, the responses are in the order of the searches
+func MultiSearch(ctx context.Context, searches []SearchOptions) ([]*SearchResponse, error) {
+	results := make([]*SearchResponse, len(searches))
+	for i, options := range searches {
+		res, err := Search(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("search %d: %w", i, err)
+		}
+		results[i] = res
+	}
+	return results, nil
+}
+
+// validate validates the options and sets the defaults
+func (options *SearchOptions) validate() error {
+	options.Query = strings.TrimSpace(options.Query)
+	if options.Query == "" {
+		return fmt.Errorf("query is required")
+	}
+
+	if len(options.CollectionIDs) == 0 {
+		return fmt.Errorf("at least one collection is required")
+	}
+
+	switch options.Mode {
+	case "":
+		options.Mode = SearchHybrid
+	case SearchHybrid, SearchSemantic, SearchKeyword:
+	default:
+		return fmt.Errorf("invalid search mode: %s", options.Mode)
+	}
+
+	if (options.RerankerProviderID == "") != (options.RerankerOptionID == "") {
+		return fmt.Errorf("reranker_provider_id and reranker_option_id must be set together")
+	}
+
+	if options.Limit <= 0 {
+		options.Limit = 10
+	}
+
+	if options.Candidates <= 0 {
+		options.Candidates = 50
+	}
+	if options.Candidates < options.Limit {
+		options.Candidates = options.Limit
+	}
+	return nil
+}
+
+// semanticSearch returns the segments nearest to the embedding of the query. The documents of the search are
+// filtered by the vector store, each one is searched on its own and the nearest candidates are kept.
+func semanticSearch(ctx context.Context, collectionID string, options SearchOptions) (ranking, error) {
+	store, err := vectorStore()
+	if err != nil {
+		return nil, err
+	}
+
+	embedding, err := collectionEmbedding(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, err := embedding.EmbedQuery(ctx, options.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed the query: %w", err)
+	}
+
+	results := ranking{}
+	for _, filter := range options.filters() {
+		res, err := store.SearchSimilar(ctx, &types.SearchOptions{
+			CollectionName:  collectionID,
+			QueryVector:     vector.Embedding,
+			K:               options.Candidates,
+			Filter:          filter,
+			MinScore:        options.MinSimilarity,
+			IncludeMetadata: true,
+			IncludeContent:  true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range res.Documents {
+			result := newSearchResult(collectionID, &item.Document)
+			result.Similarity = item.Score
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Similarity > results[j].Similarity })
+	if len(results) > options.Candidates {
+		results = results[:options.Candidates]
+	}
+	return results, nil
+}
+
+// keywordSearch scores the segments of a collection with BM25, the top candidates are returned. The segments are
+// scored in process, at most maxKeywordSegments of them: truncated is true when the collection has more.
+func keywordSearch(ctx context.Context, collectionID string, options SearchOptions) (ranking, bool, error) {
+	store, err := vectorStore()
+	if err != nil {
+		return nil, false, err
+	}
+
+	segments := ranking{}
+	texts := []string{}
+	truncated := false
+	for _, filter := range options.filters() {
+		scrollID := ""
+		for {
+			if len(segments) >= maxKeywordSegments {
+				truncated = true
+				break
+			}
+
+			res, err := store.ScrollDocuments(ctx, &types.ScrollOptions{
+				CollectionName:  collectionID,
+				Filter:          filter,
+				Limit:           keywordPageSize,
+				ScrollID:        scrollID,
+				IncludeMetadata: true,
+				IncludeContent:  true,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			for _, document := range res.Documents {
+				result := newSearchResult(collectionID, document)
+				segments = append(segments, result)
+				texts = append(texts, result.Text)
+			}
+
+			if !res.HasMore || res.NextScrollID == "" {
+				break
+			}
+			scrollID = res.NextScrollID
+		}
+
+		if truncated {
+			log.Warn("[Knowledge Base] search: the keyword search of %s is truncated to %d segments", collectionID, maxKeywordSegments)
+			break
+		}
+	}
+
+	results := ranking{}
+	for i, score := range bm25(options.Query, texts) {
+		if score > 0 {
+			segments[i].Keyword = score
+			results = append(results, segments[i])
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Keyword > results[j].Keyword })
+	if len(results) > options.Candidates {
+		results = results[:options.Candidates]
+	}
+	return results, truncated, nil
+}
+
+// filters returns the metadata filters of the vector store queries: the filter of the search, with the doc_id of
+// each document of the search
+func (options SearchOptions) filters() []map[string]interface{} {
+	if len(options.DocumentIDs) == 0 {
+		return []map[string]interface{}{options.Filter}
+	}
+
+	filters := []map[string]interface{}{}
+	seen := map[string]bool{}
+	for _, docID := range options.DocumentIDs {
+		if seen[docID] {
+			continue
+		}
+		seen[docID] = true
+
+		filter := map[string]interface{}{}
+		for key, value := range options.Filter {
+			filter[key] = value
+		}
+		filter["doc_id"] = docID
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// newSearchResult converts a document of the vector store to a search result
+func newSearchResult(collectionID string, document *types.Document) *SearchResult {
+	result := &SearchResult{
+		ID:           document.ID,
+		CollectionID: collectionID,
+		Text:         document.Content,
+		Metadata:     document.Metadata,
+	}
+
+	if docID, ok := document.Metadata["doc_id"].(string); ok {
+		result.DocumentID = docID
+	}
+	return result
+}
+
+// vectorStore returns the vector store of the knowledge base
+func vectorStore() (types.VectorStore, error) {
+	knowledgeBase, ok := Instance.(*KnowledgeBase)
+	if !ok || knowledgeBase.GraphRag == nil || knowledgeBase.Vector == nil {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+	return knowledgeBase.Vector, nil
+}
+
+// collectionEmbedding creates the embedding provider of a collection, the queries are embedded as the segments
+func collectionEmbedding(collectionID string) (types.Embedding, error) {
+	knowledgeBase, ok := Instance.(*KnowledgeBase)
+	if !ok {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+
+	collection, err := knowledgeBase.Config.FindCollection(collectionID, model.QueryParam{
+		Select: []interface{}{"embedding_provider_id", "embedding_option_id", "locale"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	providerID, _ := collection["embedding_provider_id"].(string)
+	optionID, _ := collection["embedding_option_id"].(string)
+	locale, _ := collection["locale"].(string)
+	return makeEmbedding(providerID, optionID, locale)
+}
+
+// embeddingOption returns an option of an embedding provider
+func embeddingOption(providerID string, optionID string, locale string) (*kbtypes.ProviderOption, error) {
+	provider, err := GetProviderWithLanguage("embedding", providerID, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the embedding provider %s: %w", providerID, err)
+	}
+
+	option, ok := provider.GetOption(optionID)
+	if !ok {
+		return nil, fmt.Errorf("option %s not found in provider %s", optionID, providerID)
+	}
+	return option, nil
+}
+
+// makeEmbedding creates an embedding by its provider and option
+func makeEmbedding(providerID string, optionID string, locale string) (types.Embedding, error) {
+	option, err := embeddingOption(providerID, optionID, locale)
+	if err != nil {
+		return nil, err
+	}
+	return factory.MakeEmbedding(providerID, option)
+}
@@ -0,0 +1,38 @@
+package kb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchFilters(t *testing.T) {
+	tests := []struct {
+		options  SearchOptions
+		expected []map[string]interface{}
+	}{
+		{SearchOptions{}, []map[string]interface{}{nil}},
+		{SearchOptions{Filter: map[string]interface{}{"source": "manual"}}, []map[string]interface{}{{"source": "manual"}}},
+		{
+			SearchOptions{Filter: map[string]interface{}{"source": "manual"}, DocumentIDs: []string{"doc1", "doc2", "doc1"}},
+			[]map[string]interface{}{
+				{"source": "manual", "doc_id": "doc1"},
+				{"source": "manual", "doc_id": "doc2"},
+			},
+		},
+		{SearchOptions{DocumentIDs: []string{"doc1"}}, []map[string]interface{}{{"doc_id": "doc1"}}},
+	}
+
+	for _, test := range tests {
+		filters := test.options.filters()
+		if !reflect.DeepEqual(filters, test.expected) {
+			t.Errorf("filters of %+v: expected %v, got %v", test.options, test.expected, filters)
+		}
+	}
+
+	// The filter of the search is not changed
+	filter := map[string]interface{}{"source": "manual"}
+	SearchOptions{Filter: filter, DocumentIDs: []string{"doc1"}}.filters()
+	if len(filter) != 1 {
+		t.Errorf("Expected the filter of the search to be kept, got %v", filter)
+	}
+}
@@ -131,6 +131,71 @@ func (c *Config) RemoveDocumentsByCollectionID(collectionID string) error {
 	return err
 }
 
+// ResyncJobIDs returns the IDs of the scheduled resync jobs of the documents of a collection
+func (c *Config) ResyncJobIDs(collectionID string) ([]string, error) {
+	modelName := c.DocumentModel
+	if modelName == "" {
+		modelName = "__yao.kb.document"
+	}
+
+	mod := model.Select(modelName)
+	if mod == nil {
+		return nil, fmt.Errorf("document model not found: %s", modelName)
+	}
+
+	res, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"resync_job_id"},
+		Wheres: []model.QueryWhere{
+			{Column: "collection_id", Value: collectionID},
+			{Column: "resync_job_id", OP: "notnull"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, row := range res {
+		if jobID, ok := row["resync_job_id"].(string); ok && jobID != "" {
+			ids = append(ids, jobID)
+		}
+	}
+	return ids, nil
+}
+
+// ResyncSchedules returns the resync schedules of the documents of a collection, keyed by the document ID
+func (c *Config) ResyncSchedules(collectionID string) (map[string]string, error) {
+	modelName := c.DocumentModel
+	if modelName == "" {
+		modelName = "__yao.kb.document"
+	}
+
+	mod := model.Select(modelName)
+	if mod == nil {
+		return nil, fmt.Errorf("document model not found: %s", modelName)
+	}
+
+	res, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"document_id", "resync_schedule"},
+		Wheres: []model.QueryWhere{
+			{Column: "collection_id", Value: collectionID},
+			{Column: "resync_schedule", OP: "notnull"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := map[string]string{}
+	for _, row := range res {
+		docID, _ := row["document_id"].(string)
+		if schedule, ok := row["resync_schedule"].(string); ok && docID != "" && schedule != "" {
+			schedules[docID] = schedule
+		}
+	}
+	return schedules, nil
+}
+
 // UpdateSegmentCount updates the segment_count field for a document
 func (c *Config) UpdateSegmentCount(documentID string, count int) error {
 	modelName := c.DocumentModel
@@ -0,0 +1,17 @@
+package types
+
+import "context"
+
+// Reranker reorders the candidates of a retrieval by their relevance to a query. The first stage retrieval
+// compares embeddings computed separately, a reranker reads the query and each candidate together.
+type Reranker interface {
+	// Rerank scores the documents against the query, the results are in descending score order. topN limits
+	// the number of results, 0 returns all the documents.
+	Rerank(ctx context.Context, query string, documents []string, topN int) ([]RerankResult, error)
+}
+
+// RerankResult the relevance of a document to the query
+type RerankResult struct {
+	Index int     `json:"index"` // Index of the document in the candidates
+	Score float64 `json:"score"` // Relevance score, the higher the more relevant
+}
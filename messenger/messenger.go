@@ -2,6 +2,7 @@ package messenger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,12 +11,17 @@ import (
 	"sync"
 	"time"
 
+	jsoniter "github.com/json-iterator/go"
+	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/store"
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/messenger/providers/mailgun"
 	"github.com/yaoapp/yao/messenger/providers/smtp"
 	"github.com/yaoapp/yao/messenger/providers/twilio"
+	"github.com/yaoapp/yao/messenger/providers/webhook"
 	"github.com/yaoapp/yao/messenger/types"
 	"github.com/yaoapp/yao/share"
 )
@@ -27,6 +33,12 @@ var Instance types.Messenger = nil
 var Pools = map[string]types.Provider{}
 var rwlock sync.RWMutex
 
+// ErrInvalidMessage is returned when a message fails the validation
+var ErrInvalidMessage = errors.New("message validation failed")
+
+// ErrNoProvider is returned when no provider is configured for the channel and type of a message
+var ErrNoProvider = errors.New("no provider configured")
+
 // Service implements the Messenger interface
 type Service struct {
 	config          *types.Config
@@ -34,6 +46,7 @@ type Service struct {
 	providersByType map[types.MessageType][]types.Provider // Providers grouped by message type
 	channels        map[string]types.Channel
 	defaults        map[string]string
+	limiter         *RateLimiter
 	mutex           sync.RWMutex
 }
 
@@ -82,9 +95,23 @@ func Load(cfg config.Config) error {
 		},
 	}
 
+	// Load global settings
+	globalPath := filepath.Join("messengers", "global.yao")
+	if exists, _ := application.App.Exists(globalPath); exists {
+		raw, err := application.App.Read(globalPath)
+		if err != nil {
+			return err
+		}
+		err = application.Parse("global.yao", raw, &config.Global)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Parse channels configuration and convert to defaults map
 	if channelsConfig != nil {
 		parseChannelsConfig(channelsConfig, config.Defaults)
+		config.Channels = parseChannels(channelsConfig)
 	}
 
 	// Group providers by message type
@@ -102,15 +129,33 @@ func Load(cfg config.Config) error {
 		config:          config,
 		providers:       providers,
 		providersByType: providersByType,
-		channels:        make(map[string]types.Channel),
+		channels:        config.Channels,
 		defaults:        config.Defaults,
+		limiter:         newLimiter(config.Global.Store),
 	}
 
 	// Set global instance
 	Instance = service
+
+	// Drain the persistent outbox
+	startOutbox(service, config.Global.Outbox)
 	return nil
 }
 
+// newLimiter creates the rate limiter backed by the configured store
+func newLimiter(storeName string) *RateLimiter {
+	if storeName == "" {
+		return NewRateLimiter(nil)
+	}
+
+	counterStore, err := store.Get(storeName)
+	if err != nil {
+		log.Warn("[Messenger] Store %s not found, the rate limits are kept in memory: %v", storeName, err)
+		return NewRateLimiter(nil)
+	}
+	return NewRateLimiter(counterStore)
+}
+
 // loadProviders loads all provider configurations from the providers directory
 func loadProviders() (map[string]types.Provider, error) {
 	providers := make(map[string]types.Provider)
@@ -201,6 +246,10 @@ func createProvider(config types.ProviderConfig) (types.Provider, error) {
 		return createTwilioProvider(config)
 	case "mailgun":
 		return mailgun.NewMailgunProvider(config)
+	case "webhook":
+		return webhook.NewWebhookProvider(config)
+	case "slack", "discord", "feishu":
+		return webhook.NewChatProvider(config)
 	default:
 		return nil, fmt.Errorf("unsupported connector: %s", connector)
 	}
@@ -211,18 +260,128 @@ func createTwilioProvider(config types.ProviderConfig) (types.Provider, error) {
 	return twilio.NewTwilioProvider(config)
 }
 
-// Send sends a message using the specified channel or default provider
-func (m *Service) Send(ctx context.Context, channel string, message *types.Message) error {
+// Send sends a message using the specified channel or default provider.
+// The fallback providers of the channel are tried in order when the primary provider fails
+// or reaches its rate limit, the result names the provider that delivered the message.
+// The delivery is written to the delivery log, so the provider webhooks update its status.
+func (m *Service) Send(ctx context.Context, channel string, message *types.Message) (*types.SendResult, error) {
+	if message == nil {
+		return m.send(ctx, channel, message)
+	}
+
+	messageID, err := gonanoid.New()
+	if err != nil {
+		return nil, err
+	}
+
+	// The providers echo the metadata back in their webhooks, the message of the caller is not changed
+	tagged := *message
+	tagged.Metadata = map[string]interface{}{}
+	for key, value := range message.Metadata {
+		tagged.Metadata[key] = value
+	}
+	tagged.Metadata["message_id"] = messageID
+
+	result, err := m.send(ctx, channel, &tagged)
+	if result == nil {
+		return result, err
+	}
+
+	result.MessageID = messageID
+	if model.Exists("__yao.messenger.delivery") {
+		if err := recordDelivery(messageID, 1, result, err, time.Now()); err != nil {
+			log.Error("[Messenger] Failed to record the delivery of %s: %v", messageID, err)
+		}
+	}
+	return result, err
+}
+
+// send sends a message via the providers of a channel without writing the delivery log
+func (m *Service) send(ctx context.Context, channel string, message *types.Message) (*types.SendResult, error) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
-	// Get provider for channel
-	providerName := m.getProviderForChannel(channel, string(message.Type))
-	if providerName == "" {
-		return fmt.Errorf("no provider configured for channel: %s, type: %s", channel, message.Type)
+	// Validate message
+	if err := m.validateMessage(message); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+
+	// Get providers for channel, the primary provider first
+	providerNames := m.getProvidersForChannel(channel, string(message.Type))
+	if len(providerNames) == 0 {
+		return nil, fmt.Errorf("%w for channel: %s, type: %s", ErrNoProvider, channel, message.Type)
+	}
+
+	result := &types.SendResult{
+		Metadata: map[string]interface{}{"channel": channel},
+	}
+
+	// Reserve the recipient limits, the fallback providers do not help here. The reservation is released when no
+	// provider sends the message.
+	limit, scope := m.getRateLimit(channel, string(message.Type))
+	recipients, err := m.limiter.ReserveRecipients(limit, scope, message.To)
+	if err != nil {
+		result.Error = err
+		return result, err
+	}
+	sent := false
+	defer func() {
+		if !sent {
+			recipients.Release()
+		}
+	}()
+
+	var errors []string
+	limited := 0
+	for _, providerName := range providerNames {
+		result.Tried = append(result.Tried, providerName)
+
+		reservation, ok := m.limiter.ReserveProvider(limit, scope, providerName)
+		if !ok {
+			log.Warn("[Messenger] Provider %s reached the rate limit of channel %s, trying the next one", providerName, channel)
+			errors = append(errors, fmt.Sprintf("%s: %v", providerName, ErrRateLimited))
+			limited++
+			continue
+		}
+
+		provider, exists := m.providers[providerName]
+		if !exists {
+			reservation.Release()
+			errors = append(errors, fmt.Sprintf("provider not found: %s", providerName))
+			continue
+		}
+
+		attempts, ids, err := m.sendWithRetry(ctx, provider, message)
+		result.Attempts += attempts
+		if err == nil {
+			sent = true
+			result.ProviderMessageIDs = ids
+			result.Success = true
+			result.Provider = providerName
+			result.Status = types.DeliveryStatusSent
+			result.SentAt = time.Now()
+			return result, nil
+		}
+
+		reservation.Release()
+
+		// Stop the failover when the context is cancelled
+		if ctx.Err() != nil {
+			result.Error = err
+			return result, err
+		}
+
+		log.Warn("[Messenger] Provider %s failed for channel %s: %v", providerName, channel, err)
+		errors = append(errors, fmt.Sprintf("%s: %v", providerName, err))
 	}
 
-	return m.SendWithProvider(ctx, providerName, message)
+	if limited == len(providerNames) {
+		err = fmt.Errorf("%w: all providers of channel %s, type: %s", ErrRateLimited, channel, message.Type)
+	} else {
+		err = fmt.Errorf("failed to send message via channel %s: %s", channel, strings.Join(errors, "; "))
+	}
+	result.Error = err
+	return result, err
 }
 
 // SendWithProvider sends a message using a specific provider
@@ -234,10 +393,17 @@ func (m *Service) SendWithProvider(ctx context.Context, providerName string, mes
 
 	// Validate message
 	if err := m.validateMessage(message); err != nil {
-		return fmt.Errorf("message validation failed: %w", err)
+		return fmt.Errorf("%w: %w", ErrInvalidMessage, err)
 	}
 
-	// Send message with retry logic
+	_, _, err := m.sendWithRetry(ctx, provider, message)
+	return err
+}
+
+// sendWithRetry sends a message with the retry logic, returns the number of attempts and the
+// message IDs assigned by the provider when it is a tracker
+func (m *Service) sendWithRetry(ctx context.Context, provider types.Provider, message *types.Message) (int, []string, error) {
+	providerName := provider.GetName()
 	var lastErr error
 	maxAttempts := m.config.Global.RetryAttempts
 	if maxAttempts <= 0 {
@@ -248,14 +414,20 @@ func (m *Service) SendWithProvider(ctx context.Context, providerName string, mes
 		// Check if context is cancelled before each attempt
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("send cancelled: %w", ctx.Err())
+			return attempt - 1, nil, fmt.Errorf("send cancelled: %w", ctx.Err())
 		default:
 		}
 
-		err := provider.Send(ctx, message)
+		var ids []string
+		var err error
+		if tracker, ok := provider.(types.Tracker); ok {
+			ids, err = tracker.SendTracked(ctx, message)
+		} else {
+			err = provider.Send(ctx, message)
+		}
 		if err == nil {
 			log.Info("[Messenger] Message sent successfully via %s (attempt %d/%d)", providerName, attempt, maxAttempts)
-			return nil
+			return attempt, ids, nil
 		}
 
 		lastErr = err
@@ -265,13 +437,13 @@ func (m *Service) SendWithProvider(ctx context.Context, providerName string, mes
 			// Use context-aware sleep for retry delay
 			select {
 			case <-ctx.Done():
-				return fmt.Errorf("send cancelled during retry: %w", ctx.Err())
+				return attempt, nil, fmt.Errorf("send cancelled during retry: %w", ctx.Err())
 			case <-time.After(m.config.Global.RetryDelay):
 			}
 		}
 	}
 
-	return fmt.Errorf("failed to send message after %d attempts: %w", maxAttempts, lastErr)
+	return maxAttempts, nil, fmt.Errorf("failed to send message after %d attempts: %w", maxAttempts, lastErr)
 }
 
 // SendBatch sends multiple messages in batch
@@ -285,7 +457,7 @@ func (m *Service) SendBatch(ctx context.Context, channel string, messages []*typ
 	for _, message := range messages {
 		providerName := m.getProviderForChannel(channel, string(message.Type))
 		if providerName == "" {
-			return fmt.Errorf("no provider configured for channel: %s, type: %s", channel, message.Type)
+			return fmt.Errorf("%w for channel: %s, type: %s", ErrNoProvider, channel, message.Type)
 		}
 		providerMessages[providerName] = append(providerMessages[providerName], message)
 	}
@@ -410,6 +582,9 @@ func (m *Service) getProviderForChannel(channel, messageType string) string {
 		if ch.Provider != "" {
 			return ch.Provider
 		}
+		if typeChannel, has := ch.Types[messageType]; has && typeChannel != nil && typeChannel.Provider != "" {
+			return typeChannel.Provider
+		}
 	}
 
 	// Check defaults for channel.messageType
@@ -438,6 +613,45 @@ func (m *Service) getProviderForChannel(channel, messageType string) string {
 	return ""
 }
 
+// getProvidersForChannel returns the primary provider followed by the fallback providers
+// of the channel type section and the channel, without duplicates
+func (m *Service) getProvidersForChannel(channel, messageType string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	add(m.getProviderForChannel(channel, messageType))
+	if ch, exists := m.channels[channel]; exists {
+		if typeChannel, has := ch.Types[messageType]; has && typeChannel != nil {
+			for _, name := range typeChannel.Fallbacks {
+				add(name)
+			}
+		}
+		for _, name := range ch.Fallbacks {
+			add(name)
+		}
+	}
+	return names
+}
+
+// getRateLimit returns the rate limit of a channel message type and the counter scope
+// The type section limit overrides the channel limit
+func (m *Service) getRateLimit(channel, messageType string) (*types.RateLimit, string) {
+	ch, exists := m.channels[channel]
+	if !exists {
+		return nil, ""
+	}
+	if typeChannel, has := ch.Types[messageType]; has && typeChannel != nil && typeChannel.RateLimit != nil {
+		return typeChannel.RateLimit, channel + "." + messageType
+	}
+	return ch.RateLimit, channel
+}
+
 // resolveProviderEnvVars resolves environment variables in provider configuration
 func resolveProviderEnvVars(config *types.ProviderConfig) error {
 	if config.Options != nil {
@@ -520,12 +734,76 @@ func parseChannelsConfig(channelsConfig map[string]interface{}, defaults map[str
 	}
 }
 
+// parseChannels parses the channels configuration into channel structures (templates, fallbacks, etc.)
+// Keys other than the reserved channel fields are treated as message type sections (email, sms, whatsapp)
+func parseChannels(channelsConfig map[string]interface{}) map[string]types.Channel {
+	reserved := map[string]bool{
+		"provider": true, "description": true, "fallbacks": true,
+		"rate_limit": true, "settings": true, "templates": true,
+	}
+
+	channels := make(map[string]types.Channel)
+	for channelName, channelData := range channelsConfig {
+		channelMap, ok := channelData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		base := map[string]interface{}{}
+		typeSections := map[string]interface{}{}
+		for key, value := range channelMap {
+			if reserved[key] {
+				base[key] = value
+				continue
+			}
+			typeSections[key] = value
+		}
+
+		var channel types.Channel
+		if err := convertChannel(base, &channel); err != nil {
+			log.Warn("[Messenger] Failed to parse channel %s: %v", channelName, err)
+			continue
+		}
+
+		channel.Types = make(map[string]*types.Channel)
+		for typeName, section := range typeSections {
+			typeChannel := &types.Channel{}
+			switch v := section.(type) {
+			case string:
+				// Direct provider assignment (legacy support)
+				typeChannel.Provider = v
+			case map[string]interface{}:
+				if err := convertChannel(v, typeChannel); err != nil {
+					log.Warn("[Messenger] Failed to parse channel %s.%s: %v", channelName, typeName, err)
+					continue
+				}
+			default:
+				continue
+			}
+			channel.Types[typeName] = typeChannel
+		}
+
+		channels[channelName] = channel
+	}
+	return channels
+}
+
+// convertChannel converts a raw channel map into a channel structure
+func convertChannel(raw map[string]interface{}, channel *types.Channel) error {
+	data, err := jsoniter.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal(data, channel)
+}
+
 // validateMessage validates a message before sending
 func (m *Service) validateMessage(message *types.Message) error {
 	if message == nil {
 		return fmt.Errorf("message is nil")
 	}
-	if len(message.To) == 0 {
+	// The chat webhooks post to a channel, the recipients are optional
+	if len(message.To) == 0 && message.Type != types.MessageTypeChat {
 		return fmt.Errorf("message has no recipients")
 	}
 	if message.Body == "" && message.HTML == "" {
@@ -549,6 +827,8 @@ func (m *Service) supportsChannelType(provider types.Provider, channelType strin
 		return providerType == "twilio"
 	case "whatsapp":
 		return providerType == "twilio"
+	case "chat":
+		return providerType == "webhook" || providerType == "slack" || providerType == "discord" || providerType == "feishu"
 	default:
 		return false
 	}
@@ -567,6 +847,10 @@ func getSupportedMessageTypes(provider types.Provider) []types.MessageType {
 		// Twilio provider supports all message types
 		return []types.MessageType{types.MessageTypeSMS, types.MessageTypeWhatsApp, types.MessageTypeEmail}
 	default:
+		// The webhook providers declare the message types they post
+		if typed, ok := provider.(interface{ MessageTypes() []types.MessageType }); ok {
+			return typed.MessageTypes()
+		}
 		return []types.MessageType{}
 	}
 }
@@ -1,6 +1,9 @@
 package messenger
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,6 +38,83 @@ func createTestMessage(msgType types.MessageType) *types.Message {
 	return message
 }
 
+// mockProvider records the messages it sends, and fails the first failures calls
+type mockProvider struct {
+	name     string
+	typ      string
+	failures int
+	calls    int
+	sent     []*types.Message
+	mutex    sync.Mutex
+}
+
+func (p *mockProvider) Send(ctx context.Context, message *types.Message) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.calls++
+	if p.calls <= p.failures {
+		return fmt.Errorf("mock provider %s failure %d", p.name, p.calls)
+	}
+	p.sent = append(p.sent, message)
+	return nil
+}
+
+func (p *mockProvider) SendBatch(ctx context.Context, messages []*types.Message) error {
+	for _, message := range messages {
+		if err := p.Send(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *mockProvider) GetType() string { return p.typ }
+func (p *mockProvider) GetName() string { return p.name }
+func (p *mockProvider) Validate() error { return nil }
+func (p *mockProvider) Close() error    { return nil }
+
+func (p *mockProvider) sentCount() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.sent)
+}
+
+func (p *mockProvider) callCount() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.calls
+}
+
+func (p *mockProvider) last() *types.Message {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.sent) == 0 {
+		return nil
+	}
+	return p.sent[len(p.sent)-1]
+}
+
+// newTestService creates a messenger service with the given providers and channels, without loading an application
+func newTestService(channels map[string]types.Channel, providers ...types.Provider) *Service {
+	service := &Service{
+		config: &types.Config{
+			Global: types.GlobalConfig{RetryAttempts: 1},
+		},
+		providers:       map[string]types.Provider{},
+		providersByType: map[types.MessageType][]types.Provider{},
+		channels:        channels,
+		defaults:        map[string]string{},
+		limiter:         NewRateLimiter(nil),
+	}
+	for _, provider := range providers {
+		service.providers[provider.GetName()] = provider
+		for _, msgType := range getSupportedMessageTypes(provider) {
+			service.providersByType[msgType] = append(service.providersByType[msgType], provider)
+		}
+	}
+	return service
+}
+
 // setupTestEnvironment sets up required environment variables for testing
 // Note: This function is now optional since messenger package handles env var substitution
 // and env.local.sh already sets the required variables. Keeping it for explicit test control.
@@ -442,7 +522,7 @@ func TestSendMessage(t *testing.T) {
 
 	// Try to send via default channel
 	ctx := context.Background()
-	err = service.Send(ctx, "default", emailMessage)
+	_, err = service.Send(ctx, "default", emailMessage)
 	// Expected to fail with test credentials, but should handle gracefully
 	if err != nil {
 		t.Logf("Send failed as expected with test credentials: %v", err)
@@ -560,6 +640,25 @@ func TestMessengerIntegration(t *testing.T) {
 	}
 }
 
+func TestChatProvider(t *testing.T) {
+	provider, err := createProvider(types.ProviderConfig{
+		Name:      "team",
+		Connector: "slack",
+		Options:   map[string]interface{}{"webhook_url": "https://hooks.slack.com/services/T000/B000/XXX"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []types.MessageType{types.MessageTypeChat}, getSupportedMessageTypes(provider))
+
+	service := newTestService(map[string]types.Channel{}, provider)
+	assert.Len(t, service.GetProviders("chat"), 1)
+
+	// The chat messages do not need recipients
+	message := &types.Message{Type: types.MessageTypeChat, Body: "Job failed"}
+	assert.NoError(t, service.validateMessage(message))
+	message.Type = types.MessageTypeSMS
+	assert.Error(t, service.validateMessage(message))
+}
+
 // Helper function to check if slice contains string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,479 @@
+package messenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// OutboxFields defines the fields to select for outbox queries
+var OutboxFields = []interface{}{
+	"id", "message_id", "channel", "type", "recipients", "subject", "status", "priority",
+	"scheduled_at", "next_attempt_at", "attempts", "max_attempts", "provider",
+	"last_error", "sent_at", "metadata", "created_at", "updated_at",
+}
+
+// ErrDecodeMessage is returned when a stored message can not be decoded
+var ErrDecodeMessage = errors.New("cannot decode message")
+
+// DeliveryFields defines the fields to select for delivery queries
+var DeliveryFields = []interface{}{
+	"id", "message_id", "attempt", "success", "provider", "provider_message_id", "status", "status_at",
+	"tried", "provider_attempts", "error", "sent_at", "metadata", "created_at",
+}
+
+// errOutboxNotClaimed is returned when another worker claimed the message first
+var errOutboxNotClaimed = errors.New("outbox message claimed by another worker")
+
+// Outbox drains the persistent outbox, the messages are claimed with a conditional update
+// so several nodes can drain the same outbox.
+type Outbox struct {
+	service *Service
+	config  types.OutboxConfig
+	now     func() time.Time
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// outboxStaleAfter is how long a message may stay in sending before another worker picks it up again
+const outboxStaleAfter = 10 * time.Minute
+
+var globalOutbox *Outbox
+var outboxLock sync.Mutex
+
+// NewOutbox creates an outbox worker for the service
+func NewOutbox(service *Service, config types.OutboxConfig) *Outbox {
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.Backoff <= 0 {
+		config.Backoff = 30 * time.Second
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Outbox{service: service, config: config, now: time.Now, ctx: ctx, cancel: cancel}
+}
+
+// Start starts draining the outbox
+func (o *Outbox) Start() {
+	ticker := time.NewTicker(o.config.Interval)
+	defer ticker.Stop()
+
+	log.Info("[Messenger] Outbox worker started with interval: %v", o.config.Interval)
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := o.Drain(o.ctx); err != nil {
+				log.Error("[Messenger] Outbox drain failed: %v", err)
+			}
+		case <-o.ctx.Done():
+			log.Info("[Messenger] Outbox worker stopped")
+			return
+		}
+	}
+}
+
+// Stop stops the outbox worker
+func (o *Outbox) Stop() {
+	if o.cancel != nil {
+		o.cancel()
+	}
+}
+
+// Drain sends the due messages once, the higher priority messages first, returns the number of messages processed
+func (o *Outbox) Drain(ctx context.Context) (int, error) {
+	mod := model.Select("__yao.messenger.outbox")
+	if mod == nil {
+		return 0, fmt.Errorf("messenger outbox model not found")
+	}
+
+	// Release the messages left in sending by a stopped node
+	_, err := mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "status", Value: string(types.OutboxStatusSending)},
+			{Column: "updated_at", OP: "lt", Value: o.now().Add(-outboxStaleAfter)},
+		},
+	}, map[string]interface{}{"status": string(types.OutboxStatusPending), "updated_at": o.now()})
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"id", "message_id", "channel", "message", "attempts", "max_attempts"},
+		Wheres: []model.QueryWhere{
+			{Column: "status", Value: string(types.OutboxStatusPending)},
+			{Column: "next_attempt_at", OP: "le", Value: o.now()},
+		},
+		Orders: []model.QueryOrder{{Column: "priority", Option: "desc"}, {Column: "next_attempt_at", Option: "asc"}},
+		Limit:  o.config.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			return processed, ctx.Err()
+		}
+
+		err := o.deliver(ctx, mod, row)
+		if err == errOutboxNotClaimed {
+			continue
+		}
+		if err != nil {
+			log.Error("[Messenger] Outbox message %v: %v", row["message_id"], err)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// deliver claims and sends an outbox message, then records the attempt
+func (o *Outbox) deliver(ctx context.Context, mod *model.Model, row maps.MapStrAny) error {
+	messageID := fmt.Sprintf("%v", row["message_id"])
+	claimed, err := mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "message_id", Value: messageID},
+			{Column: "status", Value: string(types.OutboxStatusPending)},
+		},
+	}, map[string]interface{}{"status": string(types.OutboxStatusSending), "updated_at": o.now()})
+	if err != nil {
+		return err
+	}
+	if claimed == 0 {
+		return errOutboxNotClaimed
+	}
+
+	attempt := toInt(row["attempts"]) + 1
+	maxAttempts := toInt(row["max_attempts"])
+	if maxAttempts <= 0 {
+		maxAttempts = o.config.MaxAttempts
+	}
+
+	message := &types.Message{}
+	result := &types.SendResult{MessageID: messageID}
+	err = decodeJSON(row["message"], message)
+	if err == nil {
+		// The providers echo the metadata back in their webhooks, so the delivery status finds the message
+		if message.Metadata == nil {
+			message.Metadata = map[string]interface{}{}
+		}
+		message.Metadata["message_id"] = messageID
+
+		var sent *types.SendResult
+		sent, err = o.service.send(ctx, fmt.Sprintf("%v", row["channel"]), message)
+		if sent != nil {
+			sent.MessageID = messageID
+			result = sent
+		}
+	}
+
+	if err := recordDelivery(messageID, attempt, result, err, o.now()); err != nil {
+		log.Error("[Messenger] Failed to record the delivery of %s: %v", messageID, err)
+	}
+
+	update := map[string]interface{}{"attempts": attempt, "updated_at": o.now()}
+	switch {
+	case err == nil:
+		update["status"] = string(types.OutboxStatusSent)
+		update["provider"] = result.Provider
+		update["sent_at"] = result.SentAt
+		update["last_error"] = nil
+
+	case attempt >= maxAttempts || !retryable(err):
+		update["status"] = string(types.OutboxStatusFailed)
+		update["last_error"] = err.Error()
+
+	default:
+		update["status"] = string(types.OutboxStatusPending)
+		update["next_attempt_at"] = o.now().Add(o.backoff(attempt))
+		update["last_error"] = err.Error()
+	}
+
+	_, updateErr := mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "message_id", Value: messageID}},
+	}, update)
+	if updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// recordDelivery writes the delivery log of an attempt, one row per message ID assigned by the provider
+// so that the receipts of each ID find their row
+func recordDelivery(messageID string, attempt int, result *types.SendResult, sendErr error, now time.Time) error {
+	mod := model.Select("__yao.messenger.delivery")
+	if mod == nil {
+		return fmt.Errorf("messenger delivery model not found")
+	}
+
+	data := map[string]interface{}{
+		"message_id":        messageID,
+		"attempt":           attempt,
+		"success":           sendErr == nil,
+		"provider":          result.Provider,
+		"tried":             result.Tried,
+		"provider_attempts": result.Attempts,
+		"metadata":          result.Metadata,
+		"created_at":        now,
+	}
+	if sendErr != nil {
+		data["status"] = string(types.DeliveryStatusFailed)
+		data["error"] = sendErr.Error()
+	} else {
+		data["status"] = string(result.Status)
+		data["status_at"] = result.SentAt
+		data["sent_at"] = result.SentAt
+	}
+
+	if len(result.ProviderMessageIDs) == 0 {
+		_, err := mod.Create(data)
+		return err
+	}
+
+	for _, id := range result.ProviderMessageIDs {
+		row := map[string]interface{}{"provider_message_id": id}
+		for key, value := range data {
+			row[key] = value
+		}
+		if _, err := mod.Create(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backoff returns the retry delay after the given attempt
+func (o *Outbox) backoff(attempt int) time.Duration {
+	delay := o.config.Backoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= o.config.MaxBackoff {
+			return o.config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// retryable checks whether a failed delivery may succeed later
+// Invalid messages and unknown channels fail at once
+func retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !errors.Is(err, ErrInvalidMessage) && !errors.Is(err, ErrNoProvider) && !errors.Is(err, ErrDecodeMessage)
+}
+
+// startOutbox replaces the running outbox worker
+func startOutbox(service *Service, config types.OutboxConfig) {
+	StopOutbox()
+	if config.Disabled {
+		log.Info("[Messenger] Outbox worker is disabled")
+		return
+	}
+
+	outboxLock.Lock()
+	defer outboxLock.Unlock()
+	globalOutbox = NewOutbox(service, config)
+	go globalOutbox.Start()
+}
+
+// StopOutbox stops the outbox worker
+func StopOutbox() {
+	outboxLock.Lock()
+	defer outboxLock.Unlock()
+	if globalOutbox != nil {
+		globalOutbox.Stop()
+		globalOutbox = nil
+	}
+}
+
+// Enqueue stores a message in the outbox, the worker sends it when ScheduledAt is due,
+// the higher Priority messages first. Returns the outbox message ID.
+func (m *Service) Enqueue(ctx context.Context, channel string, message *types.Message) (string, error) {
+	if err := m.validateMessage(message); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+
+	mod := model.Select("__yao.messenger.outbox")
+	if mod == nil {
+		return "", fmt.Errorf("messenger outbox model not found")
+	}
+
+	messageID, err := gonanoid.New()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate message ID: %w", err)
+	}
+
+	now := time.Now()
+	next := now
+	if message.ScheduledAt != nil && message.ScheduledAt.After(now) {
+		next = *message.ScheduledAt
+	}
+
+	maxAttempts := m.config.Global.Outbox.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+
+	_, err = mod.Create(map[string]interface{}{
+		"message_id":      messageID,
+		"channel":         channel,
+		"type":            string(message.Type),
+		"recipients":      message.To,
+		"subject":         message.Subject,
+		"message":         message,
+		"status":          string(types.OutboxStatusPending),
+		"priority":        message.Priority,
+		"scheduled_at":    message.ScheduledAt,
+		"next_attempt_at": next,
+		"attempts":        0,
+		"max_attempts":    maxAttempts,
+		"metadata":        message.Metadata,
+		"created_at":      now,
+		"updated_at":      now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to enqueue message: %w", err)
+	}
+	return messageID, nil
+}
+
+// ListOutbox lists the outbox messages with pagination
+func ListOutbox(param model.QueryParam, page int, pagesize int) (maps.MapStrAny, error) {
+	mod := model.Select("__yao.messenger.outbox")
+	if mod == nil {
+		return nil, fmt.Errorf("messenger outbox model not found")
+	}
+
+	if len(param.Select) == 0 {
+		param.Select = OutboxFields
+	}
+	if len(param.Orders) == 0 {
+		param.Orders = []model.QueryOrder{{Column: "id", Option: "desc"}}
+	}
+	return mod.Paginate(param, page, pagesize)
+}
+
+// GetOutbox returns an outbox message with its full content
+func GetOutbox(messageID string) (maps.MapStrAny, error) {
+	mod := model.Select("__yao.messenger.outbox")
+	if mod == nil {
+		return nil, fmt.Errorf("messenger outbox model not found")
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: append(append([]interface{}{}, OutboxFields...), "message"),
+		Wheres: []model.QueryWhere{{Column: "message_id", Value: messageID}},
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("outbox message not found: %s", messageID)
+	}
+	return rows[0], nil
+}
+
+// CancelOutbox cancels a pending outbox message, the messages being sent or sent cannot be cancelled
+func CancelOutbox(messageID string) error {
+	mod := model.Select("__yao.messenger.outbox")
+	if mod == nil {
+		return fmt.Errorf("messenger outbox model not found")
+	}
+
+	affected, err := mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "message_id", Value: messageID},
+			{Column: "status", Value: string(types.OutboxStatusPending)},
+		},
+	}, map[string]interface{}{"status": string(types.OutboxStatusCancelled), "updated_at": time.Now()})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("outbox message %s not found or not pending", messageID)
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery attempts of an outbox message
+func ListDeliveries(messageID string) ([]maps.MapStrAny, error) {
+	mod := model.Select("__yao.messenger.delivery")
+	if mod == nil {
+		return nil, fmt.Errorf("messenger delivery model not found")
+	}
+
+	return mod.Get(model.QueryParam{
+		Select: DeliveryFields,
+		Wheres: []model.QueryWhere{{Column: "message_id", Value: messageID}},
+		Orders: []model.QueryOrder{{Column: "attempt", Option: "asc"}},
+	})
+}
+
+// decodeJSON decodes a json column, the drivers return it as a string, bytes or decoded value
+func decodeJSON(value interface{}, v interface{}) error {
+	var data []byte
+	switch raw := value.(type) {
+	case nil:
+		return fmt.Errorf("%w: empty message", ErrDecodeMessage)
+	case string:
+		data = []byte(raw)
+	case []byte:
+		data = raw
+	default:
+		var err error
+		data, err = jsoniter.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrDecodeMessage, err)
+		}
+	}
+
+	if err := jsoniter.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrDecodeMessage, err)
+	}
+	return nil
+}
+
+// toInt converts a numeric column value to int
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case uint:
+		return int(v)
+	case uint64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		var n int
+		fmt.Sscanf(v, "%d", &n)
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package messenger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/messenger/types"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	outbox := NewOutbox(nil, types.OutboxConfig{Backoff: time.Second, MaxBackoff: 5 * time.Second})
+	defer outbox.Stop()
+
+	assert.Equal(t, time.Second, outbox.backoff(1))
+	assert.Equal(t, 2*time.Second, outbox.backoff(2))
+	assert.Equal(t, 4*time.Second, outbox.backoff(3))
+	assert.Equal(t, 5*time.Second, outbox.backoff(4))
+	assert.Equal(t, 5*time.Second, outbox.backoff(10))
+}
+
+func TestOutboxRetryable(t *testing.T) {
+	assert.True(t, retryable(fmt.Errorf("failed to send message via channel default: timeout")))
+	assert.False(t, retryable(fmt.Errorf("%w: message has no recipients", ErrInvalidMessage)))
+	assert.False(t, retryable(fmt.Errorf("%w for channel: unknown, type: email", ErrNoProvider)))
+	assert.False(t, retryable(fmt.Errorf("%w: empty message", ErrDecodeMessage)))
+
+	// The errors are matched by identity, not by their text
+	assert.True(t, retryable(fmt.Errorf("smtp: 550 message validation failed")))
+	assert.False(t, retryable(nil))
+}
+
+func TestOutboxConfigUnmarshal(t *testing.T) {
+	var global types.GlobalConfig
+	err := json.Unmarshal([]byte(`{"retry_attempts": 2, "outbox": {"interval": "10s", "backoff": 60, "max_attempts": 3}}`), &global)
+	require.NoError(t, err)
+	assert.Equal(t, 2, global.RetryAttempts)
+	assert.Equal(t, 10*time.Second, global.Outbox.Interval)
+	assert.Equal(t, time.Minute, global.Outbox.Backoff)
+	assert.Equal(t, 3, global.Outbox.MaxAttempts)
+}
+
+func TestOutboxDrain(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	mail := &mockProvider{name: "mail", typ: "smtp"}
+	service := newTestService(map[string]types.Channel{
+		"default": {Types: map[string]*types.Channel{"email": {Provider: "mail"}}},
+	}, mail)
+
+	ctx := context.Background()
+	low := createTestMessage(types.MessageTypeEmail)
+	low.Subject = "low"
+	lowID, err := service.Enqueue(ctx, "default", low)
+	require.NoError(t, err)
+
+	high := createTestMessage(types.MessageTypeEmail)
+	high.Subject = "high"
+	high.Priority = 10
+	highID, err := service.Enqueue(ctx, "default", high)
+	require.NoError(t, err)
+
+	later := time.Now().Add(time.Hour)
+	scheduled := createTestMessage(types.MessageTypeEmail)
+	scheduled.ScheduledAt = &later
+	scheduledID, err := service.Enqueue(ctx, "default", scheduled)
+	require.NoError(t, err)
+
+	outbox := NewOutbox(service, types.OutboxConfig{})
+	defer outbox.Stop()
+
+	processed, err := outbox.Drain(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	require.Equal(t, 2, mail.sentCount())
+	assert.Equal(t, "low", mail.last().Subject, "the higher priority message is sent first")
+
+	for _, id := range []string{lowID, highID} {
+		row, err := GetOutbox(id)
+		require.NoError(t, err)
+		assert.Equal(t, string(types.OutboxStatusSent), row["status"])
+		assert.Equal(t, "mail", row["provider"])
+
+		deliveries, err := ListDeliveries(id)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "mail", deliveries[0]["provider"])
+	}
+
+	// The scheduled message is not due yet and can be cancelled
+	row, err := GetOutbox(scheduledID)
+	require.NoError(t, err)
+	assert.Equal(t, string(types.OutboxStatusPending), row["status"])
+	require.NoError(t, CancelOutbox(scheduledID))
+	assert.Error(t, CancelOutbox(scheduledID))
+
+	outbox.now = func() time.Time { return later.Add(time.Minute) }
+	processed, err = outbox.Drain(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+}
+
+func TestOutboxRetry(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	mail := &mockProvider{name: "mail", typ: "smtp", failures: 1}
+	service := newTestService(map[string]types.Channel{
+		"default": {Types: map[string]*types.Channel{"email": {Provider: "mail"}}},
+	}, mail)
+
+	ctx := context.Background()
+	messageID, err := service.Enqueue(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	require.NoError(t, err)
+
+	outbox := NewOutbox(service, types.OutboxConfig{Backoff: time.Minute})
+	defer outbox.Stop()
+
+	_, err = outbox.Drain(ctx)
+	require.NoError(t, err)
+	row, err := GetOutbox(messageID)
+	require.NoError(t, err)
+	assert.Equal(t, string(types.OutboxStatusPending), row["status"])
+	assert.NotEmpty(t, row["last_error"])
+
+	// Not due before the backoff
+	processed, err := outbox.Drain(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	outbox.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	processed, err = outbox.Drain(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	row, err = GetOutbox(messageID)
+	require.NoError(t, err)
+	assert.Equal(t, string(types.OutboxStatusSent), row["status"])
+
+	deliveries, err := ListDeliveries(messageID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.NotEmpty(t, deliveries[0]["error"])
+}
@@ -0,0 +1,170 @@
+package messenger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+func init() {
+	process.RegisterGroup("messenger", map[string]process.Handler{
+		"sendtemplate":      processSendTemplate,
+		"enqueue":           processEnqueue,
+		"outbox.list":       processOutboxList,
+		"outbox.get":        processOutboxGet,
+		"outbox.cancel":     processOutboxCancel,
+		"outbox.deliveries": processOutboxDeliveries,
+	})
+}
+
+// processSendTemplate messenger.SendTemplate <channel> <template> <variables> <to>
+// Args[0] string: the channel name, e.g. default
+// Args[1] string: the template name, e.g. invite
+// Args[2] map: the template variables, the locale variable selects the template language
+// Args[3] string|[]string: the recipients, may be empty for the chat templates
+func processSendTemplate(process *process.Process) interface{} {
+	process.ValidateArgNums(4)
+	channel := process.ArgsString(0)
+	templateName := process.ArgsString(1)
+	variables := process.ArgsMap(2, map[string]interface{}{})
+	to := recipients(process.Args[3])
+
+	if Instance == nil {
+		exception.New("messenger is not loaded", 500).Throw()
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, err := Instance.SendTemplate(ctx, channel, templateName, variables, to)
+	if err != nil {
+		exception.New("messenger.SendTemplate %s.%s: %s", 500, channel, templateName, err.Error()).Throw()
+	}
+	return result
+}
+
+// processEnqueue messenger.Enqueue <channel> <message>
+// Args[0] string: the channel name, e.g. default
+// Args[1] map: the message, scheduled_at and priority control when the outbox worker sends it
+// Returns the outbox message ID
+func processEnqueue(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+	channel := process.ArgsString(0)
+	raw := process.ArgsMap(1)
+
+	message := &types.Message{}
+	if err := decodeJSON(map[string]interface{}(raw), message); err != nil {
+		exception.New("messenger.Enqueue: %s", 400, err.Error()).Throw()
+	}
+
+	if Instance == nil {
+		exception.New("messenger is not loaded", 500).Throw()
+	}
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	messageID, err := Instance.Enqueue(ctx, channel, message)
+	if err != nil {
+		exception.New("messenger.Enqueue %s: %s", 500, channel, err.Error()).Throw()
+	}
+	return messageID
+}
+
+// processOutboxList messenger.Outbox.List <param> <page> <pagesize>
+// Args[0] map: the query param, e.g. {"wheres": [{"column": "status", "value": "failed"}]}
+// Args[1] int: the page, default 1
+// Args[2] int: the page size, default 20
+func processOutboxList(process *process.Process) interface{} {
+	param := model.QueryParam{}
+	if process.NumOfArgs() > 0 && process.Args[0] != nil {
+		if err := decodeJSON(process.Args[0], &param); err != nil {
+			exception.New("messenger.Outbox.List: invalid query param %s", 400, err.Error()).Throw()
+		}
+	}
+
+	page := 1
+	pagesize := 20
+	if process.NumOfArgs() > 1 {
+		page = process.ArgsInt(1, 1)
+	}
+	if process.NumOfArgs() > 2 {
+		pagesize = process.ArgsInt(2, 20)
+	}
+
+	result, err := ListOutbox(param, page, pagesize)
+	if err != nil {
+		exception.New("messenger.Outbox.List: %s", 500, err.Error()).Throw()
+	}
+	return result
+}
+
+// processOutboxGet messenger.Outbox.Get <message_id>
+// Args[0] string: the outbox message ID
+func processOutboxGet(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	messageID := process.ArgsString(0)
+
+	row, err := GetOutbox(messageID)
+	if err != nil {
+		exception.New("messenger.Outbox.Get: %s", 404, err.Error()).Throw()
+	}
+	return row
+}
+
+// processOutboxCancel messenger.Outbox.Cancel <message_id>
+// Args[0] string: the outbox message ID, only the pending messages can be cancelled
+func processOutboxCancel(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	messageID := process.ArgsString(0)
+
+	if err := CancelOutbox(messageID); err != nil {
+		exception.New("messenger.Outbox.Cancel: %s", 400, err.Error()).Throw()
+	}
+	return nil
+}
+
+// processOutboxDeliveries messenger.Outbox.Deliveries <message_id>
+// Args[0] string: the outbox message ID
+// Returns the delivery attempts, the provider, the tried providers and the error of each attempt
+func processOutboxDeliveries(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	messageID := process.ArgsString(0)
+
+	rows, err := ListDeliveries(messageID)
+	if err != nil {
+		exception.New("messenger.Outbox.Deliveries: %s", 500, err.Error()).Throw()
+	}
+	return rows
+}
+
+// recipients converts the process argument to a list of recipients
+func recipients(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return []string{}
+		}
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		result := []string{}
+		for _, item := range v {
+			if item == nil {
+				continue
+			}
+			result = append(result, fmt.Sprintf("%v", item))
+		}
+		return result
+	}
+	return []string{}
+}
@@ -2,6 +2,7 @@ package mailgun
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,12 +15,13 @@ import (
 
 // Provider implements the Provider interface for Mailgun email sending
 type Provider struct {
-	config     types.ProviderConfig
-	domain     string
-	apiKey     string
-	from       string
-	baseURL    string
-	httpClient *http.Client
+	config            types.ProviderConfig
+	domain            string
+	apiKey            string
+	from              string
+	baseURL           string
+	webhookSigningKey string // HTTP webhook signing key, used to verify the webhooks
+	httpClient        *http.Client
 }
 
 // NewMailgunProvider creates a new Mailgun provider
@@ -64,16 +66,30 @@ func NewMailgunProvider(config types.ProviderConfig) (*Provider, error) {
 		provider.baseURL = "https://api.mailgun.net/v3"
 	}
 
+	if signingKey, ok := options["webhook_signing_key"].(string); ok {
+		provider.webhookSigningKey = signingKey
+	}
+
 	return provider, nil
 }
 
 // Send sends a message using Mailgun
 func (p *Provider) Send(ctx context.Context, message *types.Message) error {
+	_, err := p.SendTracked(ctx, message)
+	return err
+}
+
+// SendTracked sends a message using Mailgun and returns the message ID assigned by Mailgun
+func (p *Provider) SendTracked(ctx context.Context, message *types.Message) ([]string, error) {
 	if message.Type != types.MessageTypeEmail {
-		return fmt.Errorf("Mailgun provider only supports email messages")
+		return nil, fmt.Errorf("Mailgun provider only supports email messages")
 	}
 
-	return p.sendEmail(ctx, message)
+	id, err := p.sendEmail(ctx, message)
+	if err != nil || id == "" {
+		return nil, err
+	}
+	return []string{id}, nil
 }
 
 // SendBatch sends multiple messages in batch
@@ -116,7 +132,7 @@ func (p *Provider) Close() error {
 }
 
 // sendEmail sends an email via Mailgun API
-func (p *Provider) sendEmail(ctx context.Context, message *types.Message) error {
+func (p *Provider) sendEmail(ctx context.Context, message *types.Message) (string, error) {
 	apiURL := fmt.Sprintf("%s/%s/messages", p.baseURL, p.domain)
 
 	// Prepare form data
@@ -174,7 +190,7 @@ func (p *Provider) sendEmail(ctx context.Context, message *types.Message) error
 	// Create request with context
 	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set authentication
@@ -184,15 +200,20 @@ func (p *Provider) sendEmail(ctx context.Context, message *types.Message) error
 	// Send request
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return "", fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check response
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Mailgun API error: %s - %s", resp.Status, string(body))
+		return "", fmt.Errorf("Mailgun API error: %s - %s", resp.Status, string(body))
 	}
 
-	return nil
+	// The message is sent even if the response can not be read, the webhooks report the ID without the angle brackets
+	var result struct {
+		ID string `json:"id"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return strings.Trim(result.ID, "<>"), nil
 }
@@ -551,3 +551,21 @@ func BenchmarkValidate(b *testing.B) {
 		}
 	}
 }
+
+func TestSendTracked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "<20240101.1@example.com>", "message": "Queued"})
+	}))
+	defer server.Close()
+
+	config := loadTestConfig(t)
+	provider, err := NewMailgunProvider(config)
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	// The ID is returned without the angle brackets, as the webhooks report it
+	ids, err := provider.SendTracked(context.Background(), createTestMessage(types.MessageTypeEmail))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20240101.1@example.com"}, ids)
+}
@@ -0,0 +1,217 @@
+package mailgun
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// webhookTolerance is the maximum age of a webhook signature, older requests are rejected as replays
+const webhookTolerance = 15 * time.Minute
+
+// webhookPayload is the JSON payload of the Mailgun event webhooks
+type webhookPayload struct {
+	Signature struct {
+		Timestamp string `json:"timestamp"`
+		Token     string `json:"token"`
+		Signature string `json:"signature"`
+	} `json:"signature"`
+	EventData map[string]interface{} `json:"event-data"`
+}
+
+// VerifyWebhook verifies the Mailgun signature, the HMAC-SHA256 of the timestamp and the token
+// signed with the webhook signing key. The event webhooks post JSON, the inbound routes post forms.
+func (p *Provider) VerifyWebhook(req *http.Request, body []byte) error {
+	if p.webhookSigningKey == "" {
+		return fmt.Errorf("webhook_signing_key is required to verify Mailgun webhooks")
+	}
+
+	var timestamp, token, signature string
+	if isJSON(req) {
+		var payload webhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return fmt.Errorf("invalid webhook body: %w", err)
+		}
+		timestamp, token, signature = payload.Signature.Timestamp, payload.Signature.Token, payload.Signature.Signature
+	} else {
+		values, err := formValues(req, body)
+		if err != nil {
+			return err
+		}
+		timestamp, token, signature = values.Get("timestamp"), values.Get("token"), values.Get("signature")
+	}
+
+	if timestamp == "" || token == "" || signature == "" {
+		return fmt.Errorf("missing signature fields")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp: %s", timestamp)
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return fmt.Errorf("signature timestamp is out of range")
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.webhookSigningKey))
+	mac.Write([]byte(timestamp + token))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// ParseWebhook parses the event webhooks and the inbound route messages
+func (p *Provider) ParseWebhook(req *http.Request, body []byte) ([]*types.Event, error) {
+	if isJSON(req) {
+		var payload webhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid webhook body: %w", err)
+		}
+		return []*types.Event{parseEvent(payload.EventData)}, nil
+	}
+
+	values, err := formValues(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]interface{}{}
+	for key := range values {
+		raw[key] = values.Get(key)
+	}
+
+	from := values.Get("from")
+	if from == "" {
+		from = values.Get("sender")
+	}
+
+	event := &types.Event{
+		Type:              types.EventTypeInbound,
+		MessageType:       types.MessageTypeEmail,
+		ProviderMessageID: strings.Trim(values.Get("Message-Id"), "<>"),
+		From:              from,
+		To:                values.Get("recipient"),
+		Subject:           values.Get("subject"),
+		Body:              values.Get("body-plain"),
+		HTML:              values.Get("body-html"),
+		Timestamp:         unixTime(values.Get("timestamp")),
+		Raw:               raw,
+	}
+	return []*types.Event{event}, nil
+}
+
+// parseEvent converts the event data of a Mailgun event webhook
+func parseEvent(data map[string]interface{}) *types.Event {
+	event := &types.Event{
+		Type:        types.EventTypeStatus,
+		MessageType: types.MessageTypeEmail,
+		To:          stringValue(data["recipient"]),
+		Raw:         data,
+	}
+
+	if timestamp, ok := data["timestamp"].(float64); ok {
+		event.Timestamp = time.Unix(0, int64(timestamp*float64(time.Second)))
+	}
+
+	if variables, ok := data["user-variables"].(map[string]interface{}); ok {
+		event.MessageID = stringValue(variables["message_id"])
+	}
+
+	if message, ok := data["message"].(map[string]interface{}); ok {
+		if headers, ok := message["headers"].(map[string]interface{}); ok {
+			event.ProviderMessageID = stringValue(headers["message-id"])
+			event.From = stringValue(headers["from"])
+		}
+	}
+
+	name := stringValue(data["event"])
+	switch name {
+	case "accepted":
+		event.Status = types.DeliveryStatusQueued
+	case "delivered":
+		event.Status = types.DeliveryStatusDelivered
+	case "opened":
+		event.Status = types.DeliveryStatusRead
+	case "clicked":
+		event.Status = types.DeliveryStatusClicked
+	case "complained":
+		event.Status = types.DeliveryStatusComplained
+	case "unsubscribed":
+		event.Status = types.DeliveryStatusUnsubscribed
+	case "rejected":
+		event.Status = types.DeliveryStatusFailed
+	case "failed":
+		// Mailgun keeps retrying the temporary failures
+		event.Status = types.DeliveryStatusQueued
+		if stringValue(data["severity"]) == "permanent" {
+			event.Status = types.DeliveryStatusBounced
+		}
+	default:
+		event.Status = types.DeliveryStatus(name)
+	}
+
+	if status, ok := data["delivery-status"].(map[string]interface{}); ok && event.Status != types.DeliveryStatusDelivered {
+		event.Error = stringValue(status["description"])
+		if event.Error == "" {
+			event.Error = stringValue(status["message"])
+		}
+	}
+	return event
+}
+
+// formValues parses a form or multipart webhook body, the inbound routes post multipart forms with attachments
+func formValues(req *http.Request, body []byte) (url.Values, error) {
+	contentType := req.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		clone := req.Clone(req.Context())
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+		if err := clone.ParseMultipartForm(32 << 20); err != nil {
+			return nil, fmt.Errorf("invalid webhook body: %w", err)
+		}
+		return url.Values(clone.MultipartForm.Value), nil
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook body: %w", err)
+	}
+	return values, nil
+}
+
+// isJSON checks whether the webhook request posts JSON
+func isJSON(req *http.Request) bool {
+	return strings.Contains(req.Header.Get("Content-Type"), "application/json")
+}
+
+// unixTime converts a unix timestamp string
+func unixTime(value string) time.Time {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Now()
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+// stringValue converts a JSON value to string
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,115 @@
+package mailgun
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+func newWebhookTestProvider(t *testing.T) *Provider {
+	provider, err := NewMailgunProvider(types.ProviderConfig{
+		Name:      "mailgun",
+		Connector: "mailgun",
+		Options: map[string]interface{}{
+			"domain":              "example.com",
+			"api_key":             "key-test",
+			"from":                "noreply@example.com",
+			"webhook_signing_key": "signing-key",
+		},
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func mailgunSignature(key, timestamp, token string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookEvent(t *testing.T) {
+	provider := newWebhookTestProvider(t)
+	timestamp := fmt.Sprintf("%d", time.Now().Unix())
+	body := fmt.Sprintf(`{
+		"signature": {"timestamp": "%s", "token": "abc", "signature": "%s"},
+		"event-data": {
+			"event": "failed",
+			"severity": "permanent",
+			"recipient": "alice@example.com",
+			"timestamp": %s,
+			"user-variables": {"message_id": "M1"},
+			"message": {"headers": {"message-id": "20250101.1@example.com"}},
+			"delivery-status": {"description": "No such mailbox"}
+		}
+	}`, timestamp, mailgunSignature("signing-key", timestamp, "abc"), timestamp)
+
+	req := httptest.NewRequest("POST", "/v1/messenger/webhook/mailgun", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	require.NoError(t, provider.VerifyWebhook(req, []byte(body)))
+
+	events, err := provider.ParseWebhook(req, []byte(body))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventTypeStatus, events[0].Type)
+	assert.Equal(t, types.DeliveryStatusBounced, events[0].Status)
+	assert.Equal(t, "M1", events[0].MessageID)
+	assert.Equal(t, "20250101.1@example.com", events[0].ProviderMessageID)
+	assert.Equal(t, "alice@example.com", events[0].To)
+	assert.Equal(t, "No such mailbox", events[0].Error)
+
+	// Tampered signature
+	tampered := strings.Replace(body, `"token": "abc"`, `"token": "abd"`, 1)
+	assert.Error(t, provider.VerifyWebhook(req, []byte(tampered)))
+}
+
+func TestWebhookInbound(t *testing.T) {
+	provider := newWebhookTestProvider(t)
+	timestamp := fmt.Sprintf("%d", time.Now().Unix())
+	values := url.Values{
+		"timestamp":  {timestamp},
+		"token":      {"xyz"},
+		"signature":  {mailgunSignature("signing-key", timestamp, "xyz")},
+		"sender":     {"alice@example.com"},
+		"recipient":  {"support@example.com"},
+		"subject":    {"Re: Invite"},
+		"body-plain": {"Thanks!"},
+		"Message-Id": {"<reply.1@example.com>"},
+	}
+	body := []byte(values.Encode())
+
+	req := httptest.NewRequest("POST", "/v1/messenger/webhook/mailgun", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.NoError(t, provider.VerifyWebhook(req, body))
+
+	events, err := provider.ParseWebhook(req, body)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventTypeInbound, events[0].Type)
+	assert.Equal(t, "alice@example.com", events[0].From)
+	assert.Equal(t, "Re: Invite", events[0].Subject)
+	assert.Equal(t, "Thanks!", events[0].Body)
+	assert.Equal(t, "reply.1@example.com", events[0].ProviderMessageID)
+
+	// Replayed requests are rejected
+	old := fmt.Sprintf("%d", time.Now().Add(-time.Hour).Unix())
+	values.Set("timestamp", old)
+	values.Set("signature", mailgunSignature("signing-key", old, "xyz"))
+	assert.Error(t, provider.VerifyWebhook(req, []byte(values.Encode())))
+}
+
+func TestWebhookWithoutSigningKey(t *testing.T) {
+	provider := newWebhookTestProvider(t)
+	provider.webhookSigningKey = ""
+	req := httptest.NewRequest("POST", "/v1/messenger/webhook/mailgun", strings.NewReader("{}"))
+	assert.Error(t, provider.VerifyWebhook(req, []byte("{}")))
+}
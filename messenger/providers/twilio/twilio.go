@@ -26,6 +26,8 @@ type Provider struct {
 	fromName            string
 	messagingServiceSID string
 	sendGridAPIKey      string
+	statusCallback      string // Status callback URL of the SMS and WhatsApp messages
+	webhookBaseURL      string // Public webhook URL used to verify the signatures
 	httpClient          *http.Client
 	baseURL             string
 }
@@ -91,20 +93,36 @@ func NewTwilioProvider(config types.ProviderConfig) (*Provider, error) {
 		provider.baseURL = baseURL
 	}
 
+	// Optional webhook options
+	if statusCallback, ok := options["status_callback"].(string); ok {
+		provider.statusCallback = statusCallback
+	}
+
+	if webhookURL, ok := options["webhook_url"].(string); ok {
+		provider.webhookBaseURL = webhookURL
+	}
+
 	return provider, nil
 }
 
 // Send sends a message using appropriate Twilio service based on message type
 func (p *Provider) Send(ctx context.Context, message *types.Message) error {
+	_, err := p.SendTracked(ctx, message)
+	return err
+}
+
+// SendTracked sends a message like Send and returns the message SIDs, one per recipient of a SMS or WhatsApp
+// message. The SendGrid emails have no SID.
+func (p *Provider) SendTracked(ctx context.Context, message *types.Message) ([]string, error) {
 	switch message.Type {
 	case types.MessageTypeSMS:
 		return p.sendSMS(ctx, message)
 	case types.MessageTypeWhatsApp:
 		return p.sendWhatsApp(ctx, message)
 	case types.MessageTypeEmail:
-		return p.sendEmail(ctx, message)
+		return nil, p.sendEmail(ctx, message)
 	default:
-		return fmt.Errorf("unsupported message type: %s", message.Type)
+		return nil, fmt.Errorf("unsupported message type: %s", message.Type)
 	}
 }
 
@@ -156,22 +174,26 @@ func (p *Provider) Close() error {
 }
 
 // sendSMS sends an SMS message via Twilio
-func (p *Provider) sendSMS(ctx context.Context, message *types.Message) error {
+func (p *Provider) sendSMS(ctx context.Context, message *types.Message) ([]string, error) {
 	if p.fromPhone == "" && p.messagingServiceSID == "" {
-		return fmt.Errorf("either from_phone or messaging_service_sid is required for SMS")
+		return nil, fmt.Errorf("either from_phone or messaging_service_sid is required for SMS")
 	}
 
+	sids := []string{}
 	for _, to := range message.To {
-		err := p.sendSMSToRecipient(ctx, to, message)
+		sid, err := p.sendSMSToRecipient(ctx, to, message)
 		if err != nil {
-			return fmt.Errorf("failed to send SMS to %s: %w", to, err)
+			return sids, fmt.Errorf("failed to send SMS to %s: %w", to, err)
+		}
+		if sid != "" {
+			sids = append(sids, sid)
 		}
 	}
-	return nil
+	return sids, nil
 }
 
 // sendSMSToRecipient sends SMS to a single recipient
-func (p *Provider) sendSMSToRecipient(ctx context.Context, to string, message *types.Message) error {
+func (p *Provider) sendSMSToRecipient(ctx context.Context, to string, message *types.Message) (string, error) {
 	apiURL := fmt.Sprintf("%s/Accounts/%s/Messages.json", p.baseURL, p.accountSID)
 
 	// Prepare form data
@@ -185,26 +207,34 @@ func (p *Provider) sendSMSToRecipient(ctx context.Context, to string, message *t
 		data.Set("From", p.fromPhone)
 	}
 
+	if callback := p.statusCallbackURL(message); callback != "" {
+		data.Set("StatusCallback", callback)
+	}
+
 	return p.sendTwilioRequest(ctx, apiURL, data)
 }
 
 // sendWhatsApp sends a WhatsApp message via Twilio
-func (p *Provider) sendWhatsApp(ctx context.Context, message *types.Message) error {
+func (p *Provider) sendWhatsApp(ctx context.Context, message *types.Message) ([]string, error) {
 	if p.fromPhone == "" {
-		return fmt.Errorf("from_phone is required for WhatsApp messages")
+		return nil, fmt.Errorf("from_phone is required for WhatsApp messages")
 	}
 
+	sids := []string{}
 	for _, to := range message.To {
-		err := p.sendWhatsAppToRecipient(ctx, to, message)
+		sid, err := p.sendWhatsAppToRecipient(ctx, to, message)
 		if err != nil {
-			return fmt.Errorf("failed to send WhatsApp message to %s: %w", to, err)
+			return sids, fmt.Errorf("failed to send WhatsApp message to %s: %w", to, err)
+		}
+		if sid != "" {
+			sids = append(sids, sid)
 		}
 	}
-	return nil
+	return sids, nil
 }
 
 // sendWhatsAppToRecipient sends WhatsApp message to a single recipient
-func (p *Provider) sendWhatsAppToRecipient(ctx context.Context, to string, message *types.Message) error {
+func (p *Provider) sendWhatsAppToRecipient(ctx context.Context, to string, message *types.Message) (string, error) {
 	apiURL := fmt.Sprintf("%s/Accounts/%s/Messages.json", p.baseURL, p.accountSID)
 
 	// Ensure phone numbers have WhatsApp prefix
@@ -224,6 +254,10 @@ func (p *Provider) sendWhatsAppToRecipient(ctx context.Context, to string, messa
 	data.Set("To", toWhatsApp)
 	data.Set("Body", message.Body)
 
+	if callback := p.statusCallbackURL(message); callback != "" {
+		data.Set("StatusCallback", callback)
+	}
+
 	return p.sendTwilioRequest(ctx, apiURL, data)
 }
 
@@ -311,11 +345,11 @@ func (p *Provider) sendEmail(ctx context.Context, message *types.Message) error
 }
 
 // sendTwilioRequest sends a request to Twilio API
-func (p *Provider) sendTwilioRequest(ctx context.Context, apiURL string, data url.Values) error {
+func (p *Provider) sendTwilioRequest(ctx context.Context, apiURL string, data url.Values) (string, error) {
 	// Add custom metadata as status callback parameters
 	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Use API Key authentication if available, otherwise fall back to Auth Token
@@ -329,17 +363,22 @@ func (p *Provider) sendTwilioRequest(ctx context.Context, apiURL string, data ur
 	// Send request
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return "", fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check response
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Twilio API error: %s - %s", resp.Status, string(body))
+		return "", fmt.Errorf("Twilio API error: %s - %s", resp.Status, string(body))
 	}
 
-	return nil
+	// The message is sent even if the response can not be read, the status callbacks report the same SID
+	var result struct {
+		SID string `json:"sid"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result.SID, nil
 }
 
 // buildEmailRecipients builds the recipients array for SendGrid
@@ -0,0 +1,165 @@
+package twilio
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// VerifyWebhook verifies the X-Twilio-Signature header, the HMAC-SHA1 of the webhook URL
+// followed by the sorted POST parameters, signed with the auth token
+func (p *Provider) VerifyWebhook(req *http.Request, body []byte) error {
+	if p.authToken == "" {
+		return fmt.Errorf("auth_token is required to verify Twilio webhooks")
+	}
+
+	signature := req.Header.Get("X-Twilio-Signature")
+	if signature == "" {
+		return fmt.Errorf("missing X-Twilio-Signature header")
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook body: %w", err)
+	}
+
+	expected := p.signature(p.webhookURL(req), values)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// ParseWebhook parses the status callbacks and the inbound messages
+func (p *Provider) ParseWebhook(req *http.Request, body []byte) ([]*types.Event, error) {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook body: %w", err)
+	}
+
+	raw := map[string]interface{}{}
+	for key := range values {
+		raw[key] = values.Get(key)
+	}
+
+	from := values.Get("From")
+	messageType := types.MessageTypeSMS
+	if strings.HasPrefix(from, "whatsapp:") || strings.HasPrefix(values.Get("To"), "whatsapp:") {
+		messageType = types.MessageTypeWhatsApp
+	}
+
+	event := &types.Event{
+		MessageType:       messageType,
+		MessageID:         req.URL.Query().Get("message_id"),
+		ProviderMessageID: values.Get("MessageSid"),
+		From:              strings.TrimPrefix(from, "whatsapp:"),
+		To:                strings.TrimPrefix(values.Get("To"), "whatsapp:"),
+		Timestamp:         time.Now(),
+		Raw:               raw,
+	}
+
+	status := values.Get("MessageStatus")
+	if status == "" {
+		status = values.Get("SmsStatus")
+	}
+
+	// Inbound messages are posted with the received status
+	if status == "" || status == "received" {
+		event.Type = types.EventTypeInbound
+		event.Body = values.Get("Body")
+		return []*types.Event{event}, nil
+	}
+
+	event.Type = types.EventTypeStatus
+	event.Status = deliveryStatus(status)
+	if code := values.Get("ErrorCode"); code != "" {
+		event.Error = strings.TrimSpace(code + " " + values.Get("ErrorMessage"))
+	}
+	return []*types.Event{event}, nil
+}
+
+// signature computes the Twilio request signature
+func (p *Provider) signature(webhookURL string, values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var data strings.Builder
+	data.WriteString(webhookURL)
+	for _, key := range keys {
+		for _, value := range values[key] {
+			data.WriteString(key)
+			data.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(p.authToken))
+	mac.Write([]byte(data.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// webhookURL returns the public URL Twilio posted to, the webhook_url option is used when
+// the server runs behind a proxy rewriting the host or the path
+func (p *Provider) webhookURL(req *http.Request) string {
+	if p.webhookBaseURL != "" {
+		if req.URL.RawQuery != "" {
+			return p.webhookBaseURL + "?" + req.URL.RawQuery
+		}
+		return p.webhookBaseURL
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, req.Host, req.URL.RequestURI())
+}
+
+// statusCallbackURL returns the status callback of a message, the outbox message ID is passed
+// as a query parameter so the delivery status finds the message
+func (p *Provider) statusCallbackURL(message *types.Message) string {
+	if p.statusCallback == "" {
+		return ""
+	}
+
+	messageID, ok := message.Metadata["message_id"].(string)
+	if !ok || messageID == "" {
+		return p.statusCallback
+	}
+
+	separator := "?"
+	if strings.Contains(p.statusCallback, "?") {
+		separator = "&"
+	}
+	return p.statusCallback + separator + "message_id=" + url.QueryEscape(messageID)
+}
+
+// deliveryStatus normalizes the Twilio message status
+func deliveryStatus(status string) types.DeliveryStatus {
+	switch strings.ToLower(status) {
+	case "accepted", "scheduled", "queued":
+		return types.DeliveryStatusQueued
+	case "sending", "sent":
+		return types.DeliveryStatusSent
+	case "delivered":
+		return types.DeliveryStatusDelivered
+	case "read":
+		return types.DeliveryStatusRead
+	case "undelivered", "failed", "canceled":
+		return types.DeliveryStatusFailed
+	}
+	return types.DeliveryStatus(strings.ToLower(status))
+}
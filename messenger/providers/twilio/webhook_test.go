@@ -0,0 +1,124 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+func newWebhookTestProvider(t *testing.T) *Provider {
+	provider, err := NewTwilioProvider(types.ProviderConfig{
+		Name:      "twilio",
+		Connector: "twilio",
+		Options: map[string]interface{}{
+			"account_sid":     "AC123",
+			"auth_token":      "token",
+			"from_phone":      "+15550000000",
+			"status_callback": "https://example.com/v1/messenger/webhook/twilio",
+		},
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestWebhookStatus(t *testing.T) {
+	provider := newWebhookTestProvider(t)
+	values := url.Values{
+		"MessageSid":    {"SM123"},
+		"MessageStatus": {"undelivered"},
+		"To":            {"+15551234567"},
+		"From":          {"+15550000000"},
+		"ErrorCode":     {"30003"},
+		"ErrorMessage":  {"Unreachable destination handset"},
+	}
+	body := []byte(values.Encode())
+
+	req := httptest.NewRequest("POST", "https://example.com/v1/messenger/webhook/twilio?message_id=M1", strings.NewReader(string(body)))
+	req.Header.Set("X-Twilio-Signature", provider.signature("https://example.com/v1/messenger/webhook/twilio?message_id=M1", values))
+	require.NoError(t, provider.VerifyWebhook(req, body))
+
+	events, err := provider.ParseWebhook(req, body)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventTypeStatus, events[0].Type)
+	assert.Equal(t, types.DeliveryStatusFailed, events[0].Status)
+	assert.Equal(t, "M1", events[0].MessageID)
+	assert.Equal(t, "SM123", events[0].ProviderMessageID)
+	assert.Equal(t, types.MessageTypeSMS, events[0].MessageType)
+	assert.Contains(t, events[0].Error, "30003")
+
+	// Tampered body
+	values.Set("MessageStatus", "delivered")
+	assert.Error(t, provider.VerifyWebhook(req, []byte(values.Encode())))
+
+	// Missing signature
+	req.Header.Del("X-Twilio-Signature")
+	assert.Error(t, provider.VerifyWebhook(req, body))
+}
+
+func TestWebhookInbound(t *testing.T) {
+	provider := newWebhookTestProvider(t)
+	provider.webhookBaseURL = "https://public.example.com/v1/messenger/webhook/twilio"
+	values := url.Values{
+		"MessageSid": {"SM456"},
+		"SmsStatus":  {"received"},
+		"From":       {"whatsapp:+15551234567"},
+		"To":         {"whatsapp:+15550000000"},
+		"Body":       {"STOP"},
+	}
+	body := []byte(values.Encode())
+
+	// The server sees the internal host behind the proxy
+	req := httptest.NewRequest("POST", "http://127.0.0.1:5099/v1/messenger/webhook/twilio", strings.NewReader(string(body)))
+	req.Header.Set("X-Twilio-Signature", provider.signature(provider.webhookBaseURL, values))
+	require.NoError(t, provider.VerifyWebhook(req, body))
+
+	events, err := provider.ParseWebhook(req, body)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventTypeInbound, events[0].Type)
+	assert.Equal(t, types.MessageTypeWhatsApp, events[0].MessageType)
+	assert.Equal(t, "+15551234567", events[0].From)
+	assert.Equal(t, "STOP", events[0].Body)
+}
+
+func TestStatusCallbackURL(t *testing.T) {
+	provider := newWebhookTestProvider(t)
+	message := &types.Message{Metadata: map[string]interface{}{"message_id": "M 1"}}
+	assert.Equal(t, "https://example.com/v1/messenger/webhook/twilio?message_id=M+1", provider.statusCallbackURL(message))
+	assert.Equal(t, "https://example.com/v1/messenger/webhook/twilio", provider.statusCallbackURL(&types.Message{}))
+
+	provider.statusCallback = ""
+	assert.Equal(t, "", provider.statusCallbackURL(message))
+}
+
+func TestSendTracked(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"sid": "SM%d", "status": "queued"}`, calls)
+	}))
+	defer server.Close()
+
+	provider := newWebhookTestProvider(t)
+	provider.baseURL = server.URL
+
+	// One SID per recipient, the status callbacks report the same SIDs
+	ids, err := provider.SendTracked(context.Background(), &types.Message{
+		Type: types.MessageTypeSMS,
+		To:   []string{"+15551234567", "+15557654321"},
+		Body: "Hello",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SM1", "SM2"}, ids)
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// chatBodies are the default body templates of the chat platform incoming webhooks
+var chatBodies = map[string]func() interface{}{
+	"slack": func() interface{} {
+		return map[string]interface{}{"text": "{{ text }}"}
+	},
+	"discord": func() interface{} {
+		return map[string]interface{}{"content": "{{ text }}"}
+	},
+	"feishu": func() interface{} {
+		return map[string]interface{}{"msg_type": "text", "content": map[string]interface{}{"text": "{{ text }}"}}
+	},
+}
+
+// NewChatProvider creates a chat platform provider posting to an incoming webhook.
+// The connector selects the platform: slack, discord or feishu. The body option overrides the
+// default text message, e.g. to post Slack blocks or Feishu cards.
+func NewChatProvider(config types.ProviderConfig) (*Provider, error) {
+	connector := strings.ToLower(config.Connector)
+	body, ok := chatBodies[connector]
+	if !ok {
+		return nil, fmt.Errorf("unsupported chat connector: %s", connector)
+	}
+
+	provider := &Provider{
+		config:       config,
+		connector:    connector,
+		method:       http.MethodPost,
+		headers:      map[string]string{},
+		body:         body(),
+		messageTypes: []types.MessageType{types.MessageTypeChat},
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		now: time.Now,
+	}
+
+	if connector == "feishu" {
+		provider.signPayload = signFeishu
+		provider.checkResponse = checkFeishu
+	}
+
+	options := config.Options
+	if options == nil {
+		return nil, fmt.Errorf("%s provider requires options", connector)
+	}
+
+	// Accept webhook_url as the name used by the platforms
+	if url, ok := options["webhook_url"].(string); ok {
+		provider.url = url
+	}
+
+	if err := provider.parseOptions(options); err != nil {
+		return nil, err
+	}
+
+	if provider.url == "" {
+		return nil, fmt.Errorf("%s provider requires 'webhook_url' option", connector)
+	}
+	return provider, nil
+}
+
+// signFeishu adds the timestamp and the signature of the Feishu custom bot security settings,
+// the HMAC-SHA256 key is "timestamp\nsecret" and the signed data is empty
+func signFeishu(payload interface{}, secret string, now time.Time) interface{} {
+	values, ok := payload.(map[string]interface{})
+	if !ok {
+		return payload
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(timestamp+"\n"+secret))
+	values["timestamp"] = timestamp
+	values["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return values
+}
+
+// checkFeishu checks the Feishu response, the errors are returned with the 200 status
+func checkFeishu(status int, body []byte) error {
+	if status >= 400 {
+		return fmt.Errorf("Feishu error: %d - %s", status, string(body))
+	}
+
+	var result struct {
+		Code       *int   `json:"code"`
+		Msg        string `json:"msg"`
+		StatusCode *int   `json:"StatusCode"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil
+	}
+
+	if result.Code != nil && *result.Code != 0 {
+		return fmt.Errorf("Feishu error: %d - %s", *result.Code, result.Msg)
+	}
+	if result.StatusCode != nil && *result.StatusCode != 0 {
+		return fmt.Errorf("Feishu error: %d - %s", *result.StatusCode, result.Msg)
+	}
+	return nil
+}
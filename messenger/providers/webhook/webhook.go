@@ -0,0 +1,288 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/yao/messenger/template"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// Provider implements the Provider interface for HTTP webhooks, each message is posted as a templated JSON body.
+// The chat platform providers (Slack, Discord, Feishu) are presets of this provider.
+type Provider struct {
+	config          types.ProviderConfig
+	connector       string
+	url             string
+	method          string
+	headers         map[string]string
+	body            interface{} // JSON body template
+	secret          string      // HMAC signing secret
+	signatureHeader string
+	perRecipient    bool // Post one request per recipient
+	messageTypes    []types.MessageType
+	httpClient      *http.Client
+	now             func() time.Time
+
+	// The chat presets sign inside the payload and report the errors in the response body
+	signPayload   func(payload interface{}, secret string, now time.Time) interface{}
+	checkResponse func(status int, body []byte) error
+}
+
+// NewWebhookProvider creates a new generic webhook provider
+func NewWebhookProvider(config types.ProviderConfig) (*Provider, error) {
+	provider := &Provider{
+		config:          config,
+		connector:       "webhook",
+		method:          http.MethodPost,
+		headers:         map[string]string{},
+		signatureHeader: "X-Webhook-Signature",
+		messageTypes:    []types.MessageType{types.MessageTypeChat},
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		now: time.Now,
+		body: map[string]interface{}{
+			"type":     "{{ type }}",
+			"to":       "{{ recipients }}",
+			"from":     "{{ from }}",
+			"subject":  "{{ subject }}",
+			"body":     "{{ body }}",
+			"html":     "{{ html }}",
+			"metadata": "{{ metadata }}",
+		},
+	}
+
+	// Extract options
+	options := config.Options
+	if options == nil {
+		return nil, fmt.Errorf("Webhook provider requires options")
+	}
+
+	if err := provider.parseOptions(options); err != nil {
+		return nil, err
+	}
+
+	if provider.url == "" {
+		return nil, fmt.Errorf("Webhook provider requires 'url' option")
+	}
+	return provider, nil
+}
+
+// parseOptions reads the options shared by the webhook provider and the chat presets
+func (p *Provider) parseOptions(options map[string]interface{}) error {
+	if url, ok := options["url"].(string); ok {
+		p.url = url
+	}
+
+	if method, ok := options["method"].(string); ok && method != "" {
+		p.method = strings.ToUpper(method)
+	}
+
+	if headers, ok := options["headers"].(map[string]interface{}); ok {
+		for key, value := range headers {
+			p.headers[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	// The body template is a JSON value, or a JSON string
+	if body, has := options["body"]; has && body != nil {
+		if raw, ok := body.(string); ok {
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+				return fmt.Errorf("invalid 'body' option, the template must be JSON: %w", err)
+			}
+			body = parsed
+		}
+		p.body = body
+	}
+
+	if secret, ok := options["secret"].(string); ok {
+		p.secret = secret
+	}
+
+	if header, ok := options["signature_header"].(string); ok && header != "" {
+		p.signatureHeader = header
+	}
+
+	if perRecipient, ok := options["per_recipient"].(bool); ok {
+		p.perRecipient = perRecipient
+	}
+
+	if messageTypes, ok := options["message_types"].([]interface{}); ok && len(messageTypes) > 0 {
+		p.messageTypes = []types.MessageType{}
+		for _, messageType := range messageTypes {
+			p.messageTypes = append(p.messageTypes, types.MessageType(strings.ToLower(fmt.Sprintf("%v", messageType))))
+		}
+	}
+
+	if timeout, has := options["timeout"]; has {
+		duration, err := types.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("invalid 'timeout' option: %w", err)
+		}
+		if duration > 0 {
+			p.httpClient.Timeout = duration
+		}
+	}
+	return nil
+}
+
+// Send posts the message to the webhook
+func (p *Provider) Send(ctx context.Context, message *types.Message) error {
+	if !p.perRecipient || len(message.To) == 0 {
+		return p.post(ctx, message, message.To)
+	}
+
+	for _, to := range message.To {
+		if err := p.post(ctx, message, []string{to}); err != nil {
+			return fmt.Errorf("failed to send message to %s: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// SendBatch sends multiple messages in batch
+func (p *Provider) SendBatch(ctx context.Context, messages []*types.Message) error {
+	for _, message := range messages {
+		if err := p.Send(ctx, message); err != nil {
+			return fmt.Errorf("failed to send message to %v: %w", message.To, err)
+		}
+	}
+	return nil
+}
+
+// GetType returns the provider type
+func (p *Provider) GetType() string {
+	return p.connector
+}
+
+// GetName returns the provider name
+func (p *Provider) GetName() string {
+	return p.config.Name
+}
+
+// MessageTypes returns the message types posted to the webhook
+func (p *Provider) MessageTypes() []types.MessageType {
+	return p.messageTypes
+}
+
+// Validate validates the provider configuration
+func (p *Provider) Validate() error {
+	if p.url == "" {
+		return fmt.Errorf("url is required")
+	}
+	if !strings.HasPrefix(p.url, "http://") && !strings.HasPrefix(p.url, "https://") {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+	return nil
+}
+
+// Close closes the provider connection (no-op for HTTP webhooks)
+func (p *Provider) Close() error {
+	return nil
+}
+
+// post renders the body template and posts it to the webhook
+func (p *Provider) post(ctx context.Context, message *types.Message, recipients []string) error {
+	now := p.now()
+	payload := template.JSON(p.body, variables(message, recipients, now))
+	if p.secret != "" && p.signPayload != nil {
+		payload = p.signPayload(payload, p.secret, now)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, p.method, p.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range p.headers {
+		req.Header.Set(key, value)
+	}
+
+	// Sign the timestamp and the body, the receiver checks both to reject the replayed requests
+	if p.secret != "" && p.signPayload == nil {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		req.Header.Set("X-Webhook-Timestamp", timestamp)
+		req.Header.Set(p.signatureHeader, "sha256="+Sign(p.secret, timestamp, data))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if p.checkResponse != nil {
+		return p.checkResponse(resp.StatusCode, body)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Webhook error: %s - %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.body", the signature sent by the webhook provider
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// variables returns the template variables of a message
+func variables(message *types.Message, recipients []string, now time.Time) map[string]interface{} {
+	text := message.Body
+	if message.Subject != "" && text != "" {
+		text = message.Subject + "\n\n" + text
+	} else if message.Subject != "" {
+		text = message.Subject
+	}
+
+	metadata := message.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	headers := map[string]interface{}{}
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+
+	if recipients == nil {
+		recipients = []string{}
+	}
+
+	return map[string]interface{}{
+		"type":       string(message.Type),
+		"to":         strings.Join(recipients, ","),
+		"recipients": recipients,
+		"from":       message.From,
+		"subject":    message.Subject,
+		"body":       message.Body,
+		"html":       message.HTML,
+		"text":       text,
+		"priority":   message.Priority,
+		"metadata":   metadata,
+		"headers":    headers,
+		"timestamp":  now.UTC().Format(time.RFC3339),
+	}
+}
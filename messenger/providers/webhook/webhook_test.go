@@ -0,0 +1,203 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// request is a request received by the test server
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// newTestServer starts a server recording the requests and replying with the given status and body
+func newTestServer(t *testing.T, status int, reply string) (*httptest.Server, func() []request) {
+	var mutex sync.Mutex
+	requests := []request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		requests = append(requests, request{header: r.Header.Clone(), body: body})
+		mutex.Unlock()
+		w.WriteHeader(status)
+		w.Write([]byte(reply))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []request {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]request{}, requests...)
+	}
+}
+
+func createTestMessage() *types.Message {
+	return &types.Message{
+		Type:     types.MessageTypeChat,
+		Subject:  "Job failed",
+		Body:     "The nightly import failed after 3 retries",
+		Metadata: map[string]interface{}{"job_id": "J42", "attempts": 3},
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusOK, "ok")
+	provider, err := NewWebhookProvider(types.ProviderConfig{
+		Name:      "alerts",
+		Connector: "webhook",
+		Options: map[string]interface{}{
+			"url":     server.URL,
+			"headers": map[string]interface{}{"Authorization": "Bearer token"},
+			"secret":  "s3cret",
+			"body":    `{"title": "{{ subject }}", "job": "{{ metadata.job_id }}", "attempts": "{{ metadata.attempts }}", "summary": "{{ subject }}: {{ body }}"}`,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, provider.Validate())
+	assert.Equal(t, "webhook", provider.GetType())
+	assert.Equal(t, []types.MessageType{types.MessageTypeChat}, provider.MessageTypes())
+
+	require.NoError(t, provider.Send(context.Background(), createTestMessage()))
+	received := requests()
+	require.Len(t, received, 1)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(received[0].body, &payload))
+	assert.Equal(t, "Job failed", payload["title"])
+	assert.Equal(t, "J42", payload["job"])
+	assert.Equal(t, float64(3), payload["attempts"], "a single variable keeps its JSON type")
+	assert.Equal(t, "Job failed: The nightly import failed after 3 retries", payload["summary"])
+
+	header := received[0].header
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	timestamp := header.Get("X-Webhook-Timestamp")
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t, "sha256="+Sign("s3cret", timestamp, received[0].body), header.Get("X-Webhook-Signature"))
+}
+
+func TestWebhookDefaultBody(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusOK, "")
+	provider, err := NewWebhookProvider(types.ProviderConfig{
+		Name:      "hook",
+		Connector: "webhook",
+		Options: map[string]interface{}{
+			"url":           server.URL,
+			"per_recipient": true,
+			"message_types": []interface{}{"sms", "Email"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []types.MessageType{types.MessageTypeSMS, types.MessageTypeEmail}, provider.MessageTypes())
+
+	message := createTestMessage()
+	message.To = []string{"a@example.com", "b@example.com"}
+	require.NoError(t, provider.Send(context.Background(), message))
+
+	received := requests()
+	require.Len(t, received, 2)
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(received[1].body, &payload))
+	assert.Equal(t, []interface{}{"b@example.com"}, payload["to"])
+	assert.Equal(t, "Job failed", payload["subject"])
+	assert.Empty(t, received[1].header.Get("X-Webhook-Signature"))
+}
+
+func TestWebhookError(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusUnauthorized, "invalid token")
+	provider, err := NewWebhookProvider(types.ProviderConfig{
+		Name:      "hook",
+		Connector: "webhook",
+		Options:   map[string]interface{}{"url": server.URL},
+	})
+	require.NoError(t, err)
+
+	err = provider.Send(context.Background(), createTestMessage())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid token")
+
+	_, err = NewWebhookProvider(types.ProviderConfig{Name: "hook", Options: map[string]interface{}{}})
+	assert.Error(t, err)
+
+	_, err = NewWebhookProvider(types.ProviderConfig{Name: "hook", Options: map[string]interface{}{"url": server.URL, "body": "{invalid"}})
+	assert.Error(t, err)
+}
+
+func TestChatProviders(t *testing.T) {
+	tests := []struct {
+		connector string
+		check     func(payload map[string]interface{})
+	}{
+		{"slack", func(payload map[string]interface{}) {
+			assert.Equal(t, "Job failed\n\nThe nightly import failed after 3 retries", payload["text"])
+		}},
+		{"discord", func(payload map[string]interface{}) {
+			assert.Equal(t, "Job failed\n\nThe nightly import failed after 3 retries", payload["content"])
+		}},
+		{"feishu", func(payload map[string]interface{}) {
+			assert.Equal(t, "text", payload["msg_type"])
+			content := payload["content"].(map[string]interface{})
+			assert.Equal(t, "Job failed\n\nThe nightly import failed after 3 retries", content["text"])
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.connector, func(t *testing.T) {
+			server, requests := newTestServer(t, http.StatusOK, `{"code": 0}`)
+			provider, err := NewChatProvider(types.ProviderConfig{
+				Name:      tt.connector,
+				Connector: tt.connector,
+				Options:   map[string]interface{}{"webhook_url": server.URL},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.connector, provider.GetType())
+
+			require.NoError(t, provider.Send(context.Background(), createTestMessage()))
+			received := requests()
+			require.Len(t, received, 1)
+
+			var payload map[string]interface{}
+			require.NoError(t, json.Unmarshal(received[0].body, &payload))
+			tt.check(payload)
+		})
+	}
+
+	_, err := NewChatProvider(types.ProviderConfig{Name: "teams", Connector: "teams", Options: map[string]interface{}{"webhook_url": "https://example.com"}})
+	assert.Error(t, err)
+}
+
+func TestFeishuSign(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusOK, `{"code": 19021, "msg": "sign match fail or timestamp is not within one hour from current time"}`)
+	provider, err := NewChatProvider(types.ProviderConfig{
+		Name:      "feishu",
+		Connector: "feishu",
+		Options:   map[string]interface{}{"webhook_url": server.URL, "secret": "s3cret"},
+	})
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	provider.now = func() time.Time { return now }
+
+	err = provider.Send(context.Background(), createTestMessage())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "19021")
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(requests()[0].body, &payload))
+	mac := hmac.New(sha256.New, []byte("1700000000\ns3cret"))
+	assert.Equal(t, "1700000000", payload["timestamp"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), payload["sign"])
+}
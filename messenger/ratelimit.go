@@ -0,0 +1,282 @@
+package messenger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// ErrRateLimited is returned when a message exceeds the channel rate limits
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// CounterStore is the key-value store keeping the rate limit counters, the gou store.Store satisfies the interface.
+// A store which also implements AtomicCounterStore (e.g. a redis INCRBY adapter) keeps the limits exact across
+// restarts and nodes. Other stores are updated with Get and Set under the lock of the limiter: the limits are exact
+// on one node and hold across restarts, concurrent nodes sharing such a store may overshoot them.
+type CounterStore interface {
+	Get(key string) (value interface{}, ok bool)
+	Set(key string, value interface{}, ttl time.Duration) error
+}
+
+// AtomicCounterStore is a counter store adding to a counter in one atomic operation. The ttl is set when the
+// counter is created, the new value is returned.
+type AtomicCounterStore interface {
+	Incr(key string, delta int, ttl time.Duration) (int, error)
+}
+
+// RateLimiter counts the messages sent per channel provider and per recipient
+type RateLimiter struct {
+	store  CounterStore
+	prefix string
+	now    func() time.Time
+	mutex  sync.Mutex
+}
+
+// Reservation is a message counted by the rate limiter before it is sent, released when the message is not sent
+type Reservation struct {
+	limiter  *RateLimiter
+	counters []counter
+}
+
+// counter a counter of a reservation
+type counter struct {
+	key string
+	ttl time.Duration
+}
+
+// NewRateLimiter creates a rate limiter, the counters are kept in memory when the store is nil
+func NewRateLimiter(store CounterStore) *RateLimiter {
+	if store == nil {
+		store = newMemoryStore()
+	}
+	return &RateLimiter{store: store, prefix: "messenger:ratelimit:", now: time.Now}
+}
+
+// ReserveProvider counts a message for the provider if it has not reached the hourly and daily limits of the
+// channel. The check and the count are one step, a nil reservation is returned when a limit is reached.
+func (l *RateLimiter) ReserveProvider(limit *types.RateLimit, scope string, provider string) (*Reservation, bool) {
+	if l == nil || !limitEnabled(limit) {
+		return nil, true
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	reservation := &Reservation{limiter: l}
+	key := "provider:" + scope + ":" + provider
+	if !l.reserve(reservation, key, limit.MaxPerHour, limit.MaxPerDay) {
+		return nil, false
+	}
+	return reservation, true
+}
+
+// ReserveRecipients counts a message for each recipient if none has reached the recipient limits or is within the
+// minimum interval between two messages. Nothing is counted when a recipient is rejected.
+func (l *RateLimiter) ReserveRecipients(limit *types.RateLimit, scope string, recipients []string) (*Reservation, error) {
+	if l == nil || !limitEnabled(limit) {
+		return nil, nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	reservation := &Reservation{limiter: l}
+	for _, recipient := range recipients {
+		key := "recipient:" + scope + ":" + strings.ToLower(recipient)
+
+		// The interval is a counter of one message living for the window
+		if limit.Window > 0 && !l.take(reservation, l.prefix+key+":last", 1, limit.Window) {
+			reservation.release()
+			return nil, fmt.Errorf("%w: %s must wait %s between messages", ErrRateLimited, recipient, limit.Window)
+		}
+
+		if !l.reserve(reservation, key, limit.MaxPerRecipientPerHour, limit.MaxPerRecipientPerDay) {
+			reservation.release()
+			return nil, fmt.Errorf("%w: too many messages to %s", ErrRateLimited, recipient)
+		}
+	}
+	return reservation, nil
+}
+
+// Release gives back the counts of a message which was not sent
+func (r *Reservation) Release() {
+	if r == nil || r.limiter == nil {
+		return
+	}
+
+	r.limiter.mutex.Lock()
+	defer r.limiter.mutex.Unlock()
+	r.release()
+}
+
+// release gives back the counts, the lock of the limiter is held
+func (r *Reservation) release() {
+	for i := len(r.counters) - 1; i >= 0; i-- {
+		r.limiter.add(r.counters[i].key, -1, r.counters[i].ttl)
+	}
+	r.counters = nil
+}
+
+// reserve takes the hourly and daily counters of a key, zero means unlimited. The counters taken are given back
+// when a limit is reached.
+func (l *RateLimiter) reserve(reservation *Reservation, key string, maxPerHour int, maxPerDay int) bool {
+	hourKey, dayKey := l.bucketKeys(key)
+	taken := len(reservation.counters)
+	if maxPerHour > 0 && !l.take(reservation, hourKey, maxPerHour, time.Hour) {
+		return false
+	}
+	if maxPerDay > 0 && !l.take(reservation, dayKey, maxPerDay, 24*time.Hour) {
+		for _, c := range reservation.counters[taken:] {
+			l.add(c.key, -1, c.ttl)
+		}
+		reservation.counters = reservation.counters[:taken]
+		return false
+	}
+	return true
+}
+
+// take increases a counter first and checks the limit then, so that two concurrent messages can not both pass
+// the last slot of an atomic store. The counter is given back when the limit is exceeded.
+func (l *RateLimiter) take(reservation *Reservation, key string, max int, ttl time.Duration) bool {
+	if l.add(key, 1, ttl) > max {
+		l.add(key, -1, ttl)
+		return false
+	}
+	reservation.counters = append(reservation.counters, counter{key: key, ttl: ttl})
+	return true
+}
+
+// add adds to a counter and returns the new value, atomically when the store supports it
+func (l *RateLimiter) add(key string, delta int, ttl time.Duration) int {
+	if atomic, ok := l.store.(AtomicCounterStore); ok {
+		value, err := atomic.Incr(key, delta, ttl)
+		if err == nil {
+			return value
+		}
+		log.Warn("[Messenger] Failed to update the rate limit counter %s: %v", key, err)
+	}
+
+	value := l.count(key) + delta
+	if value < 0 {
+		value = 0
+	}
+	l.store.Set(key, value, ttl)
+	return value
+}
+
+// bucketKeys returns the fixed window keys of the current hour and day
+func (l *RateLimiter) bucketKeys(key string) (string, string) {
+	now := l.now().Unix()
+	return fmt.Sprintf("%s%s:h:%d", l.prefix, key, now/3600), fmt.Sprintf("%s%s:d:%d", l.prefix, key, now/86400)
+}
+
+// count reads a counter, the stores may return the number in different types
+func (l *RateLimiter) count(key string) int {
+	value, has := l.store.Get(key)
+	if !has || value == nil {
+		return 0
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	case []byte:
+		n, _ := strconv.Atoi(string(v))
+		return n
+	}
+	return 0
+}
+
+// limitEnabled checks whether a rate limit is configured and enabled
+func limitEnabled(limit *types.RateLimit) bool {
+	return limit != nil && limit.Enabled
+}
+
+// memoryStore is the in-memory counter store used when no store is configured
+type memoryStore struct {
+	data  map[string]memoryItem
+	mutex sync.Mutex
+}
+
+type memoryItem struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{data: map[string]memoryItem{}}
+}
+
+// Get returns the value of a key which is not expired
+func (s *memoryStore) Get(key string) (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	item, has := s.data[key]
+	if !has {
+		return nil, false
+	}
+	if !item.expires.IsZero() && time.Now().After(item.expires) {
+		delete(s.data, key)
+		return nil, false
+	}
+	return item.value, true
+}
+
+// Incr adds to a counter atomically, the ttl is set when the counter is created
+func (s *memoryStore) Incr(key string, delta int, ttl time.Duration) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	item, has := s.data[key]
+	if !has || (!item.expires.IsZero() && time.Now().After(item.expires)) {
+		item = memoryItem{value: 0}
+		if ttl > 0 {
+			item.expires = time.Now().Add(ttl)
+		}
+	}
+
+	value, _ := item.value.(int)
+	value += delta
+	if value < 0 {
+		value = 0
+	}
+	item.value = value
+	s.data[key] = item
+	return value, nil
+}
+
+// Set sets the value of a key, zero ttl never expires
+func (s *memoryStore) Set(key string, value interface{}, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	item := memoryItem{value: value}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+	s.data[key] = item
+
+	// Drop the expired items from time to time
+	if len(s.data)%1024 == 0 {
+		now := time.Now()
+		for k, v := range s.data {
+			if !v.expires.IsZero() && now.After(v.expires) {
+				delete(s.data, k)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package messenger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+func TestRateLimiterProvider(t *testing.T) {
+	limiter := NewRateLimiter(nil)
+	limit := &types.RateLimit{Enabled: true, MaxPerHour: 2}
+
+	_, ok := limiter.ReserveProvider(limit, "default", "primary")
+	assert.True(t, ok)
+	second, ok := limiter.ReserveProvider(limit, "default", "primary")
+	assert.True(t, ok)
+	_, ok = limiter.ReserveProvider(limit, "default", "primary")
+	assert.False(t, ok)
+	_, ok = limiter.ReserveProvider(limit, "default", "backup")
+	assert.True(t, ok)
+	_, ok = limiter.ReserveProvider(limit, "alerts", "primary")
+	assert.True(t, ok)
+
+	// A released reservation frees its slot
+	second.Release()
+	_, ok = limiter.ReserveProvider(limit, "default", "primary")
+	assert.True(t, ok)
+
+	// Disabled limits are ignored
+	reservation, ok := limiter.ReserveProvider(&types.RateLimit{MaxPerHour: 1}, "default", "primary")
+	assert.True(t, ok)
+	reservation.Release()
+}
+
+func TestRateLimiterRecipients(t *testing.T) {
+	limiter := NewRateLimiter(nil)
+	limit := &types.RateLimit{Enabled: true, MaxPerRecipientPerDay: 1}
+
+	_, err := limiter.ReserveRecipients(limit, "default", []string{"a@example.com"})
+	require.NoError(t, err)
+	_, err = limiter.ReserveRecipients(limit, "default", []string{"A@example.com"})
+	assert.True(t, errors.Is(err, ErrRateLimited))
+
+	// A rejected recipient does not count the others
+	_, err = limiter.ReserveRecipients(limit, "default", []string{"b@example.com", "a@example.com"})
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	_, err = limiter.ReserveRecipients(limit, "default", []string{"b@example.com"})
+	assert.NoError(t, err)
+
+	window := &types.RateLimit{Enabled: true, Window: time.Minute}
+	reservation, err := limiter.ReserveRecipients(window, "otp", []string{"+1234567890"})
+	require.NoError(t, err)
+	_, err = limiter.ReserveRecipients(window, "otp", []string{"+1234567890"})
+	assert.Error(t, err)
+	reservation.Release()
+	_, err = limiter.ReserveRecipients(window, "otp", []string{"+1234567890"})
+	assert.NoError(t, err)
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	for name, store := range map[string]CounterStore{"atomic": newMemoryStore(), "get and set": plainStore{newMemoryStore()}} {
+		t.Run(name, func(t *testing.T) {
+			limiter := NewRateLimiter(store)
+			limit := &types.RateLimit{Enabled: true, MaxPerHour: 10, MaxPerRecipientPerHour: 10}
+
+			var wg sync.WaitGroup
+			var passed int32
+			for i := 0; i < 100; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					recipients, err := limiter.ReserveRecipients(limit, "default", []string{"a@example.com"})
+					if err != nil {
+						return
+					}
+					if _, ok := limiter.ReserveProvider(limit, "default", "primary"); !ok {
+						recipients.Release()
+						return
+					}
+					atomic.AddInt32(&passed, 1)
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, int32(10), passed)
+		})
+	}
+}
+
+func TestRateLimiterStoreValues(t *testing.T) {
+	store := plainStore{newMemoryStore()}
+	limiter := NewRateLimiter(store)
+	limit := &types.RateLimit{Enabled: true, MaxPerHour: 3}
+
+	// Stores like redis return the counters as strings or floats
+	hourKey, _ := limiter.bucketKeys("provider:default:primary")
+	store.Set(hourKey, "3", time.Hour)
+	_, ok := limiter.ReserveProvider(limit, "default", "primary")
+	assert.False(t, ok)
+	assert.Equal(t, 3, limiter.count(hourKey))
+	store.Set(hourKey, float64(2), time.Hour)
+	_, ok = limiter.ReserveProvider(limit, "default", "primary")
+	assert.True(t, ok)
+	assert.Equal(t, 3, limiter.count(hourKey))
+}
+
+// plainStore a counter store without atomic increments
+type plainStore struct {
+	store *memoryStore
+}
+
+func (s plainStore) Get(key string) (interface{}, bool) {
+	return s.store.Get(key)
+}
+
+func (s plainStore) Set(key string, value interface{}, ttl time.Duration) error {
+	return s.store.Set(key, value, ttl)
+}
+
+func TestRateLimitUnmarshal(t *testing.T) {
+	var limit types.RateLimit
+	err := json.Unmarshal([]byte(`{"enabled": true, "max_per_hour": 10, "window": "30s"}`), &limit)
+	require.NoError(t, err)
+	assert.True(t, limit.Enabled)
+	assert.Equal(t, 10, limit.MaxPerHour)
+	assert.Equal(t, 30*time.Second, limit.Window)
+
+	err = json.Unmarshal([]byte(`{"enabled": true, "window": 60}`), &limit)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, limit.Window)
+}
+
+func TestSendFallback(t *testing.T) {
+	primary := &mockProvider{name: "primary", typ: "smtp", failures: 10}
+	backup := &mockProvider{name: "backup", typ: "mailgun"}
+	service := newTestService(map[string]types.Channel{
+		"default": {
+			Types: map[string]*types.Channel{
+				"email": {Provider: "primary", Fallbacks: []string{"backup"}},
+			},
+		},
+	}, primary, backup)
+	service.config.Global.RetryAttempts = 2
+
+	result, err := service.Send(context.Background(), "default", createTestMessage(types.MessageTypeEmail))
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "backup", result.Provider)
+	assert.Equal(t, []string{"primary", "backup"}, result.Tried)
+	assert.Equal(t, 3, result.Attempts)
+	assert.Equal(t, 2, primary.callCount())
+	assert.Equal(t, 1, backup.sentCount())
+}
+
+func TestSendRateLimitFallback(t *testing.T) {
+	primary := &mockProvider{name: "primary", typ: "smtp"}
+	backup := &mockProvider{name: "backup", typ: "mailgun"}
+	service := newTestService(map[string]types.Channel{
+		"default": {
+			Fallbacks: []string{"backup"},
+			RateLimit: &types.RateLimit{Enabled: true, MaxPerHour: 1},
+			Types: map[string]*types.Channel{
+				"email": {Provider: "primary"},
+			},
+		},
+	}, primary, backup)
+
+	ctx := context.Background()
+	result, err := service.Send(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	require.NoError(t, err)
+	assert.Equal(t, "primary", result.Provider)
+
+	result, err = service.Send(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	require.NoError(t, err)
+	assert.Equal(t, "backup", result.Provider)
+
+	result, err = service.Send(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.False(t, result.Success)
+	assert.Equal(t, 1, primary.sentCount())
+	assert.Equal(t, 1, backup.sentCount())
+}
+
+func TestSendRecipientRateLimit(t *testing.T) {
+	primary := &mockProvider{name: "primary", typ: "smtp"}
+	service := newTestService(map[string]types.Channel{
+		"default": {
+			Types: map[string]*types.Channel{
+				"email": {
+					Provider:  "primary",
+					RateLimit: &types.RateLimit{Enabled: true, MaxPerRecipientPerHour: 1},
+				},
+			},
+		},
+	}, primary)
+
+	ctx := context.Background()
+	_, err := service.Send(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	require.NoError(t, err)
+
+	_, err = service.Send(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, 1, primary.sentCount())
+}
+
+func TestSendFailureReleasesLimits(t *testing.T) {
+	primary := &mockProvider{name: "primary", typ: "smtp", failures: 1}
+	service := newTestService(map[string]types.Channel{
+		"default": {
+			RateLimit: &types.RateLimit{Enabled: true, MaxPerHour: 1, MaxPerRecipientPerHour: 1},
+			Types: map[string]*types.Channel{
+				"email": {Provider: "primary"},
+			},
+		},
+	}, primary)
+	service.config.Global.RetryAttempts = 1
+
+	// The failed message does not use up the limits of the provider and the recipient
+	ctx := context.Background()
+	_, err := service.Send(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrRateLimited))
+
+	_, err = service.Send(ctx, "default", createTestMessage(types.MessageTypeEmail))
+	require.NoError(t, err)
+	assert.Equal(t, 1, primary.sentCount())
+}
@@ -0,0 +1,159 @@
+package messenger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/messenger/template"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// messageTypeOrder is the lookup order of the channel type sections
+var messageTypeOrder = []types.MessageType{types.MessageTypeEmail, types.MessageTypeSMS, types.MessageTypeWhatsApp, types.MessageTypeChat}
+
+// SendTemplate renders a channel template with the given variables and sends it to the recipients
+// The locale is read from variables["locale"], and falls back to the short language code,
+// the application default language and finally the template without locale suffix.
+func (m *Service) SendTemplate(ctx context.Context, channel string, templateName string, variables map[string]interface{}, to []string) (*types.SendResult, error) {
+	message, err := m.RenderTemplate(channel, templateName, variables)
+	if err != nil {
+		return nil, err
+	}
+	message.To = to
+	return m.Send(ctx, channel, message)
+}
+
+// RenderTemplate renders a channel template into a message without recipients
+func (m *Service) RenderTemplate(channel string, templateName string, variables map[string]interface{}) (*types.Message, error) {
+	m.mutex.RLock()
+	tmpl, msgType, err := m.findTemplate(channel, templateName, templateLocale(variables))
+	m.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	message := &types.Message{
+		Type:    msgType,
+		Subject: template.Text(tmpl.Subject, variables, false),
+		Body:    template.Text(tmpl.Body, variables, false),
+		HTML:    template.Text(tmpl.HTML, variables, true),
+		Metadata: map[string]interface{}{
+			"template": templateName,
+			"channel":  channel,
+		},
+	}
+
+	if len(tmpl.Headers) > 0 {
+		message.Headers = make(map[string]string, len(tmpl.Headers))
+		for key, value := range tmpl.Headers {
+			message.Headers[key] = template.Text(value, variables, false)
+		}
+	}
+
+	for key, value := range tmpl.Metadata {
+		message.Metadata[key] = value
+	}
+
+	return message, nil
+}
+
+// findTemplate looks up a template in the channel type sections first, then in the channel itself
+func (m *Service) findTemplate(channel string, templateName string, locale string) (*types.Template, types.MessageType, error) {
+	ch, exists := m.channels[channel]
+	if !exists {
+		return nil, "", fmt.Errorf("channel not found: %s", channel)
+	}
+
+	names := templateCandidates(templateName, locale)
+	for _, name := range names {
+		for _, msgType := range channelTypes(ch) {
+			typeChannel := ch.Types[string(msgType)]
+			if typeChannel == nil {
+				continue
+			}
+			if tmpl, has := typeChannel.Templates[name]; has {
+				if tmpl.Type == "" {
+					tmpl.Type = msgType
+				}
+				return &tmpl, tmpl.Type, nil
+			}
+		}
+
+		if tmpl, has := ch.Templates[name]; has {
+			if tmpl.Type == "" {
+				return nil, "", fmt.Errorf("template %s of channel %s has no message type", name, channel)
+			}
+			return &tmpl, tmpl.Type, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("template not found: %s (channel: %s)", templateName, channel)
+}
+
+// channelTypes returns the message types configured for a channel in a stable order
+func channelTypes(ch types.Channel) []types.MessageType {
+	result := []types.MessageType{}
+	seen := map[string]bool{}
+	for _, msgType := range messageTypeOrder {
+		if _, has := ch.Types[string(msgType)]; has {
+			result = append(result, msgType)
+			seen[string(msgType)] = true
+		}
+	}
+
+	others := []string{}
+	for name := range ch.Types {
+		if !seen[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		result = append(result, types.MessageType(name))
+	}
+	return result
+}
+
+// templateCandidates returns the template names to look up, the most specific locale first
+// e.g. invite.zh-cn, invite.zh, invite.en-us, invite.en, invite
+func templateCandidates(name string, locale string) []string {
+	candidates := []string{}
+	seen := map[string]bool{}
+	add := func(locale string) {
+		key := name
+		if locale != "" {
+			key = name + "." + locale
+		}
+		if !seen[key] {
+			seen[key] = true
+			candidates = append(candidates, key)
+		}
+	}
+
+	for _, lang := range []string{locale, config.Conf.Lang} {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang == "" {
+			continue
+		}
+		add(lang)
+		if parts := strings.Split(lang, "-"); len(parts) > 1 {
+			add(parts[0])
+		}
+	}
+	add("")
+	return candidates
+}
+
+// templateLocale reads the locale from the template variables
+func templateLocale(variables map[string]interface{}) string {
+	if variables == nil {
+		return ""
+	}
+	if locale, ok := variables["locale"].(string); ok {
+		return locale
+	}
+	return ""
+}
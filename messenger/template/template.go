@@ -0,0 +1,116 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Pattern matches the placeholders like {{ name }} or {{ user.name }}
+var Pattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_][A-Za-z0-9_\.\-]*)\s*\}\}`)
+
+// Text replaces the {{ name }} placeholders of a text with the variable values, nested values are addressable with
+// dot notation. Unknown variables are kept as is, values are escaped when rendering HTML.
+func Text(text string, vars map[string]interface{}, escape bool) string {
+	if text == "" {
+		return text
+	}
+
+	return Pattern.ReplaceAllStringFunc(text, func(match string) string {
+		value, has := Lookup(vars, Pattern.FindStringSubmatch(match)[1])
+		if !has {
+			return match
+		}
+		if escape {
+			return html.EscapeString(Format(value))
+		}
+		return Format(value)
+	})
+}
+
+// JSON renders a JSON template, the strings of the objects and arrays are rendered. A string holding a single
+// placeholder is replaced by the variable value to keep its JSON type, unknown variables are rendered empty.
+func JSON(tmpl interface{}, vars map[string]interface{}) interface{} {
+	switch v := tmpl.(type) {
+	case string:
+		if match := Pattern.FindStringSubmatch(v); match != nil && match[0] == strings.TrimSpace(v) {
+			value, _ := Lookup(vars, match[1])
+			return value
+		}
+		return Pattern.ReplaceAllStringFunc(v, func(match string) string {
+			value, _ := Lookup(vars, Pattern.FindStringSubmatch(match)[1])
+			return Format(value)
+		})
+
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			result[key] = JSON(value, vars)
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, value := range v {
+			result[i] = JSON(value, vars)
+		}
+		return result
+	}
+	return tmpl
+}
+
+// Lookup returns a variable by its name, a dotted name walks the nested maps and arrays, e.g. user.name or items.0
+func Lookup(vars map[string]interface{}, name string) (interface{}, bool) {
+	if value, has := vars[name]; has {
+		return value, true
+	}
+
+	var current interface{} = vars
+	for _, key := range strings.Split(name, ".") {
+		value := reflect.ValueOf(current)
+		switch value.Kind() {
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			item := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+			if !item.IsValid() {
+				return nil, false
+			}
+			current = item.Interface()
+
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= value.Len() {
+				return nil, false
+			}
+			current = value.Index(index).Interface()
+
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Format formats a variable value as text, the objects and arrays are written as JSON
+func Format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		if raw, err := json.Marshal(value); err == nil {
+			return string(raw)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,57 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	vars := map[string]interface{}{
+		"name":  "<Alice>",
+		"user":  map[string]interface{}{"id": 42},
+		"items": []interface{}{map[string]interface{}{"sku": "A1"}},
+	}
+
+	assert.Equal(t, "Hi <Alice> 42", Text("Hi {{ name }} {{user.id}}", vars, false))
+	assert.Equal(t, "Hi &lt;Alice&gt;", Text("Hi {{ name }}", vars, true))
+	assert.Equal(t, "Hi {{ missing }}", Text("Hi {{ missing }}", vars, false))
+	assert.Equal(t, "First A1", Text("First {{ items.0.sku }}", vars, false))
+}
+
+func TestJSON(t *testing.T) {
+	vars := map[string]interface{}{
+		"subject":  "Hi",
+		"metadata": map[string]interface{}{"tags": []interface{}{"a", "b"}},
+	}
+
+	result := JSON(map[string]interface{}{
+		"text":  "{{ subject }} {{ missing }}!",
+		"tags":  "{{ metadata.tags }}",
+		"list":  []interface{}{"{{subject}}", 1},
+		"inner": "tags: {{ metadata.tags }}",
+	}, vars).(map[string]interface{})
+
+	assert.Equal(t, "Hi !", result["text"])
+	assert.Equal(t, []interface{}{"a", "b"}, result["tags"])
+	assert.Equal(t, []interface{}{"Hi", 1}, result["list"])
+	assert.Equal(t, `tags: ["a","b"]`, result["inner"])
+}
+
+func TestLookup(t *testing.T) {
+	vars := map[string]interface{}{
+		"user.name": "flat",
+		"user":      map[string]string{"name": "nested", "id": "7"},
+	}
+
+	value, has := Lookup(vars, "user.name")
+	assert.True(t, has)
+	assert.Equal(t, "flat", value, "a flat key wins over the nested value")
+
+	value, has = Lookup(vars, "user.id")
+	assert.True(t, has)
+	assert.Equal(t, "7", value)
+
+	_, has = Lookup(vars, "user.id.more")
+	assert.False(t, has)
+}
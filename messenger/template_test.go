@@ -0,0 +1,147 @@
+package messenger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+func templateTestChannels() map[string]types.Channel {
+	return map[string]types.Channel{
+		"default": {
+			Types: map[string]*types.Channel{
+				"email": {
+					Provider: "mail",
+					Templates: map[string]types.Template{
+						"invite": {
+							Subject: "Join {{ team.name }}",
+							Body:    "Hi {{ name }}, {{ inviter }} invited you to {{ team.name }}.",
+							HTML:    "<p>Hi {{ name }}</p>",
+							Headers: map[string]string{"X-Team": "{{ team.id }}"},
+						},
+						"invite.zh-cn": {
+							Subject: "加入 {{ team.name }}",
+							Body:    "{{ name }}，您好",
+						},
+					},
+				},
+				"sms": {
+					Provider: "sms",
+					Templates: map[string]types.Template{
+						"otp": {Body: "Your code is {{ code }}"},
+					},
+				},
+			},
+			Templates: map[string]types.Template{
+				"notice": {Type: types.MessageTypeSMS, Body: "Notice: {{ text }}"},
+				"broken": {Body: "no type"},
+			},
+		},
+	}
+}
+
+func TestTemplateCandidates(t *testing.T) {
+	candidates := templateCandidates("invite", "zh-CN")
+	require.NotEmpty(t, candidates)
+	assert.Equal(t, "invite.zh-cn", candidates[0])
+	assert.Equal(t, "invite.zh", candidates[1])
+	assert.Equal(t, "invite", candidates[len(candidates)-1])
+
+	candidates = templateCandidates("invite", "")
+	assert.Equal(t, "invite", candidates[len(candidates)-1])
+}
+
+func TestRenderTemplate(t *testing.T) {
+	service := newTestService(templateTestChannels())
+
+	message, err := service.RenderTemplate("default", "invite", map[string]interface{}{
+		"name":    "Alice",
+		"inviter": "Bob",
+		"team":    map[string]interface{}{"id": "t1", "name": "Yao"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.MessageTypeEmail, message.Type)
+	assert.Equal(t, "Join Yao", message.Subject)
+	assert.Equal(t, "Hi Alice, Bob invited you to Yao.", message.Body)
+	assert.Equal(t, "<p>Hi Alice</p>", message.HTML)
+	assert.Equal(t, "t1", message.Headers["X-Team"])
+	assert.Equal(t, "invite", message.Metadata["template"])
+
+	// Locale fallback
+	message, err = service.RenderTemplate("default", "invite", map[string]interface{}{
+		"locale": "zh-CN",
+		"name":   "Alice",
+		"team":   map[string]interface{}{"name": "Yao"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "加入 Yao", message.Subject)
+
+	message, err = service.RenderTemplate("default", "invite", map[string]interface{}{
+		"locale": "fr-FR",
+		"name":   "Alice",
+		"team":   map[string]interface{}{"name": "Yao"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Join Yao", message.Subject)
+
+	// Type section and channel level templates
+	message, err = service.RenderTemplate("default", "otp", map[string]interface{}{"code": "123456"})
+	require.NoError(t, err)
+	assert.Equal(t, types.MessageTypeSMS, message.Type)
+	assert.Equal(t, "Your code is 123456", message.Body)
+
+	message, err = service.RenderTemplate("default", "notice", map[string]interface{}{"text": "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, types.MessageTypeSMS, message.Type)
+
+	_, err = service.RenderTemplate("default", "broken", nil)
+	assert.Error(t, err)
+
+	_, err = service.RenderTemplate("default", "missing", nil)
+	assert.Error(t, err)
+
+	_, err = service.RenderTemplate("unknown", "invite", nil)
+	assert.Error(t, err)
+}
+
+func TestSendTemplate(t *testing.T) {
+	mail := &mockProvider{name: "mail", typ: "smtp"}
+	service := newTestService(templateTestChannels(), mail)
+	service.defaults["default.email"] = "mail"
+
+	result, err := service.SendTemplate(context.Background(), "default", "invite", map[string]interface{}{
+		"name": "Alice",
+		"team": map[string]interface{}{"name": "Yao"},
+	}, []string{"alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "mail", result.Provider)
+	require.Equal(t, 1, mail.sentCount())
+	assert.Equal(t, []string{"alice@example.com"}, mail.last().To)
+	assert.Equal(t, "Join Yao", mail.last().Subject)
+}
+
+func TestParseChannels(t *testing.T) {
+	channels := parseChannels(map[string]interface{}{
+		"default": map[string]interface{}{
+			"description": "Default channel",
+			"email": map[string]interface{}{
+				"provider": "primary",
+				"templates": map[string]interface{}{
+					"invite": map[string]interface{}{"subject": "Hi {{ name }}", "body": "Welcome"},
+				},
+			},
+			"sms": "unified",
+		},
+	})
+
+	require.Contains(t, channels, "default")
+	channel := channels["default"]
+	assert.Equal(t, "Default channel", channel.Description)
+	require.Contains(t, channel.Types, "email")
+	assert.Equal(t, "primary", channel.Types["email"].Provider)
+	assert.Equal(t, "Hi {{ name }}", channel.Types["email"].Templates["invite"].Subject)
+	assert.Equal(t, "unified", channel.Types["sms"].Provider)
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// UnmarshalJSON accepts the window as a duration string ("1h", "30s") or a number of seconds
+func (r *RateLimit) UnmarshalJSON(data []byte) error {
+	type alias RateLimit
+	raw := struct {
+		*alias
+		Window interface{} `json:"window,omitempty"`
+	}{alias: (*alias)(r)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	window, err := ParseDuration(raw.Window)
+	if err != nil {
+		return fmt.Errorf("rate_limit.window: %w", err)
+	}
+	r.Window = window
+	return nil
+}
+
+// UnmarshalJSON accepts the durations as duration strings ("2s", "1m") or numbers of seconds
+func (g *GlobalConfig) UnmarshalJSON(data []byte) error {
+	type alias GlobalConfig
+	raw := struct {
+		*alias
+		RetryDelay interface{} `json:"retry_delay,omitempty"`
+		Timeout    interface{} `json:"timeout,omitempty"`
+	}{alias: (*alias)(g)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.RetryDelay != nil {
+		delay, err := ParseDuration(raw.RetryDelay)
+		if err != nil {
+			return fmt.Errorf("retry_delay: %w", err)
+		}
+		g.RetryDelay = delay
+	}
+
+	if raw.Timeout != nil {
+		timeout, err := ParseDuration(raw.Timeout)
+		if err != nil {
+			return fmt.Errorf("timeout: %w", err)
+		}
+		g.Timeout = timeout
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts the durations as duration strings ("5s", "1h") or numbers of seconds
+func (o *OutboxConfig) UnmarshalJSON(data []byte) error {
+	type alias OutboxConfig
+	raw := struct {
+		*alias
+		Interval   interface{} `json:"interval,omitempty"`
+		Backoff    interface{} `json:"backoff,omitempty"`
+		MaxBackoff interface{} `json:"max_backoff,omitempty"`
+	}{alias: (*alias)(o)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if o.Interval, err = ParseDuration(raw.Interval); err != nil {
+		return fmt.Errorf("outbox.interval: %w", err)
+	}
+	if o.Backoff, err = ParseDuration(raw.Backoff); err != nil {
+		return fmt.Errorf("outbox.backoff: %w", err)
+	}
+	if o.MaxBackoff, err = ParseDuration(raw.MaxBackoff); err != nil {
+		return fmt.Errorf("outbox.max_backoff: %w", err)
+	}
+	return nil
+}
+
+// ParseDuration parses a configuration duration, strings use the time.ParseDuration format
+// and numbers are seconds. An empty value returns zero.
+func ParseDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(seconds * float64(time.Second)), nil
+		}
+		return time.ParseDuration(v)
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case json.Number:
+		seconds, err := v.Float64()
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("invalid duration %v", value)
+}
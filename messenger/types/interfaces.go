@@ -1,6 +1,9 @@
 package types
 
-import "context"
+import (
+	"context"
+	"net/http"
+)
 
 // Provider defines the interface for message providers
 type Provider interface {
@@ -23,10 +26,19 @@ type Provider interface {
 	Close() error
 }
 
+// Receiver is implemented by the providers receiving webhooks, such as delivery receipts and inbound replies
+type Receiver interface {
+	// VerifyWebhook verifies the provider signature of a webhook request, body is the raw request body
+	VerifyWebhook(req *http.Request, body []byte) error
+
+	// ParseWebhook parses a verified webhook request into events
+	ParseWebhook(req *http.Request, body []byte) ([]*Event, error)
+}
+
 // Messenger defines the main messenger interface
 type Messenger interface {
-	// Send sends a message using the specified channel or default provider
-	Send(ctx context.Context, channel string, message *Message) error
+	// Send sends a message using the specified channel or default provider, falling back to the channel fallback providers
+	Send(ctx context.Context, channel string, message *Message) (*SendResult, error)
 
 	// SendWithProvider sends a message using a specific provider
 	SendWithProvider(ctx context.Context, providerName string, message *Message) error
@@ -34,6 +46,15 @@ type Messenger interface {
 	// SendBatch sends multiple messages in batch
 	SendBatch(ctx context.Context, channel string, messages []*Message) error
 
+	// SendTemplate renders a channel template with the given variables and sends it to the recipients
+	SendTemplate(ctx context.Context, channel string, templateName string, variables map[string]interface{}, to []string) (*SendResult, error)
+
+	// Enqueue stores a message in the persistent outbox, it is sent by the outbox worker when ScheduledAt is due
+	Enqueue(ctx context.Context, channel string, message *Message) (string, error)
+
+	// Receive verifies and handles a webhook request of a provider, returns the received events
+	Receive(ctx context.Context, providerName string, req *http.Request, body []byte) ([]*Event, error)
+
 	// GetProvider returns a provider by name
 	GetProvider(name string) (Provider, error)
 
@@ -46,3 +67,10 @@ type Messenger interface {
 	// Close closes all provider connections
 	Close() error
 }
+
+// Tracker is implemented by the providers returning the IDs they assigned to a sent message, one per request made
+// to the provider API. The IDs match the delivery receipts of the provider webhooks.
+type Tracker interface {
+	// SendTracked sends a message like Send and returns the provider message IDs
+	SendTracked(ctx context.Context, message *Message) ([]string, error)
+}
@@ -17,6 +17,8 @@ const (
 	MessageTypeSMS MessageType = "sms"
 	// MessageTypeWhatsApp represents WhatsApp messaging
 	MessageTypeWhatsApp MessageType = "whatsapp"
+	// MessageTypeChat represents team chat messaging (Slack, Discord, Feishu, webhooks)
+	MessageTypeChat MessageType = "chat"
 )
 
 // Message represents a message to be sent
@@ -48,7 +50,7 @@ type ProviderConfig struct {
 	types.MetaInfo
 	Name        string                 `json:"name"`
 	Description string                 `json:"description,omitempty"`
-	Connector   string                 `json:"connector"`         // Provider type: smtp, twilio, mailgun
+	Connector   string                 `json:"connector"`         // Provider type: smtp, twilio, mailgun, webhook, slack, discord, feishu
 	Options     map[string]interface{} `json:"options,omitempty"` // Provider-specific options
 	Enabled     bool                   `json:"enabled,omitempty"` // Whether the provider is enabled (default: true)
 }
@@ -73,15 +75,20 @@ type Channel struct {
 }
 
 // RateLimit represents rate limiting configuration
+// MaxPerHour and MaxPerDay limit each provider of the channel, the next fallback provider is used when reached.
+// The recipient limits and Window (minimum interval between two messages to the same recipient) reject the message.
 type RateLimit struct {
-	Enabled    bool          `json:"enabled"`
-	MaxPerHour int           `json:"max_per_hour,omitempty"`
-	MaxPerDay  int           `json:"max_per_day,omitempty"`
-	Window     time.Duration `json:"window,omitempty"`
+	Enabled                bool          `json:"enabled"`
+	MaxPerHour             int           `json:"max_per_hour,omitempty"`
+	MaxPerDay              int           `json:"max_per_day,omitempty"`
+	MaxPerRecipientPerHour int           `json:"max_per_recipient_per_hour,omitempty"`
+	MaxPerRecipientPerDay  int           `json:"max_per_recipient_per_day,omitempty"`
+	Window                 time.Duration `json:"window,omitempty"`
 }
 
 // Template represents a message template
 type Template struct {
+	Type     MessageType            `json:"type,omitempty"` // Message type, inferred from the channel type section when omitted
 	Subject  string                 `json:"subject,omitempty"`
 	Body     string                 `json:"body"`
 	HTML     string                 `json:"html,omitempty"`
@@ -95,8 +102,34 @@ type GlobalConfig struct {
 	RetryDelay    time.Duration `json:"retry_delay,omitempty"`
 	Timeout       time.Duration `json:"timeout,omitempty"`
 	LogLevel      string        `json:"log_level,omitempty"`
+	Store         string        `json:"store,omitempty"`      // Store name for the rate limit counters, kept in memory when empty
+	OnReceive     string        `json:"on_receive,omitempty"` // Process called with each webhook event, e.g. scripts.messenger.OnReceive
+	Outbox        OutboxConfig  `json:"outbox,omitempty"`     // Persistent outbox settings
 }
 
+// OutboxConfig represents the persistent outbox settings
+// The failed deliveries are retried after Backoff, doubled on each attempt up to MaxBackoff.
+type OutboxConfig struct {
+	Disabled    bool          `json:"disabled,omitempty"`     // Do not drain the outbox on this node
+	Interval    time.Duration `json:"interval,omitempty"`     // Polling interval, default 5s
+	BatchSize   int           `json:"batch_size,omitempty"`   // Messages claimed per poll, default 50
+	MaxAttempts int           `json:"max_attempts,omitempty"` // Delivery attempts before the message fails, default 5
+	Backoff     time.Duration `json:"backoff,omitempty"`      // First retry delay, default 30s
+	MaxBackoff  time.Duration `json:"max_backoff,omitempty"`  // Retry delay cap, default 1h
+}
+
+// OutboxStatus represents the status of an outbox message
+type OutboxStatus string
+
+// Outbox status constants
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusSending   OutboxStatus = "sending"
+	OutboxStatusSent      OutboxStatus = "sent"
+	OutboxStatusFailed    OutboxStatus = "failed"
+	OutboxStatusCancelled OutboxStatus = "cancelled"
+)
+
 // SendOptions represents options for sending messages
 type SendOptions struct {
 	Provider    string                 `json:"provider,omitempty"`
@@ -109,11 +142,59 @@ type SendOptions struct {
 
 // SendResult represents the result of a send operation
 type SendResult struct {
-	Success   bool                   `json:"success"`
-	MessageID string                 `json:"message_id,omitempty"`
-	Provider  string                 `json:"provider"`
-	Error     error                  `json:"error,omitempty"`
-	Attempts  int                    `json:"attempts"`
-	SentAt    time.Time              `json:"sent_at"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Success            bool                   `json:"success"`
+	MessageID          string                 `json:"message_id,omitempty"`
+	Provider           string                 `json:"provider"`                       // The provider that delivered the message
+	ProviderMessageIDs []string               `json:"provider_message_ids,omitempty"` // Message IDs assigned by the provider, see Tracker
+	Status             DeliveryStatus         `json:"status,omitempty"`               // Delivery status, updated by the provider webhooks
+	Error              error                  `json:"error,omitempty"`
+	Attempts           int                    `json:"attempts"`        // Total attempts across all the providers
+	Tried              []string               `json:"tried,omitempty"` // Providers tried in order, including the rate limited ones
+	SentAt             time.Time              `json:"sent_at"`
+	Metadata           map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// DeliveryStatus represents the delivery status reported by a provider
+type DeliveryStatus string
+
+// Delivery status constants, the provider statuses are normalized to these values
+const (
+	DeliveryStatusQueued       DeliveryStatus = "queued"
+	DeliveryStatusSent         DeliveryStatus = "sent"
+	DeliveryStatusDelivered    DeliveryStatus = "delivered"
+	DeliveryStatusRead         DeliveryStatus = "read"
+	DeliveryStatusClicked      DeliveryStatus = "clicked"
+	DeliveryStatusFailed       DeliveryStatus = "failed"
+	DeliveryStatusBounced      DeliveryStatus = "bounced"
+	DeliveryStatusComplained   DeliveryStatus = "complained"
+	DeliveryStatusUnsubscribed DeliveryStatus = "unsubscribed"
+)
+
+// EventType defines the type of a webhook event
+type EventType string
+
+// Event type constants
+const (
+	// EventTypeStatus represents a delivery status update of a sent message
+	EventTypeStatus EventType = "status"
+	// EventTypeInbound represents a message received from a recipient
+	EventTypeInbound EventType = "inbound"
+)
+
+// Event represents a webhook event posted by a provider
+type Event struct {
+	Type              EventType              `json:"type"`
+	Provider          string                 `json:"provider"`
+	MessageType       MessageType            `json:"message_type,omitempty"`
+	MessageID         string                 `json:"message_id,omitempty"`          // Outbox message ID, echoed back by the provider
+	ProviderMessageID string                 `json:"provider_message_id,omitempty"` // Message ID assigned by the provider
+	Status            DeliveryStatus         `json:"status,omitempty"`              // For status events
+	From              string                 `json:"from,omitempty"`
+	To                string                 `json:"to,omitempty"`
+	Subject           string                 `json:"subject,omitempty"` // For inbound events
+	Body              string                 `json:"body,omitempty"`    // For inbound events
+	HTML              string                 `json:"html,omitempty"`    // For inbound email events
+	Error             string                 `json:"error,omitempty"`
+	Timestamp         time.Time              `json:"timestamp"`
+	Raw               map[string]interface{} `json:"raw,omitempty"` // The original payload
 }
@@ -0,0 +1,153 @@
+package messenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/messenger/types"
+)
+
+// ErrInvalidSignature is returned when a webhook request fails the provider signature verification
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// ErrNotReceiver is returned when the provider does not receive webhooks
+var ErrNotReceiver = errors.New("provider does not receive webhooks")
+
+// statusRanks orders the delivery statuses, a webhook never moves a delivery back to an earlier status
+// because the providers may post the events out of order
+var statusRanks = map[types.DeliveryStatus]int{
+	types.DeliveryStatusQueued:       1,
+	types.DeliveryStatusSent:         2,
+	types.DeliveryStatusDelivered:    3,
+	types.DeliveryStatusRead:         4,
+	types.DeliveryStatusClicked:      5,
+	types.DeliveryStatusFailed:       6,
+	types.DeliveryStatusBounced:      6,
+	types.DeliveryStatusComplained:   7,
+	types.DeliveryStatusUnsubscribed: 7,
+}
+
+// Receive verifies and handles a webhook request of a provider.
+// The status events update the delivery log of the outbox message, and every event
+// is passed to the OnReceive process when it is configured.
+func (m *Service) Receive(ctx context.Context, providerName string, req *http.Request, body []byte) ([]*types.Event, error) {
+	m.mutex.RLock()
+	provider, exists := m.providers[providerName]
+	hook := m.config.Global.OnReceive
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("provider not found: %s", providerName)
+	}
+
+	receiver, ok := provider.(types.Receiver)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotReceiver, providerName)
+	}
+
+	if err := receiver.VerifyWebhook(req, body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	events, err := receiver.ParseWebhook(req, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook of %s: %w", providerName, err)
+	}
+
+	for _, event := range events {
+		event.Provider = providerName
+		if event.Timestamp.IsZero() {
+			event.Timestamp = time.Now()
+		}
+
+		if event.Type == types.EventTypeStatus {
+			if err := updateDeliveryStatus(event); err != nil {
+				log.Error("[Messenger] Failed to update the delivery status of %s: %v", event.MessageID, err)
+			}
+		}
+
+		if hook != "" {
+			if err := callReceiveHook(ctx, hook, event); err != nil {
+				log.Error("[Messenger] %s failed for the %s event of %s: %v", hook, event.Type, providerName, err)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// updateDeliveryStatus writes a status event to the delivery log, the successful attempts of the
+// message, sent directly or by the outbox, are matched by the echoed message ID or by the provider message ID
+func updateDeliveryStatus(event *types.Event) error {
+	if event.Status == "" || (event.MessageID == "" && event.ProviderMessageID == "") {
+		return nil
+	}
+
+	mod := model.Select("__yao.messenger.delivery")
+	if mod == nil {
+		return fmt.Errorf("messenger delivery model not found")
+	}
+
+	where := model.QueryWhere{Column: "message_id", Value: event.MessageID}
+	if event.MessageID == "" {
+		where = model.QueryWhere{Column: "provider_message_id", Value: event.ProviderMessageID}
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"id", "status"},
+		Wheres: []model.QueryWhere{where, {Column: "success", Value: true}},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		current := types.DeliveryStatus(fmt.Sprintf("%v", row["status"]))
+		if statusRanks[current] > statusRanks[event.Status] {
+			continue
+		}
+
+		data := map[string]interface{}{"status": string(event.Status), "status_at": event.Timestamp}
+		if event.Error != "" {
+			data["error"] = event.Error
+		}
+		if event.ProviderMessageID != "" {
+			data["provider_message_id"] = event.ProviderMessageID
+		}
+
+		_, err := mod.UpdateWhere(model.QueryParam{
+			Wheres: []model.QueryWhere{{Column: "id", Value: row["id"]}},
+		}, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callReceiveHook calls the OnReceive process with the event
+func callReceiveHook(ctx context.Context, name string, event *types.Event) error {
+	var data map[string]interface{}
+	raw, err := jsoniter.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := jsoniter.Unmarshal(raw, &data); err != nil {
+		return err
+	}
+
+	p, err := process.Of(name, data)
+	if err != nil {
+		return err
+	}
+	p.Context = ctx
+	_, err = p.Exec()
+	return err
+}
@@ -0,0 +1,127 @@
+package messenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/messenger/types"
+	"github.com/yaoapp/yao/test"
+)
+
+// mockReceiver is a provider receiving webhooks signed with the X-Mock-Signature header
+type mockReceiver struct {
+	mockProvider
+	secret string
+}
+
+func (r *mockReceiver) VerifyWebhook(req *http.Request, body []byte) error {
+	if req.Header.Get("X-Mock-Signature") != r.secret {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func (r *mockReceiver) ParseWebhook(req *http.Request, body []byte) ([]*types.Event, error) {
+	if strings.HasPrefix(string(body), "reply:") {
+		return []*types.Event{{
+			Type: types.EventTypeInbound,
+			From: "+15551234567",
+			Body: strings.TrimPrefix(string(body), "reply:"),
+		}}, nil
+	}
+	return []*types.Event{{Type: types.EventTypeStatus, Status: types.DeliveryStatus(body)}}, nil
+}
+
+// mockTracker is a receiver returning the provider message ID of the sent messages, its receipts carry the ID only
+type mockTracker struct {
+	mockReceiver
+}
+
+func (r *mockTracker) SendTracked(ctx context.Context, message *types.Message) ([]string, error) {
+	if err := r.Send(ctx, message); err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("SM%d", r.sentCount())}, nil
+}
+
+func (r *mockTracker) ParseWebhook(req *http.Request, body []byte) ([]*types.Event, error) {
+	return []*types.Event{{Type: types.EventTypeStatus, Status: types.DeliveryStatusDelivered, ProviderMessageID: string(body)}}, nil
+}
+
+func TestReceive(t *testing.T) {
+	receiver := &mockReceiver{mockProvider: mockProvider{name: "sms", typ: "twilio"}, secret: "s3cret"}
+	mail := &mockProvider{name: "mail", typ: "smtp"}
+	service := newTestService(map[string]types.Channel{}, receiver, mail)
+
+	ctx := context.Background()
+	req := httptest.NewRequest("POST", "/v1/messenger/webhook/sms", nil)
+	req.Header.Set("X-Mock-Signature", "s3cret")
+
+	events, err := service.Receive(ctx, "sms", req, []byte("reply:STOP"))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventTypeInbound, events[0].Type)
+	assert.Equal(t, "sms", events[0].Provider)
+	assert.Equal(t, "STOP", events[0].Body)
+	assert.False(t, events[0].Timestamp.IsZero())
+
+	// Status events without a message ID are not written to the delivery log
+	events, err = service.Receive(ctx, "sms", req, []byte("delivered"))
+	require.NoError(t, err)
+	assert.Equal(t, types.DeliveryStatusDelivered, events[0].Status)
+
+	req.Header.Set("X-Mock-Signature", "wrong")
+	_, err = service.Receive(ctx, "sms", req, []byte("reply:STOP"))
+	assert.True(t, errors.Is(err, ErrInvalidSignature))
+
+	_, err = service.Receive(ctx, "mail", req, []byte("delivered"))
+	assert.True(t, errors.Is(err, ErrNotReceiver))
+
+	_, err = service.Receive(ctx, "unknown", req, []byte("delivered"))
+	assert.Error(t, err)
+}
+
+func TestStatusRanks(t *testing.T) {
+	assert.Less(t, statusRanks[types.DeliveryStatusSent], statusRanks[types.DeliveryStatusDelivered])
+	assert.Less(t, statusRanks[types.DeliveryStatusDelivered], statusRanks[types.DeliveryStatusRead])
+	assert.Less(t, statusRanks[types.DeliveryStatusQueued], statusRanks[types.DeliveryStatusBounced])
+}
+
+func TestReceiveDirectSend(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	sms := &mockTracker{mockReceiver: mockReceiver{mockProvider: mockProvider{name: "sms", typ: "twilio"}, secret: "s3cret"}}
+	service := newTestService(map[string]types.Channel{
+		"default": {Types: map[string]*types.Channel{"sms": {Provider: "sms"}}},
+	}, sms)
+
+	ctx := context.Background()
+	message := createTestMessage(types.MessageTypeSMS)
+	message.Metadata = map[string]interface{}{"campaign": "welcome"}
+	result, err := service.Send(ctx, "default", message)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.MessageID)
+	assert.Equal(t, []string{"SM1"}, result.ProviderMessageIDs)
+	assert.NotContains(t, message.Metadata, "message_id", "the message of the caller is not changed")
+
+	// The receipt carries the provider message ID only
+	req := httptest.NewRequest("POST", "/v1/messenger/webhook/sms", nil)
+	req.Header.Set("X-Mock-Signature", "s3cret")
+	_, err = service.Receive(ctx, "sms", req, []byte("SM1"))
+	require.NoError(t, err)
+
+	deliveries, err := ListDeliveries(result.MessageID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "SM1", deliveries[0]["provider_message_id"])
+	assert.Equal(t, string(types.DeliveryStatusDelivered), deliveries[0]["status"])
+}
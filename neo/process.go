@@ -11,7 +11,9 @@ import (
 	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/gou/rag/driver"
 	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/kb"
 	"github.com/yaoapp/yao/neo/message"
+	"github.com/yaoapp/yao/neo/rag"
 	"github.com/yaoapp/yao/neo/store"
 )
 
@@ -274,9 +276,62 @@ func assistantMatchRAG(content interface{}, params map[string]interface{}) inter
 		exception.New("get assistants error: %s", 500, err).Throw()
 	}
 
+	// Rerank the assistants with the reranker of the RAG setting, rerank=false disables it
+	reranker := Neo.RAG.Setting().Reranker
+	if reranker != nil && reranker.Provider != "" && params["rerank"] != false {
+		assistants, err := rerankAssistants(ctx, reranker, contentStr, res.Data)
+		if err != nil {
+			exception.New("Failed to rerank assistants: %s", 500, err.Error()).Throw()
+		}
+		return assistants
+	}
+
 	return res.Data
 }
 
+// rerankAssistants orders the assistants by the relevance of their name and description to the content
+func rerankAssistants(ctx context.Context, setting *rag.Reranker, content string, assistants []map[string]interface{}) ([]map[string]interface{}, error) {
+	if len(assistants) == 0 {
+		return assistants, nil
+	}
+
+	reranker, err := kb.MakeReranker(setting.Provider, setting.Option, setting.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, len(assistants))
+	for i, assistant := range assistants {
+		name, _ := assistant["name"].(string)
+		description, _ := assistant["description"].(string)
+		texts[i] = strings.TrimSpace(name + "\n" + description)
+	}
+
+	scores, err := reranker.Rerank(ctx, content, texts, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := make([]map[string]interface{}, 0, len(assistants))
+	seen := make([]bool, len(assistants))
+	for _, score := range scores {
+		if score.Index < 0 || score.Index >= len(assistants) || seen[score.Index] {
+			continue
+		}
+		seen[score.Index] = true
+		assistants[score.Index]["rerank_score"] = score.Score
+		reranked = append(reranked, assistants[score.Index])
+	}
+
+	// The assistants the reranker did not return keep their order
+	for i, assistant := range assistants {
+		if !seen[i] {
+			reranked = append(reranked, assistant)
+		}
+	}
+	return reranked, nil
+}
+
 // parseAssistantFilter parse common filter parameters
 func parseAssistantFilter(params map[string]interface{}) store.AssistantFilter {
 	filter := store.AssistantFilter{}
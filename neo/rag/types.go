@@ -6,6 +6,7 @@ type Setting struct {
 	Vectorizer  Vectorizer `json:"vectorizer" yaml:"vectorizer"`
 	Upload      Upload     `json:"upload" yaml:"upload"`
 	IndexPrefix string     `json:"index_prefix" yaml:"index_prefix"`
+	Reranker    *Reranker  `json:"reranker,omitempty" yaml:"reranker,omitempty"`
 }
 
 // Engine the vector database engine settings
@@ -20,6 +21,13 @@ type Vectorizer struct {
 	Options map[string]interface{} `json:"options" yaml:"options"`
 }
 
+// Reranker the reranker of the matched assistants, a reranker provider of the knowledge base
+type Reranker struct {
+	Provider string `json:"provider" yaml:"provider"`                 // Reranker provider ID, e.g. __yao.openai
+	Option   string `json:"option" yaml:"option"`                     // Option of the provider
+	Locale   string `json:"locale,omitempty" yaml:"locale,omitempty"` // Locale of the provider
+}
+
 // Upload the file upload settings
 type Upload struct {
 	Async        bool     `json:"async" yaml:"async"`
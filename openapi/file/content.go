@@ -0,0 +1,170 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// defaultSignedURLTTL the default lifetime of the signed URLs
+const defaultSignedURLTTL = time.Hour
+
+// maxSignedURLTTL the maximum lifetime of the signed URLs
+const maxSignedURLTTL = 7 * 24 * time.Hour
+
+// signed serves the file content of a signed URL
+func signed(c *gin.Context) {
+	uploaderID := c.Param("uploaderID")
+	fileID, _ := url.QueryUnescape(c.Param("fileID"))
+
+	manager, ok := attachment.Managers[uploaderID]
+	if !ok {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Uploader not found: " + uploaderID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid expires",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	disposition := c.Query("disposition")
+	err = manager.VerifySignature(fileID, expires, disposition, c.Query("signature"))
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "The signed URL is not valid: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return
+	}
+
+	// The content may be cached until the URL expires
+	maxAge := expires - time.Now().Unix()
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	serveContent(c, manager, fileID, disposition)
+}
+
+// signedURL creates a signed URL of the file content
+func signedURL(c *gin.Context) {
+	uploaderID := c.Param("uploaderID")
+	fileID, _ := url.QueryUnescape(c.Param("fileID"))
+
+	manager, ok := attachment.Managers[uploaderID]
+	if !ok {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Uploader not found: " + uploaderID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	// Lifetime in seconds
+	ttl := defaultSignedURLTTL
+	if ttlStr := c.Query("ttl"); ttlStr != "" {
+		seconds, err := strconv.Atoi(ttlStr)
+		if err != nil || seconds <= 0 || time.Duration(seconds)*time.Second > maxSignedURLTTL {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: fmt.Sprintf("ttl must be between 1 and %d seconds", int(maxSignedURLTTL.Seconds())),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+
+	if !manager.Exists(c.Request.Context(), fileID) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "File not found",
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	signedURL, err := manager.SignedURL(fileID, ttl, c.Query("disposition"))
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, gin.H{
+		"url":        signedURL,
+		"file_id":    fileID,
+		"expires_at": time.Now().Add(ttl).Unix(),
+	})
+}
+
+// serveContent serves the file content, or the variant given by the query, with the Range, If-None-Match
+// and If-Modified-Since support
+func serveContent(c *gin.Context, manager *attachment.Manager, fileID string, disposition string) {
+	var content *attachment.FileContent
+	var err error
+	if variant := c.Query("variant"); variant != "" {
+		content, err = manager.OpenVariant(c.Request.Context(), fileID, variant)
+	} else {
+		content, err = manager.Open(c.Request.Context(), fileID)
+	}
+
+	if err != nil {
+		status := response.StatusNotFound
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "File not found: " + err.Error(),
+		}
+
+		switch {
+		case errors.Is(err, attachment.ErrVariantNotFound), errors.Is(err, attachment.ErrVariantNotSupported):
+			status = response.StatusBadRequest
+			errorResp.ErrorDescription = err.Error()
+
+		case errors.Is(err, attachment.ErrFileUnavailable):
+			status = response.StatusForbidden
+			errorResp.Code = response.ErrAccessDenied.Code
+			errorResp.ErrorDescription = err.Error()
+
+		case errors.Is(err, attachment.ErrVariantFailed):
+			status = response.StatusInternalServerError
+			errorResp.Code = response.ErrServerError.Code
+			errorResp.ErrorDescription = err.Error()
+		}
+		response.RespondWithError(c, status, errorResp)
+		return
+	}
+	defer content.Close()
+
+	if disposition == "" {
+		disposition = "inline"
+	}
+
+	// Set headers based on file info, http.ServeContent keeps them
+	c.Header("Content-Type", content.ContentType)
+	c.Header("ETag", content.ETag)
+	if content.Filename != "" {
+		c.Header("Content-Disposition", fmt.Sprintf("%s; filename=\"%s\"", disposition, content.Filename))
+	}
+
+	http.ServeContent(c.Writer, c.Request, content.Filename, content.ModTime, content)
+}
@@ -1,8 +1,7 @@
 package file
 
 import (
-	"fmt"
-	"net/http"
+	"errors"
 	"net/url"
 	"strconv"
 	"strings"
@@ -15,16 +14,31 @@ import (
 
 // Attach attaches the file management handlers to the router
 func Attach(group *gin.RouterGroup, oauth types.OAuth) {
+	// The signed URLs point to this API unless the uploader has a public URL
+	if attachment.SignedURLBase == "" {
+		attachment.SignedURLBase = group.BasePath()
+	}
+
 	// https://api.openai.com/v1/files
+
+	// Signed file content, the signature authenticates the request
+	group.GET("/:uploaderID/:fileID/signed", signed)
+
 	// Protect all endpoints with OAuth
 	group.Use(oauth.Guard)
 
 	// Upload a file (supports chunked upload)
 	group.POST("/:uploaderID", upload)
 
+	// Create a file from a stored content by its SHA-256, skips the upload of the known contents
+	group.POST("/:uploaderID/hash/:hash", uploadByHash)
+
 	// List files
 	group.GET("/:uploaderID", list)
 
+	// Storage usage and quotas
+	group.GET("/:uploaderID/usage", usage)
+
 	// Retrieve file
 	group.GET("/:uploaderID/:fileID", retrieve)
 
@@ -36,6 +50,23 @@ func Attach(group *gin.RouterGroup, oauth types.OAuth) {
 
 	// Check if file exists
 	group.GET("/:uploaderID/:fileID/exists", exists)
+
+	// Create a signed URL of the file content
+	group.GET("/:uploaderID/:fileID/url", signedURL)
+
+	// Review the quarantined files, requires the file:review scope
+	group.POST("/:uploaderID/:fileID/release", release)
+	group.POST("/:uploaderID/:fileID/reject", reject)
+
+	// Resumable uploads (tus.io protocol)
+	group.OPTIONS("/:uploaderID/uploads", tusOptions)
+	group.POST("/:uploaderID/uploads", tusCreate)
+	group.HEAD("/:uploaderID/uploads/:uploadID", tusHead)
+	group.PATCH("/:uploaderID/uploads/:uploadID", tusPatch)
+	group.DELETE("/:uploaderID/uploads/:uploadID", tusDelete)
+
+	// Retrieve an upload session with the received byte ranges
+	group.GET("/:uploaderID/uploads/:uploadID", uploadStatus)
 }
 
 // upload handles file upload
@@ -134,15 +165,27 @@ func upload(c *gin.Context) {
 	uploadOption := attachment.UploadOption{
 		OriginalFilename: originalFilename, // Use original filename from form data
 		Groups:           groups,           // Groups for directory structure
-		ClientID:         c.PostForm("client_id"),
-		OpenID:           c.PostForm("openid"),
-		Gzip:             gzip,          // Gzip compression
-		CompressImage:    compressImage, // Image compression
-		CompressSize:     compressSize,  // Compression size
+		Gzip:             gzip,             // Gzip compression
+		CompressImage:    compressImage,    // Image compression
+		CompressSize:     compressSize,     // Compression size
+	}
+
+	// The client and the user are the ones of the access token
+	if !authorizeOption(c, &uploadOption, c.PostForm("team_id")) {
+		return
 	}
 
 	// Upload the file
 	uploadedFile, err := manager.Upload(c.Request.Context(), header, file, uploadOption)
+	if errors.Is(err, attachment.ErrQuotaExceeded) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "Failed to upload file: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return
+	}
+
 	if err != nil {
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrServerError.Code,
@@ -392,37 +435,8 @@ func content(c *gin.Context) {
 		return
 	}
 
-	// Get file info first to obtain metadata
-	fileInfo, err := manager.Info(c.Request.Context(), fileID)
-	if err != nil {
-		errorResp := &response.ErrorResponse{
-			Code:             response.ErrInvalidRequest.Code,
-			ErrorDescription: "File not found: " + err.Error(),
-		}
-		response.RespondWithError(c, response.StatusNotFound, errorResp)
-		return
-	}
-
-	// Read the file content
-	content, err := manager.Read(c.Request.Context(), fileID)
-	if err != nil {
-		errorResp := &response.ErrorResponse{
-			Code:             response.ErrServerError.Code,
-			ErrorDescription: "Failed to read file: " + err.Error(),
-		}
-		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
-		return
-	}
-
-	// Set headers based on file info
-	c.Header("Content-Type", fileInfo.ContentType)
-	if fileInfo.Filename != "" {
-		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileInfo.Filename))
-	}
-	c.Header("Content-Length", fmt.Sprintf("%d", len(content)))
-
-	// Return file content directly
-	c.Data(http.StatusOK, fileInfo.ContentType, content)
+	// Serve the file content, supports Range and conditional requests
+	serveContent(c, manager, fileID, "attachment")
 }
 
 // exists checks if a file exists
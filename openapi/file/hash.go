@@ -0,0 +1,89 @@
+package file
+
+import (
+	"errors"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// uploadByHash creates a file from a content the user or the team of the token already stores, the client sends
+// the SHA-256 of the content instead of the content. 404 is returned when the content is not stored, the client uploads it.
+func uploadByHash(c *gin.Context) {
+	uploaderID := c.Param("uploaderID")
+
+	manager, ok := attachment.Managers[uploaderID]
+	if !ok {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Uploader not found: " + uploaderID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	// The fields are the ones of the upload form, the filename replaces the file
+	originalFilename := c.PostForm("original_filename")
+	filename := c.PostForm("filename")
+	if filename == "" {
+		filename = filepath.Base(originalFilename)
+	}
+
+	if filename == "" || filename == "." {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Filename is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// The client and the user are the ones of the access token
+	option := attachment.UploadOption{OriginalFilename: originalFilename}
+	if !authorizeOption(c, &option, c.PostForm("team_id")) {
+		return
+	}
+
+	if groups := c.PostForm("groups"); groups != "" {
+		for _, group := range strings.Split(groups, ",") {
+			option.Groups = append(option.Groups, strings.TrimSpace(group))
+		}
+	}
+
+	header := attachment.GetHeader(c.Request.Header, textproto.MIMEHeader{}, 0)
+	header.Filename = filename
+
+	file, err := manager.UploadByHash(c.Request.Context(), c.Param("hash"), header, option)
+	if errors.Is(err, attachment.ErrBlobNotFound) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Content not found, upload the file: " + c.Param("hash"),
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	if errors.Is(err, attachment.ErrQuotaExceeded) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "Failed to upload file: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return
+	}
+
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to upload file: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, file)
+}
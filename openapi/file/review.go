@@ -0,0 +1,103 @@
+package file
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/openapi/oauth"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// ReviewScope the OAuth scope required to release or reject the quarantined files
+const ReviewScope = "file:review"
+
+// release makes a quarantined file available
+func release(c *gin.Context) {
+	review(c, "released", func(manager *attachment.Manager, fileID string, reviewer string) (*attachment.File, error) {
+		return manager.Release(c.Request.Context(), fileID, reviewer)
+	})
+}
+
+// reject rejects a quarantined file and removes its content
+func reject(c *gin.Context) {
+	review(c, "rejected", func(manager *attachment.Manager, fileID string, reviewer string) (*attachment.File, error) {
+		return manager.Reject(c.Request.Context(), fileID, reviewer)
+	})
+}
+
+// review checks the reviewer and the file, then applies the decision to the quarantined file
+func review(c *gin.Context, decision string, apply func(manager *attachment.Manager, fileID string, reviewer string) (*attachment.File, error)) {
+	uploaderID := c.Param("uploaderID")
+	fileID, _ := url.QueryUnescape(c.Param("fileID"))
+
+	authInfo := oauth.GetAuthorizedInfo(c)
+	if !hasScope(authInfo.Scope, ReviewScope) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "The " + ReviewScope + " scope is required to review the quarantined files",
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return
+	}
+
+	manager, ok := attachment.Managers[uploaderID]
+	if !ok {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Uploader not found: " + uploaderID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	if _, err := manager.Info(c.Request.Context(), fileID); fileID == "" || err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "File not found",
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	reviewer := authInfo.Subject
+	if reviewer == "" {
+		reviewer = authInfo.ClientID
+	}
+
+	file, err := apply(manager, fileID, reviewer)
+	if errors.Is(err, attachment.ErrNotQuarantined) {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusConflict, errorResp)
+		return
+	}
+
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to review the file: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, gin.H{
+		"message": "File " + decision,
+		"file":    file,
+	})
+}
+
+// hasScope checks if a granted scope list contains a scope
+func hasScope(granted string, scope string) bool {
+	for _, s := range strings.Fields(granted) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,345 @@
+package file
+
+import (
+	"encoding/base64"
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/openapi/oauth"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// The resumable uploads speak the tus.io protocol https://tus.io/protocols/resumable-upload
+const (
+	tusVersion     = "1.0.0"
+	tusExtensions  = "creation,creation-with-upload,termination,expiration"
+	tusContentType = "application/offset+octet-stream"
+)
+
+// tusOptions handles the tus capabilities discovery
+func tusOptions(c *gin.Context) {
+	manager, ok := tusUploader(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Tus-Version", tusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	if maxsize := manager.MaxSize(); maxsize > 0 {
+		c.Header("Tus-Max-Size", strconv.FormatInt(maxsize, 10))
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// tusCreate creates an upload session, the first bytes may be sent with the request (creation-with-upload)
+func tusCreate(c *gin.Context) {
+	manager, ok := tusUploader(c)
+	if !ok || !tusResumable(c) {
+		return
+	}
+
+	if c.GetHeader("Upload-Defer-Length") != "" {
+		tusError(c, http.StatusBadRequest, "Upload-Defer-Length is not supported")
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		tusError(c, http.StatusBadRequest, "Upload-Length is required")
+		return
+	}
+
+	metadata, err := tusParseMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		tusError(c, http.StatusBadRequest, "Invalid Upload-Metadata: "+err.Error())
+		return
+	}
+
+	// The file name and type are sent by the tus clients as metadata
+	filename := tusMetadata(metadata, "filename", "name")
+	if filename == "" {
+		tusError(c, http.StatusBadRequest, "The filename metadata is required")
+		return
+	}
+
+	contentType := tusMetadata(metadata, "filetype", "type", "content_type")
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := &attachment.FileHeader{FileHeader: &multipart.FileHeader{Filename: filename, Header: textproto.MIMEHeader{}}}
+	header.Header.Set("Content-Type", contentType)
+
+	// The client and the user are the ones of the access token
+	option := tusUploadOption(metadata, filename)
+	if !authorizeOption(c, &option, metadata["team_id"]) {
+		return
+	}
+
+	session, err := manager.CreateUpload(c.Request.Context(), oauth.GetAuthorizedInfo(c).Subject, header, length, metadata, option)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, attachment.ErrUploadTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		} else if errors.Is(err, attachment.ErrQuotaExceeded) {
+			status = http.StatusForbidden
+		}
+		tusError(c, status, "Failed to create upload: "+err.Error())
+		return
+	}
+
+	// Creation with upload
+	if c.ContentType() == tusContentType && c.Request.ContentLength != 0 {
+		written, err := manager.WriteUpload(c.Request.Context(), session.Subject, session.ID, 0, c.Request.Body)
+		if err != nil && !errors.Is(err, attachment.ErrUploadInterrupted) {
+			tusWriteError(c, err)
+			return
+		}
+
+		// The session is created with the bytes received before a broken connection
+		session = written
+		c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+session.ID)
+	tusExpires(c, session)
+	response.RespondWithSuccess(c, response.StatusCreated, session)
+}
+
+// tusHead returns the offset of an upload session, the client resumes the upload from it
+func tusHead(c *gin.Context) {
+	manager, ok := tusUploader(c)
+	if !ok || !tusResumable(c) {
+		return
+	}
+
+	session, err := manager.GetUpload(c.Request.Context(), oauth.GetAuthorizedInfo(c).Subject, c.Param("uploadID"))
+	if err != nil {
+		tusWriteError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.Length, 10))
+	if len(session.Metadata) > 0 {
+		c.Header("Upload-Metadata", tusFormatMetadata(session.Metadata))
+	}
+	tusExpires(c, session)
+	c.Status(http.StatusOK)
+}
+
+// tusPatch appends the request body at the offset of an upload session
+func tusPatch(c *gin.Context) {
+	manager, ok := tusUploader(c)
+	if !ok || !tusResumable(c) {
+		return
+	}
+
+	if c.ContentType() != tusContentType {
+		tusError(c, http.StatusUnsupportedMediaType, "Content-Type must be "+tusContentType)
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		tusError(c, http.StatusBadRequest, "Upload-Offset is required")
+		return
+	}
+
+	// The bytes received before a broken connection are kept, the client resumes from the new offset
+	session, err := manager.WriteUpload(c.Request.Context(), oauth.GetAuthorizedInfo(c).Subject, c.Param("uploadID"), offset, c.Request.Body)
+	if err != nil {
+		if session != nil {
+			c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+		}
+		tusWriteError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	tusExpires(c, session)
+	c.Status(http.StatusNoContent)
+}
+
+// tusDelete aborts an upload session and removes the received chunks (termination)
+func tusDelete(c *gin.Context) {
+	manager, ok := tusUploader(c)
+	if !ok || !tusResumable(c) {
+		return
+	}
+
+	err := manager.AbortUpload(c.Request.Context(), oauth.GetAuthorizedInfo(c).Subject, c.Param("uploadID"))
+	if err != nil {
+		tusWriteError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// uploadStatus returns an upload session with the byte ranges received so far
+func uploadStatus(c *gin.Context) {
+	manager, ok := tusUploader(c)
+	if !ok {
+		return
+	}
+
+	session, err := manager.GetUpload(c.Request.Context(), oauth.GetAuthorizedInfo(c).Subject, c.Param("uploadID"))
+	if err != nil {
+		tusWriteError(c, err)
+		return
+	}
+	response.RespondWithSuccess(c, response.StatusOK, session)
+}
+
+// tusUploader returns the attachment manager of the request and sets the Tus-Resumable header
+func tusUploader(c *gin.Context) (*attachment.Manager, bool) {
+	c.Header("Tus-Resumable", tusVersion)
+
+	uploaderID := c.Param("uploaderID")
+	manager, exists := attachment.Managers[uploaderID]
+	if !exists {
+		tusError(c, http.StatusNotFound, "Uploader not found: "+uploaderID)
+		return nil, false
+	}
+	return manager, true
+}
+
+// tusResumable checks the client speaks the supported version of the protocol
+func tusResumable(c *gin.Context) bool {
+	if c.GetHeader("Tus-Resumable") != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		tusError(c, http.StatusPreconditionFailed, "Tus-Resumable "+tusVersion+" is required")
+		return false
+	}
+	return true
+}
+
+// tusExpires sets the expiration of an unfinished upload session
+func tusExpires(c *gin.Context, session *attachment.UploadSession) {
+	if session.Status == "completed" {
+		return
+	}
+	c.Header("Upload-Expires", time.Unix(session.ExpiresAt, 0).UTC().Format(http.TimeFormat))
+}
+
+// tusWriteError responds with the status of an upload session error
+func tusWriteError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, attachment.ErrUploadNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, attachment.ErrUploadOffset):
+		status = http.StatusConflict
+	case errors.Is(err, attachment.ErrUploadLocked):
+		status = http.StatusLocked
+	case errors.Is(err, attachment.ErrUploadTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, attachment.ErrUploadInterrupted):
+		status = http.StatusBadRequest
+	}
+	tusError(c, status, err.Error())
+}
+
+// tusError responds with an error
+func tusError(c *gin.Context, status int, description string) {
+	errorResp := &response.ErrorResponse{
+		Code:             response.ErrInvalidRequest.Code,
+		ErrorDescription: description,
+	}
+	if status >= http.StatusInternalServerError {
+		errorResp.Code = response.ErrServerError.Code
+	}
+	response.RespondWithError(c, status, errorResp)
+}
+
+// tusUploadOption returns the upload options sent as metadata, they are named as the form fields of the regular upload.
+// The client, the user and the team are set by authorizeOption.
+func tusUploadOption(metadata map[string]string, filename string) attachment.UploadOption {
+	option := attachment.UploadOption{
+		OriginalFilename: filename,
+		Gzip:             metadata["gzip"] == "true",
+		CompressImage:    metadata["compress_image"] == "true",
+	}
+
+	if path := metadata["path"]; path != "" {
+		option.OriginalFilename = path
+	}
+
+	if groups := metadata["groups"]; groups != "" {
+		for _, group := range strings.Split(groups, ",") {
+			option.Groups = append(option.Groups, strings.TrimSpace(group))
+		}
+	}
+
+	if size, err := strconv.Atoi(metadata["compress_size"]); err == nil && size > 0 {
+		option.CompressSize = size
+	}
+	return option
+}
+
+// tusMetadata returns the first metadata value found
+func tusMetadata(metadata map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := metadata[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// tusParseMetadata parses the Upload-Metadata header, comma separated keys with base64 encoded values
+func tusParseMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, " ", 2)
+		if len(parts) == 1 {
+			metadata[parts[0]] = ""
+			continue
+		}
+
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.New("the value of " + parts[0] + " is not base64 encoded")
+		}
+		metadata[parts[0]] = string(value)
+	}
+	return metadata, nil
+}
+
+// tusFormatMetadata formats the metadata as an Upload-Metadata header
+func tusFormatMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if metadata[key] == "" {
+			pairs = append(pairs, key)
+			continue
+		}
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(metadata[key])))
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,125 @@
+package file
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/openapi/oauth"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// usage returns the storage consumption and the quotas of a client, user, team or group
+func usage(c *gin.Context) {
+	uploaderID := c.Param("uploaderID")
+
+	manager, ok := attachment.Managers[uploaderID]
+	if !ok {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Uploader not found: " + uploaderID,
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	// The client and the user are the ones of the access token, the groups are given as the upload form field
+	option := attachment.UploadOption{}
+	if !authorizeOption(c, &option, c.Query("team_id")) {
+		return
+	}
+
+	if groups := c.Query("groups"); groups != "" {
+		for _, group := range strings.Split(groups, ",") {
+			option.Groups = append(option.Groups, strings.TrimSpace(group))
+		}
+	}
+
+	// The usage of a group is reported to the callers storing files in it
+	owns, err := manager.OwnsGroup(c.Request.Context(), option)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to check the groups: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	if !owns {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "No file of the caller in the groups " + strings.Join(option.Groups, ","),
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return
+	}
+
+	usages, err := manager.Usage(c.Request.Context(), option)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to get the storage usage: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, gin.H{
+		"uploader": uploaderID,
+		"usage":    usages,
+	})
+}
+
+// authorizeOption sets the client, the user and the team the quotas count the files for. The client and the
+// user are read from the access token of the request, the team sent by the client is accepted when the user
+// is one of its members. The request is answered with 403 and false is returned otherwise.
+func authorizeOption(c *gin.Context, option *attachment.UploadOption, teamID string) bool {
+	authInfo := oauth.GetAuthorizedInfo(c)
+	option.ClientID = authInfo.ClientID
+	option.OpenID = authInfo.Subject
+	option.TeamID = ""
+	if teamID == "" {
+		return true
+	}
+
+	isMember, err := isTeamMember(c, teamID, authInfo.UserID)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to check the team membership: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return false
+	}
+
+	if !isMember {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrAccessDenied.Code,
+			ErrorDescription: "Not a member of the team " + teamID,
+		}
+		response.RespondWithError(c, response.StatusForbidden, errorResp)
+		return false
+	}
+
+	option.TeamID = teamID
+	return true
+}
+
+// isTeamMember checks the user is a member of the team
+func isTeamMember(c *gin.Context, teamID string, userID string) (bool, error) {
+	if userID == "" {
+		return false, nil
+	}
+
+	if oauth.OAuth == nil {
+		return false, fmt.Errorf("OAuth service not initialized")
+	}
+
+	provider, err := oauth.OAuth.GetUserProvider()
+	if err != nil {
+		return false, err
+	}
+	return provider.IsTeamMember(c.Request.Context(), teamID, userID)
+}
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -144,6 +145,61 @@ func StopExecution(c *gin.Context) {
 	})
 }
 
+// ListDeadExecutions lists the executions whose retries are exhausted, filtered by the optional job_id query
+func ListDeadExecutions(c *gin.Context) {
+	page := 1
+	pagesize := 50
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	if ps := c.Query("pagesize"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 1000 {
+			pagesize = parsed
+		}
+	}
+
+	result, err := job.ListDeadExecutions(c.Query("job_id"), page, pagesize)
+	if err != nil {
+		log.Error("Failed to list dead executions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RequeueExecution queues a dead or failed execution again
+func RequeueExecution(c *gin.Context) {
+	executionID := c.Param("executionID")
+	if executionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "execution_id is required"})
+		return
+	}
+
+	execution, err := job.RequeueExecution(executionID)
+	if err != nil {
+		log.Error("Failed to requeue execution %s: %v", executionID, err)
+		switch {
+		case err.Error() == "execution not found: "+executionID:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Execution not found"})
+		case errors.Is(err, job.ErrExecutionStatus):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Execution requeued successfully",
+		"execution_id": executionID,
+		"job_id":       execution.JobID,
+		"status":       execution.Status,
+	})
+}
+
 // GetExecutionProgress gets execution progress information
 func GetExecutionProgress(c *gin.Context) {
 	executionID := c.Param("executionID")
@@ -331,3 +387,57 @@ func ProcessStopExecution(process *process.Process) interface{} {
 		"job_id":       execution.JobID,
 	}
 }
+
+// ProcessListDeadExecutions process handler for listing the dead executions
+// Args: [job_id, page, pagesize], all optional
+func ProcessListDeadExecutions(process *process.Process) interface{} {
+	args := process.Args
+	jobID := ""
+	if len(args) > 0 {
+		if id, ok := args[0].(string); ok {
+			jobID = id
+		}
+	}
+
+	page := 1
+	pagesize := 50
+	if len(args) > 1 {
+		page = process.ArgsInt(1, 1)
+	}
+	if len(args) > 2 {
+		pagesize = process.ArgsInt(2, 50)
+	}
+
+	result, err := job.ListDeadExecutions(jobID, page, pagesize)
+	if err != nil {
+		log.Error("Failed to list dead executions: %v", err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	return result
+}
+
+// ProcessRequeueExecution process handler for requeueing a dead or failed execution
+func ProcessRequeueExecution(process *process.Process) interface{} {
+	args := process.Args
+	if len(args) == 0 {
+		return map[string]interface{}{"error": "execution_id is required"}
+	}
+
+	executionID, ok := args[0].(string)
+	if !ok {
+		return map[string]interface{}{"error": "execution_id must be a string"}
+	}
+
+	execution, err := job.RequeueExecution(executionID)
+	if err != nil {
+		log.Error("Failed to requeue execution %s: %v", executionID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	return map[string]interface{}{
+		"message":      "Execution requeued successfully",
+		"execution_id": executionID,
+		"job_id":       execution.JobID,
+	}
+}
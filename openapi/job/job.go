@@ -9,18 +9,29 @@ import (
 func init() {
 	// Register job process handlers
 	process.RegisterGroup("job", map[string]process.Handler{
-		"jobs.list":        ProcessListJobs,
-		"jobs.get":         ProcessGetJob,
-		"jobs.count":       ProcessCountJobs,
-		"jobs.stop":        ProcessStopJob,
-		"executions.list":  ProcessListExecutions,
-		"executions.get":   ProcessGetExecution,
-		"executions.count": ProcessCountExecutions,
-		"executions.stop":  ProcessStopExecution,
-		"logs.list":        ProcessListLogs,
-		"categories.list":  ProcessListCategories,
-		"categories.get":   ProcessGetCategory,
-		"categories.count": ProcessCountCategories,
+		"jobs.list":          ProcessListJobs,
+		"jobs.get":           ProcessGetJob,
+		"jobs.graph":         ProcessGetJobGraph,
+		"jobs.count":         ProcessCountJobs,
+		"jobs.stop":          ProcessStopJob,
+		"jobs.create":        ProcessCreateJob,
+		"jobs.schedule":      ProcessUpdateJobSchedule,
+		"jobs.pause":         ProcessPauseJob,
+		"jobs.resume":        ProcessResumeJob,
+		"jobs.run":           ProcessRunJob,
+		"jobs.delete":        ProcessDeleteJob,
+		"jobs.metrics":       ProcessGetJobMetrics,
+		"metrics":            ProcessGetMetrics,
+		"executions.list":    ProcessListExecutions,
+		"executions.get":     ProcessGetExecution,
+		"executions.count":   ProcessCountExecutions,
+		"executions.stop":    ProcessStopExecution,
+		"executions.dead":    ProcessListDeadExecutions,
+		"executions.requeue": ProcessRequeueExecution,
+		"logs.list":          ProcessListLogs,
+		"categories.list":    ProcessListCategories,
+		"categories.get":     ProcessGetCategory,
+		"categories.count":   ProcessCountCategories,
 	})
 }
 
@@ -29,20 +40,35 @@ func Attach(group *gin.RouterGroup, oauth types.OAuth) {
 	// Protect all endpoints with OAuth
 	group.Use(oauth.Guard)
 
-	// Job Management (Read-only operations)
+	// Job Management
 	group.GET("/jobs", ListJobs)
+	group.POST("/jobs", CreateJob)
 	group.GET("/jobs/:jobID", GetJob)
+	group.DELETE("/jobs/:jobID", DeleteJob)
+	group.GET("/jobs/:jobID/graph", GetJobGraph)
+	group.PUT("/jobs/:jobID/schedule", UpdateJobSchedule)
 	group.POST("/jobs/:jobID/stop", StopJob)
+	group.POST("/jobs/:jobID/pause", PauseJob)
+	group.POST("/jobs/:jobID/resume", ResumeJob)
+	group.POST("/jobs/:jobID/run", RunJob)
 
 	// Execution Management
 	group.GET("/jobs/:jobID/executions", ListExecutions)
+	group.GET("/executions/dead", ListDeadExecutions)
 	group.GET("/executions/:executionID", GetExecution)
 	group.POST("/executions/:executionID/stop", StopExecution)
+	group.POST("/executions/:executionID/requeue", RequeueExecution)
 
 	// Log Management
 	group.GET("/jobs/:jobID/logs", ListLogs)
 	group.GET("/executions/:executionID/logs", ListExecutionLogs)
 
+	// Live Streams (SSE, WebSocket)
+	group.GET("/jobs/:jobID/stream", StreamJob)
+	group.GET("/jobs/:jobID/ws", StreamJobWebSocket)
+	group.GET("/executions/:executionID/stream", StreamExecution)
+	group.GET("/executions/:executionID/ws", StreamExecutionWebSocket)
+
 	// Category Management (Read-only)
 	group.GET("/categories", ListCategories)
 	group.GET("/categories/:categoryID", GetCategory)
@@ -51,6 +77,8 @@ func Attach(group *gin.RouterGroup, oauth types.OAuth) {
 	group.GET("/jobs/:jobID/progress", GetJobProgress)
 	group.GET("/executions/:executionID/progress", GetExecutionProgress)
 
-	// Statistics
+	// Statistics and Metrics (Prometheus)
 	group.GET("/stats", GetStats)
+	group.GET("/metrics", GetMetrics)
+	group.GET("/jobs/:jobID/metrics", GetJobMetrics)
 }
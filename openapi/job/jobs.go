@@ -1,14 +1,19 @@
 package job
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/openapi/oauth"
 )
 
 // ListJobs lists jobs with pagination
@@ -121,6 +126,29 @@ func GetJob(c *gin.Context) {
 	c.JSON(http.StatusOK, jobInstance)
 }
 
+// GetJobGraph gets the dependency graph of a job
+func GetJobGraph(c *gin.Context) {
+	jobID := c.Param("jobID")
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job_id is required"})
+		return
+	}
+
+	// Call job.GetGraph function
+	graph, err := job.GetGraph(jobID)
+	if err != nil {
+		log.Error("Failed to get job graph %s: %v", jobID, err)
+		if err.Error() == "job not found: "+jobID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, graph)
+}
+
 // StopJob stops a running job
 func StopJob(c *gin.Context) {
 	jobID := c.Param("jobID")
@@ -298,6 +326,348 @@ func GetStats(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// CreateJobRequest the request body of CreateJob
+type CreateJobRequest struct {
+	Name               string                 `json:"name"`
+	Description        string                 `json:"description,omitempty"`
+	CategoryID         string                 `json:"category_id,omitempty"`
+	CategoryName       string                 `json:"category_name,omitempty"`
+	Mode               job.ModeType           `json:"mode,omitempty"`                // goroutine (default) or process
+	ScheduleType       string                 `json:"schedule_type,omitempty"`       // once (default), cron or daemon
+	ScheduleExpression string                 `json:"schedule_expression,omitempty"` // Required for cron jobs
+	Priority           int                    `json:"priority,omitempty"`
+	MaxRetryCount      int                    `json:"max_retry_count,omitempty"`
+	DefaultTimeout     int                    `json:"default_timeout,omitempty"` // In seconds
+	RetryPolicy        *job.RetryPolicy       `json:"retry_policy,omitempty"`
+	SLA                *job.SLA               `json:"sla,omitempty"` // Alerts on long runs and repeated failures
+	Config             map[string]interface{} `json:"config,omitempty"`
+	Executions         []ExecutionRequest     `json:"executions"`
+	Run                bool                   `json:"run,omitempty"` // Runs the job once created
+}
+
+// CommandScope the OAuth scope required to create command executions
+const CommandScope = "job:command"
+
+// ExecutionRequest an execution of CreateJobRequest
+type ExecutionRequest struct {
+	Type        job.ExecutionType     `json:"type,omitempty"` // process (default) or command
+	Process     string                `json:"process,omitempty"`
+	Args        []interface{}         `json:"args,omitempty"`
+	Command     string                `json:"command,omitempty"`
+	CommandArgs []string              `json:"command_args,omitempty"`
+	Environment map[string]string     `json:"environment,omitempty"`
+	Options     *job.ExecutionOptions `json:"options,omitempty"`
+}
+
+// ScheduleRequest the request body of UpdateJobSchedule
+type ScheduleRequest struct {
+	ScheduleExpression string `json:"schedule_expression"`
+}
+
+// CreateJob creates a job with its executions
+func CreateJob(c *gin.Context) {
+	var req CreateJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Command executions run system commands on the server, only the tokens granted the command scope add them
+	if req.hasCommands() && !hasScope(oauth.GetAuthorizedInfo(c).Scope, CommandScope) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "The " + CommandScope + " scope is required to add command executions"})
+		return
+	}
+
+	jobInstance, err := createJob(&req)
+	if err != nil {
+		log.Error("Failed to create job: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, jobInstance)
+}
+
+// UpdateJobSchedule updates the cron expression of a job and returns the next run time
+func UpdateJobSchedule(c *gin.Context) {
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ScheduleExpression == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "schedule_expression is required"})
+		return
+	}
+
+	jobInstance, ok := findJob(c)
+	if !ok {
+		return
+	}
+
+	if err := jobInstance.SetSchedule(req.ScheduleExpression); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := job.SaveJob(jobInstance); err != nil {
+		log.Error("Failed to save job %s: %v", jobInstance.JobID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job_id":              jobInstance.JobID,
+		"schedule_type":       jobInstance.ScheduleType,
+		"schedule_expression": jobInstance.ScheduleExpression,
+		"next_run_at":         jobInstance.NextRunAt,
+		"status":              jobInstance.Status,
+	})
+}
+
+// PauseJob pauses a job, the running executions finish
+func PauseJob(c *gin.Context) {
+	jobAction(c, "paused", func(jobInstance *job.Job) error { return jobInstance.Pause() })
+}
+
+// ResumeJob resumes a paused or stopped job
+func ResumeJob(c *gin.Context) {
+	jobAction(c, "resumed", func(jobInstance *job.Job) error { return jobInstance.Resume() })
+}
+
+// RunJob runs a job now, outside of its schedule
+func RunJob(c *gin.Context) {
+	jobAction(c, "triggered", func(jobInstance *job.Job) error { return jobInstance.Trigger() })
+}
+
+// DeleteJob stops and deletes a job
+func DeleteJob(c *gin.Context) {
+	jobInstance, ok := findJob(c)
+	if !ok {
+		return
+	}
+
+	if err := deleteJob(jobInstance); err != nil {
+		log.Error("Failed to delete job %s: %v", jobInstance.JobID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job deleted successfully", "job_id": jobInstance.JobID})
+}
+
+// findJob gets the job of the request, it writes the error response when the job is not found
+func findJob(c *gin.Context) (*job.Job, bool) {
+	jobID := c.Param("jobID")
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job_id is required"})
+		return nil, false
+	}
+
+	jobInstance, err := job.GetJob(jobID)
+	if err != nil {
+		log.Error("Failed to get job %s: %v", jobID, err)
+		if err.Error() == "job not found: "+jobID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return nil, false
+	}
+
+	return jobInstance, true
+}
+
+// jobAction runs an action on the job of the request, the state conflicts return 409
+func jobAction(c *gin.Context, done string, action func(*job.Job) error) {
+	jobInstance, ok := findJob(c)
+	if !ok {
+		return
+	}
+
+	if err := action(jobInstance); err != nil {
+		log.Error("Failed to update job %s: %v", jobInstance.JobID, err)
+		if isConflict(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Job " + done + " successfully",
+		"job_id":      jobInstance.JobID,
+		"status":      jobInstance.Status,
+		"next_run_at": jobInstance.NextRunAt,
+	})
+}
+
+// isConflict checks if the error is caused by the status of the job
+func isConflict(err error) bool {
+	return errors.Is(err, job.ErrJobStatus) || errors.Is(err, job.ErrNoExecutions)
+}
+
+// hasCommands checks if the request adds command executions
+func (req *CreateJobRequest) hasCommands() bool {
+	for _, execution := range req.Executions {
+		if execution.Type == job.ExecutionTypeCommand {
+			return true
+		}
+	}
+	return false
+}
+
+// hasScope checks if the granted scope string contains the scope
+func hasScope(granted string, scope string) bool {
+	for _, s := range strings.Fields(granted) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the request before the job is created
+func (req *CreateJobRequest) validate() error {
+	if req.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch req.Mode {
+	case "":
+		req.Mode = job.GOROUTINE
+	case job.GOROUTINE, job.PROCESS:
+	default:
+		return fmt.Errorf("invalid mode %q", req.Mode)
+	}
+
+	switch req.ScheduleType {
+	case "":
+		req.ScheduleType = string(job.ScheduleTypeOnce)
+	case string(job.ScheduleTypeOnce), string(job.ScheduleTypeDaemon):
+	case string(job.ScheduleTypeCron):
+		if req.ScheduleExpression == "" {
+			return fmt.Errorf("schedule_expression is required for cron jobs")
+		}
+		if _, err := job.ParseSchedule(req.ScheduleExpression); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid schedule_type %q", req.ScheduleType)
+	}
+
+	for i, execution := range req.Executions {
+		switch execution.Type {
+		case "", job.ExecutionTypeProcess:
+			if execution.Process == "" {
+				return fmt.Errorf("executions[%d]: process is required", i)
+			}
+		case job.ExecutionTypeCommand:
+			if execution.Command == "" {
+				return fmt.Errorf("executions[%d]: command is required", i)
+			}
+		default:
+			return fmt.Errorf("executions[%d]: invalid type %q", i, execution.Type)
+		}
+	}
+
+	if req.SLA != nil {
+		if err := req.SLA.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if req.Run && len(req.Executions) == 0 {
+		return fmt.Errorf("executions are required to run the job")
+	}
+	return nil
+}
+
+// createJob creates and saves a validated job with its executions
+func createJob(req *CreateJobRequest) (*job.Job, error) {
+	data := map[string]interface{}{
+		"name":            req.Name,
+		"priority":        req.Priority,
+		"max_retry_count": req.MaxRetryCount,
+	}
+	if req.Description != "" {
+		data["description"] = req.Description
+	}
+	if req.CategoryID != "" {
+		data["category_id"] = req.CategoryID
+	}
+	if req.CategoryName != "" {
+		data["category_name"] = req.CategoryName
+	}
+	if req.Config != nil {
+		data["config"] = req.Config
+	}
+
+	var jobInstance *job.Job
+	var err error
+	switch req.ScheduleType {
+	case string(job.ScheduleTypeCron):
+		jobInstance, err = job.Cron(req.Mode, data, req.ScheduleExpression)
+	case string(job.ScheduleTypeDaemon):
+		jobInstance, err = job.Daemon(req.Mode, data)
+	default:
+		jobInstance, err = job.Once(req.Mode, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DefaultTimeout > 0 {
+		jobInstance.SetDefaultTimeout(req.DefaultTimeout)
+	}
+	if req.RetryPolicy != nil {
+		jobInstance.SetRetryPolicy(req.RetryPolicy)
+	}
+	if req.SLA != nil {
+		jobInstance.SetSLA(req.SLA)
+	}
+	if err := job.SaveJob(jobInstance); err != nil {
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	for i, execution := range req.Executions {
+		if execution.Type == job.ExecutionTypeCommand {
+			err = jobInstance.AddCommand(execution.Options, execution.Command, execution.CommandArgs, execution.Environment)
+		} else {
+			err = jobInstance.Add(execution.Options, execution.Process, execution.Args...)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("executions[%d]: %w", i, err)
+		}
+	}
+
+	// The scheduler runs the ready cron jobs
+	if len(req.Executions) > 0 {
+		jobInstance.SetStatus("ready")
+		if err := job.SaveJob(jobInstance); err != nil {
+			return nil, fmt.Errorf("failed to save job: %w", err)
+		}
+	}
+
+	if req.Run {
+		if err := jobInstance.Trigger(); err != nil {
+			return nil, fmt.Errorf("failed to run job: %w", err)
+		}
+	}
+
+	return jobInstance, nil
+}
+
+// deleteJob stops the running executions of a job and deletes it
+func deleteJob(jobInstance *job.Job) error {
+	if err := jobInstance.Stop(); err != nil {
+		log.Warn("Failed to stop job %s before deleting it: %v", jobInstance.JobID, err)
+	}
+	return job.RemoveJobs([]string{jobInstance.JobID})
+}
+
 // ========================
 // Process Handlers
 // ========================
@@ -367,6 +737,28 @@ func ProcessGetJob(process *process.Process) interface{} {
 	return result
 }
 
+// ProcessGetJobGraph process handler for getting the dependency graph of a job
+func ProcessGetJobGraph(process *process.Process) interface{} {
+	args := process.Args
+	if len(args) == 0 {
+		return map[string]interface{}{"error": "job_id is required"}
+	}
+
+	jobID, ok := args[0].(string)
+	if !ok {
+		return map[string]interface{}{"error": "job_id must be a string"}
+	}
+
+	// Call job.GetGraph function
+	graph, err := job.GetGraph(jobID)
+	if err != nil {
+		log.Error("Failed to get job graph %s: %v", jobID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	return graph
+}
+
 // ProcessCountJobs process handler for counting jobs
 func ProcessCountJobs(process *process.Process) interface{} {
 	// TODO: Implement process handler for counting jobs
@@ -422,3 +814,111 @@ func ProcessStopJob(process *process.Process) interface{} {
 
 	return map[string]interface{}{"message": "Job stopped successfully", "job_id": jobID}
 }
+
+// ProcessCreateJob process handler for creating a job
+func ProcessCreateJob(process *process.Process) interface{} {
+	args := process.Args
+	if len(args) == 0 {
+		return map[string]interface{}{"error": "job data is required"}
+	}
+
+	raw, err := jsoniter.Marshal(args[0])
+	if err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	var req CreateJobRequest
+	if err := jsoniter.Unmarshal(raw, &req); err != nil {
+		return map[string]interface{}{"error": "invalid job data: " + err.Error()}
+	}
+	if err := req.validate(); err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	jobInstance, err := createJob(&req)
+	if err != nil {
+		log.Error("Failed to create job: %v", err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	return jobInstance
+}
+
+// ProcessUpdateJobSchedule process handler for updating the cron expression of a job
+func ProcessUpdateJobSchedule(process *process.Process) interface{} {
+	args := process.Args
+	if len(args) < 2 {
+		return map[string]interface{}{"error": "job_id and schedule_expression are required"}
+	}
+
+	jobID, ok := args[0].(string)
+	if !ok {
+		return map[string]interface{}{"error": "job_id must be a string"}
+	}
+	expression, ok := args[1].(string)
+	if !ok {
+		return map[string]interface{}{"error": "schedule_expression must be a string"}
+	}
+
+	jobInstance, err := job.GetJob(jobID)
+	if err != nil {
+		log.Error("Failed to get job %s: %v", jobID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	if err := jobInstance.SetSchedule(expression); err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+	if err := job.SaveJob(jobInstance); err != nil {
+		log.Error("Failed to save job %s: %v", jobID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	return map[string]interface{}{"job_id": jobID, "schedule_expression": expression, "next_run_at": jobInstance.NextRunAt}
+}
+
+// ProcessPauseJob process handler for pausing a job
+func ProcessPauseJob(process *process.Process) interface{} {
+	return processJobAction(process, "paused", func(jobInstance *job.Job) error { return jobInstance.Pause() })
+}
+
+// ProcessResumeJob process handler for resuming a job
+func ProcessResumeJob(process *process.Process) interface{} {
+	return processJobAction(process, "resumed", func(jobInstance *job.Job) error { return jobInstance.Resume() })
+}
+
+// ProcessRunJob process handler for running a job now
+func ProcessRunJob(process *process.Process) interface{} {
+	return processJobAction(process, "triggered", func(jobInstance *job.Job) error { return jobInstance.Trigger() })
+}
+
+// ProcessDeleteJob process handler for deleting a job
+func ProcessDeleteJob(process *process.Process) interface{} {
+	return processJobAction(process, "deleted", deleteJob)
+}
+
+// processJobAction runs an action on the job of the first argument
+func processJobAction(process *process.Process, done string, action func(*job.Job) error) interface{} {
+	args := process.Args
+	if len(args) == 0 {
+		return map[string]interface{}{"error": "job_id is required"}
+	}
+
+	jobID, ok := args[0].(string)
+	if !ok {
+		return map[string]interface{}{"error": "job_id must be a string"}
+	}
+
+	jobInstance, err := job.GetJob(jobID)
+	if err != nil {
+		log.Error("Failed to get job %s: %v", jobID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	if err := action(jobInstance); err != nil {
+		log.Error("Failed to update job %s: %v", jobID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	return map[string]interface{}{"message": "Job " + done + " successfully", "job_id": jobID, "status": jobInstance.Status}
+}
@@ -0,0 +1,66 @@
+package job
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/job"
+)
+
+// GetMetrics exports the job metrics of this node in the Prometheus text format
+func GetMetrics(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := job.WritePrometheus(c.Writer); err != nil {
+		log.Error("Failed to write job metrics: %v", err)
+	}
+}
+
+// GetJobMetrics returns the run history metrics of a job: outcomes, duration, queue wait, retries and SLA breaches
+func GetJobMetrics(c *gin.Context) {
+	jobInstance, ok := findJob(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, jobMetrics(jobInstance))
+}
+
+// jobMetrics returns the metrics of a job, empty if the job did not run on this node
+func jobMetrics(jobInstance *job.Job) *job.JobMetrics {
+	if metrics := job.GetJobMetrics(jobInstance.JobID); metrics != nil {
+		return metrics
+	}
+	return &job.JobMetrics{
+		JobID:       jobInstance.JobID,
+		Name:        jobInstance.Name,
+		Outcomes:    map[string]int64{},
+		SLABreaches: map[string]int64{},
+	}
+}
+
+// ProcessGetMetrics process handler for getting the metrics of all the jobs run on this node
+func ProcessGetMetrics(process *process.Process) interface{} {
+	return job.GetMetrics()
+}
+
+// ProcessGetJobMetrics process handler for getting the metrics of a job
+func ProcessGetJobMetrics(process *process.Process) interface{} {
+	args := process.Args
+	if len(args) == 0 {
+		return map[string]interface{}{"error": "job_id is required"}
+	}
+
+	jobID, ok := args[0].(string)
+	if !ok {
+		return map[string]interface{}{"error": "job_id must be a string"}
+	}
+
+	jobInstance, err := job.GetJob(jobID)
+	if err != nil {
+		log.Error("Failed to get job %s: %v", jobID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+	return jobMetrics(jobInstance)
+}
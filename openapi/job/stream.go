@@ -0,0 +1,182 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/job"
+)
+
+// upgrader upgrades the stream requests to WebSocket
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// StreamJob streams the logs and the progress of a job as server-sent events
+func StreamJob(c *gin.Context) {
+	options, ok := jobStreamOptions(c)
+	if !ok {
+		return
+	}
+	streamSSE(c, options)
+}
+
+// StreamExecution streams the logs and the progress of an execution as server-sent events
+func StreamExecution(c *gin.Context) {
+	options, ok := executionStreamOptions(c)
+	if !ok {
+		return
+	}
+	streamSSE(c, options)
+}
+
+// StreamJobWebSocket streams the logs and the progress of a job over WebSocket
+func StreamJobWebSocket(c *gin.Context) {
+	options, ok := jobStreamOptions(c)
+	if !ok {
+		return
+	}
+	streamWebSocket(c, options)
+}
+
+// StreamExecutionWebSocket streams the logs and the progress of an execution over WebSocket
+func StreamExecutionWebSocket(c *gin.Context) {
+	options, ok := executionStreamOptions(c)
+	if !ok {
+		return
+	}
+	streamWebSocket(c, options)
+}
+
+// jobStreamOptions checks the job and returns the stream options
+func jobStreamOptions(c *gin.Context) (job.StreamOptions, bool) {
+	jobID := c.Param("jobID")
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job_id is required"})
+		return job.StreamOptions{}, false
+	}
+
+	if _, err := job.GetJob(jobID); err != nil {
+		log.Error("Failed to get job %s: %v", jobID, err)
+		if err.Error() == "job not found: "+jobID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return job.StreamOptions{}, false
+	}
+
+	return job.StreamOptions{JobID: jobID, LastEventID: lastEventID(c)}, true
+}
+
+// executionStreamOptions checks the execution and returns the stream options
+func executionStreamOptions(c *gin.Context) (job.StreamOptions, bool) {
+	executionID := c.Param("executionID")
+	if executionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "execution_id is required"})
+		return job.StreamOptions{}, false
+	}
+
+	execution, err := job.GetExecution(executionID, model.QueryParam{})
+	if err != nil {
+		log.Error("Failed to get execution %s: %v", executionID, err)
+		if err.Error() == "execution not found: "+executionID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Execution not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return job.StreamOptions{}, false
+	}
+
+	return job.StreamOptions{JobID: execution.JobID, ExecutionID: executionID, LastEventID: lastEventID(c)}, true
+}
+
+// lastEventID returns the event ID to resume from, the Last-Event-ID header is set by the browsers on reconnect
+func lastEventID(c *gin.Context) string {
+	if id := c.GetHeader("Last-Event-ID"); id != "" {
+		return id
+	}
+	return c.Query("last_event_id")
+}
+
+// streamSSE writes the stream events as server-sent events
+func streamSSE(c *gin.Context, options job.StreamOptions) {
+	c.Header("Content-Type", "text/event-stream;charset=utf-8")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	err := job.Stream(c.Request.Context(), options, func(event *job.Event) error {
+		if event.Type == job.EventPing {
+			_, err := fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+			return err
+		}
+
+		data, err := jsoniter.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if event.ID != "" {
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\n", event.ID); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	if err != nil {
+		log.Error("Failed to stream job %s: %v", options.JobID, err)
+		data, _ := jsoniter.Marshal(gin.H{"error": err.Error()})
+		fmt.Fprintf(c.Writer, "event: error\ndata: %s\n\n", data)
+		c.Writer.Flush()
+	}
+}
+
+// streamWebSocket writes the stream events as WebSocket JSON messages
+func streamWebSocket(c *gin.Context, options job.StreamOptions) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Error("Failed to upgrade the stream of job %s: %v", options.JobID, err)
+		return
+	}
+	defer conn.Close()
+
+	// Read until the client closes the connection
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = job.Stream(ctx, options, func(event *job.Event) error {
+		if event.Type == job.EventPing {
+			return conn.WriteMessage(websocket.PingMessage, nil)
+		}
+		return conn.WriteJSON(event)
+	})
+
+	if err != nil {
+		log.Error("Failed to stream job %s: %v", options.JobID, err)
+		conn.WriteJSON(gin.H{"type": "error", "error": err.Error()})
+	}
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
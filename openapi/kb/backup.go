@@ -1,23 +1,191 @@
 package kb
 
 import (
-	"net/http"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/kb"
+	"github.com/yaoapp/yao/openapi/response"
 )
 
 // Collection Backup and Restore Handlers
 
-// Backup backs up a collection
+// Backup streams the backup archive of a collection, a gzip compressed tar
 func Backup(c *gin.Context) {
-	// TODO: Implement backup logic
-	c.Header("Content-Type", "application/octet-stream")
-	c.Header("Content-Disposition", "attachment; filename=collection-backup.gz")
-	c.Status(http.StatusOK)
+	collectionID := c.Param("collectionID")
+	if collectionID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Collection ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Check if kb.Instance is available
+	if kb.Instance == nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Knowledge base not initialized",
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	// TODO: Implement collection permission validation
+
+	config, err := kb.GetConfig()
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to get KB config: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	// The errors are reported before the archive is streamed
+	_, err = config.FindCollection(collectionID, model.QueryParam{Select: []interface{}{"collection_id"}})
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Collection not found",
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.tar.gz", collectionID, time.Now().Format("20060102150405"))
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(response.StatusOK)
+
+	// The status is sent, a failure truncates the archive and the restore rejects it
+	err = kb.Backup(c.Request.Context(), collectionID, c.Writer)
+	if err != nil {
+		log.Error("Failed to back up collection %s: %v", collectionID, err)
+		c.Abort()
+	}
 }
 
-// Restore restores a collection
+// Restore creates a collection from a backup archive. The archive is the request body, or the file field of a
+// multipart form. The query can set another embedding, and reembed=true embeds the segments again.
 func Restore(c *gin.Context) {
-	// TODO: Implement restore logic
-	c.JSON(http.StatusOK, gin.H{"message": "Collection restored"})
+	collectionID := c.Param("collectionID")
+	if collectionID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Collection ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req RestoreRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request format: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Check if kb.Instance is available
+	if kb.Instance == nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Knowledge base not initialized",
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	// TODO: Implement collection permission validation
+
+	var reader io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, _, err := c.Request.FormFile("file")
+		if err != nil {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: "The backup file is required",
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	result, err := kb.Restore(c.Request.Context(), reader, kb.RestoreOptions{
+		CollectionID:        collectionID,
+		EmbeddingProviderID: req.EmbeddingProviderID,
+		EmbeddingOptionID:   req.EmbeddingOptionID,
+		Locale:              req.Locale,
+		Reembed:             req.Reembed,
+	})
+	if err != nil {
+		status := response.StatusInternalServerError
+		code := response.ErrServerError.Code
+		switch {
+		case errors.Is(err, kb.ErrCollectionExists):
+			status = response.StatusConflict
+			code = response.ErrInvalidRequest.Code
+		case errors.Is(err, kb.ErrInvalidBackup):
+			status = response.StatusBadRequest
+			code = response.ErrInvalidRequest.Code
+		}
+
+		errorResp := &response.ErrorResponse{
+			Code:             code,
+			ErrorDescription: "Failed to restore collection: " + err.Error(),
+		}
+		response.RespondWithError(c, status, errorResp)
+		return
+	}
+
+	rescheduleResyncs(result.CollectionID)
+
+	response.RespondWithSuccess(c, response.StatusCreated, gin.H{
+		"message":       "Collection restored successfully",
+		"collection_id": result.CollectionID,
+		"result":        result,
+	})
+}
+
+// rescheduleResyncs schedules the resyncs of the restored documents, the archived jobs are not restored
+func rescheduleResyncs(collectionID string) {
+	config, err := kb.GetConfig()
+	if err != nil {
+		log.Warn("Failed to get KB config: %v", err)
+		return
+	}
+
+	schedules, err := config.ResyncSchedules(collectionID)
+	if err != nil {
+		log.Warn("Failed to read the resync schedules of collection %s: %v", collectionID, err)
+		return
+	}
+
+	for docID, schedule := range schedules {
+		if _, err := scheduleResync(docID, schedule); err != nil {
+			log.Warn("Failed to schedule the resync of document %s: %v", docID, err)
+		}
+	}
 }
@@ -24,7 +24,8 @@ var (
 		"id": true, "collection_id": true, "name": true, "description": true,
 		"status": true, "system": true, "readonly": true, "sort": true, "cover": true,
 		"document_count": true, "embedding_provider_id": true, "embedding_option_id": true,
-		"embedding_properties": true, "locale": true, "dimension": true,
+		"embedding_properties": true, "reranker_provider_id": true, "reranker_option_id": true,
+		"locale": true, "dimension": true,
 		"distance_metric": true, "hnsw_m": true, "ef_construction": true,
 		"ef_search": true, "num_lists": true, "num_probes": true,
 		"created_at": true, "updated_at": true,
@@ -188,6 +189,11 @@ func RemoveCollection(c *gin.Context) {
 			documentsRemoved = count
 		}
 
+		// The scheduled resyncs of the documents
+		if err := kb.RemoveResyncJobs(config, collectionID); err != nil {
+			log.Error("Failed to remove the resync jobs of collection %s: %v", collectionID, err)
+		}
+
 		// Remove all documents belonging to this collection
 		if err := config.RemoveDocumentsByCollectionID(collectionID); err != nil {
 			log.Error("Failed to remove documents from collection %s: %v", collectionID, err)
@@ -571,6 +577,13 @@ func UpdateCollectionMetadata(c *gin.Context) {
 		if status, ok := req.Metadata["status"]; ok {
 			updateData["status"] = status
 		}
+		// An empty reranker disables the reranking of the collection
+		if rerankerProviderID, ok := req.Metadata["reranker_provider_id"]; ok {
+			updateData["reranker_provider_id"] = rerankerProviderID
+		}
+		if rerankerOptionID, ok := req.Metadata["reranker_option_id"]; ok {
+			updateData["reranker_option_id"] = rerankerOptionID
+		}
 
 		if len(updateData) > 0 {
 			// Only update database, don't sync to GraphRag again to avoid duplicate updates
@@ -599,6 +612,8 @@ type CreateCollectionConfig struct {
 	EmbeddingProviderID string `json:"embedding_provider_id" binding:"required"` // embedding provider id
 	EmbeddingOptionID   string `json:"embedding_option_id" binding:"required"`   // embedding option id
 	Locale              string `json:"locale,omitempty"`                         // locale for provider reading
+	RerankerProviderID  string `json:"reranker_provider_id,omitempty"`           // reranker provider id, optional
+	RerankerOptionID    string `json:"reranker_option_id,omitempty"`             // reranker option id, optional
 	*types.CreateCollectionOptions
 }
 
@@ -617,6 +632,10 @@ func validateCreateCollectionRequest(req *CreateCollectionRequest) error {
 		return fmt.Errorf("config is required")
 	}
 
+	if (req.Config.RerankerProviderID == "") != (req.Config.RerankerOptionID == "") {
+		return fmt.Errorf("reranker_provider_id and reranker_option_id must be set together")
+	}
+
 	// Validate CreateCollectionOptions (ignore collection name cannot be empty error)
 	if err := req.Config.Validate(); err != nil && err.Error() != "collection name cannot be empty" {
 		return fmt.Errorf("invalid config: %w", err)
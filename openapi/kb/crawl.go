@@ -0,0 +1,424 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/gou/graphrag/utils"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/kb"
+	"github.com/yaoapp/yao/kb/providers/crawler"
+	kbtypes "github.com/yaoapp/yao/kb/types"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// crawlerFetcherID the fetcher provider of the crawls
+const crawlerFetcherID = "__yao.crawler"
+
+// CrawlResult the result of a crawl
+type CrawlResult struct {
+	crawler.Result
+	Documents []string `json:"documents"` // Documents of the crawled pages
+	Errors    int      `json:"errors"`    // Pages crawled but not added
+}
+
+// Website Crawl Handlers
+
+// CrawlHandler crawls a website and adds each page as an url document of the collection. The pages are added with
+// the text extracted by the crawler, the crawler is the fetcher of the documents so that a resync reads the same text.
+func CrawlHandler(ctx context.Context, req *CrawlRequest, jobID string, progress func(percent int, message string)) (*CrawlResult, error) {
+	// Check if kb.Instance is available
+	if kb.Instance == nil {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+
+	// Get KB config
+	config, err := kb.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KB config: %w", err)
+	}
+
+	if req.Fetcher == nil {
+		req.Fetcher = &ProviderConfig{ProviderID: crawlerFetcherID, Option: &kbtypes.ProviderOption{Properties: map[string]interface{}{}}}
+	}
+
+	upsertOptions, err := req.BaseUpsertRequest.ToUpsertOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert request to upsert options: %w", err)
+	}
+
+	c, err := requestCrawler(req, upsertOptions.Fetcher)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPages := c.Options().MaxPages
+	result := &CrawlResult{Documents: []string{}}
+	stats, err := c.Crawl(ctx, req.URL, func(page *crawler.Page) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		docID, err := addCrawledPage(ctx, config, req, upsertOptions, page, jobID)
+		if err != nil {
+			log.Error("Failed to add the crawled page %s: %v", page.URL, err)
+			result.Errors++
+		} else {
+			result.Documents = append(result.Documents, docID)
+		}
+
+		if progress != nil {
+			done := len(result.Documents) + result.Errors
+			progress(done*99/maxPages, fmt.Sprintf("Crawled %s (%d/%d)", page.URL, done, maxPages))
+		}
+		return nil
+	})
+	if stats != nil {
+		result.Result = *stats
+	}
+
+	// Update document count for the collection and sync to GraphRag, the pages added before an error are kept
+	if len(result.Documents) > 0 {
+		if err := UpdateDocumentCountWithSync(req.CollectionID, config); err != nil {
+			log.Error("Failed to update document count for collection %s: %v", req.CollectionID, err)
+		}
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("failed to crawl %s: %w", req.URL, err)
+	}
+
+	if progress != nil {
+		progress(100, fmt.Sprintf("Crawled %d pages, %d skipped, %d duplicates, %d failed", result.Pages, result.Skipped, result.Duplicates, result.Failed+result.Errors))
+	}
+
+	log.Info("Crawled %s into collection %s: %d pages, %d documents", req.URL, req.CollectionID, result.Pages, len(result.Documents))
+	return result, nil
+}
+
+// requestCrawler returns the crawler of a request, the limits and patterns of the request override its options
+func requestCrawler(req *CrawlRequest, fetcher types.Fetcher) (*crawler.Crawler, error) {
+	c, ok := fetcher.(*crawler.Crawler)
+	if !ok {
+		return nil, fmt.Errorf("the fetcher of a crawl must be %s", crawlerFetcherID)
+	}
+
+	if req.MaxDepth == nil && req.MaxPages == nil && req.Include == nil && req.Exclude == nil {
+		return c, nil
+	}
+
+	options := c.Options()
+	if req.MaxDepth != nil {
+		options.MaxDepth = *req.MaxDepth
+	}
+	if req.MaxPages != nil {
+		options.MaxPages = *req.MaxPages
+	}
+	if req.Include != nil {
+		options.Include = req.Include
+	}
+	if req.Exclude != nil {
+		options.Exclude = req.Exclude
+	}
+	return crawler.New(options)
+}
+
+// addCrawledPage adds a crawled page as an url document with the providers of the crawl, returns the ID of the document.
+// The document of an url already in the collection is synced again with the page, its unchanged segments are kept.
+func addCrawledPage(ctx context.Context, config *kbtypes.Config, req *CrawlRequest, upsertOptions *types.UpsertOptions, page *crawler.Page, jobID string) (string, error) {
+	existing, err := findCrawledPage(config, req.CollectionID, page.URL)
+	if err != nil {
+		return "", err
+	}
+
+	pageReq := req.BaseUpsertRequest
+	pageReq.DocID = utils.GenDocIDWithCollectionID(req.CollectionID)
+	if existing != nil {
+		pageReq.DocID, _ = existing["document_id"].(string)
+	}
+
+	// The metadata of the request is shared by the pages, the name is the title of each page
+	pageReq.Metadata = map[string]interface{}{}
+	for key, value := range req.Metadata {
+		if key != "name" {
+			pageReq.Metadata[key] = value
+		}
+	}
+	pageReq.Metadata["url"] = page.URL
+	pageReq.Metadata["title"] = page.Title
+	pageReq.Metadata["depth"] = page.Depth
+	pageReq.Metadata["source"] = "crawler"
+
+	name := page.Title
+	if name == "" {
+		name = page.URL
+	}
+
+	documentData := map[string]interface{}{
+		"document_id":   pageReq.DocID,
+		"collection_id": req.CollectionID,
+		"name":          name,
+		"type":          "url",
+		"status":        "pending",
+		"url":           page.URL,
+		"job_id":        jobID,
+	}
+	pageReq.AddBaseFields(documentData)
+
+	pageOptions := *upsertOptions
+	pageOptions.DocID = pageReq.DocID
+	pageOptions.Metadata = pageReq.Metadata
+
+	if existing == nil {
+		if _, err := config.CreateDocument(maps.MapStrAny(documentData)); err != nil {
+			return "", fmt.Errorf("failed to save document metadata: %w", err)
+		}
+
+		if _, err := kb.Instance.AddText(ctx, page.Text, &pageOptions); err != nil {
+			config.UpdateDocument(pageReq.DocID, maps.MapStrAny{"status": "error", "error_message": err.Error()})
+			return "", fmt.Errorf("failed to add the page: %w", err)
+		}
+	} else {
+		if err := config.UpdateDocument(pageReq.DocID, maps.MapStrAny(documentData)); err != nil {
+			return "", fmt.Errorf("failed to save document metadata: %w", err)
+		}
+
+		hash, _ := existing["content_hash"].(string)
+		_, err := kb.Resync(ctx, kb.ResyncOptions{DocumentID: pageReq.DocID, Text: page.Text, ContentHash: hash, Upsert: &pageOptions})
+		if err != nil {
+			config.UpdateDocument(pageReq.DocID, maps.MapStrAny{"status": "error", "error_message": err.Error()})
+			return "", fmt.Errorf("failed to sync the page again: %w", err)
+		}
+	}
+
+	// The hash of the text lets a resync skip the unchanged pages
+	data := maps.MapStrAny{
+		"status":        "completed",
+		"error_message": nil,
+		"content_hash":  kb.ContentHash(page.Text),
+		"synced_at":     time.Now(),
+	}
+	if err := config.UpdateDocument(pageReq.DocID, data); err != nil {
+		log.Error("Failed to update document status to completed: %v", err)
+	}
+
+	// Update segment count for the document
+	if segmentCount, err := kb.Instance.SegmentCount(ctx, pageReq.DocID); err != nil {
+		log.Error("Failed to get segment count for document %s: %v", pageReq.DocID, err)
+	} else if err := config.UpdateSegmentCount(pageReq.DocID, segmentCount); err != nil {
+		log.Error("Failed to update segment count for document %s: %v", pageReq.DocID, err)
+	}
+
+	return pageReq.DocID, nil
+}
+
+// findCrawledPage finds the url document of a page in a collection, nil when the page was never added
+func findCrawledPage(config *kbtypes.Config, collectionID string, url string) (maps.MapStrAny, error) {
+	res, err := config.SearchDocuments(model.QueryParam{
+		Select: []interface{}{"document_id", "content_hash"},
+		Wheres: []model.QueryWhere{
+			{Column: "collection_id", Value: collectionID},
+			{Column: "type", Value: "url"},
+			{Column: "url", Value: url},
+		},
+		Orders: []model.QueryOrder{{Column: "id", Option: "asc"}},
+	}, 1, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the document of %s: %w", url, err)
+	}
+
+	documents, _ := res["data"].([]maps.MapStrAny)
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	return documents[0], nil
+}
+
+// CrawlAsync crawls a website into a collection in a job, the progress of the job is the share of the max pages
+// crawled
+func CrawlAsync(c *gin.Context) {
+	var req CrawlRequest
+
+	// Check if kb.Instance is available
+	if !checkKBInstance(c) {
+		return
+	}
+
+	// TODO: Implement collection permission validation
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request format: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Validate request
+	if err := req.Validate(); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	if req.Fetcher == nil {
+		req.Fetcher = &ProviderConfig{ProviderID: crawlerFetcherID, Option: &kbtypes.ProviderOption{Properties: map[string]interface{}{}}}
+	}
+
+	// Convert request to UpsertOptions (just for validation)
+	upsertOptions, err := getUpsertOptions(c, &req.BaseUpsertRequest)
+	if err != nil {
+		return
+	}
+
+	if _, err := requestCrawler(&req, upsertOptions.Fetcher); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Get job options with defaults
+	jobName, jobDescription, jobIcon, jobCategory := req.GetJobOptions(
+		"Knowledge Base Website Crawling",                           // default name
+		"Crawling "+req.URL+" and indexing each page as a document", // default description
+		"travel_explore", // default icon (Material Icon)
+		"Knowledge Base", // default category
+	)
+
+	// Create job data
+	jobCreateData := map[string]interface{}{
+		"name":          jobName,
+		"description":   jobDescription,
+		"category_name": jobCategory, // Pass category name directly, let SaveJob handle it
+	}
+	if jobIcon != "" {
+		jobCreateData["icon"] = jobIcon
+	}
+
+	// Create and save Job in one step to get JobID
+	j, err := job.OnceAndSave(job.GOROUTINE, jobCreateData)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to create and save job: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	// The request is passed as is, the documents are created by the job as the pages are crawled
+	jobData := map[string]interface{}{
+		"collection_id": req.CollectionID,
+		"url":           req.URL,
+		"locale":        req.Locale,
+		"metadata":      req.Metadata,
+		"chunking":      req.Chunking,
+		"embedding":     req.Embedding,
+		"extraction":    req.Extraction,
+		"fetcher":       req.Fetcher,
+		"max_depth":     req.MaxDepth,
+		"max_pages":     req.MaxPages,
+		"include":       req.Include,
+		"exclude":       req.Exclude,
+		"job_id":        j.JobID,
+	}
+
+	err = j.Add(&job.ExecutionOptions{
+		Priority: 1,
+	}, "kb.documents.crawl", jobData)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to add job execution: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	// Push the job to execution queue
+	err = j.Push()
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to push job: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusCreated, gin.H{
+		"job_id":        j.JobID,
+		"collection_id": req.CollectionID,
+		"url":           req.URL,
+	})
+}
+
+// ProcessCrawl documents.crawl Knowledge Base website crawl processor
+// Args[0] map: Request parameters {"collection_id": "collection", "url": "https://example.com/sitemap.xml", "max_pages": 50, ...}
+// Return: map: The result of the crawl {"pages": 10, "documents": ["document_id", ...], ...}
+func ProcessCrawl(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+
+	// Get parameters
+	reqMap := process.ArgsMap(0)
+
+	// Check knowledge base instance
+	if kb.Instance == nil {
+		exception.New("knowledge base not initialized", 500).Throw()
+	}
+
+	// The providers and the overrides are read as the JSON request
+	var req CrawlRequest
+	raw, err := jsoniter.Marshal(reqMap)
+	if err != nil {
+		exception.New("invalid request: %s", 400, err.Error()).Throw()
+	}
+	if err := jsoniter.Unmarshal(raw, &req); err != nil {
+		exception.New("invalid request: %s", 400, err.Error()).Throw()
+	}
+	if err := req.Validate(); err != nil {
+		exception.New("invalid request: %s", 400, err.Error()).Throw()
+	}
+
+	jobID, _ := reqMap["job_id"].(string)
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The progress is reported to the job executing the process
+	progress := func(percent int, message string) {
+		if process.Callback == nil {
+			return
+		}
+		process.Callback(process, map[string]interface{}{
+			"type":     "progress",
+			"progress": percent,
+			"message":  message,
+		})
+	}
+
+	result, err := CrawlHandler(ctx, &req, jobID, progress)
+	if err != nil {
+		exception.New("failed to crawl: %s", 500, err.Error()).Throw()
+	}
+	return result
+}
@@ -403,11 +403,15 @@ func RemoveDocs(c *gin.Context) {
 	for _, docID := range validDocIDs {
 		// Get document info before deletion to track collection
 		if docInfo, err := config.FindDocument(docID, model.QueryParam{
-			Select: []interface{}{"collection_id"},
+			Select: []interface{}{"collection_id", "resync_job_id"},
 		}); err == nil && docInfo != nil {
 			if collectionID, ok := docInfo["collection_id"].(string); ok && collectionID != "" {
 				collectionsToUpdate[collectionID] = true
 			}
+			// The scheduled resync of the document
+			if jobID, ok := docInfo["resync_job_id"].(string); ok && jobID != "" {
+				removeResyncJob(jobID)
+			}
 		}
 
 		if err := config.RemoveDocument(docID); err != nil {
@@ -14,6 +14,8 @@ func init() {
 		"documents.addfile": ProcessAddFile,
 		"documents.addtext": ProcessAddText,
 		"documents.addurl":  ProcessAddURL,
+		"documents.crawl":   ProcessCrawl,
+		"documents.resync":  ProcessResync,
 	})
 }
 
@@ -46,7 +48,11 @@ func Attach(group *gin.RouterGroup, oauth types.OAuth) {
 	group.POST("/collections/:collectionID/documents/text/async", AddTextAsync)
 	group.POST("/collections/:collectionID/documents/url", AddURL)
 	group.POST("/collections/:collectionID/documents/url/async", AddURLAsync)
+	group.POST("/collections/:collectionID/documents/crawl/async", CrawlAsync)
 	group.DELETE("/documents", RemoveDocs)
+	group.POST("/documents/:docID/resync", Resync)
+	group.POST("/documents/:docID/resync/async", ResyncAsync)
+	group.PUT("/documents/:docID/resync/schedule", ScheduleResync)
 
 	// Segment Management
 	group.GET("/documents/:docID/segments", ScrollSegments)
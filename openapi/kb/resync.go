@@ -0,0 +1,440 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/kb"
+	kbtypes "github.com/yaoapp/yao/kb/types"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// Document Resync Handlers
+
+// ResyncHandler fetches the source of an url or a file document again and updates its changed segments
+func ResyncHandler(ctx context.Context, docID string, force bool) (*kb.ResyncResult, error) {
+	// Check if kb.Instance is available
+	if kb.Instance == nil {
+		return nil, fmt.Errorf("knowledge base not initialized")
+	}
+
+	// Get KB config
+	config, err := kb.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KB config: %w", err)
+	}
+
+	document, err := config.FindDocument(docID, model.QueryParam{})
+	if err != nil || document == nil {
+		return nil, fmt.Errorf("document not found: %s", docID)
+	}
+
+	options := kb.ResyncOptions{DocumentID: docID, Force: force}
+	options.ContentHash, _ = document["content_hash"].(string)
+
+	// Rebuild the providers of the document from its record
+	req := documentUpsertRequest(maps.MapStrAny(document))
+	var fileInfo []string
+	switch document["type"] {
+	case "url":
+		options.URL, _ = document["url"].(string)
+
+	case "file":
+		uploader, _ := document["uploader_id"].(string)
+		fileID, _ := document["file_id"].(string)
+		m, ok := attachment.Managers[uploader]
+		if !ok {
+			return nil, fmt.Errorf("invalid uploader: %s not found", uploader)
+		}
+
+		path, contentType, err := m.LocalPath(ctx, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get local path: %w", err)
+		}
+		options.Path = path
+		fileInfo = []string{path, contentType}
+
+	default:
+		return nil, fmt.Errorf("only the url and file documents can be synced again")
+	}
+
+	options.Upsert, err = req.ToUpsertOptions(fileInfo...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert document to upsert options: %w", err)
+	}
+
+	if err := config.UpdateDocument(docID, maps.MapStrAny{"status": "syncing"}); err != nil {
+		log.Error("Failed to update document status to syncing: %v", err)
+	}
+
+	result, err := kb.Resync(ctx, options)
+	if err != nil {
+		// Update status to error, the segments not updated are kept
+		config.UpdateDocument(docID, maps.MapStrAny{"status": "error", "error_message": err.Error()})
+		return nil, fmt.Errorf("failed to resync document: %w", err)
+	}
+
+	data := maps.MapStrAny{
+		"status":        "completed",
+		"content_hash":  result.ContentHash,
+		"synced_at":     time.Now(),
+		"error_message": nil,
+	}
+	if err := config.UpdateDocument(docID, data); err != nil {
+		log.Error("Failed to update document %s after resync: %v", docID, err)
+	}
+
+	if !result.Changed {
+		return result, nil
+	}
+
+	// Update segment count for the document
+	if segmentCount, err := kb.Instance.SegmentCount(ctx, docID); err != nil {
+		log.Error("Failed to get segment count for document %s: %v", docID, err)
+	} else if err := config.UpdateSegmentCount(docID, segmentCount); err != nil {
+		log.Error("Failed to update segment count for document %s: %v", docID, err)
+	}
+
+	log.Info("Document %s synced again: %d kept, %d added, %d removed", docID, result.Kept, result.Added, result.Removed)
+	return result, nil
+}
+
+// Resync fetches the source of a document again, force=true diffs the segments even if the source is unchanged
+func Resync(c *gin.Context) {
+	docID := c.Param("docID")
+	if docID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Document ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Check if kb.Instance is available
+	if !checkKBInstance(c) {
+		return
+	}
+
+	// TODO: Implement document permission validation
+
+	result, err := ResyncHandler(c.Request.Context(), docID, c.Query("force") == "true")
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, gin.H{
+		"message": "Document synced successfully",
+		"doc_id":  docID,
+		"result":  result,
+	})
+}
+
+// ResyncAsync fetches the source of a document again in a job
+func ResyncAsync(c *gin.Context) {
+	docID := c.Param("docID")
+	if docID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Document ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Check if kb.Instance is available
+	if !checkKBInstance(c) {
+		return
+	}
+
+	// TODO: Implement document permission validation
+
+	document, err := findResyncDocument(docID)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusNotFound, errorResp)
+		return
+	}
+
+	name, _ := document["name"].(string)
+	j, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{
+		"name":          "Knowledge Base Document Resync",
+		"description":   fmt.Sprintf("Syncing %s again", name),
+		"icon":          "sync",
+		"category_name": "Knowledge Base",
+	})
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to create and save job: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	err = j.Add(&job.ExecutionOptions{Priority: 1}, "kb.documents.resync", map[string]interface{}{
+		"doc_id": docID,
+		"force":  c.Query("force") == "true",
+	})
+	if err == nil {
+		err = j.Push()
+	}
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to run job: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusCreated, gin.H{
+		"job_id": j.JobID,
+		"doc_id": docID,
+	})
+}
+
+// ScheduleResync sets the scheduled resync of a document, a cron job syncing the source again. An empty
+// schedule removes it.
+func ScheduleResync(c *gin.Context) {
+	docID := c.Param("docID")
+	if docID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Document ID is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	var req ScheduleResyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request format: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Check if kb.Instance is available
+	if !checkKBInstance(c) {
+		return
+	}
+
+	// TODO: Implement document permission validation
+
+	jobID, err := scheduleResync(docID, req.Schedule)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to schedule resync: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, response.StatusOK, gin.H{
+		"message":  "Document resync scheduled successfully",
+		"doc_id":   docID,
+		"schedule": req.Schedule,
+		"job_id":   jobID,
+	})
+}
+
+// scheduleResync replaces the resync job of a document, returns the ID of the new job
+func scheduleResync(docID string, schedule string) (string, error) {
+	config, err := kb.GetConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to get KB config: %w", err)
+	}
+
+	document, err := findResyncDocument(docID)
+	if err != nil {
+		return "", err
+	}
+
+	if jobID, ok := document["resync_job_id"].(string); ok && jobID != "" {
+		removeResyncJob(jobID)
+	}
+
+	if schedule == "" {
+		err = config.UpdateDocument(docID, maps.MapStrAny{"resync_schedule": nil, "resync_job_id": nil})
+		return "", err
+	}
+
+	name, _ := document["name"].(string)
+	j, err := job.Cron(job.GOROUTINE, map[string]interface{}{
+		"name":          "Knowledge Base Scheduled Resync",
+		"description":   fmt.Sprintf("Syncing %s again (%s)", name, schedule),
+		"icon":          "sync",
+		"category_name": "Knowledge Base",
+	}, schedule)
+	if err != nil {
+		return "", err
+	}
+
+	if err := job.SaveJob(j); err != nil {
+		return "", fmt.Errorf("failed to save job: %w", err)
+	}
+
+	err = j.Add(&job.ExecutionOptions{Priority: 1}, "kb.documents.resync", map[string]interface{}{"doc_id": docID})
+	if err != nil {
+		removeResyncJob(j.JobID)
+		return "", err
+	}
+
+	// The scheduler runs the ready cron jobs
+	j.SetStatus("ready")
+	if err := job.SaveJob(j); err != nil {
+		removeResyncJob(j.JobID)
+		return "", fmt.Errorf("failed to save job: %w", err)
+	}
+
+	err = config.UpdateDocument(docID, maps.MapStrAny{"resync_schedule": schedule, "resync_job_id": j.JobID})
+	if err != nil {
+		removeResyncJob(j.JobID)
+		return "", err
+	}
+	return j.JobID, nil
+}
+
+// removeResyncJob removes the resync job of a document, a job removed meanwhile is ignored
+func removeResyncJob(jobID string) {
+	if err := job.RemoveJobs([]string{jobID}); err != nil {
+		log.Warn("Failed to remove the resync job %s: %v", jobID, err)
+	}
+}
+
+// findResyncDocument finds a document that can be synced again
+func findResyncDocument(docID string) (maps.MapStr, error) {
+	config, err := kb.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KB config: %w", err)
+	}
+
+	document, err := config.FindDocument(docID, model.QueryParam{
+		Select: []interface{}{"document_id", "name", "type", "resync_job_id"},
+	})
+	if err != nil || document == nil {
+		return nil, fmt.Errorf("document not found: %s", docID)
+	}
+
+	if document["type"] != "url" && document["type"] != "file" {
+		return nil, fmt.Errorf("only the url and file documents can be synced again")
+	}
+	return document, nil
+}
+
+// documentUpsertRequest rebuilds the upsert request of a document from its record
+func documentUpsertRequest(document maps.MapStrAny) *BaseUpsertRequest {
+	req := &BaseUpsertRequest{}
+	req.CollectionID, _ = document["collection_id"].(string)
+	req.DocID, _ = document["document_id"].(string)
+	req.Locale, _ = document["locale"].(string)
+
+	req.Chunking = documentProvider(document, "chunking")
+	req.Embedding = documentProvider(document, "embedding")
+	req.Extraction = documentProvider(document, "extraction")
+	req.Fetcher = documentProvider(document, "fetcher")
+	req.Converter = documentProvider(document, "converter")
+	return req
+}
+
+// documentProvider returns the provider of a type stored in a document record, nil if the document has none
+func documentProvider(document maps.MapStrAny, typ string) *ProviderConfig {
+	providerID, _ := document[typ+"_provider_id"].(string)
+	if providerID == "" {
+		return nil
+	}
+
+	config := &ProviderConfig{ProviderID: providerID}
+	config.OptionID, _ = document[typ+"_option_id"].(string)
+	if properties := documentProperties(document[typ+"_properties"]); properties != nil {
+		config.Option = &kbtypes.ProviderOption{Properties: properties}
+	}
+	return config
+}
+
+// documentProperties returns the provider properties of a document record, the JSON columns may be read as a
+// map or as a JSON string
+func documentProperties(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v
+
+	case maps.MapStrAny:
+		return v
+
+	case string, []byte:
+		raw, _ := v.(string)
+		if data, ok := v.([]byte); ok {
+			raw = string(data)
+		}
+
+		properties := map[string]interface{}{}
+		if err := jsoniter.UnmarshalFromString(raw, &properties); err != nil {
+			log.Warn("Failed to decode the provider properties %q: %v", raw, err)
+			return nil
+		}
+		if len(properties) == 0 {
+			return nil
+		}
+		return properties
+	}
+	return nil
+}
+
+// ProcessResync documents.resync Knowledge Base resync processor
+// Args[0] map: Request parameters {"doc_id": "document_id", "force": false}
+// Return: map: The result of the resync
+func ProcessResync(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+
+	// Get parameters
+	reqMap := process.ArgsMap(0)
+
+	docID, ok := reqMap["doc_id"].(string)
+	if !ok || docID == "" {
+		exception.New("doc_id is required", 400).Throw()
+	}
+	force, _ := reqMap["force"].(bool)
+
+	ctx := process.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, err := ResyncHandler(ctx, docID, force)
+	if err != nil {
+		exception.New("failed to resync document: %s", 500, err.Error()).Throw()
+	}
+	return result
+}
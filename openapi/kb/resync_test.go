@@ -0,0 +1,46 @@
+package kb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaoapp/kun/maps"
+)
+
+func TestDocumentUpsertRequest(t *testing.T) {
+	properties := map[string]interface{}{"size": float64(300), "overlap": float64(20)}
+	document := maps.MapStrAny{
+		"collection_id":          "docs",
+		"document_id":            "docs__guide",
+		"chunking_provider_id":   "__yao.structured",
+		"chunking_properties":    `{"size": 300, "overlap": 20}`,
+		"embedding_provider_id":  "__yao.openai",
+		"embedding_option_id":    "text-embedding-3-small",
+		"embedding_properties":   map[string]interface{}{"model": "text-embedding-3-small"},
+		"extraction_provider_id": "__yao.openai",
+		"extraction_properties":  []byte(`{"model": "gpt-4o-mini"}`),
+		"fetcher_provider_id":    "__yao.http",
+		"fetcher_properties":     "not json",
+	}
+
+	req := documentUpsertRequest(document)
+	if req.CollectionID != "docs" || req.DocID != "docs__guide" || req.Converter != nil {
+		t.Fatalf("Unexpected request %+v", req)
+	}
+
+	// The properties stored as a JSON string are decoded
+	if req.Chunking == nil || req.Chunking.Option == nil || !reflect.DeepEqual(req.Chunking.Option.Properties, properties) {
+		t.Errorf("Expected the chunking properties %v, got %+v", properties, req.Chunking)
+	}
+	if req.Extraction == nil || req.Extraction.Option == nil || req.Extraction.Option.Properties["model"] != "gpt-4o-mini" {
+		t.Errorf("Expected the extraction properties to be decoded, got %+v", req.Extraction)
+	}
+	if req.Embedding == nil || req.Embedding.OptionID != "text-embedding-3-small" || req.Embedding.Option.Properties["model"] != "text-embedding-3-small" {
+		t.Errorf("Expected the embedding properties, got %+v", req.Embedding)
+	}
+
+	// The invalid properties are ignored, the provider is kept
+	if req.Fetcher == nil || req.Fetcher.ProviderID != "__yao.http" || req.Fetcher.Option != nil {
+		t.Errorf("Expected the fetcher without properties, got %+v", req.Fetcher)
+	}
+}
@@ -1,21 +1,177 @@
 package kb
 
 import (
-	"net/http"
+	"fmt"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/kb"
+	"github.com/yaoapp/yao/openapi/response"
 )
 
 // Search Management Handlers
 
-// Search searches for segments
+// Search searches for segments across one or more collections
 func Search(c *gin.Context) {
-	// TODO: Implement search logic
-	c.JSON(http.StatusOK, gin.H{"results": []interface{}{}})
+	var req SearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request format: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Check if kb.Instance is available
+	if kb.Instance == nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Knowledge base not initialized",
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	// TODO: Implement collection permission validation for the collection IDs
+
+	res, err := kb.Search(c.Request.Context(), req.SearchOptions)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to search: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	recordHits(c, req.Query, res.Results)
+
+	data := gin.H{
+		"query":   req.Query,
+		"results": res.Results,
+	}
+	if len(res.Warnings) > 0 {
+		data["warnings"] = res.Warnings
+	}
+	response.RespondWithSuccess(c, response.StatusOK, data)
 }
 
-// MultiSearch performs multi-search for segments
+// MultiSearch performs several searches, the results are keyed by the ID of the queries
 func MultiSearch(c *gin.Context) {
-	// TODO: Implement multi-search logic
-	c.JSON(http.StatusOK, gin.H{"results": map[string]interface{}{}})
+	var req MultiSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid request format: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	if len(req.Queries) == 0 {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "At least one query is required",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return
+	}
+
+	searches := make([]kb.SearchOptions, len(req.Queries))
+	for i := range req.Queries {
+		if err := req.Queries[i].Validate(); err != nil {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: fmt.Sprintf("queries[%d]: %s", i, err.Error()),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return
+		}
+		searches[i] = req.Queries[i].SearchOptions
+	}
+
+	// Check if kb.Instance is available
+	if kb.Instance == nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Knowledge base not initialized",
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	// TODO: Implement collection permission validation for the collection IDs
+
+	results, err := kb.MultiSearch(c.Request.Context(), searches)
+	if err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to search: " + err.Error(),
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	keyed := map[string]interface{}{}
+	warnings := map[string]interface{}{}
+	for i, query := range req.Queries {
+		id := query.ID
+		if id == "" {
+			id = strconv.Itoa(i)
+		}
+		keyed[id] = results[i].Results
+		if len(results[i].Warnings) > 0 {
+			warnings[id] = results[i].Warnings
+		}
+		recordHits(c, query.Query, results[i].Results)
+	}
+
+	data := gin.H{"results": keyed}
+	if len(warnings) > 0 {
+		data["warnings"] = warnings
+	}
+	response.RespondWithSuccess(c, response.StatusOK, data)
+}
+
+// recordHits records a hit of each result through the hits API, the hits feed the scores of the segments
+func recordHits(c *gin.Context, query string, results []*kb.SearchResult) {
+	hits := map[string][]types.SegmentHit{}
+	for _, result := range results {
+		if result.DocumentID == "" {
+			continue
+		}
+		hits[result.DocumentID] = append(hits[result.DocumentID], types.SegmentHit{ID: result.ID})
+	}
+
+	for docID, segments := range hits {
+		options := types.UpdateHitOptions{
+			Reaction: &types.SegmentReaction{
+				Source:   "api",
+				Scenario: "search",
+				Context: map[string]interface{}{
+					"query":     query,
+					"method":    c.Request.Method,
+					"path":      c.Request.URL.Path,
+					"client_ip": c.ClientIP(),
+				},
+			},
+		}
+
+		_, err := kb.Instance.UpdateHits(c.Request.Context(), docID, segments, options)
+		if err != nil {
+			log.Warn("Failed to record the search hits of document %s: %v", docID, err)
+		}
+	}
 }
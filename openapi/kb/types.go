@@ -2,9 +2,11 @@ package kb
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/yaoapp/gou/graphrag/types"
+	"github.com/yaoapp/yao/job"
 	"github.com/yaoapp/yao/kb"
 	"github.com/yaoapp/yao/kb/providers/factory"
 	kbtypes "github.com/yaoapp/yao/kb/types"
@@ -128,6 +130,16 @@ type AddURLRequest struct {
 	URL string `json:"url" binding:"required"`
 }
 
+// CrawlRequest represents the request for Crawl API, each crawled page is added as a document
+type CrawlRequest struct {
+	BaseUpsertRequest
+	URL      string   `json:"url" binding:"required"` // Seed url or sitemap.xml
+	MaxDepth *int     `json:"max_depth,omitempty"`    // Overrides the options of the crawler
+	MaxPages *int     `json:"max_pages,omitempty"`    // Overrides the options of the crawler
+	Include  []string `json:"include,omitempty"`      // Overrides the include patterns of the crawler
+	Exclude  []string `json:"exclude,omitempty"`      // Overrides the exclude patterns of the crawler
+}
+
 // AddSegmentsRequest represents the request for AddSegments API
 type AddSegmentsRequest struct {
 	BaseUpsertRequest
@@ -157,6 +169,18 @@ type UpdateScoreRequest struct {
 	Segments []types.SegmentScore `json:"segments" binding:"required"`
 }
 
+// SearchRequest represents the request for Search API
+type SearchRequest struct {
+	kb.SearchOptions
+	ID           string `json:"id,omitempty"`            // Key of the results in a MultiSearch, default is the index of the query
+	CollectionID string `json:"collection_id,omitempty"` // A single collection, added to collection_ids
+}
+
+// MultiSearchRequest represents the request for MultiSearch API
+type MultiSearchRequest struct {
+	Queries []SearchRequest `json:"queries" binding:"required"`
+}
+
 // UpdateWeightRequest represents the request for UpdateWeight API
 type UpdateWeightRequest struct {
 	Segments []types.SegmentWeight `json:"segments" binding:"required"`
@@ -172,6 +196,19 @@ type UpdateWeightsRequest struct {
 	Weights []types.SegmentWeight `json:"weights" binding:"required"`
 }
 
+// RestoreRequest represents the query of the Restore API, the archive is the request body
+type RestoreRequest struct {
+	EmbeddingProviderID string `form:"embedding_provider_id"` // Embedding of the restored collection, default is the one of the backup
+	EmbeddingOptionID   string `form:"embedding_option_id"`
+	Locale              string `form:"locale"`
+	Reembed             bool   `form:"reembed"` // Embed the segments again even if the embedding is unchanged
+}
+
+// ScheduleResyncRequest represents the request for ScheduleResync API
+type ScheduleResyncRequest struct {
+	Schedule string `json:"schedule"` // Cron expression, e.g. "0 3 * * *" or "@daily", empty removes the schedule
+}
+
 // ProviderOption resolves a ProviderConfig to a *kbtypes.ProviderOption
 // If OptionID is provided, it looks up the option from the provider
 // If Option is provided directly, it uses the Option field
@@ -416,6 +453,29 @@ func (r *AddURLRequest) Validate() error {
 	return nil
 }
 
+// Validate validates the CrawlRequest fields
+func (r *CrawlRequest) Validate() error {
+	if err := r.BaseUpsertRequest.Validate(); err != nil {
+		return err
+	}
+	if r.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an http or https url")
+	}
+	if r.Fetcher != nil && r.Fetcher.ProviderID != crawlerFetcherID {
+		return fmt.Errorf("the fetcher of a crawl must be %s", crawlerFetcherID)
+	}
+	if r.MaxDepth != nil && *r.MaxDepth < 0 {
+		return fmt.Errorf("max_depth must not be negative")
+	}
+	if r.MaxPages != nil && *r.MaxPages <= 0 {
+		return fmt.Errorf("max_pages must be positive")
+	}
+	return nil
+}
+
 // Validate validates the AddSegmentsRequest fields
 func (r *AddSegmentsRequest) Validate() error {
 	if err := r.BaseUpsertRequest.Validate(); err != nil {
@@ -430,6 +490,60 @@ func (r *AddSegmentsRequest) Validate() error {
 	return nil
 }
 
+// Validate validates the SearchRequest fields, the collection_id is merged into the collection_ids
+func (r *SearchRequest) Validate() error {
+	if r.CollectionID != "" {
+		r.CollectionIDs = append(r.CollectionIDs, r.CollectionID)
+		r.CollectionID = ""
+	}
+	if len(r.CollectionIDs) == 0 {
+		return fmt.Errorf("collection_id or collection_ids is required")
+	}
+	for i, id := range r.CollectionIDs {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("collection_ids[%d] cannot be empty", i)
+		}
+	}
+	if strings.TrimSpace(r.Query) == "" {
+		return fmt.Errorf("query is required")
+	}
+	switch r.Mode {
+	case "", kb.SearchHybrid, kb.SearchSemantic, kb.SearchKeyword:
+	default:
+		return fmt.Errorf("mode must be one of hybrid, semantic or keyword")
+	}
+	if r.Limit > 100 {
+		return fmt.Errorf("limit cannot exceed 100")
+	}
+	if r.Boost != nil && *r.Boost < 0 {
+		return fmt.Errorf("boost cannot be negative")
+	}
+	if (r.RerankerProviderID == "") != (r.RerankerOptionID == "") {
+		return fmt.Errorf("reranker_provider_id and reranker_option_id must be set together")
+	}
+	return nil
+}
+
+// Validate validates the ScheduleResyncRequest fields
+func (r *ScheduleResyncRequest) Validate() error {
+	r.Schedule = strings.TrimSpace(r.Schedule)
+	if r.Schedule == "" {
+		return nil
+	}
+	if _, err := job.ParseSchedule(r.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	return nil
+}
+
+// Validate validates the RestoreRequest fields
+func (r *RestoreRequest) Validate() error {
+	if (r.EmbeddingProviderID == "") != (r.EmbeddingOptionID == "") {
+		return fmt.Errorf("embedding_provider_id and embedding_option_id must be set together")
+	}
+	return nil
+}
+
 // Validate validates the UpdateWeightRequest fields
 func (r *UpdateWeightRequest) Validate() error {
 	if len(r.Segments) == 0 {
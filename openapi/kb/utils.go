@@ -65,6 +65,8 @@ func PrepareCreateCollection(c *gin.Context) (*CreateCollectionRequest, map[stri
 		"embedding_provider_id": req.Config.EmbeddingProviderID,
 		"embedding_option_id":   req.Config.EmbeddingOptionID,
 		"embedding_properties":  embeddingProperties,
+		"reranker_provider_id":  req.Config.RerankerProviderID,
+		"reranker_option_id":    req.Config.RerankerOptionID,
 		"locale":                req.Config.Locale,
 		"distance":              req.Config.Distance,
 		"index_type":            req.Config.IndexType,
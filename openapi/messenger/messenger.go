@@ -0,0 +1,55 @@
+package messenger
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/messenger"
+	"github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// maxWebhookBody limits the size of a webhook request, the inbound emails may carry attachments
+const maxWebhookBody = 32 << 20
+
+// Attach attaches the messenger webhook handlers to the router
+// The webhooks are posted by the providers and authenticated by the provider signatures instead of OAuth
+func Attach(group *gin.RouterGroup, oauth types.OAuth) {
+	group.POST("/webhook/:provider", webhook)
+}
+
+// webhook receives the delivery receipts and the inbound messages of a provider
+func webhook(c *gin.Context) {
+	if messenger.Instance == nil {
+		response.RespondWithError(c, http.StatusNotFound, &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "messenger is not loaded",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBody))
+	if err != nil {
+		response.RespondWithError(c, http.StatusBadRequest, &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: err.Error(),
+		})
+		return
+	}
+
+	events, err := messenger.Instance.Receive(c.Request.Context(), c.Param("provider"), c.Request, body)
+	if err != nil {
+		status := http.StatusBadRequest
+		code := response.ErrInvalidRequest.Code
+		if errors.Is(err, messenger.ErrInvalidSignature) {
+			status = http.StatusUnauthorized
+			code = response.ErrAccessDenied.Code
+		}
+		response.RespondWithError(c, status, &response.ErrorResponse{Code: code, ErrorDescription: err.Error()})
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, gin.H{"received": len(events)})
+}
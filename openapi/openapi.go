@@ -13,6 +13,7 @@ import (
 	"github.com/yaoapp/yao/openapi/hello"
 	"github.com/yaoapp/yao/openapi/job"
 	"github.com/yaoapp/yao/openapi/kb"
+	"github.com/yaoapp/yao/openapi/messenger"
 	"github.com/yaoapp/yao/openapi/oauth"
 	"github.com/yaoapp/yao/openapi/oauth/types"
 	"github.com/yaoapp/yao/openapi/team"
@@ -106,6 +107,9 @@ func (openapi *OpenAPI) Attach(router *gin.Engine) {
 	// Captcha handlers
 	captcha.Attach(group.Group("/captcha"), openapi.OAuth)
 
+	// Messenger webhook handlers
+	messenger.Attach(group.Group("/messenger"), openapi.OAuth)
+
 	// User handlers
 	user.Attach(group.Group("/user"), openapi.OAuth)
 
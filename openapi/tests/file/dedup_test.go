@@ -0,0 +1,105 @@
+package openapi_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// TestFileUploadByHash tests a known content is created by its hash without being uploaded again
+func TestFileUploadByHash(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	uploaderID := "test-dedup"
+	_, err := attachment.Register(uploaderID, "local", attachment.ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, uploaderID)},
+		AllowedTypes: []string{"text/*"},
+		Dedup:        true,
+	})
+	assert.NoError(t, err)
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "File Dedup Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+	otherToken := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	content := []byte("a document shared by the team")
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	uploadURL := serverURL + baseURL + "/file/" + uploaderID
+
+	postHash := func(hash string, token *testutils.TokenInfo) *http.Response {
+		form := url.Values{"filename": {"shared.txt"}, "groups": {"team"}}
+		req, err := http.NewRequest("POST", uploadURL+"/hash/"+hash, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	// The content is unknown, the client uploads it
+	resp := postHash(hash, tokenInfo)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	req, err := createMultipartRequest(uploadURL, "file", "document.txt", content, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	var uploaded map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&uploaded)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, hash, uploaded["hash"])
+
+	// Another user does not find the content
+	resp = postHash(hash, otherToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// The content is known now
+	resp = postHash(hash, tokenInfo)
+	var linked map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&linked)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, hash, linked["hash"])
+	assert.Equal(t, uploaded["path"], linked["path"])
+	assert.NotEqual(t, uploaded["file_id"], linked["file_id"])
+
+	// The linked file has the content
+	contentURL := uploadURL + "/" + url.QueryEscape(linked["file_id"].(string)) + "/content"
+	req, err = http.NewRequest("GET", contentURL, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,175 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// TestFileContentScan tests a file whose content does not match the declared type is rejected
+func TestFileContentScan(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	uploaderID := "test-scan"
+	_, err := attachment.Register(uploaderID, "local", attachment.ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, uploaderID)},
+		AllowedTypes: []string{"image/*"},
+	})
+	assert.NoError(t, err)
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "File Scan Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	// A html page uploaded as an image
+	req, err := createImageUploadRequest(serverURL+baseURL+"/file/"+uploaderID, "image.png", []byte("<html><script>alert(1)</script></html>"))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	var uploaded map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&uploaded)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "rejected", uploaded["status"])
+
+	metadata, _ := uploaded["metadata"].(map[string]interface{})
+	scan, _ := metadata["scan"].(map[string]interface{})
+	assert.Equal(t, "content_type", scan["scanner"])
+
+	// The content of the rejected file is not available
+	contentURL := serverURL + baseURL + "/file/" + uploaderID + "/" + url.QueryEscape(uploaded["file_id"].(string)) + "/content"
+	req, err = http.NewRequest("GET", contentURL, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+// TestFileReview tests a reviewer releases or rejects the quarantined files
+func TestFileReview(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// The files are quarantined when clamd is not reachable
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	uploaderID := "test-review"
+	_, err = attachment.Register(uploaderID, "local", attachment.ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, uploaderID)},
+		AllowedTypes: []string{"text/*"},
+		Scanner:      &attachment.ScannerOption{Driver: "clamd", Address: address, Timeout: 1},
+	})
+	assert.NoError(t, err)
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "File Review Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+	reviewerInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile file:review")
+
+	upload := func() string {
+		req, err := createMultipartRequest(serverURL+baseURL+"/file/"+uploaderID, "file", "report.txt", []byte("Hello World"), nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		var uploaded map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&uploaded)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, "quarantined", uploaded["status"])
+		fileID, _ := uploaded["file_id"].(string)
+		return url.QueryEscape(fileID)
+	}
+
+	review := func(fileID string, decision string, token string) (int, map[string]interface{}) {
+		req, err := http.NewRequest("POST", serverURL+baseURL+"/file/"+uploaderID+"/"+fileID+"/"+decision, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	content := func(fileID string) int {
+		req, err := http.NewRequest("GET", serverURL+baseURL+"/file/"+uploaderID+"/"+fileID+"/content", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("Release", func(t *testing.T) {
+		fileID := upload()
+		assert.Equal(t, http.StatusForbidden, content(fileID))
+
+		// The review requires the file:review scope
+		status, _ := review(fileID, "release", tokenInfo.AccessToken)
+		assert.Equal(t, http.StatusForbidden, status)
+
+		status, result := review(fileID, "release", reviewerInfo.AccessToken)
+		assert.Equal(t, http.StatusOK, status)
+		file, _ := result["file"].(map[string]interface{})
+		assert.Equal(t, "uploaded", file["status"])
+		assert.Equal(t, http.StatusOK, content(fileID))
+
+		// The released file is not quarantined anymore
+		status, _ = review(fileID, "reject", reviewerInfo.AccessToken)
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		fileID := upload()
+		status, result := review(fileID, "reject", reviewerInfo.AccessToken)
+		assert.Equal(t, http.StatusOK, status)
+		file, _ := result["file"].(map[string]interface{})
+		assert.Equal(t, "rejected", file["status"])
+		assert.Equal(t, http.StatusForbidden, content(fileID))
+
+		status, _ = review(fileID, "release", reviewerInfo.AccessToken)
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		status, _ := review("missing", "release", reviewerInfo.AccessToken)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,135 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// TestFileSignedURL tests the signed URLs and the Range and conditional requests of the file content
+func TestFileSignedURL(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	setupTestUploader(t)
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "File Signed URL Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	// Upload a file first
+	req, err := createMultipartRequest(serverURL+baseURL+"/file/"+testUploaderID, "file", testFileName, []byte(testFileContent), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	var uploaded map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&uploaded)
+	resp.Body.Close()
+	assert.NoError(t, err)
+
+	fileID := url.QueryEscape(uploaded["file_id"].(string))
+	contentURL := serverURL + baseURL + "/file/" + testUploaderID + "/" + fileID + "/content"
+
+	t.Run("RangeRequest", func(t *testing.T) {
+		req, err := http.NewRequest("GET", contentURL, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		req.Header.Set("Range", "bytes=4-9")
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, testFileContent[4:10], string(data))
+	})
+
+	t.Run("NotModified", func(t *testing.T) {
+		req, err := http.NewRequest("GET", contentURL, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		etag := resp.Header.Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		req, err = http.NewRequest("GET", contentURL, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		req.Header.Set("If-None-Match", etag)
+
+		resp, err = http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	})
+
+	t.Run("SignedURL", func(t *testing.T) {
+		req, err := http.NewRequest("GET", serverURL+baseURL+"/file/"+testUploaderID+"/"+fileID+"/url?ttl=60&disposition=attachment", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		assert.NoError(t, err)
+
+		signedURL := result["url"].(string)
+		assert.Contains(t, signedURL, "/signed?")
+		assert.NotZero(t, result["expires_at"])
+
+		// The signed URL is served without the access token
+		signedResp, err := http.Get(serverURL + signedURL)
+		assert.NoError(t, err)
+		defer signedResp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, signedResp.StatusCode)
+		assert.True(t, strings.HasPrefix(signedResp.Header.Get("Content-Disposition"), "attachment;"))
+		data, err := io.ReadAll(signedResp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, testFileContent, string(data))
+
+		// A tampered URL is rejected
+		tampered := strings.Replace(signedURL, "disposition=attachment", "disposition=inline", 1)
+		tamperedResp, err := http.Get(serverURL + tampered)
+		assert.NoError(t, err)
+		tamperedResp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, tamperedResp.StatusCode)
+	})
+
+	t.Run("SignedURLInvalidTTL", func(t *testing.T) {
+		req, err := http.NewRequest("GET", serverURL+baseURL+"/file/"+testUploaderID+"/"+fileID+"/url?ttl=-1", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
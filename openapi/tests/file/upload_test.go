@@ -0,0 +1,211 @@
+package openapi_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// createTusRequest creates a tus.io protocol request
+func createTusRequest(method, url, token string, body io.Reader, headers map[string]string) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// TestFileResumableUpload tests the tus.io resumable upload endpoints
+func TestFileResumableUpload(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	setupTestUploader(t)
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "File Resumable Upload Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	uploadsURL := serverURL + baseURL + "/file/" + testUploaderID + "/uploads"
+	metadata := "filename " + base64.StdEncoding.EncodeToString([]byte(testFileName)) +
+		",filetype " + base64.StdEncoding.EncodeToString([]byte(testContentType))
+	content := testFileContent
+	length := strconv.Itoa(len(content))
+
+	// create creates an upload session and returns its URL
+	create := func(t *testing.T) string {
+		req, err := createTusRequest("POST", uploadsURL, tokenInfo.AccessToken, nil, map[string]string{
+			"Upload-Length":   length,
+			"Upload-Metadata": metadata,
+		})
+		assert.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "1.0.0", resp.Header.Get("Tus-Resumable"))
+		assert.NotEmpty(t, resp.Header.Get("Upload-Expires"))
+
+		location := resp.Header.Get("Location")
+		assert.True(t, strings.HasPrefix(location, baseURL+"/file/"+testUploaderID+"/uploads/"))
+		return serverURL + location
+	}
+
+	// patch sends the data at the offset
+	patch := func(t *testing.T, uploadURL string, offset string, data string) *http.Response {
+		req, err := createTusRequest("PATCH", uploadURL, tokenInfo.AccessToken, strings.NewReader(data), map[string]string{
+			"Content-Type":  "application/offset+octet-stream",
+			"Upload-Offset": offset,
+		})
+		assert.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	t.Run("Options", func(t *testing.T) {
+		req, err := createTusRequest("OPTIONS", uploadsURL, tokenInfo.AccessToken, nil, nil)
+		assert.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "1.0.0", resp.Header.Get("Tus-Version"))
+		assert.Contains(t, resp.Header.Get("Tus-Extension"), "termination")
+	})
+
+	t.Run("ResumeUpload", func(t *testing.T) {
+		uploadURL := create(t)
+
+		resp := patch(t, uploadURL, "0", content[:20])
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "20", resp.Header.Get("Upload-Offset"))
+
+		// The connection dropped, the client asks for the offset
+		req, err := createTusRequest("HEAD", uploadURL, tokenInfo.AccessToken, nil, nil)
+		assert.NoError(t, err)
+		headResp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		headResp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, headResp.StatusCode)
+		assert.Equal(t, "20", headResp.Header.Get("Upload-Offset"))
+		assert.Equal(t, length, headResp.Header.Get("Upload-Length"))
+		assert.Equal(t, "no-store", headResp.Header.Get("Cache-Control"))
+
+		// The received byte ranges
+		req, err = createTusRequest("GET", uploadURL, tokenInfo.AccessToken, nil, nil)
+		assert.NoError(t, err)
+		statusResp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer statusResp.Body.Close()
+
+		var session map[string]interface{}
+		err = json.NewDecoder(statusResp.Body).Decode(&session)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"start": float64(0), "end": float64(19)}}, session["ranges"])
+
+		// A write at a wrong offset conflicts
+		resp = patch(t, uploadURL, "10", content[10:])
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		// Resume from the offset
+		resp = patch(t, uploadURL, "20", content[20:])
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, length, resp.Header.Get("Upload-Offset"))
+
+		req, err = createTusRequest("GET", uploadURL, tokenInfo.AccessToken, nil, nil)
+		assert.NoError(t, err)
+		completeResp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer completeResp.Body.Close()
+
+		var completed map[string]interface{}
+		err = json.NewDecoder(completeResp.Body).Decode(&completed)
+		assert.NoError(t, err)
+		assert.Equal(t, "completed", completed["status"])
+
+		// The uploaded file content
+		fileID := completed["file_id"].(string)
+		req, err = http.NewRequest("GET", serverURL+baseURL+"/file/"+testUploaderID+"/"+fileID+"/content", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		contentResp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer contentResp.Body.Close()
+
+		data, err := io.ReadAll(contentResp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("AbortUpload", func(t *testing.T) {
+		uploadURL := create(t)
+
+		resp := patch(t, uploadURL, "0", content[:10])
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		req, err := createTusRequest("DELETE", uploadURL, tokenInfo.AccessToken, nil, nil)
+		assert.NoError(t, err)
+		deleteResp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		deleteResp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, deleteResp.StatusCode)
+
+		req, err = createTusRequest("HEAD", uploadURL, tokenInfo.AccessToken, nil, nil)
+		assert.NoError(t, err)
+		headResp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		headResp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, headResp.StatusCode)
+	})
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		// The protocol version is required
+		req, err := http.NewRequest("POST", uploadsURL, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+		req.Header.Set("Upload-Length", length)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		// The content type of the data
+		uploadURL := create(t)
+		req, err = createTusRequest("PATCH", uploadURL, tokenInfo.AccessToken, strings.NewReader("data"), map[string]string{
+			"Content-Type":  "text/plain",
+			"Upload-Offset": "0",
+		})
+		assert.NoError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+}
@@ -0,0 +1,150 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// TestFileUsage tests the storage quotas and the usage endpoint
+func TestFileUsage(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	// An uploader with a quota of two test files (64 bytes) per user
+	uploaderID := "test-quota"
+	_, err := attachment.Register(uploaderID, "local", attachment.ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, uploaderID)},
+		AllowedTypes: []string{"text/*"},
+		Quotas:       []attachment.QuotaOption{{Scope: "openid", MaxBytes: "128"}},
+	})
+	assert.NoError(t, err)
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "File Usage Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+	otherToken := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+	strangerToken := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	// upload uploads the test file as the user of the token, the openid sent by the client is ignored
+	upload := func(t *testing.T, token *testutils.TokenInfo) int {
+		req, err := createMultipartRequest(serverURL+baseURL+"/file/"+uploaderID, "file", testFileName, []byte(testFileContent), map[string]string{
+			"openid": "usage-spoofed",
+			"groups": "usage-group",
+		})
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("QuotaExceeded", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, upload(t, tokenInfo))
+		assert.Equal(t, http.StatusOK, upload(t, tokenInfo))
+		assert.Equal(t, http.StatusForbidden, upload(t, tokenInfo))
+
+		// Another user has its own quota
+		assert.Equal(t, http.StatusOK, upload(t, otherToken))
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		req, err := http.NewRequest("GET", serverURL+baseURL+"/file/"+uploaderID+"/usage?openid=usage-other", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Uploader string                  `json:"uploader"`
+			Usage    []attachment.QuotaUsage `json:"usage"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		assert.NoError(t, err)
+
+		// The usage of the token subject is returned
+		assert.Equal(t, uploaderID, result.Uploader)
+		if assert.Len(t, result.Usage, 2) {
+			assert.Equal(t, "client_id", result.Usage[0].Scope)
+			assert.Equal(t, client.ClientID, result.Usage[0].ID)
+			assert.Equal(t, int64(3), result.Usage[0].Files)
+
+			assert.Equal(t, "openid", result.Usage[1].Scope)
+			assert.NotEqual(t, "usage-other", result.Usage[1].ID)
+			assert.Equal(t, int64(2*len(testFileContent)), result.Usage[1].Bytes)
+			assert.Equal(t, int64(2), result.Usage[1].Files)
+			assert.Equal(t, int64(128), result.Usage[1].MaxBytes)
+		}
+	})
+
+	t.Run("UsageGroup", func(t *testing.T) {
+		getUsage := func(token *testutils.TokenInfo) (int, []attachment.QuotaUsage) {
+			req, err := http.NewRequest("GET", serverURL+baseURL+"/file/"+uploaderID+"/usage?groups=usage-group", nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var result struct {
+				Usage []attachment.QuotaUsage `json:"usage"`
+			}
+			json.NewDecoder(resp.Body).Decode(&result)
+			return resp.StatusCode, result.Usage
+		}
+
+		// The group counts the files of all its users
+		status, usages := getUsage(tokenInfo)
+		assert.Equal(t, http.StatusOK, status)
+		if assert.Len(t, usages, 3) {
+			assert.Equal(t, "group", usages[2].Scope)
+			assert.Equal(t, "usage-group", usages[2].ID)
+			assert.Equal(t, int64(3), usages[2].Files)
+		}
+
+		// A user without files in the group can not read its usage
+		status, _ = getUsage(strangerToken)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("UsageTeamNotMember", func(t *testing.T) {
+		req, err := http.NewRequest("GET", serverURL+baseURL+"/file/"+uploaderID+"/usage?team_id=usage-team", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("UsageUploaderNotFound", func(t *testing.T) {
+		req, err := http.NewRequest("GET", serverURL+baseURL+"/file/not-exists/usage", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
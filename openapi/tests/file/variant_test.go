@@ -0,0 +1,126 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// TestFileVariant tests the image variants of the file content
+func TestFileVariant(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	uploaderID := "test-variants"
+	_, err := attachment.Register(uploaderID, "local", attachment.ManagerOption{
+		Driver:       "local",
+		Options:      map[string]interface{}{"path": filepath.Join(config.Conf.DataRoot, uploaderID)},
+		AllowedTypes: []string{"image/*"},
+		Variants:     map[string]attachment.VariantOption{"thumb": {Width: 32, Height: 32, Fit: "cover"}},
+	})
+	assert.NoError(t, err)
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "File Variant Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	// Upload a 128x64 image
+	img := image.NewRGBA(image.Rect(0, 0, 128, 64))
+	for x := 0; x < 128; x++ {
+		img.Set(x, 32, color.RGBA{255, 0, 0, 255})
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+
+	req, err := createImageUploadRequest(serverURL+baseURL+"/file/"+uploaderID, "image.png", buf.Bytes())
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	var uploaded map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&uploaded)
+	resp.Body.Close()
+	assert.NoError(t, err)
+
+	metadata, _ := uploaded["metadata"].(map[string]interface{})
+	assert.Equal(t, float64(128), metadata["width"])
+	assert.Equal(t, float64(64), metadata["height"])
+
+	contentURL := serverURL + baseURL + "/file/" + uploaderID + "/" + url.QueryEscape(uploaded["file_id"].(string)) + "/content"
+
+	t.Run("Thumbnail", func(t *testing.T) {
+		req, err := http.NewRequest("GET", contentURL+"?variant=thumb", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+
+		imageConfig, _, err := image.DecodeConfig(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, 32, imageConfig.Width)
+		assert.Equal(t, 32, imageConfig.Height)
+	})
+
+	t.Run("UnknownVariant", func(t *testing.T) {
+		req, err := http.NewRequest("GET", contentURL+"?variant=large", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+// createImageUploadRequest creates a multipart upload request of a png image
+func createImageUploadRequest(requestURL string, filename string, data []byte) (*http.Request, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	h.Set("Content-Type", "image/png")
+	part, err := writer.CreatePart(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req, nil
+}
@@ -0,0 +1,187 @@
+package openapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/gou/graphrag/types"
+	yaokb "github.com/yaoapp/yao/kb"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// TestResync tests that a resync keeps the votes, hits and weights of the unchanged segments
+func TestResync(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "KB Resync Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	send := func(method string, path string, data interface{}) (int, map[string]interface{}) {
+		body, err := json.Marshal(data)
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(method, serverURL+baseURL+"/kb"+path, bytes.NewBuffer(body))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		assert.NoError(t, err)
+		return resp.StatusCode, result
+	}
+
+	// The source page, the last paragraph is changed before the resync
+	var mu sync.Mutex
+	unchanged := strings.Repeat("Yao is an application engine to build web applications with processes, models and flows. ", 6)
+	page := unchanged + "\n\nThe first release of the guide describes the installation on Linux."
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(page))
+	}))
+	defer source.Close()
+
+	collectionID := fmt.Sprintf("test_resync_%d", time.Now().UnixNano())
+	testutils.RegisterTestCollection(collectionID)
+	status, result := send("POST", "/collections", map[string]interface{}{
+		"id":       collectionID,
+		"metadata": map[string]interface{}{"name": "Test Resync " + collectionID},
+		"config": map[string]interface{}{
+			"embedding_provider": "__yao.openai",
+			"embedding_option":   "text-embedding-3-small",
+			"locale":             "en",
+			"index_type":         "hnsw",
+			"distance":           "cosine",
+		},
+	})
+	if !assert.Equal(t, http.StatusCreated, status, "create collection: %v", result) {
+		return
+	}
+
+	docID := collectionID + "__guide"
+	status, result = send("POST", "/collections/"+collectionID+"/documents/url", map[string]interface{}{
+		"collection_id": collectionID,
+		"doc_id":        docID,
+		"url":           source.URL + "/guide.txt",
+		"chunking": map[string]interface{}{
+			"provider_id": "__yao.structured",
+			"option": map[string]interface{}{
+				"label":       "Test",
+				"value":       "test",
+				"description": "Small segments to split the page",
+				"properties":  map[string]interface{}{"size": 200, "overlap": 20, "max_depth": 1},
+			},
+		},
+		"embedding": map[string]interface{}{"provider_id": "__yao.openai", "option_id": "text-embedding-3-small"},
+	})
+	if !assert.Equal(t, http.StatusCreated, status, "add url: %v", result) {
+		return
+	}
+
+	segments := func() map[string]*types.Segment {
+		knowledgeBase, ok := yaokb.Instance.(*yaokb.KnowledgeBase)
+		if !assert.True(t, ok, "knowledge base not initialized") {
+			return nil
+		}
+
+		ctx := context.Background()
+		res, err := knowledgeBase.Vector.ScrollDocuments(ctx, &types.ScrollOptions{
+			CollectionName: collectionID,
+			Filter:         map[string]interface{}{"doc_id": docID},
+			Limit:          100,
+			IncludeContent: true,
+		})
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		items := map[string]*types.Segment{}
+		for _, document := range res.Documents {
+			segment, err := yaokb.Instance.GetSegment(ctx, docID, document.ID)
+			if assert.NoError(t, err) && segment != nil {
+				items[document.ID] = segment
+			}
+		}
+		return items
+	}
+
+	// Every segment gets a vote, a hit and a weight
+	before := segments()
+	if !assert.Greater(t, len(before), 1, "expected several segments") {
+		return
+	}
+	for id := range before {
+		status, result := send("POST", "/documents/"+docID+"/segments/"+id+"/votes", map[string]interface{}{
+			"segments": []map[string]interface{}{{"id": id, "vote": "positive"}},
+		})
+		assert.Equal(t, http.StatusOK, status, "add vote: %v", result)
+
+		status, result = send("POST", "/documents/"+docID+"/segments/"+id+"/hits", map[string]interface{}{
+			"segments": []map[string]interface{}{{"id": id}},
+		})
+		assert.Equal(t, http.StatusOK, status, "add hit: %v", result)
+
+		status, result = send("PUT", "/documents/"+docID+"/segments/weights", map[string]interface{}{
+			"weights": []types.SegmentWeight{{ID: id, Weight: 2.5}},
+		})
+		assert.Equal(t, http.StatusOK, status, "update weight: %v", result)
+	}
+	before = segments()
+
+	mu.Lock()
+	page = unchanged + "\n\nThe second release of the guide describes the installation on Linux, macOS and Windows."
+	mu.Unlock()
+
+	status, result = send("POST", "/documents/"+docID+"/resync", map[string]interface{}{})
+	if !assert.Equal(t, http.StatusOK, status, "resync: %v", result) {
+		return
+	}
+	resync, _ := result["result"].(map[string]interface{})
+	assert.Equal(t, true, resync["changed"], "%v", resync)
+	assert.Greater(t, resync["kept"], 0.0, "%v", resync)
+	assert.Greater(t, resync["added"], 0.0, "%v", resync)
+
+	// The unchanged segments keep their IDs and their feedback, the new segments have none
+	after := segments()
+	kept := 0
+	for id, segment := range after {
+		previous, ok := before[id]
+		if !ok {
+			assert.Zero(t, segment.Positive, "new segment %s", id)
+			assert.Zero(t, segment.Hit, "new segment %s", id)
+			continue
+		}
+
+		kept++
+		assert.Equal(t, previous.Text, segment.Text)
+		assert.Equal(t, previous.Weight, segment.Weight, "weight of %s", id)
+		assert.Equal(t, previous.Positive, segment.Positive, "votes of %s", id)
+		assert.Equal(t, previous.Hit, segment.Hit, "hits of %s", id)
+		assert.True(t, segment.Positive > 0 && segment.Hit > 0 && segment.Weight == 2.5, "feedback of %s: %+v", id, segment)
+	}
+	assert.Equal(t, resync["kept"], float64(kept))
+	assert.Less(t, kept, len(before), "expected the changed segment to be removed")
+}
@@ -0,0 +1,153 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/openapi/tests/testutils"
+)
+
+// TestSearch tests the search endpoint on the documents of a collection
+func TestSearch(t *testing.T) {
+	serverURL := testutils.Prepare(t)
+	defer testutils.Clean()
+
+	baseURL := ""
+	if openapi.Server != nil && openapi.Server.Config != nil {
+		baseURL = openapi.Server.Config.BaseURL
+	}
+
+	client := testutils.RegisterTestClient(t, "KB Search Test Client", []string{"https://localhost/callback"})
+	defer testutils.CleanupTestClient(t, client.ClientID)
+	tokenInfo := testutils.ObtainAccessToken(t, serverURL, client.ClientID, client.ClientSecret, "https://localhost/callback", "openid profile")
+
+	post := func(path string, data map[string]interface{}) (int, map[string]interface{}) {
+		body, err := json.Marshal(data)
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest("POST", serverURL+baseURL+"/kb"+path, bytes.NewBuffer(body))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenInfo.AccessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		assert.NoError(t, err)
+		return resp.StatusCode, result
+	}
+
+	collectionID := fmt.Sprintf("test_search_%d", time.Now().UnixNano())
+	testutils.RegisterTestCollection(collectionID)
+	status, result := post("/collections", map[string]interface{}{
+		"id":       collectionID,
+		"metadata": map[string]interface{}{"name": "Test Search " + collectionID},
+		"config": map[string]interface{}{
+			"embedding_provider": "__yao.openai",
+			"embedding_option":   "text-embedding-3-small",
+			"locale":             "en",
+			"index_type":         "hnsw",
+			"distance":           "cosine",
+		},
+	})
+	if !assert.Equal(t, http.StatusCreated, status, "create collection: %v", result) {
+		return
+	}
+
+	texts := map[string]string{
+		collectionID + "__fox":    "The quick brown fox jumps over the lazy dog.",
+		collectionID + "__engine": "Yao is an application engine to build web applications with processes and models.",
+		collectionID + "__vector": "The vector store keeps the embeddings of the segments of the documents.",
+	}
+	for docID, text := range texts {
+		status, result := post("/collections/"+collectionID+"/documents/text", map[string]interface{}{
+			"collection_id": collectionID,
+			"doc_id":        docID,
+			"text":          text,
+			"chunking": map[string]interface{}{
+				"provider_id": "__yao.structured",
+				"option": map[string]interface{}{
+					"label":       "Test",
+					"value":       "test",
+					"description": "One segment of each text",
+					"properties":  map[string]interface{}{"size": 300, "overlap": 20, "max_depth": 1},
+				},
+			},
+			"embedding": map[string]interface{}{"provider_id": "__yao.openai", "option_id": "text-embedding-3-small"},
+		})
+		if !assert.Equal(t, http.StatusCreated, status, "add text %s: %v", docID, result) {
+			return
+		}
+	}
+
+	documentIDs := func(result map[string]interface{}) []string {
+		ids := []string{}
+		results, _ := result["results"].([]interface{})
+		for _, item := range results {
+			segment, _ := item.(map[string]interface{})
+			id, _ := segment["document_id"].(string)
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	t.Run("Keyword", func(t *testing.T) {
+		status, result := post("/search", map[string]interface{}{
+			"collection_id": collectionID,
+			"query":         "application engine",
+			"mode":          "keyword",
+		})
+		assert.Equal(t, http.StatusOK, status, "%v", result)
+		assert.Equal(t, []string{collectionID + "__engine"}, documentIDs(result))
+
+		results, _ := result["results"].([]interface{})
+		if len(results) > 0 {
+			segment, _ := results[0].(map[string]interface{})
+			assert.Greater(t, segment["keyword"], 0.0)
+			assert.Equal(t, 0.0, segment["similarity"])
+		}
+	})
+
+	t.Run("Hybrid", func(t *testing.T) {
+		status, result := post("/search", map[string]interface{}{
+			"collection_id": collectionID,
+			"query":         "application engine",
+			"limit":         2,
+		})
+		assert.Equal(t, http.StatusOK, status, "%v", result)
+		ids := documentIDs(result)
+		assert.Len(t, ids, 2)
+		if len(ids) > 0 {
+			assert.Equal(t, collectionID+"__engine", ids[0])
+		}
+	})
+
+	t.Run("DocumentIDs", func(t *testing.T) {
+		status, result := post("/search", map[string]interface{}{
+			"collection_id": collectionID,
+			"query":         "application engine",
+			"mode":          "keyword",
+			"document_ids":  []string{collectionID + "__fox"},
+		})
+		assert.Equal(t, http.StatusOK, status, "%v", result)
+		assert.Empty(t, documentIDs(result))
+	})
+
+	t.Run("InvalidMode", func(t *testing.T) {
+		status, _ := post("/search", map[string]interface{}{
+			"collection_id": collectionID,
+			"query":         "application engine",
+			"mode":          "fuzzy",
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
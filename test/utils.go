@@ -198,6 +198,8 @@ var testSystemModels = map[string]string{
 	"__yao.agent.chat":         "yao/models/agent/chat.mod.yao",
 	"__yao.agent.history":      "yao/models/agent/history.mod.yao",
 	"__yao.attachment":         "yao/models/attachment.mod.yao",
+	"__yao.attachment.blob":    "yao/models/attachment/blob.mod.yao",
+	"__yao.attachment.upload":  "yao/models/attachment/upload.mod.yao",
 	"__yao.audit":              "yao/models/audit.mod.yao",
 	"__yao.config":             "yao/models/config.mod.yao",
 	"__yao.dsl":                "yao/models/dsl.mod.yao",
@@ -205,6 +207,8 @@ var testSystemModels = map[string]string{
 	"__yao.job":                "yao/models/job/job.mod.yao",
 	"__yao.job.execution":      "yao/models/job/execution.mod.yao",
 	"__yao.job.log":            "yao/models/job/log.mod.yao",
+	"__yao.messenger.outbox":   "yao/models/messenger/outbox.mod.yao",
+	"__yao.messenger.delivery": "yao/models/messenger/delivery.mod.yao",
 	"__yao.kb.collection":      "yao/models/kb/collection.mod.yao",
 	"__yao.kb.document":        "yao/models/kb/document.mod.yao",
 	"__yao.team":               "yao/models/team.mod.yao",